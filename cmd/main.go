@@ -5,10 +5,12 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/fr13n8/todo-app/docs"
 	"github.com/fr13n8/todo-app/pkg/repository"
 	"github.com/fr13n8/todo-app/pkg/service"
+	"github.com/fr13n8/todo-app/pkg/storage"
 	"github.com/joho/godotenv"
 	_ "github.com/lib/pq"
 	"github.com/sirupsen/logrus"
@@ -59,14 +61,59 @@ func main() {
 		logrus.Fatalf("failed to initialize db: %s", err.Error())
 	}
 
+	store, err := storage.NewBlobStore(storage.Config{
+		Driver:    viper.GetString("storage.driver"),
+		Path:      viper.GetString("storage.path"),
+		Endpoint:  os.Getenv("S3_ENDPOINT"),
+		Bucket:    os.Getenv("S3_BUCKET"),
+		Region:    os.Getenv("S3_REGION"),
+		AccessKey: os.Getenv("S3_ACCESS_KEY"),
+		SecretKey: os.Getenv("S3_SECRET_KEY"),
+	})
+	if err != nil {
+		logrus.Fatalf("failed to initialize blob storage: %s", err.Error())
+	}
+
 	repos := repository.NewRepository(db)
-	services := service.NewService(repos)
+	services := service.NewService(repos, store, service.Config{
+		EnforceDependencies: viper.GetBool("items.enforceDependencies"),
+		MaxAttachmentSize:   viper.GetInt64("attachments.maxSize"),
+		AttachmentQuota:     viper.GetInt64("attachments.quota"),
+		TrashRetention:      time.Duration(viper.GetInt("trash.retentionDays")) * 24 * time.Hour,
+		IdempotencyWindow:   viper.GetDuration("idempotency.window"),
+		UndoWindow:          viper.GetDuration("undo.window"),
+	})
 	handlers := handler.NewHandler(services)
 
+	jobs, stopJobs := context.WithCancel(context.Background())
+	go todo.RunJob(jobs, "trash retention", time.Hour, func() error {
+		purge, err := services.Trash.PurgeExpired()
+		if err == nil && (purge.Lists > 0 || purge.Items > 0) {
+			logrus.Printf("trash retention purged %d lists and %d items", purge.Lists, purge.Items)
+		}
+		return err
+	})
+	go todo.RunJob(jobs, "auto archive", time.Hour, func() error {
+		archived, err := services.TodoItem.ArchiveCompleted()
+		if err == nil && archived > 0 {
+			logrus.Printf("auto archive archived %d items", archived)
+		}
+		return err
+	})
+	go todo.RunJob(jobs, "idempotency keys", time.Hour, func() error {
+		_, err := services.Idempotency.PurgeExpired()
+		return err
+	})
+	go todo.RunJob(jobs, "undo journal", time.Hour, func() error {
+		_, err := services.Undo.PurgeExpired()
+		return err
+	})
+
 	srv := new(todo.Server)
 
 	go func() {
-		if err := srv.Run(os.Getenv("PORT"), handlers.InitRoutes()); err != nil {
+		if err := srv.Run(os.Getenv("PORT"), handlers.InitRoutes(),
+			viper.GetDuration("server.readTimeout"), viper.GetDuration("server.writeTimeout")); err != nil {
 			logrus.Fatalf("error ocurred while running http server: %s", err.Error())
 		}
 	}()
@@ -78,6 +125,8 @@ func main() {
 
 	logrus.Printf("%s shutting down", appName)
 
+	stopJobs()
+
 	if err := srv.Shutdown(context.Background()); err != nil {
 		logrus.Errorf("error occured on server shutting down: %s", err.Error())
 	}
@@ -0,0 +1,27 @@
+package todo
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// RunJob calls run right away and then every interval until ctx is done.
+// A failed run is logged and the job carries on with the next one.
+func RunJob(ctx context.Context, name string, interval time.Duration, run func() error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := run(); err != nil {
+			logrus.Errorf("job %s failed: %s", name, err.Error())
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,223 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/fr13n8/todo-app/structs"
+	"github.com/gin-gonic/gin"
+)
+
+// multipartOverhead is the room left for part headers and boundaries on top
+// of the attachment size limit.
+const multipartOverhead = 1 << 20
+
+type getAllAttachmentsResponse struct {
+	Data []structs.Attachment `json:"data"`
+}
+
+// @Summary Upload attachment
+// @Security ApiKeyAuth
+// @Tags attachments
+// @Description upload a file to the item
+// @ID upload-attachment
+// @Accept  mpfd
+// @Produce  json
+// @Param id path int true "item id"
+// @Param file formData file true "attachment"
+// @Success 200 {integer} integer 1
+// @Header 200 {string} Undo-Token "token undoing the changes"
+// @Failure 400,404 {object} HTTPError
+// @Failure 413 {object} HTTPError
+// @Failure 500 {object} HTTPError
+// @Failure default {object} HTTPError
+// @Router /api/items/:id/attachments [post]
+func (h *Handler) uploadAttachment(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	itemId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	// Oversized bodies are turned away before reading them whenever the
+	// client announces the length; otherwise the reader stops at the limit.
+	if maxSize := h.services.Attachment.MaxSize(); maxSize > 0 {
+		if c.Request.ContentLength > maxSize+multipartOverhead {
+			newResponseError(c, http.StatusRequestEntityTooLarge, errors.New("attachment is too large"))
+			return
+		}
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxSize+multipartOverhead)
+	}
+
+	reader, err := c.Request.MultipartReader()
+	if err != nil {
+		newResponseError(c, http.StatusBadRequest, errors.New("invalid multipart body"))
+		return
+	}
+
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			newResponseError(c, http.StatusBadRequest, errors.New("invalid multipart body"))
+			return
+		}
+		if part.FormName() != "file" || part.FileName() == "" {
+			part.Close()
+			continue
+		}
+
+		id, token, err := h.services.Attachment.Upload(userId, itemId, structs.AttachmentUpload{
+			Filename:    part.FileName(),
+			ContentType: part.Header.Get("Content-Type"),
+			Reader:      part,
+		})
+		if err != nil {
+			newResponseError(c, serviceErrorStatus(err), err)
+			return
+		}
+		setUndoToken(c, token)
+
+		c.JSON(http.StatusOK, gin.H{
+			"id": id,
+		})
+		return
+	}
+
+	newResponseError(c, http.StatusBadRequest, errors.New("file is required"))
+}
+
+// @Summary Get item attachments
+// @Security ApiKeyAuth
+// @Tags attachments
+// @Description get attachments of the item
+// @ID get-attachments
+// @Accept  json
+// @Produce  json
+// @Param id path int true "item id"
+// @Success 200 {object} getAllAttachmentsResponse
+// @Failure 400,404 {object} HTTPError
+// @Failure 500 {object} HTTPError
+// @Failure default {object} HTTPError
+// @Router /api/items/:id/attachments [get]
+func (h *Handler) getAttachments(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	itemId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	attachments, err := h.services.Attachment.GetAll(userId, itemId)
+	if err != nil {
+		newResponseError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, getAllAttachmentsResponse{
+		Data: attachments,
+	})
+}
+
+// @Summary Download attachment
+// @Security ApiKeyAuth
+// @Tags attachments
+// @Description download attachment content
+// @ID download-attachment
+// @Produce  octet-stream
+// @Param id path int true "item id"
+// @Param attachment_id path int true "attachment id"
+// @Success 200 {file} file
+// @Failure 400,404 {object} HTTPError
+// @Failure 500 {object} HTTPError
+// @Failure default {object} HTTPError
+// @Router /api/items/:id/attachments/:attachment_id [get]
+func (h *Handler) downloadAttachment(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	itemId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	attachmentId, err := strconv.Atoi(c.Param("attachment_id"))
+	if err != nil {
+		newResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	attachment, blob, err := h.services.Attachment.Download(userId, itemId, attachmentId)
+	if err != nil {
+		newResponseError(c, http.StatusInternalServerError, err)
+		return
+	}
+	defer blob.Close()
+
+	c.DataFromReader(http.StatusOK, attachment.Size, attachment.ContentType, blob, map[string]string{
+		"Content-Disposition":    mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename}),
+		"X-Content-Type-Options": "nosniff",
+	})
+}
+
+// @Summary Delete attachment
+// @Security ApiKeyAuth
+// @Tags attachments
+// @Description delete attachment from the item
+// @ID delete-attachment
+// @Accept  json
+// @Produce  json
+// @Param id path int true "item id"
+// @Param attachment_id path int true "attachment id"
+// @Success 200 {string} string Ok
+// @Header 200 {string} Undo-Token "token undoing the changes"
+// @Failure 400,404 {object} HTTPError
+// @Failure 500 {object} HTTPError
+// @Failure default {object} HTTPError
+// @Router /api/items/:id/attachments/:attachment_id [delete]
+func (h *Handler) deleteAttachment(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	itemId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	attachmentId, err := strconv.Atoi(c.Param("attachment_id"))
+	if err != nil {
+		newResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	token, err := h.services.Attachment.Delete(userId, itemId, attachmentId)
+	if err != nil {
+		newResponseError(c, http.StatusInternalServerError, err)
+		return
+	}
+	setUndoToken(c, token)
+
+	c.JSON(http.StatusOK, StatusResponse{
+		Status: "ok",
+	})
+}
@@ -0,0 +1,225 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/fr13n8/todo-app/pkg/service"
+	mockservice "github.com/fr13n8/todo-app/pkg/service/mocks"
+	"github.com/fr13n8/todo-app/structs"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_uploadAttachment(t *testing.T) {
+	type input struct {
+		userId int
+		itemId int
+	}
+
+	type mockBehavior func(s *mockservice.MockAttachment, input input)
+
+	multipartBody := func(field string, filename string, content string) (string, *bytes.Buffer) {
+		body := &bytes.Buffer{}
+		w := multipart.NewWriter(body)
+		part, _ := w.CreateFormFile(field, filename)
+		io.WriteString(part, content)
+		w.Close()
+		return w.FormDataContentType(), body
+	}
+
+	testTable := []struct {
+		name                 string
+		input                input
+		field                string
+		content              string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+		expectedUndoToken    string
+	}{
+		{
+			name:                 "Ok",
+			input:                input{userId: 1, itemId: 1},
+			field:                "file",
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"id":1}`,
+			expectedUndoToken:    "d6d5c5a1-8b53-4c1e-9a0b-3c3d2b6f0e11",
+			mockBehavior: func(s *mockservice.MockAttachment, input input) {
+				s.EXPECT().MaxSize().Return(int64(1024))
+				s.EXPECT().Upload(input.userId, input.itemId, gomock.Any()).
+					DoAndReturn(func(userId int, itemId int, upload structs.AttachmentUpload) (int, string, error) {
+						content, _ := io.ReadAll(upload.Reader)
+						assert.Equal(t, "notes.txt", upload.Filename)
+						assert.Equal(t, "content", string(content))
+						return 1, "d6d5c5a1-8b53-4c1e-9a0b-3c3d2b6f0e11", nil
+					})
+			},
+		},
+		{
+			name:                 "Too large",
+			input:                input{userId: 1, itemId: 1},
+			field:                "file",
+			expectedStatusCode:   413,
+			expectedResponseBody: `{"message":"attachment is too large"}`,
+			mockBehavior: func(s *mockservice.MockAttachment, input input) {
+				s.EXPECT().MaxSize().Return(int64(1024))
+				s.EXPECT().Upload(input.userId, input.itemId, gomock.Any()).Return(0, "", service.ErrAttachmentTooLarge)
+			},
+		},
+		{
+			name:                 "Quota exceeded",
+			input:                input{userId: 1, itemId: 1},
+			field:                "file",
+			expectedStatusCode:   413,
+			expectedResponseBody: `{"message":"attachment quota exceeded"}`,
+			mockBehavior: func(s *mockservice.MockAttachment, input input) {
+				s.EXPECT().MaxSize().Return(int64(1024))
+				s.EXPECT().Upload(input.userId, input.itemId, gomock.Any()).Return(0, "", service.ErrQuotaExceeded)
+			},
+		},
+		{
+			name:                 "Content-Length over limit",
+			input:                input{userId: 1, itemId: 1},
+			field:                "file",
+			content:              strings.Repeat("a", multipartOverhead+1),
+			expectedStatusCode:   413,
+			expectedResponseBody: `{"message":"attachment is too large"}`,
+			mockBehavior: func(s *mockservice.MockAttachment, input input) {
+				s.EXPECT().MaxSize().Return(int64(1))
+			},
+		},
+		{
+			name:                 "No file",
+			input:                input{userId: 1, itemId: 1},
+			field:                "other",
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"message":"file is required"}`,
+			mockBehavior: func(s *mockservice.MockAttachment, input input) {
+				s.EXPECT().MaxSize().Return(int64(0))
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			attachment := mockservice.NewMockAttachment(c)
+			testCase.mockBehavior(attachment, testCase.input)
+
+			services := &service.Service{Attachment: attachment}
+			handler := NewHandler(services)
+
+			r := gin.New()
+			r.POST("/api/items/:id/attachments", func(c *gin.Context) {
+				c.Set(userCtx, testCase.input.userId)
+			}, handler.uploadAttachment)
+
+			content := testCase.content
+			if content == "" {
+				content = "content"
+			}
+			contentType, body := multipartBody(testCase.field, "notes.txt", content)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", fmt.Sprintf("/api/items/%d/attachments", testCase.input.itemId), body)
+			req.Header.Set("Content-Type", contentType)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedResponseBody, w.Body.String())
+			assert.Equal(t, testCase.expectedUndoToken, w.Header().Get("Undo-Token"))
+		})
+	}
+}
+
+func TestHandler_downloadAttachment(t *testing.T) {
+	type input struct {
+		userId       int
+		itemId       int
+		attachmentId int
+	}
+
+	type mockBehavior func(s *mockservice.MockAttachment, input input)
+
+	testTable := []struct {
+		name                 string
+		input                input
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedHeaders      map[string]string
+		expectedResponseBody string
+	}{
+		{
+			name:               "Ok",
+			input:              input{userId: 1, itemId: 1, attachmentId: 2},
+			expectedStatusCode: 200,
+			expectedHeaders: map[string]string{
+				"Content-Type":           "text/html; charset=utf-8",
+				"Content-Disposition":    "attachment; filename=page.html",
+				"X-Content-Type-Options": "nosniff",
+				"Content-Length":         "14",
+			},
+			expectedResponseBody: "<p>content</p>",
+			mockBehavior: func(s *mockservice.MockAttachment, input input) {
+				s.EXPECT().Download(input.userId, input.itemId, input.attachmentId).Return(structs.Attachment{
+					Id:          input.attachmentId,
+					ItemId:      input.itemId,
+					Filename:    "page.html",
+					ContentType: "text/html; charset=utf-8",
+					Size:        14,
+				}, io.NopCloser(strings.NewReader("<p>content</p>")), nil)
+			},
+		},
+		{
+			name:                 "Not found",
+			input:                input{userId: 1, itemId: 1, attachmentId: 2},
+			expectedStatusCode:   500,
+			expectedResponseBody: `{"message":"record not found"}`,
+			mockBehavior: func(s *mockservice.MockAttachment, input input) {
+				s.EXPECT().Download(input.userId, input.itemId, input.attachmentId).
+					Return(structs.Attachment{}, nil, errors.New("record not found"))
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			attachment := mockservice.NewMockAttachment(c)
+			testCase.mockBehavior(attachment, testCase.input)
+
+			services := &service.Service{Attachment: attachment}
+			handler := NewHandler(services)
+
+			r := gin.New()
+			r.GET("/api/items/:id/attachments/:attachment_id", func(c *gin.Context) {
+				c.Set(userCtx, testCase.input.userId)
+			}, handler.downloadAttachment)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", fmt.Sprintf("/api/items/%d/attachments/%d",
+				testCase.input.itemId, testCase.input.attachmentId), nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			for name, value := range testCase.expectedHeaders {
+				assert.Equal(t, value, w.Header().Get(name))
+			}
+			assert.Equal(t, testCase.expectedResponseBody, w.Body.String())
+		})
+	}
+}
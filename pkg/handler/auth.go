@@ -30,7 +30,7 @@ func (h *Handler) signUp(c *gin.Context) {
 
 	id, err := h.services.CreateUser(input)
 	if err != nil {
-		newResponseError(c, http.StatusInternalServerError, err)
+		newResponseError(c, serviceErrorStatus(err), err)
 		return
 	}
 
@@ -60,6 +60,21 @@ func TestHandler_signUp(t *testing.T) {
 			expectedStatusCode:   500,
 			expectedResponseBody: `{"message":"service failure"}`,
 		},
+		{
+			name:      "Unknown search language",
+			inputBody: `{"name": "Test", "username": "test", "password": "test", "search_language": "klingon"}`,
+			inputUser: structs.SignUpInput{
+				Name:           "Test",
+				UserName:       "test",
+				Password:       "test",
+				SearchLanguage: "klingon",
+			},
+			mockBehavior: func(r *mockservice.MockAuthorization, user structs.SignUpInput) {
+				r.EXPECT().CreateUser(user).Return(0, service.ErrInvalidSearchLanguage)
+			},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"message":"unknown search language"}`,
+		},
 	}
 
 	for _, testCase := range testTable {
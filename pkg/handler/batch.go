@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/fr13n8/todo-app/pkg/service"
+	"github.com/fr13n8/todo-app/structs"
+	"github.com/gin-gonic/gin"
+)
+
+type batchResult struct {
+	Op     string `json:"op"`
+	Id     int    `json:"id,omitempty"`
+	Status int    `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+type batchResponse struct {
+	Data []batchResult `json:"data"`
+}
+
+// @Summary Batch item operations
+// @Security ApiKeyAuth
+// @Tags items
+// @Description create, update, move and delete items in one transaction, all or none of them in atomic mode or the ones that succeed in best effort mode
+// @ID batch-items
+// @Accept  json
+// @Produce  json
+// @Param input body structs.ItemBatchInput true "operations"
+// @Success 200 {object} batchResponse
+// @Header 200 {string} Undo-Token "token undoing the changes"
+// @Failure 400,404 {object} HTTPError
+// @Failure 500 {object} HTTPError
+// @Failure default {object} HTTPError
+// @Router /api/items/batch [post]
+func (h *Handler) batchItems(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	var input structs.ItemBatchInput
+	if err := c.BindJSON(&input); err != nil {
+		newResponseError(c, http.StatusBadRequest, errors.New("invalid input body"))
+		return
+	}
+
+	results, token, err := h.services.TodoItem.Batch(userId, input)
+	if err != nil {
+		newResponseError(c, http.StatusInternalServerError, err)
+		return
+	}
+	setUndoToken(c, token)
+
+	ops := make([]string, len(input.Operations))
+	for i, op := range input.Operations {
+		ops[i] = op.Op
+	}
+	newBatchResponse(c, ops, results, input.Mode != structs.BatchBestEffort)
+}
+
+// @Summary Batch list operations
+// @Security ApiKeyAuth
+// @Tags lists
+// @Description create, update and delete lists in one transaction, all or none of them in atomic mode or the ones that succeed in best effort mode
+// @ID batch-lists
+// @Accept  json
+// @Produce  json
+// @Param input body structs.ListBatchInput true "operations"
+// @Success 200 {object} batchResponse
+// @Header 200 {string} Undo-Token "token undoing the changes"
+// @Failure 400,404 {object} HTTPError
+// @Failure 500 {object} HTTPError
+// @Failure default {object} HTTPError
+// @Router /api/lists/batch [post]
+func (h *Handler) batchLists(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	var input structs.ListBatchInput
+	if err := c.BindJSON(&input); err != nil {
+		newResponseError(c, http.StatusBadRequest, errors.New("invalid input body"))
+		return
+	}
+
+	results, token, err := h.services.TodoList.Batch(userId, input)
+	if err != nil {
+		newResponseError(c, http.StatusInternalServerError, err)
+		return
+	}
+	setUndoToken(c, token)
+
+	ops := make([]string, len(input.Operations))
+	for i, op := range input.Operations {
+		ops[i] = op.Op
+	}
+	newBatchResponse(c, ops, results, input.Mode != structs.BatchBestEffort)
+}
+
+// newBatchResponse reports the outcome of each operation. A failed atomic
+// batch answers with the status of the operation that failed.
+func newBatchResponse(c *gin.Context, ops []string, results []structs.BatchResult, atomic bool) {
+	status := http.StatusOK
+	response := batchResponse{Data: make([]batchResult, len(results))}
+	for i, result := range results {
+		response.Data[i] = batchResult{Op: ops[i], Id: result.Id, Status: http.StatusOK}
+		if result.Err == nil {
+			continue
+		}
+		response.Data[i].Status = serviceErrorStatus(result.Err)
+		response.Data[i].Error = result.Err.Error()
+		if atomic && !errors.Is(result.Err, service.ErrBatchAborted) {
+			status = response.Data[i].Status
+		}
+	}
+	c.JSON(status, response)
+}
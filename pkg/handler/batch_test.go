@@ -0,0 +1,227 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fr13n8/todo-app/pkg/service"
+	mockservice "github.com/fr13n8/todo-app/pkg/service/mocks"
+	"github.com/fr13n8/todo-app/structs"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_batchItems(t *testing.T) {
+	type mockBehavior func(r *mockservice.MockTodoItem, input structs.ItemBatchInput)
+
+	testTable := []struct {
+		name                 string
+		inputBody            string
+		input                structs.ItemBatchInput
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+		expectedUndoToken    string
+	}{
+		{
+			name: "Ok",
+			inputBody: `{"operations":[{"op":"create","list_id":1,"item":{"title":"title"}},` +
+				`{"op":"update","id":2,"update":{"done":true}},{"op":"move","id":3,"list_id":2},{"op":"delete","id":4}]}`,
+			input: structs.ItemBatchInput{Operations: []structs.ItemOperation{
+				{Op: "create", ListId: 1, Item: &structs.Item{Title: "title"}},
+				{Op: "update", Id: 2, Update: &structs.UpdateItemInput{Done: boolPointer(true)}},
+				{Op: "move", Id: 3, ListId: 2},
+				{Op: "delete", Id: 4},
+			}},
+			expectedStatusCode: 200,
+			expectedResponseBody: `{"data":[{"op":"create","id":5,"status":200},{"op":"update","id":2,"status":200},` +
+				`{"op":"move","id":3,"status":200},{"op":"delete","id":4,"status":200}]}`,
+			expectedUndoToken: "d6d5c5a1-8b53-4c1e-9a0b-3c3d2b6f0e11",
+			mockBehavior: func(r *mockservice.MockTodoItem, input structs.ItemBatchInput) {
+				r.EXPECT().Batch(1, input).Return([]structs.BatchResult{{Id: 5}, {Id: 2}, {Id: 3}, {Id: 4}}, "d6d5c5a1-8b53-4c1e-9a0b-3c3d2b6f0e11", nil)
+			},
+		},
+		{
+			name:      "Atomic failure",
+			inputBody: `{"operations":[{"op":"delete","id":4},{"op":"update","id":2,"update":{"done":true}}]}`,
+			input: structs.ItemBatchInput{Operations: []structs.ItemOperation{
+				{Op: "delete", Id: 4},
+				{Op: "update", Id: 2, Update: &structs.UpdateItemInput{Done: boolPointer(true)}},
+			}},
+			expectedStatusCode: 409,
+			expectedResponseBody: `{"data":[{"op":"delete","status":424,"error":"not applied, another operation of the batch failed"},` +
+				`{"op":"update","status":409,"error":"item is blocked by open items"}]}`,
+			mockBehavior: func(r *mockservice.MockTodoItem, input structs.ItemBatchInput) {
+				r.EXPECT().Batch(1, input).Return([]structs.BatchResult{
+					{Err: service.ErrBatchAborted},
+					{Err: service.ErrItemBlocked},
+				}, "", nil)
+			},
+		},
+		{
+			name:      "Best effort",
+			inputBody: `{"mode":"best_effort","operations":[{"op":"delete","id":4},{"op":"move","id":3}]}`,
+			input: structs.ItemBatchInput{Mode: "best_effort", Operations: []structs.ItemOperation{
+				{Op: "delete", Id: 4},
+				{Op: "move", Id: 3},
+			}},
+			expectedStatusCode: 200,
+			expectedResponseBody: `{"data":[{"op":"delete","id":4,"status":200},` +
+				`{"op":"move","status":400,"error":"invalid batch operation: move needs id and list_id"}]}`,
+			mockBehavior: func(r *mockservice.MockTodoItem, input structs.ItemBatchInput) {
+				r.EXPECT().Batch(1, input).Return([]structs.BatchResult{
+					{Id: 4},
+					{Err: input.Operations[1].Validate()},
+				}, "", nil)
+			},
+		},
+		{
+			name:                 "Unknown op",
+			inputBody:            `{"operations":[{"op":"archive","id":4}]}`,
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"message":"invalid input body"}`,
+			mockBehavior:         func(r *mockservice.MockTodoItem, input structs.ItemBatchInput) {},
+		},
+		{
+			name:                 "No operations",
+			inputBody:            `{"operations":[]}`,
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"message":"invalid input body"}`,
+			mockBehavior:         func(r *mockservice.MockTodoItem, input structs.ItemBatchInput) {},
+		},
+		{
+			name:                 "Invalid item",
+			inputBody:            `{"operations":[{"op":"create","list_id":1,"item":{"description":"no title"}}]}`,
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"message":"invalid input body"}`,
+			mockBehavior:         func(r *mockservice.MockTodoItem, input structs.ItemBatchInput) {},
+		},
+		{
+			name:      "Service failure",
+			inputBody: `{"operations":[{"op":"delete","id":4}]}`,
+			input: structs.ItemBatchInput{Operations: []structs.ItemOperation{
+				{Op: "delete", Id: 4},
+			}},
+			expectedStatusCode:   500,
+			expectedResponseBody: `{"message":"service failure"}`,
+			mockBehavior: func(r *mockservice.MockTodoItem, input structs.ItemBatchInput) {
+				r.EXPECT().Batch(1, input).Return(nil, "", errors.New("service failure"))
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			item := mockservice.NewMockTodoItem(c)
+			testCase.mockBehavior(item, testCase.input)
+
+			services := &service.Service{TodoItem: item}
+			handler := NewHandler(services)
+
+			r := gin.New()
+			r.POST("/api/items/batch", func(c *gin.Context) {
+				c.Set(userCtx, 1)
+			}, handler.batchItems)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/api/items/batch", bytes.NewBufferString(testCase.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedResponseBody, w.Body.String())
+			assert.Equal(t, testCase.expectedUndoToken, w.Header().Get("Undo-Token"))
+		})
+	}
+}
+
+func TestHandler_batchLists(t *testing.T) {
+	type mockBehavior func(r *mockservice.MockTodoList, input structs.ListBatchInput)
+
+	testTable := []struct {
+		name                 string
+		inputBody            string
+		input                structs.ListBatchInput
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "Ok",
+			inputBody: `{"mode":"atomic","operations":[{"op":"create","list":{"title":"sprint 2"}},` +
+				`{"op":"update","id":1,"update":{"title":"sprint 1 (closed)"}}]}`,
+			input: structs.ListBatchInput{Mode: "atomic", Operations: []structs.ListOperation{
+				{Op: "create", List: &structs.List{Title: "sprint 2"}},
+				{Op: "update", Id: 1, Update: &structs.UpdateListInput{Title: stringPointer("sprint 1 (closed)")}},
+			}},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"data":[{"op":"create","id":2,"status":200},{"op":"update","id":1,"status":200}]}`,
+			mockBehavior: func(r *mockservice.MockTodoList, input structs.ListBatchInput) {
+				r.EXPECT().Batch(1, input).Return([]structs.BatchResult{{Id: 2}, {Id: 1}}, "", nil)
+			},
+		},
+		{
+			name:      "Atomic failure",
+			inputBody: `{"operations":[{"op":"delete","id":1},{"op":"delete","id":7}]}`,
+			input: structs.ListBatchInput{Operations: []structs.ListOperation{
+				{Op: "delete", Id: 1},
+				{Op: "delete", Id: 7},
+			}},
+			expectedStatusCode: 404,
+			expectedResponseBody: `{"data":[{"op":"delete","status":424,"error":"not applied, another operation of the batch failed"},` +
+				`{"op":"delete","status":404,"error":"record not found"}]}`,
+			mockBehavior: func(r *mockservice.MockTodoList, input structs.ListBatchInput) {
+				r.EXPECT().Batch(1, input).Return([]structs.BatchResult{
+					{Err: service.ErrBatchAborted},
+					{Err: service.ErrRecordNotFound},
+				}, "", nil)
+			},
+		},
+		{
+			name:                 "Move isn't a list operation",
+			inputBody:            `{"operations":[{"op":"move","id":1}]}`,
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"message":"invalid input body"}`,
+			mockBehavior:         func(r *mockservice.MockTodoList, input structs.ListBatchInput) {},
+		},
+		{
+			name:                 "Unknown mode",
+			inputBody:            `{"mode":"eventually","operations":[{"op":"delete","id":1}]}`,
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"message":"invalid input body"}`,
+			mockBehavior:         func(r *mockservice.MockTodoList, input structs.ListBatchInput) {},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			list := mockservice.NewMockTodoList(c)
+			testCase.mockBehavior(list, testCase.input)
+
+			services := &service.Service{TodoList: list}
+			handler := NewHandler(services)
+
+			r := gin.New()
+			r.POST("/api/lists/batch", func(c *gin.Context) {
+				c.Set(userCtx, 1)
+			}, handler.batchLists)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/api/lists/batch", bytes.NewBufferString(testCase.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedResponseBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/fr13n8/todo-app/structs"
+	"github.com/gin-gonic/gin"
+)
+
+type getBoardResponse struct {
+	Data structs.Board `json:"data"`
+}
+
+// @Summary Get board
+// @Security ApiKeyAuth
+// @Tags board
+// @Description get list items grouped into columns by status or label
+// @ID get-board
+// @Accept  json
+// @Produce  json
+// @Param id path int true "list id"
+// @Param group_by query string false "status (default) or label"
+// @Success 200 {object} getBoardResponse
+// @Failure 400,404 {object} HTTPError
+// @Failure 500 {object} HTTPError
+// @Failure default {object} HTTPError
+// @Router /api/lists/:id/board [get]
+func (h *Handler) getBoard(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	listId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	var query structs.BoardQuery
+	if err := c.BindQuery(&query); err != nil {
+		newResponseError(c, http.StatusBadRequest, errors.New("invalid query params"))
+		return
+	}
+
+	board, err := h.services.Board.Get(userId, listId, query.GroupBy)
+	if err != nil {
+		newResponseError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, getBoardResponse{
+		Data: board,
+	})
+}
+
+// @Summary Move item on board
+// @Security ApiKeyAuth
+// @Tags board
+// @Description move an item to a column and position in one step
+// @ID move-on-board
+// @Accept  json
+// @Produce  json
+// @Param id path int true "list id"
+// @Param input body structs.BoardMoveInput true "target column and position"
+// @Success 200 {string} string Ok
+// @Header 200 {string} Undo-Token "token undoing the changes"
+// @Failure 400,404 {object} HTTPError
+// @Failure 409 {object} HTTPError
+// @Failure 500 {object} HTTPError
+// @Failure default {object} HTTPError
+// @Router /api/lists/:id/board/move [post]
+func (h *Handler) moveOnBoard(c *gin.Context) {
+	var input structs.BoardMoveInput
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	listId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := c.BindJSON(&input); err != nil {
+		newResponseError(c, http.StatusBadRequest, errors.New("invalid input body"))
+		return
+	}
+
+	token, err := h.services.Board.Move(userId, listId, input)
+	if err != nil {
+		newResponseError(c, serviceErrorStatus(err), err)
+		return
+	}
+	setUndoToken(c, token)
+
+	c.JSON(http.StatusOK, StatusResponse{
+		Status: "ok",
+	})
+}
@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fr13n8/todo-app/pkg/service"
+	mockservice "github.com/fr13n8/todo-app/pkg/service/mocks"
+	"github.com/fr13n8/todo-app/structs"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_getBoard(t *testing.T) {
+	type mockBehavior func(s *mockservice.MockBoard)
+
+	testTable := []struct {
+		name                 string
+		query                string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:  "Ok",
+			query: "?group_by=status",
+			mockBehavior: func(s *mockservice.MockBoard) {
+				s.EXPECT().Get(1, 2, "status").Return(structs.Board{
+					GroupBy: "status",
+					Columns: []structs.BoardColumn{
+						{Id: intPointer(3), Name: "Todo", WipLimit: intPointer(2), Items: []structs.Item{{Id: 4, Title: "task"}}},
+					},
+				}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"data":{"group_by":"status","columns":[{"id":3,"name":"Todo","wip_limit":2,"items":[{"id":4,"title":"task","description":"","done":false}]}]}}`,
+		},
+		{
+			name:                 "Unknown grouping",
+			query:                "?group_by=owner",
+			mockBehavior:         func(s *mockservice.MockBoard) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"message":"invalid query params"}`,
+		},
+		{
+			name:  "Service error",
+			query: "",
+			mockBehavior: func(s *mockservice.MockBoard) {
+				s.EXPECT().Get(1, 2, "").Return(structs.Board{}, errors.New("record not found"))
+			},
+			expectedStatusCode:   500,
+			expectedResponseBody: `{"message":"record not found"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			board := mockservice.NewMockBoard(c)
+			testCase.mockBehavior(board)
+
+			services := &service.Service{Board: board}
+			handler := NewHandler(services)
+
+			r := gin.New()
+			r.GET("/api/lists/:id/board", func(c *gin.Context) {
+				c.Set(userCtx, 1)
+			}, handler.getBoard)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/api/lists/2/board"+testCase.query, nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_moveOnBoard(t *testing.T) {
+	type mockBehavior func(s *mockservice.MockBoard)
+
+	testTable := []struct {
+		name                 string
+		inputBody            string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+		expectedUndoToken    string
+	}{
+		{
+			name:      "Ok",
+			inputBody: `{"item_id":4,"column_id":3,"position":1}`,
+			mockBehavior: func(s *mockservice.MockBoard) {
+				s.EXPECT().Move(1, 2, structs.BoardMoveInput{ItemId: 4, ColumnId: 3, Position: 1}).Return("d6d5c5a1-8b53-4c1e-9a0b-3c3d2b6f0e11", nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"status":"ok"}`,
+			expectedUndoToken:    "d6d5c5a1-8b53-4c1e-9a0b-3c3d2b6f0e11",
+		},
+		{
+			name:                 "No column",
+			inputBody:            `{"item_id":4}`,
+			mockBehavior:         func(s *mockservice.MockBoard) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"message":"invalid input body"}`,
+		},
+		{
+			name:      "WIP limit reached",
+			inputBody: `{"group_by":"label","item_id":4,"from_column_id":5,"column_id":3}`,
+			mockBehavior: func(s *mockservice.MockBoard) {
+				s.EXPECT().Move(1, 2, structs.BoardMoveInput{GroupBy: "label", ItemId: 4, FromColumnId: intPointer(5), ColumnId: 3}).
+					Return("", service.ErrWipLimitReached)
+			},
+			expectedStatusCode:   409,
+			expectedResponseBody: `{"message":"column is at its WIP limit"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			board := mockservice.NewMockBoard(c)
+			testCase.mockBehavior(board)
+
+			services := &service.Service{Board: board}
+			handler := NewHandler(services)
+
+			r := gin.New()
+			r.POST("/api/lists/:id/board/move", func(c *gin.Context) {
+				c.Set(userCtx, 1)
+			}, handler.moveOnBoard)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/api/lists/2/board/move", bytes.NewBufferString(testCase.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedResponseBody, w.Body.String())
+			assert.Equal(t, testCase.expectedUndoToken, w.Header().Get("Undo-Token"))
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/fr13n8/todo-app/structs"
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Add item dependency
+// @Security ApiKeyAuth
+// @Tags dependencies
+// @Description mark item as blocked by another item
+// @ID create-dependency
+// @Accept  json
+// @Produce  json
+// @Param id path int true "item id"
+// @Param input body structs.DependencyInput true "blocking item"
+// @Success 200 {string} string Ok
+// @Header 200 {string} Undo-Token "token undoing the changes"
+// @Failure 400,404 {object} HTTPError
+// @Failure 409 {object} HTTPError
+// @Failure 500 {object} HTTPError
+// @Failure default {object} HTTPError
+// @Router /api/items/:id/dependencies [post]
+func (h *Handler) createDependency(c *gin.Context) {
+	var input structs.DependencyInput
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	itemId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := c.BindJSON(&input); err != nil {
+		newResponseError(c, http.StatusBadRequest, errors.New("invalid input body"))
+		return
+	}
+
+	token, err := h.services.Dependency.Create(userId, itemId, input)
+	if err != nil {
+		newResponseError(c, serviceErrorStatus(err), err)
+		return
+	}
+	setUndoToken(c, token)
+
+	c.JSON(http.StatusOK, StatusResponse{
+		Status: "ok",
+	})
+}
+
+// @Summary Get item dependencies
+// @Security ApiKeyAuth
+// @Tags dependencies
+// @Description get items blocking the item
+// @ID get-dependencies
+// @Accept  json
+// @Produce  json
+// @Param id path int true "item id"
+// @Success 200 {object} getAllItemsResponse
+// @Failure 400,404 {object} HTTPError
+// @Failure 500 {object} HTTPError
+// @Failure default {object} HTTPError
+// @Router /api/items/:id/dependencies [get]
+func (h *Handler) getDependencies(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	itemId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	items, err := h.services.Dependency.GetBlockers(userId, itemId)
+	if err != nil {
+		newResponseError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, getAllItemsResponse{
+		Data: items,
+	})
+}
+
+// @Summary Delete item dependency
+// @Security ApiKeyAuth
+// @Tags dependencies
+// @Description remove blocking item from the item
+// @ID delete-dependency
+// @Accept  json
+// @Produce  json
+// @Param id path int true "item id"
+// @Param blocker_id path int true "blocking item id"
+// @Success 200 {string} string Ok
+// @Header 200 {string} Undo-Token "token undoing the changes"
+// @Failure 400,404 {object} HTTPError
+// @Failure 500 {object} HTTPError
+// @Failure default {object} HTTPError
+// @Router /api/items/:id/dependencies/:blocker_id [delete]
+func (h *Handler) deleteDependency(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	itemId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	blockerId, err := strconv.Atoi(c.Param("blocker_id"))
+	if err != nil {
+		newResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	token, err := h.services.Dependency.Delete(userId, itemId, blockerId)
+	if err != nil {
+		newResponseError(c, http.StatusInternalServerError, err)
+		return
+	}
+	setUndoToken(c, token)
+
+	c.JSON(http.StatusOK, StatusResponse{
+		Status: "ok",
+	})
+}
@@ -0,0 +1,187 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fr13n8/todo-app/pkg/service"
+	mockservice "github.com/fr13n8/todo-app/pkg/service/mocks"
+	"github.com/fr13n8/todo-app/structs"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_createDependency(t *testing.T) {
+	type input struct {
+		userId     int
+		itemId     int
+		dependency structs.DependencyInput
+	}
+
+	type mockBehavior func(s *mockservice.MockDependency, input input)
+
+	testTable := []struct {
+		name                 string
+		input                input
+		inputBody            string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+		expectedUndoToken    string
+	}{
+		{
+			name: "Ok",
+			input: input{
+				userId:     1,
+				itemId:     1,
+				dependency: structs.DependencyInput{BlockerId: 2},
+			},
+			inputBody:            `{"blocker_id":2}`,
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"status":"ok"}`,
+			mockBehavior: func(s *mockservice.MockDependency, input input) {
+				s.EXPECT().Create(input.userId, input.itemId, input.dependency).Return("d6d5c5a1-8b53-4c1e-9a0b-3c3d2b6f0e11", nil)
+			},
+			expectedUndoToken: "d6d5c5a1-8b53-4c1e-9a0b-3c3d2b6f0e11",
+		},
+		{
+			name: "Cycle",
+			input: input{
+				userId:     1,
+				itemId:     1,
+				dependency: structs.DependencyInput{BlockerId: 2},
+			},
+			inputBody:            `{"blocker_id":2}`,
+			expectedStatusCode:   409,
+			expectedResponseBody: `{"message":"dependency would create a cycle"}`,
+			mockBehavior: func(s *mockservice.MockDependency, input input) {
+				s.EXPECT().Create(input.userId, input.itemId, input.dependency).Return("", service.ErrDependencyCycle)
+			},
+		},
+		{
+			name: "No inputs",
+			input: input{
+				userId: 1,
+				itemId: 1,
+			},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"message":"invalid input body"}`,
+			mockBehavior:         func(s *mockservice.MockDependency, input input) {},
+		},
+		{
+			name: "Not found",
+			input: input{
+				userId:     1,
+				itemId:     1,
+				dependency: structs.DependencyInput{BlockerId: 2},
+			},
+			inputBody:            `{"blocker_id":2}`,
+			expectedStatusCode:   500,
+			expectedResponseBody: `{"message":"record not found"}`,
+			mockBehavior: func(s *mockservice.MockDependency, input input) {
+				s.EXPECT().Create(input.userId, input.itemId, input.dependency).Return("", errors.New("record not found"))
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			dependency := mockservice.NewMockDependency(c)
+			testCase.mockBehavior(dependency, testCase.input)
+
+			services := &service.Service{Dependency: dependency}
+			handler := NewHandler(services)
+
+			r := gin.New()
+			r.POST("/api/items/:id/dependencies", func(c *gin.Context) {
+				c.Set(userCtx, testCase.input.userId)
+			}, handler.createDependency)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", fmt.Sprintf("/api/items/%d/dependencies", testCase.input.itemId), bytes.NewBufferString(testCase.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedResponseBody, w.Body.String())
+			assert.Equal(t, testCase.expectedUndoToken, w.Header().Get("Undo-Token"))
+		})
+	}
+}
+
+func TestHandler_getDependencies(t *testing.T) {
+	type input struct {
+		userId int
+		itemId int
+	}
+
+	type mockBehavior func(s *mockservice.MockDependency, input input)
+
+	testTable := []struct {
+		name                 string
+		input                input
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "Ok",
+			input: input{
+				userId: 1,
+				itemId: 1,
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"data":[{"id":2,"title":"title","description":"description","done":false,"state":"ready"}]}`,
+			mockBehavior: func(s *mockservice.MockDependency, input input) {
+				s.EXPECT().GetBlockers(input.userId, input.itemId).Return([]structs.Item{
+					{Id: 2, Title: "title", Description: "description", State: structs.ItemStateReady},
+				}, nil)
+			},
+		},
+		{
+			name: "Service failure",
+			input: input{
+				userId: 1,
+				itemId: 1,
+			},
+			expectedStatusCode:   500,
+			expectedResponseBody: `{"message":"service failure"}`,
+			mockBehavior: func(s *mockservice.MockDependency, input input) {
+				s.EXPECT().GetBlockers(input.userId, input.itemId).Return(nil, errors.New("service failure"))
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			dependency := mockservice.NewMockDependency(c)
+			testCase.mockBehavior(dependency, testCase.input)
+
+			services := &service.Service{Dependency: dependency}
+			handler := NewHandler(services)
+
+			r := gin.New()
+			r.GET("/api/items/:id/dependencies", func(c *gin.Context) {
+				c.Set(userCtx, testCase.input.userId)
+			}, handler.getDependencies)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", fmt.Sprintf("/api/items/%d/dependencies", testCase.input.itemId), nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedResponseBody, w.Body.String())
+		})
+	}
+}
@@ -28,15 +28,26 @@ func (h *Handler) InitRoutes() *gin.Engine {
 		auth.POST("/refresh", h.refreshToken)
 	}
 
-	api := router.Group("/api", h.userIdentity)
+	api := router.Group("/api", h.userIdentity, h.idempotent)
 	{
 		lists := api.Group("/lists")
 		{
 			lists.POST("/", h.createList)
+			lists.POST("/batch", h.batchLists)
 			lists.GET("/", h.getAllList)
 			lists.GET("/:id", h.getListById)
 			lists.PUT("/:id", h.updateList)
+			lists.PATCH("/:id", h.patchList)
 			lists.DELETE("/:id", h.deleteList)
+			lists.POST("/:id/archive", h.archiveList)
+			lists.POST("/:id/unarchive", h.unarchiveList)
+			lists.POST("/:id/duplicate", h.duplicateList)
+
+			lists.POST("/:id/statuses", h.createStatus)
+			lists.GET("/:id/statuses", h.getAllStatuses)
+
+			lists.GET("/:id/board", h.getBoard)
+			lists.POST("/:id/board/move", h.moveOnBoard)
 
 			items := lists.Group(":id/items")
 			{
@@ -48,10 +59,87 @@ func (h *Handler) InitRoutes() *gin.Engine {
 		items := api.Group("/items")
 		{
 
+			items.POST("/batch", h.batchItems)
 			items.GET("/:id", h.getItemById)
 			items.PUT("/:id", h.updateItem)
+			items.PATCH("/:id", h.patchItem)
 			items.DELETE("/:id", h.deleteItem)
+			items.GET("/:id/completions", h.getItemCompletions)
+			items.POST("/:id/move", h.moveItem)
+			items.POST("/:id/copy", h.copyItem)
+			items.POST("/:id/dependencies", h.createDependency)
+			items.GET("/:id/dependencies", h.getDependencies)
+			items.DELETE("/:id/dependencies/:blocker_id", h.deleteDependency)
+			items.POST("/:id/labels/:label_id", h.attachLabel)
+			items.DELETE("/:id/labels/:label_id", h.detachLabel)
+			items.POST("/:id/attachments", h.uploadAttachment)
+			items.GET("/:id/attachments", h.getAttachments)
+			items.GET("/:id/attachments/:attachment_id", h.downloadAttachment)
+			items.DELETE("/:id/attachments/:attachment_id", h.deleteAttachment)
+			items.POST("/:id/timer/start", h.startTimer)
+			items.POST("/:id/timer/stop", h.stopTimer)
+			items.POST("/:id/time-entries", h.createTimeEntry)
+			items.GET("/:id/time-entries", h.getTimeEntries)
+			items.DELETE("/:id/time-entries/:entry_id", h.deleteTimeEntry)
+			items.GET("/:id/revisions", h.getRevisions)
+			items.GET("/:id/revisions/:revision", h.getRevision)
+			items.GET("/:id/revisions/:revision/diff", h.diffRevisions)
+			items.POST("/:id/revisions/:revision/revert", h.revertRevision)
+		}
+
+		labels := api.Group("/labels")
+		{
+			labels.POST("/", h.createLabel)
+			labels.GET("/", h.getAllLabels)
+			labels.GET("/:id", h.getLabelById)
+			labels.PUT("/:id", h.updateLabel)
+			labels.DELETE("/:id", h.deleteLabel)
+			labels.GET("/:id/items", h.getLabelItems)
+		}
+
+		statuses := api.Group("/statuses")
+		{
+			statuses.GET("/:id", h.getStatusById)
+			statuses.PUT("/:id", h.updateStatus)
+			statuses.DELETE("/:id", h.deleteStatus)
+		}
+
+		reports := api.Group("/reports")
+		{
+			reports.GET("/time", h.getTimeReport)
 		}
+
+		trash := api.Group("/trash")
+		{
+			trash.GET("/", h.getTrash)
+			trash.DELETE("/", h.emptyTrash)
+			trash.POST("/lists/:id/restore", h.restoreList)
+			trash.DELETE("/lists/:id", h.purgeList)
+			trash.POST("/items/:id/restore", h.restoreItem)
+			trash.DELETE("/items/:id", h.purgeItem)
+		}
+
+		templates := api.Group("/templates")
+		{
+			templates.POST("/", h.createTemplate)
+			templates.GET("/", h.getAllTemplates)
+			templates.GET("/:id", h.getTemplateById)
+			templates.DELETE("/:id", h.deleteTemplate)
+			templates.POST("/:id/instantiate", h.instantiateTemplate)
+		}
+
+		views := api.Group("/views")
+		{
+			views.POST("/", h.createView)
+			views.GET("/", h.getAllViews)
+			views.GET("/:id", h.getViewById)
+			views.PUT("/:id", h.updateView)
+			views.DELETE("/:id", h.deleteView)
+			views.GET("/:id/items", h.getViewItems)
+		}
+
+		api.GET("/search", h.search)
+		api.POST("/undo/:token", h.undo)
 	}
 
 	return router
@@ -0,0 +1,197 @@
+package handler
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/fr13n8/todo-app/pkg/service"
+	"github.com/fr13n8/todo-app/structs"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	idempotencyKeyHeader = "Idempotency-Key"
+	replayedHeader       = "Idempotent-Replayed"
+	maxIdempotencyKeyLen = 255
+	// maxIdempotentBody bounds the bodies read to fingerprint requests other
+	// than uploads, which are bound by the attachment size limit.
+	maxIdempotentBody = 8 << 20
+)
+
+// replayedHeaders are the response headers stored with an idempotent
+// response and replayed with it.
+var replayedHeaders = []string{undoTokenHeader}
+
+// recordingWriter keeps a copy of the response body written through it.
+type recordingWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *recordingWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *recordingWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// idempotent makes POST requests sent with an Idempotency-Key header safe to
+// retry: the first response to a key is stored and replayed to every retry
+// of the same request. Reusing the key for another request answers with 422,
+// and retrying while the first request is still in progress with 409.
+func (h *Handler) idempotent(c *gin.Context) {
+	key := c.GetHeader(idempotencyKeyHeader)
+	if c.Request.Method != http.MethodPost || key == "" {
+		c.Next()
+		return
+	}
+	if len(key) > maxIdempotencyKeyLen {
+		newResponseError(c, http.StatusBadRequest, errors.New("idempotency key is too long"))
+		return
+	}
+
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	hash := sha256.New()
+	hash.Write([]byte(c.Request.Method + " " + c.Request.RequestURI + "\n"))
+	cleanup, ok := h.bufferBody(c, hash)
+	if !ok {
+		return
+	}
+	defer cleanup()
+	fingerprint := hex.EncodeToString(hash.Sum(nil))
+
+	stored, err := h.services.Idempotency.Claim(userId, key, fingerprint)
+	switch {
+	case errors.Is(err, service.ErrIdempotencyKeyReused):
+		newResponseError(c, http.StatusUnprocessableEntity, err)
+		return
+	case errors.Is(err, service.ErrRequestInProgress):
+		newResponseError(c, http.StatusConflict, err)
+		return
+	case err != nil:
+		newResponseError(c, http.StatusInternalServerError, err)
+		return
+	}
+	if stored != nil {
+		for name, value := range stored.Headers {
+			c.Header(name, value)
+		}
+		c.Header(replayedHeader, "true")
+		c.Data(stored.Status, stored.ContentType, stored.Body)
+		c.Abort()
+		return
+	}
+
+	writer := &recordingWriter{ResponseWriter: c.Writer}
+	c.Writer = writer
+	c.Next()
+
+	// Server errors aren't replayed, so a retry gets another go.
+	if writer.Status() >= http.StatusInternalServerError {
+		if err := h.services.Idempotency.Release(userId, key); err != nil {
+			logrus.Errorf("error releasing idempotency key: %s", err.Error())
+		}
+		return
+	}
+	response := structs.IdempotentResponse{
+		Status:      writer.Status(),
+		ContentType: writer.Header().Get("Content-Type"),
+		Body:        writer.body.Bytes(),
+		Headers:     structs.ResponseHeaders{},
+	}
+	for _, name := range replayedHeaders {
+		if value := writer.Header().Get(name); value != "" {
+			response.Headers[name] = value
+		}
+	}
+	// A response that can't be stored can't be replayed either, so the key
+	// is freed rather than left in progress until the claim times out.
+	if err := h.services.Idempotency.Store(userId, key, response); err != nil {
+		logrus.Errorf("error storing idempotent response: %s", err.Error())
+		if err := h.services.Idempotency.Release(userId, key); err != nil {
+			logrus.Errorf("error releasing idempotency key: %s", err.Error())
+		}
+	}
+}
+
+// bufferBody feeds the request body to the hash and puts a copy of it back
+// for the handler, answering with 413 when it is over its limit. Uploads are
+// copied to a temporary file, other bodies to memory. It returns the func
+// removing the copy and whether the request may go on.
+func (h *Handler) bufferBody(c *gin.Context, hash hash.Hash) (func(), bool) {
+	upload := strings.HasPrefix(c.ContentType(), "multipart/")
+	limit := int64(maxIdempotentBody)
+	if upload {
+		limit = -1
+		if maxSize := h.services.Attachment.MaxSize(); maxSize > 0 {
+			limit = maxSize + multipartOverhead
+		}
+	}
+	tooLarge := func() (func(), bool) {
+		newResponseError(c, http.StatusRequestEntityTooLarge, errors.New("request body is too large"))
+		return nil, false
+	}
+	if limit >= 0 && c.Request.ContentLength > limit {
+		return tooLarge()
+	}
+
+	var body io.Reader = c.Request.Body
+	if limit >= 0 {
+		body = io.LimitReader(body, limit+1)
+	}
+
+	if !upload {
+		data, err := io.ReadAll(io.TeeReader(body, hash))
+		if err != nil {
+			newResponseError(c, http.StatusBadRequest, errors.New("invalid input body"))
+			return nil, false
+		}
+		if int64(len(data)) > limit {
+			return tooLarge()
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(data))
+		return func() {}, true
+	}
+
+	file, err := os.CreateTemp("", "idempotent-upload-")
+	if err != nil {
+		newResponseError(c, http.StatusInternalServerError, err)
+		return nil, false
+	}
+	cleanup := func() {
+		file.Close()
+		os.Remove(file.Name())
+	}
+	size, err := io.Copy(io.MultiWriter(file, hash), body)
+	if err != nil {
+		cleanup()
+		newResponseError(c, http.StatusBadRequest, errors.New("invalid multipart body"))
+		return nil, false
+	}
+	if limit >= 0 && size > limit {
+		cleanup()
+		return tooLarge()
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		cleanup()
+		newResponseError(c, http.StatusInternalServerError, err)
+		return nil, false
+	}
+	c.Request.Body = file
+	return cleanup, true
+}
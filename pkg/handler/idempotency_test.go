@@ -0,0 +1,277 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/fr13n8/todo-app/pkg/service"
+	mockservice "github.com/fr13n8/todo-app/pkg/service/mocks"
+	"github.com/fr13n8/todo-app/structs"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_idempotent(t *testing.T) {
+	type mockBehavior func(r *mockservice.MockIdempotency)
+
+	testTable := []struct {
+		name                 string
+		method               string
+		key                  string
+		body                 string
+		handlerStatus        int
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedReplayed     string
+		expectedUndoToken    string
+		expectedResponseBody string
+	}{
+		{
+			name:                 "No key",
+			method:               "POST",
+			handlerStatus:        200,
+			mockBehavior:         func(r *mockservice.MockIdempotency) {},
+			expectedStatusCode:   200,
+			expectedUndoToken:    "token",
+			expectedResponseBody: `{"id":1}`,
+		},
+		{
+			name:                 "Not a POST",
+			method:               "PUT",
+			key:                  "key",
+			handlerStatus:        200,
+			mockBehavior:         func(r *mockservice.MockIdempotency) {},
+			expectedStatusCode:   200,
+			expectedUndoToken:    "token",
+			expectedResponseBody: `{"id":1}`,
+		},
+		{
+			name:          "First request",
+			method:        "POST",
+			key:           "key",
+			handlerStatus: 200,
+			mockBehavior: func(r *mockservice.MockIdempotency) {
+				r.EXPECT().Claim(1, "key", gomock.Any()).Return(nil, nil)
+				r.EXPECT().Store(1, "key", structs.IdempotentResponse{
+					Status:      200,
+					ContentType: "application/json; charset=utf-8",
+					Body:        []byte(`{"id":1}`),
+					Headers:     structs.ResponseHeaders{"Undo-Token": "token"},
+				}).Return(nil)
+			},
+			expectedStatusCode:   200,
+			expectedUndoToken:    "token",
+			expectedResponseBody: `{"id":1}`,
+		},
+		{
+			name:   "Retry",
+			method: "POST",
+			key:    "key",
+			mockBehavior: func(r *mockservice.MockIdempotency) {
+				r.EXPECT().Claim(1, "key", gomock.Any()).Return(&structs.IdempotentResponse{
+					Status:      200,
+					ContentType: "application/json; charset=utf-8",
+					Body:        []byte(`{"id":1}`),
+					Headers:     structs.ResponseHeaders{"Undo-Token": "token"},
+				}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedReplayed:     "true",
+			expectedUndoToken:    "token",
+			expectedResponseBody: `{"id":1}`,
+		},
+		{
+			name:          "Server error",
+			method:        "POST",
+			key:           "key",
+			handlerStatus: 500,
+			mockBehavior: func(r *mockservice.MockIdempotency) {
+				r.EXPECT().Claim(1, "key", gomock.Any()).Return(nil, nil)
+				r.EXPECT().Release(1, "key").Return(nil)
+			},
+			expectedStatusCode:   500,
+			expectedUndoToken:    "token",
+			expectedResponseBody: `{"id":1}`,
+		},
+		{
+			name:          "Store failed",
+			method:        "POST",
+			key:           "key",
+			handlerStatus: 200,
+			mockBehavior: func(r *mockservice.MockIdempotency) {
+				r.EXPECT().Claim(1, "key", gomock.Any()).Return(nil, nil)
+				r.EXPECT().Store(1, "key", gomock.Any()).Return(errors.New("something went wrong"))
+				r.EXPECT().Release(1, "key").Return(nil)
+			},
+			expectedStatusCode:   200,
+			expectedUndoToken:    "token",
+			expectedResponseBody: `{"id":1}`,
+		},
+		{
+			name:   "Key reused",
+			method: "POST",
+			key:    "key",
+			mockBehavior: func(r *mockservice.MockIdempotency) {
+				r.EXPECT().Claim(1, "key", gomock.Any()).Return(nil, service.ErrIdempotencyKeyReused)
+			},
+			expectedStatusCode:   422,
+			expectedResponseBody: `{"message":"the idempotency key was used for another request"}`,
+		},
+		{
+			name:   "In progress",
+			method: "POST",
+			key:    "key",
+			mockBehavior: func(r *mockservice.MockIdempotency) {
+				r.EXPECT().Claim(1, "key", gomock.Any()).Return(nil, service.ErrRequestInProgress)
+			},
+			expectedStatusCode:   409,
+			expectedResponseBody: `{"message":"a request with the idempotency key is in progress"}`,
+		},
+		{
+			name:                 "Key too long",
+			method:               "POST",
+			key:                  string(bytes.Repeat([]byte("k"), 256)),
+			mockBehavior:         func(r *mockservice.MockIdempotency) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"message":"idempotency key is too long"}`,
+		},
+		{
+			name:                 "Body too large",
+			method:               "POST",
+			key:                  "key",
+			body:                 strings.Repeat(" ", maxIdempotentBody+1),
+			mockBehavior:         func(r *mockservice.MockIdempotency) {},
+			expectedStatusCode:   413,
+			expectedResponseBody: `{"message":"request body is too large"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			idempotency := mockservice.NewMockIdempotency(c)
+			testCase.mockBehavior(idempotency)
+
+			services := &service.Service{Idempotency: idempotency}
+			handler := NewHandler(services)
+
+			r := gin.New()
+			setUser := func(c *gin.Context) {
+				c.Set(userCtx, 1)
+			}
+			respond := func(c *gin.Context) {
+				var input map[string]interface{}
+				if err := c.BindJSON(&input); err != nil || input["title"] != "new" {
+					t.Errorf("the handler got the body %v", input)
+				}
+				setUndoToken(c, "token")
+				c.JSON(testCase.handlerStatus, map[string]interface{}{"id": 1})
+			}
+			r.Handle(testCase.method, "/api/lists", setUser, handler.idempotent, respond)
+
+			body := testCase.body
+			if body == "" {
+				body = `{"title":"new"}`
+			}
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(testCase.method, "/api/lists", bytes.NewBufferString(body))
+			if testCase.key != "" {
+				req.Header.Set("Idempotency-Key", testCase.key)
+			}
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedReplayed, w.Header().Get("Idempotent-Replayed"))
+			assert.Equal(t, testCase.expectedUndoToken, w.Header().Get("Undo-Token"))
+			assert.Equal(t, testCase.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_idempotentUpload(t *testing.T) {
+	type mockBehavior func(a *mockservice.MockAttachment, i *mockservice.MockIdempotency)
+
+	testTable := []struct {
+		name                 string
+		content              string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:    "Ok",
+			content: "content",
+			mockBehavior: func(a *mockservice.MockAttachment, i *mockservice.MockIdempotency) {
+				a.EXPECT().MaxSize().Return(int64(1024))
+				i.EXPECT().Claim(1, "key", gomock.Any()).Return(nil, nil)
+				i.EXPECT().Store(1, "key", gomock.Any()).Return(nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"content":"content"}`,
+		},
+		{
+			name:    "Too large",
+			content: strings.Repeat("a", 1024+multipartOverhead+1),
+			mockBehavior: func(a *mockservice.MockAttachment, i *mockservice.MockIdempotency) {
+				a.EXPECT().MaxSize().Return(int64(1024))
+			},
+			expectedStatusCode:   413,
+			expectedResponseBody: `{"message":"request body is too large"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			attachment := mockservice.NewMockAttachment(c)
+			idempotency := mockservice.NewMockIdempotency(c)
+			testCase.mockBehavior(attachment, idempotency)
+
+			services := &service.Service{Attachment: attachment, Idempotency: idempotency}
+			handler := NewHandler(services)
+
+			r := gin.New()
+			setUser := func(c *gin.Context) {
+				c.Set(userCtx, 1)
+			}
+			respond := func(c *gin.Context) {
+				header, err := c.FormFile("file")
+				if err != nil {
+					t.Fatalf("the handler got no file: %s", err)
+				}
+				file, _ := header.Open()
+				defer file.Close()
+				content, _ := io.ReadAll(file)
+				c.JSON(200, map[string]interface{}{"content": string(content)})
+			}
+			r.POST("/api/items/1/attachments", setUser, handler.idempotent, respond)
+
+			body := &bytes.Buffer{}
+			mw := multipart.NewWriter(body)
+			part, _ := mw.CreateFormFile("file", "notes.txt")
+			io.WriteString(part, testCase.content)
+			mw.Close()
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/api/items/1/attachments", body)
+			req.Header.Set("Content-Type", mw.FormDataContentType())
+			req.Header.Set("Idempotency-Key", "key")
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedResponseBody, w.Body.String())
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/fr13n8/todo-app/pkg/service"
 	"github.com/fr13n8/todo-app/structs"
 	"github.com/gin-gonic/gin"
 )
@@ -19,6 +20,7 @@ import (
 // @Param input body structs.Item true "item info"
 // @Param id path int true "list id"
 // @Success 200 {integer} integer 1
+// @Header 200 {string} Undo-Token "token undoing the changes"
 // @Failure 400,404 {object} HTTPError
 // @Failure 500 {object} HTTPError
 // @Failure default {object} HTTPError
@@ -41,11 +43,12 @@ func (h *Handler) createItem(c *gin.Context) {
 		return
 	}
 
-	id, err := h.services.TodoItem.Create(listId, userId, input)
+	id, token, err := h.services.TodoItem.Create(listId, userId, input)
 	if err != nil {
-		newResponseError(c, http.StatusInternalServerError, err)
+		newResponseError(c, serviceErrorStatus(err), err)
 		return
 	}
+	setUndoToken(c, token)
 
 	c.JSON(http.StatusOK, gin.H{"id": id})
 }
@@ -54,6 +57,12 @@ type getAllItemsResponse struct {
 	Data []structs.Item `json:"data"`
 }
 
+type getItemsPageResponse struct {
+	Data       []structs.Item `json:"data"`
+	Total      int            `json:"total"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+}
+
 // @Summary Get All items
 // @Security ApiKeyAuth
 // @Tags items
@@ -62,7 +71,30 @@ type getAllItemsResponse struct {
 // @Accept  json
 // @Produce  json
 // @Param id path int true "list id"
-// @Success 200 {object} getAllItemsResponse
+// @Param label query []int false "label ids the items must carry"
+// @Param sort query string false "comma separated keys among id (default), title, created_at, updated_at, completed_at, due_date and priority, descending when prefixed with -"
+// @Param order query string false "direction of the keys without a prefix, asc (default) or desc"
+// @Param done query bool false "only the done items, or only the open ones"
+// @Param title~ query string false "text the title contains, ignoring case"
+// @Param limit query int false "page size, 1 to 500; all items when omitted"
+// @Param cursor query string false "next_cursor of the previous page"
+// @Param created_after query string false "RFC 3339 time"
+// @Param created_before query string false "RFC 3339 time"
+// @Param updated_after query string false "RFC 3339 time"
+// @Param updated_before query string false "RFC 3339 time"
+// @Param completed_after query string false "RFC 3339 time"
+// @Param completed_before query string false "RFC 3339 time"
+// @Param created_by query int false "creator user id"
+// @Param updated_by query int false "last editor user id"
+// @Param archived query bool false "list the archived items instead"
+// @Param q query string false "filter expression, e.g. done:false priority>=high assignee:me due<2021-07-01 label:work -label:someday"
+// @Param render query string false "html to add the description rendered from Markdown"
+// @Param If-None-Match header string false "ETag of the copy the client holds"
+// @Param If-Modified-Since header string false "Last-Modified of the copy the client holds"
+// @Success 200 {object} getItemsPageResponse
+// @Header 200 {string} ETag "state of the items and the query"
+// @Header 200 {string} Last-Modified "time of the last change"
+// @Success 304 {string} string "not modified"
 // @Failure 400,404 {object} HTTPError
 // @Failure 500 {object} HTTPError
 // @Failure default {object} HTTPError
@@ -79,14 +111,42 @@ func (h *Handler) getAllItems(c *gin.Context) {
 		return
 	}
 
-	items, err := h.services.TodoItem.GetAll(listId, userId)
+	var filter structs.ItemFilter
+	if err := c.BindQuery(&filter); err != nil {
+		newResponseError(c, http.StatusBadRequest, errors.New("invalid query params"))
+		return
+	}
+
+	render, err := bindRender(c)
+	if err != nil {
+		newResponseError(c, http.StatusBadRequest, errors.New("invalid query params"))
+		return
+	}
+
+	stamp, err := h.services.TodoItem.Stamp(listId, userId)
 	if err != nil {
 		newResponseError(c, http.StatusInternalServerError, err)
 		return
 	}
+	if notModified(c, collectionETag(c, stamp), stamp.LastModified) {
+		return
+	}
+
+	items, page, err := h.services.TodoItem.GetAll(listId, userId, filter)
+	if err != nil {
+		newResponseError(c, serviceErrorStatus(err), err)
+		return
+	}
+	if render {
+		for i := range items {
+			renderItem(&items[i])
+		}
+	}
 
-	c.JSON(http.StatusOK, getAllItemsResponse{
-		Data: items,
+	c.JSON(http.StatusOK, getItemsPageResponse{
+		Data:       items,
+		Total:      page.Total,
+		NextCursor: page.NextCursor,
 	})
 }
 
@@ -103,7 +163,13 @@ type getItemResponse struct {
 // @Produce  json
 // @Param id path int true "list id"
 // @Param item_id path int true "item id"
+// @Param render query string false "html to add the description rendered from Markdown"
+// @Param If-None-Match header string false "ETag of the copy the client holds"
+// @Param If-Modified-Since header string false "Last-Modified of the copy the client holds"
 // @Success 200 {object} getItemResponse
+// @Header 200 {string} ETag "version of the item and digest of the response"
+// @Header 200 {string} Last-Modified "time of the last change"
+// @Success 304 {string} string "not modified"
 // @Failure 400,404 {object} HTTPError
 // @Failure 500 {object} HTTPError
 // @Failure default {object} HTTPError
@@ -120,33 +186,50 @@ func (h *Handler) getItemById(c *gin.Context) {
 		return
 	}
 
+	render, err := bindRender(c)
+	if err != nil {
+		newResponseError(c, http.StatusBadRequest, errors.New("invalid query params"))
+		return
+	}
+
 	item, err := h.services.TodoItem.GetById(userId, itemId)
 	if err != nil {
-		newResponseError(c, http.StatusInternalServerError, err)
+		newResponseError(c, serviceErrorStatus(err), err)
 		return
 	}
+	if render {
+		renderItem(&item)
+	}
 
-	c.JSON(http.StatusOK, getItemResponse{
+	response := getItemResponse{
 		Data: item,
-	})
+	}
+	if notModified(c, representationETag(item.Version, render, response), latest(item.UpdatedAt, item.ArchivedAt)) {
+		return
+	}
+	c.JSON(http.StatusOK, response)
 }
 
 // @Summary Update todo item
 // @Security ApiKeyAuth
 // @Tags items
-// @Description update todo item
+// @Description replace all the editable fields of todo item, clearing the ones left out
 // @ID update-item
 // @Accept  json
 // @Produce  json
 // @Param id path int true "item id"
-// @Param input body structs.UpdateItemInput true "item info"
+// @Param input body structs.ReplaceItemInput true "item info"
+// @Param If-Match header string false "ETag of the version to change"
 // @Success 200 {string} string Ok
+// @Header 200 {string} Undo-Token "token undoing the changes"
 // @Failure 400,404 {object} HTTPError
+// @Failure 409 {object} HTTPError
+// @Failure 412 {object} getItemResponse
 // @Failure 500 {object} HTTPError
 // @Failure default {object} HTTPError
 // @Router /api/items/:id [put]
 func (h *Handler) updateItem(c *gin.Context) {
-	var input structs.UpdateItemInput
+	var input structs.ReplaceItemInput
 	userId, err := getUserId(c)
 	if err != nil {
 		return
@@ -163,10 +246,86 @@ func (h *Handler) updateItem(c *gin.Context) {
 		return
 	}
 
-	if err := h.services.TodoItem.Update(userId, itemId, input); err != nil {
-		newResponseError(c, http.StatusInternalServerError, err)
+	var ok bool
+	if input.Version, ok = h.matchItem(c, userId, itemId); !ok {
+		return
+	}
+
+	token, err := h.services.TodoItem.Replace(userId, itemId, input)
+	if err != nil {
+		if errors.Is(err, service.ErrVersionMismatch) {
+			h.itemChanged(c, userId, itemId)
+			return
+		}
+		newResponseError(c, serviceErrorStatus(err), err)
 		return
 	}
+	setUndoToken(c, token)
+
+	c.JSON(http.StatusOK, StatusResponse{
+		Status: "ok",
+	})
+}
+
+// @Summary Patch todo item
+// @Security ApiKeyAuth
+// @Tags items
+// @Description update some fields of todo item with a JSON merge patch (RFC 7396) or a JSON patch (RFC 6902) of its editable fields, as PUT takes them
+// @ID patch-item
+// @Accept  application/merge-patch+json,application/json-patch+json
+// @Produce  json
+// @Param id path int true "item id"
+// @Param input body object true "patch"
+// @Param If-Match header string false "ETag of the version to change"
+// @Success 200 {string} string Ok
+// @Header 200 {string} Undo-Token "token undoing the changes"
+// @Failure 400,404 {object} HTTPError
+// @Failure 409 {object} HTTPError
+// @Failure 415 {object} HTTPError
+// @Failure 422 {object} HTTPError
+// @Failure 412 {object} getItemResponse
+// @Failure 500 {object} HTTPError
+// @Failure default {object} HTTPError
+// @Router /api/items/:id [patch]
+func (h *Handler) patchItem(c *gin.Context) {
+	var input structs.ReplaceItemInput
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	itemId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	item, err := h.services.TodoItem.GetById(userId, itemId)
+	if err != nil {
+		newResponseError(c, serviceErrorStatus(err), err)
+		return
+	}
+	if _, ok := ifMatch(c, item.Version); !ok {
+		preconditionFailed(c, item.Version, getItemResponse{Data: item})
+		return
+	}
+	if !bindPatch(c, item.Replacement(), &input) {
+		return
+	}
+
+	// The patch applies to this version; another change in the meantime
+	// would be undone by replacing the fields it left alone.
+	input.Version = &item.Version
+	token, err := h.services.TodoItem.Replace(userId, itemId, input)
+	if err != nil {
+		if errors.Is(err, service.ErrVersionMismatch) {
+			h.itemChanged(c, userId, itemId)
+			return
+		}
+		newResponseError(c, serviceErrorStatus(err), err)
+		return
+	}
+	setUndoToken(c, token)
 
 	c.JSON(http.StatusOK, StatusResponse{
 		Status: "ok",
@@ -182,8 +341,11 @@ func (h *Handler) updateItem(c *gin.Context) {
 // @Produce  json
 // @Param id path int true "list id"
 // @Param item_id path int true "item id"
+// @Param If-Match header string false "ETag of the version to change"
 // @Success 200 {string} Ok
+// @Header 200 {string} Undo-Token "token undoing the changes"
 // @Failure 400,404 {object} HTTPError
+// @Failure 412 {object} getItemResponse
 // @Failure 500 {object} HTTPError
 // @Failure default {object} HTTPError
 // @Router /api/lists/:id/items/:item_id [delete]
@@ -199,13 +361,153 @@ func (h *Handler) deleteItem(c *gin.Context) {
 		return
 	}
 
-	err = h.services.TodoItem.Delete(userId, itemId)
+	version, ok := h.matchItem(c, userId, itemId)
+	if !ok {
+		return
+	}
+
+	token, err := h.services.TodoItem.Delete(userId, itemId, version)
+	if err != nil {
+		if errors.Is(err, service.ErrVersionMismatch) {
+			h.itemChanged(c, userId, itemId)
+			return
+		}
+		newResponseError(c, serviceErrorStatus(err), err)
+		return
+	}
+	setUndoToken(c, token)
+
+	c.JSON(http.StatusOK, StatusResponse{
+		Status: "ok",
+	})
+}
+
+type getItemCompletionsResponse struct {
+	Data []structs.ItemCompletion `json:"data"`
+}
+
+// @Summary Get item completions
+// @Security ApiKeyAuth
+// @Tags items
+// @Description get completion history of a recurring item
+// @ID get-item-completions
+// @Accept  json
+// @Produce  json
+// @Param id path int true "item id"
+// @Success 200 {object} getItemCompletionsResponse
+// @Failure 400,404 {object} HTTPError
+// @Failure 500 {object} HTTPError
+// @Failure default {object} HTTPError
+// @Router /api/items/:id/completions [get]
+func (h *Handler) getItemCompletions(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	itemId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	completions, err := h.services.TodoItem.GetCompletions(userId, itemId)
 	if err != nil {
 		newResponseError(c, http.StatusInternalServerError, err)
 		return
 	}
 
+	c.JSON(http.StatusOK, getItemCompletionsResponse{
+		Data: completions,
+	})
+}
+
+// @Summary Move item
+// @Security ApiKeyAuth
+// @Tags items
+// @Description move item to another list
+// @ID move-item
+// @Accept  json
+// @Produce  json
+// @Param id path int true "item id"
+// @Param input body structs.MoveItemInput true "target list"
+// @Success 200 {string} string Ok
+// @Header 200 {string} Undo-Token "token undoing the changes"
+// @Failure 400,404 {object} HTTPError
+// @Failure 409 {object} HTTPError
+// @Failure 500 {object} HTTPError
+// @Failure default {object} HTTPError
+// @Router /api/items/:id/move [post]
+func (h *Handler) moveItem(c *gin.Context) {
+	var input structs.MoveItemInput
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	itemId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := c.BindJSON(&input); err != nil {
+		newResponseError(c, http.StatusBadRequest, errors.New("invalid input body"))
+		return
+	}
+
+	token, err := h.services.TodoItem.Move(userId, itemId, input)
+	if err != nil {
+		newResponseError(c, serviceErrorStatus(err), err)
+		return
+	}
+	setUndoToken(c, token)
+
 	c.JSON(http.StatusOK, StatusResponse{
 		Status: "ok",
 	})
 }
+
+// @Summary Copy item
+// @Security ApiKeyAuth
+// @Tags items
+// @Description copy item to another list
+// @ID copy-item
+// @Accept  json
+// @Produce  json
+// @Param id path int true "item id"
+// @Param input body structs.CopyItemInput true "target list and copy options"
+// @Success 200 {integer} integer 1
+// @Header 200 {string} Undo-Token "token undoing the changes"
+// @Failure 400,404 {object} HTTPError
+// @Failure 409 {object} HTTPError
+// @Failure 500 {object} HTTPError
+// @Failure default {object} HTTPError
+// @Router /api/items/:id/copy [post]
+func (h *Handler) copyItem(c *gin.Context) {
+	var input structs.CopyItemInput
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	itemId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := c.BindJSON(&input); err != nil {
+		newResponseError(c, http.StatusBadRequest, errors.New("invalid input body"))
+		return
+	}
+
+	id, token, err := h.services.TodoItem.Copy(userId, itemId, input)
+	if err != nil {
+		newResponseError(c, serviceErrorStatus(err), err)
+		return
+	}
+	setUndoToken(c, token)
+
+	c.JSON(http.StatusOK, gin.H{"id": id})
+}
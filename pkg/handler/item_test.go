@@ -5,8 +5,11 @@ import (
 	"errors"
 	"fmt"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/fr13n8/todo-app/pkg/querylang"
 	"github.com/fr13n8/todo-app/pkg/service"
 	mockservice "github.com/fr13n8/todo-app/pkg/service/mocks"
 	"github.com/fr13n8/todo-app/structs"
@@ -30,6 +33,7 @@ func TestHandler_createItem(t *testing.T) {
 		inputBody            string
 		expectedStatusCode   int
 		expectedResponseBody string
+		expectedUndoToken    string
 		mockBehavior         mockBehavior
 	}{
 		{
@@ -46,8 +50,9 @@ func TestHandler_createItem(t *testing.T) {
 			expectedStatusCode:   200,
 			expectedResponseBody: `{"id":1}`,
 			mockBehavior: func(r *mockservice.MockTodoItem, input input) {
-				r.EXPECT().Create(input.listId, input.userId, input.item).Return(1, nil)
+				r.EXPECT().Create(input.listId, input.userId, input.item).Return(1, "d6d5c5a1-8b53-4c1e-9a0b-3c3d2b6f0e11", nil)
 			},
+			expectedUndoToken: "d6d5c5a1-8b53-4c1e-9a0b-3c3d2b6f0e11",
 		},
 		{
 			name: "No inputs",
@@ -73,7 +78,7 @@ func TestHandler_createItem(t *testing.T) {
 			expectedStatusCode:   500,
 			expectedResponseBody: `{"message":"service failure"}`,
 			mockBehavior: func(r *mockservice.MockTodoItem, input input) {
-				r.EXPECT().Create(input.listId, input.userId, input.item).Return(0, errors.New("service failure"))
+				r.EXPECT().Create(input.listId, input.userId, input.item).Return(0, "", errors.New("service failure"))
 			},
 		},
 	}
@@ -101,6 +106,7 @@ func TestHandler_createItem(t *testing.T) {
 
 			assert.Equal(t, testCase.expectedStatusCode, w.Code)
 			assert.Equal(t, testCase.expectedResponseBody, w.Body.String())
+			assert.Equal(t, testCase.expectedUndoToken, w.Header().Get("Undo-Token"))
 		})
 	}
 }
@@ -109,6 +115,8 @@ func TestHandler_getAllItems(t *testing.T) {
 	type input struct {
 		userId int
 		listId int
+		query  string
+		filter structs.ItemFilter
 	}
 
 	type mockBehavior func(r *mockservice.MockTodoItem, input input)
@@ -127,9 +135,10 @@ func TestHandler_getAllItems(t *testing.T) {
 				listId: 1,
 			},
 			expectedStatusCode:   200,
-			expectedResponseBody: `{"data":[{"id":1,"title":"title","description":"description","done":false},{"id":2,"title":"title2","description":"description2","done":true}]}`,
+			expectedResponseBody: `{"data":[{"id":1,"title":"title","description":"description","done":false},{"id":2,"title":"title2","description":"description2","done":true}],"total":2}`,
 			mockBehavior: func(r *mockservice.MockTodoItem, input input) {
-				r.EXPECT().GetAll(input.listId, input.userId).Return([]structs.Item{
+				r.EXPECT().Stamp(input.listId, input.userId).Return(structs.CollectionStamp{}, nil)
+				r.EXPECT().GetAll(input.listId, input.userId, input.filter).Return([]structs.Item{
 					{
 						Id:          1,
 						Title:       "title",
@@ -142,9 +151,125 @@ func TestHandler_getAllItems(t *testing.T) {
 						Description: "description2",
 						Done:        true,
 					},
-				}, nil)
+				}, structs.PageInfo{Total: 2}, nil)
+			},
+		},
+		{
+			name: "Ok_WithLabels",
+			input: input{
+				userId: 1,
+				listId: 1,
+				query:  "?label=1&label=2",
+				filter: structs.ItemFilter{LabelIds: []int{1, 2}},
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"data":[{"id":1,"title":"title","description":"description","done":false,"labels":[{"id":1,"name":"work","color":"#ff0000"},{"id":2,"name":"home","color":"#00ff00"}]}],"total":1}`,
+			mockBehavior: func(r *mockservice.MockTodoItem, input input) {
+				r.EXPECT().Stamp(input.listId, input.userId).Return(structs.CollectionStamp{}, nil)
+				r.EXPECT().GetAll(input.listId, input.userId, input.filter).Return([]structs.Item{
+					{
+						Id:          1,
+						Title:       "title",
+						Description: "description",
+						Labels: []structs.Label{
+							{Id: 1, Name: "work", Color: "#ff0000"},
+							{Id: 2, Name: "home", Color: "#00ff00"},
+						},
+					},
+				}, structs.PageInfo{Total: 1}, nil)
+			},
+		},
+		{
+			name: "Invalid filter expression",
+			input: input{
+				userId: 1,
+				listId: 1,
+				query:  "?q=done%3Afalse+due%3C2021-13-01",
+				filter: structs.ItemFilter{Query: "done:false due<2021-13-01"},
+			},
+			expectedStatusCode: 400,
+			expectedResponseBody: `{"message":"invalid date \"2021-13-01\", expected YYYY-MM-DD, an RFC 3339 time or none at position 16",` +
+				`"position":16}`,
+			mockBehavior: func(r *mockservice.MockTodoItem, input input) {
+				_, err := querylang.Parse(input.filter.Query)
+				r.EXPECT().Stamp(input.listId, input.userId).Return(structs.CollectionStamp{}, nil)
+				r.EXPECT().GetAll(input.listId, input.userId, input.filter).Return(nil, structs.PageInfo{}, err)
+			},
+		},
+		{
+			name: "Ok_Completed",
+			input: input{
+				userId: 1,
+				listId: 1,
+				query:  "?completed_after=2021-06-01T00:00:00Z&sort=completed_at&order=desc",
+				filter: structs.ItemFilter{AuditFilter: structs.AuditFilter{
+					Sort:           "completed_at",
+					Order:          "desc",
+					CompletedAfter: timePointer(time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)),
+				}},
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"data":[{"id":1,"title":"title","description":"description","done":true,"completed_at":"2021-06-02T10:00:00Z"}],"total":1}`,
+			mockBehavior: func(r *mockservice.MockTodoItem, input input) {
+				r.EXPECT().Stamp(input.listId, input.userId).Return(structs.CollectionStamp{}, nil)
+				r.EXPECT().GetAll(input.listId, input.userId, input.filter).Return([]structs.Item{
+					{
+						Id:          1,
+						Title:       "title",
+						Description: "description",
+						Done:        true,
+						CompletedAt: timePointer(time.Date(2021, 6, 2, 10, 0, 0, 0, time.UTC)),
+					},
+				}, structs.PageInfo{Total: 1}, nil)
+			},
+		},
+		{
+			name: "Paged",
+			input: input{
+				userId: 1,
+				listId: 1,
+				query:  "?done=true&limit=1",
+				filter: structs.ItemFilter{Done: boolPointer(true), PageFilter: structs.PageFilter{Limit: 1}},
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"data":[{"id":1,"title":"title","description":"","done":true}],"total":2,"next_cursor":"abc"}`,
+			mockBehavior: func(r *mockservice.MockTodoItem, input input) {
+				r.EXPECT().Stamp(input.listId, input.userId).Return(structs.CollectionStamp{}, nil)
+				r.EXPECT().GetAll(input.listId, input.userId, input.filter).Return([]structs.Item{
+					{
+						Id:    1,
+						Title: "title",
+						Done:  true,
+					},
+				}, structs.PageInfo{Total: 2, NextCursor: "abc"}, nil)
 			},
 		},
+		{
+			name: "Invalid cursor",
+			input: input{
+				userId: 1,
+				listId: 1,
+				query:  "?cursor=abc",
+				filter: structs.ItemFilter{PageFilter: structs.PageFilter{Cursor: "abc"}},
+			},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"message":"invalid cursor"}`,
+			mockBehavior: func(r *mockservice.MockTodoItem, input input) {
+				r.EXPECT().Stamp(input.listId, input.userId).Return(structs.CollectionStamp{}, nil)
+				r.EXPECT().GetAll(input.listId, input.userId, input.filter).Return(nil, structs.PageInfo{}, service.ErrInvalidCursor)
+			},
+		},
+		{
+			name: "Invalid label filter",
+			input: input{
+				userId: 1,
+				listId: 1,
+				query:  "?label=work",
+			},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"message":"invalid query params"}`,
+			mockBehavior:         func(r *mockservice.MockTodoItem, input input) {},
+		},
 		{
 			name: "Not found",
 			input: input{
@@ -153,7 +278,7 @@ func TestHandler_getAllItems(t *testing.T) {
 			expectedStatusCode:   500,
 			expectedResponseBody: `{"message":"record not found"}`,
 			mockBehavior: func(r *mockservice.MockTodoItem, input input) {
-				r.EXPECT().GetAll(input.listId, input.userId).Return(nil, errors.New("record not found"))
+				r.EXPECT().Stamp(input.listId, input.userId).Return(structs.CollectionStamp{}, errors.New("record not found"))
 			},
 		},
 		{
@@ -165,7 +290,8 @@ func TestHandler_getAllItems(t *testing.T) {
 			expectedStatusCode:   500,
 			expectedResponseBody: `{"message":"service failure"}`,
 			mockBehavior: func(r *mockservice.MockTodoItem, input input) {
-				r.EXPECT().GetAll(input.listId, input.userId).Return(nil, errors.New("service failure"))
+				r.EXPECT().Stamp(input.listId, input.userId).Return(structs.CollectionStamp{}, nil)
+				r.EXPECT().GetAll(input.listId, input.userId, input.filter).Return(nil, structs.PageInfo{}, errors.New("service failure"))
 			},
 		},
 	}
@@ -187,7 +313,7 @@ func TestHandler_getAllItems(t *testing.T) {
 			}, handler.getAllItems)
 
 			w := httptest.NewRecorder()
-			req := httptest.NewRequest("GET", fmt.Sprintf("/api/lists/%d/items/", testCase.input.listId), nil)
+			req := httptest.NewRequest("GET", fmt.Sprintf("/api/lists/%d/items/%s", testCase.input.listId, testCase.input.query), nil)
 
 			r.ServeHTTP(w, req)
 
@@ -294,6 +420,7 @@ func TestHandler_deleteItem(t *testing.T) {
 		input                input
 		expectedStatusCode   int
 		expectedResponseBody string
+		expectedUndoToken    string
 		mockBehavior         mockBehavior
 	}{
 		{
@@ -304,8 +431,9 @@ func TestHandler_deleteItem(t *testing.T) {
 			},
 			expectedStatusCode:   200,
 			expectedResponseBody: `{"status":"ok"}`,
+			expectedUndoToken:    "d6d5c5a1-8b53-4c1e-9a0b-3c3d2b6f0e11",
 			mockBehavior: func(r *mockservice.MockTodoItem, input input) {
-				r.EXPECT().Delete(input.userId, input.itemId).Return(nil)
+				r.EXPECT().Delete(input.userId, input.itemId, nil).Return("d6d5c5a1-8b53-4c1e-9a0b-3c3d2b6f0e11", nil)
 			},
 		},
 		{
@@ -317,7 +445,7 @@ func TestHandler_deleteItem(t *testing.T) {
 			expectedStatusCode:   500,
 			expectedResponseBody: `{"message":"service failure"}`,
 			mockBehavior: func(r *mockservice.MockTodoItem, input input) {
-				r.EXPECT().Delete(input.userId, input.itemId).Return(errors.New("service failure"))
+				r.EXPECT().Delete(input.userId, input.itemId, nil).Return("", errors.New("service failure"))
 			},
 		},
 		{
@@ -325,10 +453,10 @@ func TestHandler_deleteItem(t *testing.T) {
 			input: input{
 				userId: 1,
 			},
-			expectedStatusCode:   500,
+			expectedStatusCode:   404,
 			expectedResponseBody: `{"message":"record not found"}`,
 			mockBehavior: func(r *mockservice.MockTodoItem, input input) {
-				r.EXPECT().Delete(input.userId, input.itemId).Return(errors.New("record not found"))
+				r.EXPECT().Delete(input.userId, input.itemId, nil).Return("", service.ErrRecordNotFound)
 			},
 		},
 	}
@@ -356,6 +484,7 @@ func TestHandler_deleteItem(t *testing.T) {
 
 			assert.Equal(t, testCase.expectedStatusCode, w.Code)
 			assert.Equal(t, testCase.expectedResponseBody, w.Body.String())
+			assert.Equal(t, testCase.expectedUndoToken, w.Header().Get("Undo-Token"))
 		})
 	}
 }
@@ -364,7 +493,7 @@ func TestHandler_updateItem(t *testing.T) {
 	type input struct {
 		userId int
 		itemId int
-		item   structs.UpdateItemInput
+		item   structs.ReplaceItemInput
 	}
 
 	type mockBehavior func(s *mockservice.MockTodoItem, input input)
@@ -376,74 +505,190 @@ func TestHandler_updateItem(t *testing.T) {
 		mockBehavior         mockBehavior
 		expectedStatusCode   int
 		expectedResponseBody string
+		expectedUndoToken    string
 	}{
 		{
 			name: "Ok",
 			input: input{
 				userId: 1,
 				itemId: 1,
-				item: structs.UpdateItemInput{
-					Title:       stringPointer("title"),
-					Description: stringPointer("description"),
-					Done:        boolPointer(true),
+				item: structs.ReplaceItemInput{
+					Title:       "title",
+					Description: "description",
+					Done:        true,
 				},
 			},
 			expectedStatusCode:   200,
 			expectedResponseBody: `{"status":"ok"}`,
 			inputBody:            `{"title":"title","description":"description","done":true}`,
 			mockBehavior: func(r *mockservice.MockTodoItem, input input) {
-				r.EXPECT().Update(input.userId, input.itemId, input.item).Return(nil)
+				r.EXPECT().Replace(input.userId, input.itemId, input.item).Return("d6d5c5a1-8b53-4c1e-9a0b-3c3d2b6f0e11", nil)
 			},
+			expectedUndoToken: "d6d5c5a1-8b53-4c1e-9a0b-3c3d2b6f0e11",
 		},
 		{
-			name: "Ok_WithoutTitle",
+			name: "Ok_Recurrence",
 			input: input{
 				userId: 1,
 				itemId: 1,
-				item: structs.UpdateItemInput{
-					Description: stringPointer("description"),
-					Done:        boolPointer(true),
+				item: structs.ReplaceItemInput{
+					Title:      "title",
+					DueDate:    timePointer(time.Date(2026, 10, 20, 9, 0, 0, 0, time.UTC)),
+					Recurrence: stringPointer("FREQ=WEEKLY;BYDAY=MO"),
 				},
 			},
 			expectedStatusCode:   200,
 			expectedResponseBody: `{"status":"ok"}`,
-			inputBody:            `{"description":"description","done":true}`,
+			inputBody:            `{"title":"title","due_date":"2026-10-20T09:00:00Z","recurrence":"FREQ=WEEKLY;BYDAY=MO"}`,
 			mockBehavior: func(r *mockservice.MockTodoItem, input input) {
-				r.EXPECT().Update(input.userId, input.itemId, input.item).Return(nil)
+				r.EXPECT().Replace(input.userId, input.itemId, input.item).Return("", nil)
 			},
 		},
 		{
-			name: "Ok_WithoutDescription",
+			name: "Ok_PriorityAssignee",
 			input: input{
 				userId: 1,
 				itemId: 1,
-				item: structs.UpdateItemInput{
-					Title: stringPointer("title"),
-					Done:  boolPointer(true),
+				item: structs.ReplaceItemInput{
+					Title:      "title",
+					Priority:   structs.PriorityHigh,
+					AssigneeId: intPointer(2),
 				},
 			},
 			expectedStatusCode:   200,
 			expectedResponseBody: `{"status":"ok"}`,
-			inputBody:            `{"title":"title","done":true}`,
+			inputBody:            `{"title":"title","priority":"high","assignee_id":2}`,
+			mockBehavior: func(r *mockservice.MockTodoItem, input input) {
+				r.EXPECT().Replace(input.userId, input.itemId, input.item).Return("", nil)
+			},
+		},
+		{
+			name:                 "Unknown priority",
+			input:                input{userId: 1, itemId: 1},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"message":"invalid input body"}`,
+			inputBody:            `{"title":"title","priority":"critical"}`,
+			mockBehavior:         func(r *mockservice.MockTodoItem, input input) {},
+		},
+		{
+			name: "Assignee without access",
+			input: input{
+				userId: 1,
+				itemId: 1,
+				item:   structs.ReplaceItemInput{Title: "title", AssigneeId: intPointer(3)},
+			},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"message":"` + service.ErrInvalidAssignee.Error() + `"}`,
+			inputBody:            `{"title":"title","assignee_id":3}`,
+			mockBehavior: func(r *mockservice.MockTodoItem, input input) {
+				r.EXPECT().Replace(input.userId, input.itemId, input.item).Return("", service.ErrInvalidAssignee)
+			},
+		},
+		{
+			name: "Ok_LongDescription",
+			input: input{
+				userId: 1,
+				itemId: 1,
+				item: structs.ReplaceItemInput{
+					Title:       "title",
+					Description: strings.Repeat("a", 65535),
+				},
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"status":"ok"}`,
+			inputBody:            `{"title":"title","description":"` + strings.Repeat("a", 65535) + `"}`,
 			mockBehavior: func(r *mockservice.MockTodoItem, input input) {
-				r.EXPECT().Update(input.userId, input.itemId, input.item).Return(nil)
+				r.EXPECT().Replace(input.userId, input.itemId, input.item).Return("", nil)
 			},
 		},
 		{
-			name: "Ok_WithoutDone",
+			name: "Description too long",
 			input: input{
 				userId: 1,
 				itemId: 1,
-				item: structs.UpdateItemInput{
-					Description: stringPointer("description"),
-					Title:       stringPointer("title"),
+			},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"message":"invalid input body"}`,
+			inputBody:            `{"title":"title","description":"` + strings.Repeat("a", 65536) + `"}`,
+			mockBehavior:         func(r *mockservice.MockTodoItem, input input) {},
+		},
+		{
+			name: "Ok_Status",
+			input: input{
+				userId: 1,
+				itemId: 1,
+				item: structs.ReplaceItemInput{
+					Title:    "title",
+					StatusId: intPointer(3),
+				},
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"status":"ok"}`,
+			inputBody:            `{"title":"title","status_id":3}`,
+			mockBehavior: func(r *mockservice.MockTodoItem, input input) {
+				r.EXPECT().Replace(input.userId, input.itemId, input.item).Return("", nil)
+			},
+		},
+		{
+			name: "Transition not allowed",
+			input: input{
+				userId: 1,
+				itemId: 1,
+				item: structs.ReplaceItemInput{
+					Title:    "title",
+					StatusId: intPointer(3),
+				},
+			},
+			expectedStatusCode:   409,
+			expectedResponseBody: `{"message":"status transition is not allowed"}`,
+			inputBody:            `{"title":"title","status_id":3}`,
+			mockBehavior: func(r *mockservice.MockTodoItem, input input) {
+				r.EXPECT().Replace(input.userId, input.itemId, input.item).Return("", service.ErrTransitionNotAllowed)
+			},
+		},
+		{
+			name: "Status of another list",
+			input: input{
+				userId: 1,
+				itemId: 1,
+				item: structs.ReplaceItemInput{
+					Title:    "title",
+					StatusId: intPointer(9),
+				},
+			},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"message":"status doesn't belong to the item's list"}`,
+			inputBody:            `{"title":"title","status_id":9}`,
+			mockBehavior: func(r *mockservice.MockTodoItem, input input) {
+				r.EXPECT().Replace(input.userId, input.itemId, input.item).Return("", service.ErrInvalidStatus)
+			},
+		},
+		{
+			name: "Without title",
+			input: input{
+				userId: 1,
+				itemId: 1,
+			},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"message":"invalid input body"}`,
+			inputBody:            `{"description":"description","done":true}`,
+			mockBehavior:         func(r *mockservice.MockTodoItem, input input) {},
+		},
+		{
+			name: "Ok_WithoutDescription",
+			input: input{
+				userId: 1,
+				itemId: 1,
+				item: structs.ReplaceItemInput{
+					Title: "title",
+					Done:  true,
 				},
 			},
 			expectedStatusCode:   200,
 			expectedResponseBody: `{"status":"ok"}`,
-			inputBody:            `{"description":"description","title":"title"}`,
+			inputBody:            `{"title":"title","description":null,"done":true}`,
 			mockBehavior: func(r *mockservice.MockTodoItem, input input) {
-				r.EXPECT().Update(input.userId, input.itemId, input.item).Return(nil)
+				r.EXPECT().Replace(input.userId, input.itemId, input.item).Return("", nil)
 			},
 		},
 		{
@@ -451,17 +696,34 @@ func TestHandler_updateItem(t *testing.T) {
 			input: input{
 				userId: 1,
 				itemId: 1,
-				item: structs.UpdateItemInput{
-					Description: stringPointer("description"),
-					Title:       stringPointer("title"),
-					Done:        boolPointer(true),
+				item: structs.ReplaceItemInput{
+					Title:       "title",
+					Description: "description",
+					Done:        true,
 				},
 			},
 			expectedStatusCode:   500,
 			expectedResponseBody: `{"message":"not found"}`,
 			inputBody:            `{"description":"description","title":"title","done":true}`,
 			mockBehavior: func(r *mockservice.MockTodoItem, input input) {
-				r.EXPECT().Update(input.userId, input.itemId, input.item).Return(errors.New("not found"))
+				r.EXPECT().Replace(input.userId, input.itemId, input.item).Return("", errors.New("not found"))
+			},
+		},
+		{
+			name: "Blocked",
+			input: input{
+				userId: 1,
+				itemId: 1,
+				item: structs.ReplaceItemInput{
+					Title: "title",
+					Done:  true,
+				},
+			},
+			expectedStatusCode:   409,
+			expectedResponseBody: `{"message":"item is blocked by open items"}`,
+			inputBody:            `{"title":"title","done":true}`,
+			mockBehavior: func(r *mockservice.MockTodoItem, input input) {
+				r.EXPECT().Replace(input.userId, input.itemId, input.item).Return("", service.ErrItemBlocked)
 			},
 		},
 		{
@@ -479,17 +741,17 @@ func TestHandler_updateItem(t *testing.T) {
 			input: input{
 				userId: 1,
 				itemId: 1,
-				item: structs.UpdateItemInput{
-					Title:       stringPointer("title"),
-					Description: stringPointer("description"),
-					Done:        boolPointer(true),
+				item: structs.ReplaceItemInput{
+					Title:       "title",
+					Description: "description",
+					Done:        true,
 				},
 			},
 			expectedStatusCode:   500,
 			expectedResponseBody: `{"message":"service failure"}`,
 			inputBody:            `{"title":"title","description":"description","done":true}`,
 			mockBehavior: func(r *mockservice.MockTodoItem, input input) {
-				r.EXPECT().Update(input.userId, input.itemId, input.item).Return(errors.New("service failure"))
+				r.EXPECT().Replace(input.userId, input.itemId, input.item).Return("", errors.New("service failure"))
 			},
 		},
 	}
@@ -517,6 +779,285 @@ func TestHandler_updateItem(t *testing.T) {
 
 			assert.Equal(t, testCase.expectedStatusCode, w.Code)
 			assert.Equal(t, testCase.expectedResponseBody, w.Body.String())
+			assert.Equal(t, testCase.expectedUndoToken, w.Header().Get("Undo-Token"))
+		})
+	}
+}
+
+func TestHandler_getItemCompletions(t *testing.T) {
+	type input struct {
+		userId int
+		itemId int
+	}
+
+	type mockBehavior func(r *mockservice.MockTodoItem, input input)
+
+	dueDate := time.Date(2026, 10, 20, 9, 0, 0, 0, time.UTC)
+	completedAt := time.Date(2026, 10, 20, 11, 30, 0, 0, time.UTC)
+
+	testTable := []struct {
+		name                 string
+		input                input
+		expectedStatusCode   int
+		expectedResponseBody string
+		mockBehavior         mockBehavior
+	}{
+		{
+			name: "Ok",
+			input: input{
+				userId: 1,
+				itemId: 1,
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"data":[{"id":1,"item_id":1,"due_date":"2026-10-20T09:00:00Z","completed_at":"2026-10-20T11:30:00Z"}]}`,
+			mockBehavior: func(r *mockservice.MockTodoItem, input input) {
+				r.EXPECT().GetCompletions(input.userId, input.itemId).Return([]structs.ItemCompletion{
+					{
+						Id:          1,
+						ItemId:      1,
+						DueDate:     &dueDate,
+						CompletedAt: completedAt,
+					},
+				}, nil)
+			},
+		},
+		{
+			name: "Not found",
+			input: input{
+				userId: 1,
+				itemId: 1,
+			},
+			expectedStatusCode:   500,
+			expectedResponseBody: `{"message":"record not found"}`,
+			mockBehavior: func(r *mockservice.MockTodoItem, input input) {
+				r.EXPECT().GetCompletions(input.userId, input.itemId).Return(nil, errors.New("record not found"))
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			item := mockservice.NewMockTodoItem(c)
+			testCase.mockBehavior(item, testCase.input)
+
+			services := &service.Service{TodoItem: item}
+			handler := NewHandler(services)
+
+			r := gin.New()
+			r.GET("/api/items/:id/completions", func(c *gin.Context) {
+				c.Set(userCtx, testCase.input.userId)
+			}, handler.getItemCompletions)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", fmt.Sprintf("/api/items/%d/completions", testCase.input.itemId), nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_moveItem(t *testing.T) {
+	type input struct {
+		userId int
+		itemId int
+		move   structs.MoveItemInput
+	}
+
+	type mockBehavior func(r *mockservice.MockTodoItem, input input)
+
+	testTable := []struct {
+		name                 string
+		input                input
+		inputBody            string
+		expectedStatusCode   int
+		expectedResponseBody string
+		mockBehavior         mockBehavior
+	}{
+		{
+			name: "Ok",
+			input: input{
+				userId: 1,
+				itemId: 1,
+				move:   structs.MoveItemInput{ListId: 2},
+			},
+			inputBody:            `{"list_id":2}`,
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"status":"ok"}`,
+			mockBehavior: func(r *mockservice.MockTodoItem, input input) {
+				r.EXPECT().Move(input.userId, input.itemId, input.move).Return("", nil)
+			},
+		},
+		{
+			name: "No target list",
+			input: input{
+				userId: 1,
+				itemId: 1,
+			},
+			inputBody:            `{}`,
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"message":"invalid input body"}`,
+			mockBehavior:         func(r *mockservice.MockTodoItem, input input) {},
+		},
+		{
+			name: "Not found",
+			input: input{
+				userId: 1,
+				itemId: 1,
+				move:   structs.MoveItemInput{ListId: 2},
+			},
+			inputBody:            `{"list_id":2}`,
+			expectedStatusCode:   500,
+			expectedResponseBody: `{"message":"record not found"}`,
+			mockBehavior: func(r *mockservice.MockTodoItem, input input) {
+				r.EXPECT().Move(input.userId, input.itemId, input.move).Return("", errors.New("record not found"))
+			},
+		},
+		{
+			name: "Archived target list",
+			input: input{
+				userId: 1,
+				itemId: 1,
+				move:   structs.MoveItemInput{ListId: 2},
+			},
+			inputBody:            `{"list_id":2}`,
+			expectedStatusCode:   409,
+			expectedResponseBody: `{"message":"the list is archived, unarchive it first"}`,
+			mockBehavior: func(r *mockservice.MockTodoItem, input input) {
+				r.EXPECT().Move(input.userId, input.itemId, input.move).Return("", service.ErrListArchived)
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			item := mockservice.NewMockTodoItem(c)
+			testCase.mockBehavior(item, testCase.input)
+
+			services := &service.Service{TodoItem: item}
+			handler := NewHandler(services)
+
+			r := gin.New()
+			r.POST("/api/items/:id/move", func(c *gin.Context) {
+				c.Set(userCtx, testCase.input.userId)
+			}, handler.moveItem)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", fmt.Sprintf("/api/items/%d/move", testCase.input.itemId), bytes.NewBufferString(testCase.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_copyItem(t *testing.T) {
+	type input struct {
+		userId int
+		itemId int
+		copy   structs.CopyItemInput
+	}
+
+	type mockBehavior func(r *mockservice.MockTodoItem, input input)
+
+	testTable := []struct {
+		name                 string
+		input                input
+		inputBody            string
+		expectedStatusCode   int
+		expectedResponseBody string
+		expectedUndoToken    string
+		mockBehavior         mockBehavior
+	}{
+		{
+			name: "Ok",
+			input: input{
+				userId: 1,
+				itemId: 1,
+				copy:   structs.CopyItemInput{ListId: 2, Labels: true},
+			},
+			inputBody:            `{"list_id":2,"labels":true}`,
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"id":3}`,
+			mockBehavior: func(r *mockservice.MockTodoItem, input input) {
+				r.EXPECT().Copy(input.userId, input.itemId, input.copy).Return(3, "d6d5c5a1-8b53-4c1e-9a0b-3c3d2b6f0e11", nil)
+			},
+			expectedUndoToken: "d6d5c5a1-8b53-4c1e-9a0b-3c3d2b6f0e11",
+		},
+		{
+			name: "No inputs",
+			input: input{
+				userId: 1,
+				itemId: 1,
+			},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"message":"invalid input body"}`,
+			mockBehavior:         func(r *mockservice.MockTodoItem, input input) {},
+		},
+		{
+			name: "Service failure",
+			input: input{
+				userId: 1,
+				itemId: 1,
+				copy:   structs.CopyItemInput{ListId: 2},
+			},
+			inputBody:            `{"list_id":2}`,
+			expectedStatusCode:   500,
+			expectedResponseBody: `{"message":"service failure"}`,
+			mockBehavior: func(r *mockservice.MockTodoItem, input input) {
+				r.EXPECT().Copy(input.userId, input.itemId, input.copy).Return(0, "", errors.New("service failure"))
+			},
+		},
+		{
+			name: "Archived target list",
+			input: input{
+				userId: 1,
+				itemId: 1,
+				copy:   structs.CopyItemInput{ListId: 2},
+			},
+			inputBody:            `{"list_id":2}`,
+			expectedStatusCode:   409,
+			expectedResponseBody: `{"message":"the list is archived, unarchive it first"}`,
+			mockBehavior: func(r *mockservice.MockTodoItem, input input) {
+				r.EXPECT().Copy(input.userId, input.itemId, input.copy).Return(0, "", service.ErrListArchived)
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			item := mockservice.NewMockTodoItem(c)
+			testCase.mockBehavior(item, testCase.input)
+
+			services := &service.Service{TodoItem: item}
+			handler := NewHandler(services)
+
+			r := gin.New()
+			r.POST("/api/items/:id/copy", func(c *gin.Context) {
+				c.Set(userCtx, testCase.input.userId)
+			}, handler.copyItem)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", fmt.Sprintf("/api/items/%d/copy", testCase.input.itemId), bytes.NewBufferString(testCase.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedResponseBody, w.Body.String())
+			assert.Equal(t, testCase.expectedUndoToken, w.Header().Get("Undo-Token"))
 		})
 	}
 }
@@ -528,3 +1069,11 @@ func stringPointer(s string) *string {
 func boolPointer(b bool) *bool {
 	return &b
 }
+
+func intPointer(i int) *int {
+	return &i
+}
+
+func timePointer(t time.Time) *time.Time {
+	return &t
+}
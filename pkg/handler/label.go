@@ -0,0 +1,327 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/fr13n8/todo-app/structs"
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Create label
+// @Security ApiKeyAuth
+// @Tags labels
+// @Description create label
+// @ID create-label
+// @Accept  json
+// @Produce  json
+// @Param input body structs.Label true "label info"
+// @Success 200 {integer} integer 1
+// @Header 200 {string} Undo-Token "token undoing the changes"
+// @Failure 400,404 {object} HTTPError
+// @Failure 409 {object} HTTPError
+// @Failure 500 {object} HTTPError
+// @Failure default {object} HTTPError
+// @Router /api/labels [post]
+func (h *Handler) createLabel(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	var input structs.Label
+	if err := c.BindJSON(&input); err != nil {
+		newResponseError(c, http.StatusBadRequest, errors.New("invalid input body"))
+		return
+	}
+
+	id, token, err := h.services.Label.Create(userId, input)
+	if err != nil {
+		newResponseError(c, serviceErrorStatus(err), err)
+		return
+	}
+	setUndoToken(c, token)
+
+	c.JSON(http.StatusOK, gin.H{"id": id})
+}
+
+type getAllLabelsResponse struct {
+	Data []structs.Label `json:"data"`
+}
+
+// @Summary Get All Labels
+// @Security ApiKeyAuth
+// @Tags labels
+// @Description get all labels
+// @ID get-all-labels
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} getAllLabelsResponse
+// @Failure 400,404 {object} HTTPError
+// @Failure 500 {object} HTTPError
+// @Failure default {object} HTTPError
+// @Router /api/labels [get]
+func (h *Handler) getAllLabels(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	labels, err := h.services.Label.GetAll(userId)
+	if err != nil {
+		newResponseError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, getAllLabelsResponse{
+		Data: labels,
+	})
+}
+
+type getLabelResponse struct {
+	Data structs.Label `json:"data"`
+}
+
+// @Summary Get Label By Id
+// @Security ApiKeyAuth
+// @Tags labels
+// @Description get label by id
+// @ID get-label-by-id
+// @Accept  json
+// @Produce  json
+// @Param id path int true "label id"
+// @Success 200 {object} getLabelResponse
+// @Failure 400,404 {object} HTTPError
+// @Failure 500 {object} HTTPError
+// @Failure default {object} HTTPError
+// @Router /api/labels/:id [get]
+func (h *Handler) getLabelById(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	labelId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	label, err := h.services.Label.GetById(userId, labelId)
+	if err != nil {
+		newResponseError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, getLabelResponse{
+		Data: label,
+	})
+}
+
+// @Summary Get Label Items
+// @Security ApiKeyAuth
+// @Tags labels
+// @Description get items tagged with the label across all lists
+// @ID get-label-items
+// @Accept  json
+// @Produce  json
+// @Param id path int true "label id"
+// @Success 200 {object} getAllItemsResponse
+// @Failure 400,404 {object} HTTPError
+// @Failure 500 {object} HTTPError
+// @Failure default {object} HTTPError
+// @Router /api/labels/:id/items [get]
+func (h *Handler) getLabelItems(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	labelId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	items, err := h.services.Label.GetItems(userId, labelId)
+	if err != nil {
+		newResponseError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, getAllItemsResponse{
+		Data: items,
+	})
+}
+
+// @Summary Update label
+// @Security ApiKeyAuth
+// @Tags labels
+// @Description update label
+// @ID update-label
+// @Accept  json
+// @Produce  json
+// @Param id path int true "label id"
+// @Param input body structs.UpdateLabelInput true "label info"
+// @Success 200 {string} string Ok
+// @Header 200 {string} Undo-Token "token undoing the changes"
+// @Failure 400,404 {object} HTTPError
+// @Failure 409 {object} HTTPError
+// @Failure 500 {object} HTTPError
+// @Failure default {object} HTTPError
+// @Router /api/labels/:id [put]
+func (h *Handler) updateLabel(c *gin.Context) {
+	var input structs.UpdateLabelInput
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	labelId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := c.BindJSON(&input); err != nil {
+		newResponseError(c, http.StatusBadRequest, errors.New("invalid input body"))
+		return
+	}
+
+	token, err := h.services.Label.Update(userId, labelId, input)
+	if err != nil {
+		newResponseError(c, serviceErrorStatus(err), err)
+		return
+	}
+	setUndoToken(c, token)
+
+	c.JSON(http.StatusOK, StatusResponse{
+		Status: "ok",
+	})
+}
+
+// @Summary Delete label
+// @Security ApiKeyAuth
+// @Tags labels
+// @Description delete label by id
+// @ID delete-label-by-id
+// @Accept  json
+// @Produce  json
+// @Param id path int true "label id"
+// @Success 200 {string} Ok
+// @Header 200 {string} Undo-Token "token undoing the changes"
+// @Failure 400,404 {object} HTTPError
+// @Failure 500 {object} HTTPError
+// @Failure default {object} HTTPError
+// @Router /api/labels/:id [delete]
+func (h *Handler) deleteLabel(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	labelId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	token, err := h.services.Label.Delete(userId, labelId)
+	if err != nil {
+		newResponseError(c, http.StatusInternalServerError, err)
+		return
+	}
+	setUndoToken(c, token)
+
+	c.JSON(http.StatusOK, StatusResponse{
+		Status: "ok",
+	})
+}
+
+// @Summary Attach label to item
+// @Security ApiKeyAuth
+// @Tags labels
+// @Description attach label to item
+// @ID attach-label
+// @Accept  json
+// @Produce  json
+// @Param id path int true "item id"
+// @Param label_id path int true "label id"
+// @Success 200 {string} string Ok
+// @Header 200 {string} Undo-Token "token undoing the changes"
+// @Failure 400,404 {object} HTTPError
+// @Failure 500 {object} HTTPError
+// @Failure default {object} HTTPError
+// @Router /api/items/:id/labels/:label_id [post]
+func (h *Handler) attachLabel(c *gin.Context) {
+	userId, itemId, labelId, err := getItemLabelParams(c)
+	if err != nil {
+		return
+	}
+
+	token, err := h.services.Label.Attach(userId, itemId, labelId)
+	if err != nil {
+		newResponseError(c, http.StatusInternalServerError, err)
+		return
+	}
+	setUndoToken(c, token)
+
+	c.JSON(http.StatusOK, StatusResponse{
+		Status: "ok",
+	})
+}
+
+// @Summary Detach label from item
+// @Security ApiKeyAuth
+// @Tags labels
+// @Description detach label from item
+// @ID detach-label
+// @Accept  json
+// @Produce  json
+// @Param id path int true "item id"
+// @Param label_id path int true "label id"
+// @Success 200 {string} string Ok
+// @Header 200 {string} Undo-Token "token undoing the changes"
+// @Failure 400,404 {object} HTTPError
+// @Failure 500 {object} HTTPError
+// @Failure default {object} HTTPError
+// @Router /api/items/:id/labels/:label_id [delete]
+func (h *Handler) detachLabel(c *gin.Context) {
+	userId, itemId, labelId, err := getItemLabelParams(c)
+	if err != nil {
+		return
+	}
+
+	token, err := h.services.Label.Detach(userId, itemId, labelId)
+	if err != nil {
+		newResponseError(c, http.StatusInternalServerError, err)
+		return
+	}
+	setUndoToken(c, token)
+
+	c.JSON(http.StatusOK, StatusResponse{
+		Status: "ok",
+	})
+}
+
+func getItemLabelParams(c *gin.Context) (int, int, int, error) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	itemId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newResponseError(c, http.StatusBadRequest, err)
+		return 0, 0, 0, err
+	}
+
+	labelId, err := strconv.Atoi(c.Param("label_id"))
+	if err != nil {
+		newResponseError(c, http.StatusBadRequest, err)
+		return 0, 0, 0, err
+	}
+
+	return userId, itemId, labelId, nil
+}
@@ -0,0 +1,396 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fr13n8/todo-app/pkg/service"
+	mockservice "github.com/fr13n8/todo-app/pkg/service/mocks"
+	"github.com/fr13n8/todo-app/structs"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_createLabel(t *testing.T) {
+	type input struct {
+		userId int
+		label  structs.Label
+	}
+
+	type mockBehavior func(s *mockservice.MockLabel, input input)
+
+	testTable := []struct {
+		name                 string
+		input                input
+		inputBody            string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+		expectedUndoToken    string
+	}{
+		{
+			name: "Ok",
+			input: input{
+				userId: 1,
+				label: structs.Label{
+					Name:  "work",
+					Color: "#ff0000",
+				},
+			},
+			inputBody:            `{"name":"work","color":"#ff0000"}`,
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"id":1}`,
+			mockBehavior: func(s *mockservice.MockLabel, input input) {
+				s.EXPECT().Create(input.userId, input.label).Return(1, "d6d5c5a1-8b53-4c1e-9a0b-3c3d2b6f0e11", nil)
+			},
+			expectedUndoToken: "d6d5c5a1-8b53-4c1e-9a0b-3c3d2b6f0e11",
+		},
+		{
+			name: "Ok_WithoutColor",
+			input: input{
+				userId: 1,
+				label: structs.Label{
+					Name: "work",
+				},
+			},
+			inputBody:            `{"name":"work"}`,
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"id":1}`,
+			mockBehavior: func(s *mockservice.MockLabel, input input) {
+				s.EXPECT().Create(input.userId, input.label).Return(1, "", nil)
+			},
+		},
+		{
+			name: "Invalid color",
+			input: input{
+				userId: 1,
+			},
+			inputBody:            `{"name":"work","color":"red"}`,
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"message":"invalid input body"}`,
+			mockBehavior:         func(s *mockservice.MockLabel, input input) {},
+		},
+		{
+			name: "No inputs",
+			input: input{
+				userId: 1,
+			},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"message":"invalid input body"}`,
+			mockBehavior:         func(s *mockservice.MockLabel, input input) {},
+		},
+		{
+			name: "Service failure",
+			input: input{
+				userId: 1,
+				label: structs.Label{
+					Name: "work",
+				},
+			},
+			inputBody:            `{"name":"work"}`,
+			expectedStatusCode:   500,
+			expectedResponseBody: `{"message":"service failure"}`,
+			mockBehavior: func(s *mockservice.MockLabel, input input) {
+				s.EXPECT().Create(input.userId, input.label).Return(0, "", errors.New("service failure"))
+			},
+		},
+		{
+			name: "Duplicate name",
+			input: input{
+				userId: 1,
+				label: structs.Label{
+					Name: "work",
+				},
+			},
+			inputBody:            `{"name":"work"}`,
+			expectedStatusCode:   409,
+			expectedResponseBody: `{"message":"a label with that name already exists"}`,
+			mockBehavior: func(s *mockservice.MockLabel, input input) {
+				s.EXPECT().Create(input.userId, input.label).Return(0, "", service.ErrLabelExists)
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			label := mockservice.NewMockLabel(c)
+			testCase.mockBehavior(label, testCase.input)
+
+			services := &service.Service{Label: label}
+			handler := NewHandler(services)
+
+			r := gin.New()
+			r.POST("/api/labels/", func(c *gin.Context) {
+				c.Set(userCtx, testCase.input.userId)
+			}, handler.createLabel)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/api/labels/", bytes.NewBufferString(testCase.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedResponseBody, w.Body.String())
+			assert.Equal(t, testCase.expectedUndoToken, w.Header().Get("Undo-Token"))
+		})
+	}
+}
+
+func TestHandler_getLabelItems(t *testing.T) {
+	type input struct {
+		userId  int
+		labelId int
+	}
+
+	type mockBehavior func(s *mockservice.MockLabel, input input)
+
+	testTable := []struct {
+		name                 string
+		input                input
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "Ok",
+			input: input{
+				userId:  1,
+				labelId: 1,
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"data":[{"id":1,"title":"title","description":"description","done":false,"labels":[{"id":1,"name":"work","color":"#ff0000"}]}]}`,
+			mockBehavior: func(s *mockservice.MockLabel, input input) {
+				s.EXPECT().GetItems(input.userId, input.labelId).Return([]structs.Item{
+					{
+						Id:          1,
+						Title:       "title",
+						Description: "description",
+						Labels:      []structs.Label{{Id: 1, Name: "work", Color: "#ff0000"}},
+					},
+				}, nil)
+			},
+		},
+		{
+			name: "Not found",
+			input: input{
+				userId:  1,
+				labelId: 1,
+			},
+			expectedStatusCode:   500,
+			expectedResponseBody: `{"message":"record not found"}`,
+			mockBehavior: func(s *mockservice.MockLabel, input input) {
+				s.EXPECT().GetItems(input.userId, input.labelId).Return(nil, errors.New("record not found"))
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			label := mockservice.NewMockLabel(c)
+			testCase.mockBehavior(label, testCase.input)
+
+			services := &service.Service{Label: label}
+			handler := NewHandler(services)
+
+			r := gin.New()
+			r.GET("/api/labels/:id/items", func(c *gin.Context) {
+				c.Set(userCtx, testCase.input.userId)
+			}, handler.getLabelItems)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", fmt.Sprintf("/api/labels/%d/items", testCase.input.labelId), nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_updateLabel(t *testing.T) {
+	type input struct {
+		userId  int
+		labelId int
+		label   structs.UpdateLabelInput
+	}
+
+	type mockBehavior func(s *mockservice.MockLabel, input input)
+
+	testTable := []struct {
+		name                 string
+		input                input
+		inputBody            string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+		expectedUndoToken    string
+	}{
+		{
+			name: "Ok",
+			input: input{
+				userId:  1,
+				labelId: 1,
+				label: structs.UpdateLabelInput{
+					Color: stringPointer("#00ff00"),
+				},
+			},
+			inputBody:            `{"color":"#00ff00"}`,
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"status":"ok"}`,
+			mockBehavior: func(s *mockservice.MockLabel, input input) {
+				s.EXPECT().Update(input.userId, input.labelId, input.label).Return("d6d5c5a1-8b53-4c1e-9a0b-3c3d2b6f0e11", nil)
+			},
+			expectedUndoToken: "d6d5c5a1-8b53-4c1e-9a0b-3c3d2b6f0e11",
+		},
+		{
+			name: "Invalid color",
+			input: input{
+				userId:  1,
+				labelId: 1,
+			},
+			inputBody:            `{"color":"green"}`,
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"message":"invalid input body"}`,
+			mockBehavior:         func(s *mockservice.MockLabel, input input) {},
+		},
+		{
+			name: "Service failure",
+			input: input{
+				userId:  1,
+				labelId: 1,
+				label: structs.UpdateLabelInput{
+					Name: stringPointer("home"),
+				},
+			},
+			inputBody:            `{"name":"home"}`,
+			expectedStatusCode:   500,
+			expectedResponseBody: `{"message":"record not found"}`,
+			mockBehavior: func(s *mockservice.MockLabel, input input) {
+				s.EXPECT().Update(input.userId, input.labelId, input.label).Return("", errors.New("record not found"))
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			label := mockservice.NewMockLabel(c)
+			testCase.mockBehavior(label, testCase.input)
+
+			services := &service.Service{Label: label}
+			handler := NewHandler(services)
+
+			r := gin.New()
+			r.PUT("/api/labels/:id", func(c *gin.Context) {
+				c.Set(userCtx, testCase.input.userId)
+			}, handler.updateLabel)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("PUT", fmt.Sprintf("/api/labels/%d", testCase.input.labelId), bytes.NewBufferString(testCase.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedResponseBody, w.Body.String())
+			assert.Equal(t, testCase.expectedUndoToken, w.Header().Get("Undo-Token"))
+		})
+	}
+}
+
+func TestHandler_attachLabel(t *testing.T) {
+	type input struct {
+		userId  int
+		itemId  int
+		labelId string
+	}
+
+	type mockBehavior func(s *mockservice.MockLabel, input input)
+
+	testTable := []struct {
+		name                 string
+		input                input
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+		expectedUndoToken    string
+	}{
+		{
+			name: "Ok",
+			input: input{
+				userId:  1,
+				itemId:  1,
+				labelId: "2",
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"status":"ok"}`,
+			mockBehavior: func(s *mockservice.MockLabel, input input) {
+				s.EXPECT().Attach(input.userId, input.itemId, 2).Return("d6d5c5a1-8b53-4c1e-9a0b-3c3d2b6f0e11", nil)
+			},
+			expectedUndoToken: "d6d5c5a1-8b53-4c1e-9a0b-3c3d2b6f0e11",
+		},
+		{
+			name: "Invalid label id",
+			input: input{
+				userId:  1,
+				itemId:  1,
+				labelId: "work",
+			},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"message":"strconv.Atoi: parsing \"work\": invalid syntax"}`,
+			mockBehavior:         func(s *mockservice.MockLabel, input input) {},
+		},
+		{
+			name: "Not found",
+			input: input{
+				userId:  1,
+				itemId:  1,
+				labelId: "2",
+			},
+			expectedStatusCode:   500,
+			expectedResponseBody: `{"message":"record not found"}`,
+			mockBehavior: func(s *mockservice.MockLabel, input input) {
+				s.EXPECT().Attach(input.userId, input.itemId, 2).Return("", errors.New("record not found"))
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			label := mockservice.NewMockLabel(c)
+			testCase.mockBehavior(label, testCase.input)
+
+			services := &service.Service{Label: label}
+			handler := NewHandler(services)
+
+			r := gin.New()
+			r.POST("/api/items/:id/labels/:label_id", func(c *gin.Context) {
+				c.Set(userCtx, testCase.input.userId)
+			}, handler.attachLabel)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", fmt.Sprintf("/api/items/%d/labels/%s", testCase.input.itemId, testCase.input.labelId), nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedResponseBody, w.Body.String())
+			assert.Equal(t, testCase.expectedUndoToken, w.Header().Get("Undo-Token"))
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/fr13n8/todo-app/pkg/service"
 	"github.com/fr13n8/todo-app/structs"
 	"github.com/gin-gonic/gin"
 )
@@ -18,6 +19,7 @@ import (
 // @Produce  json
 // @Param input body structs.List true "list info"
 // @Success 200 {integer} integer 1
+// @Header 200 {string} Undo-Token "token undoing the changes"
 // @Failure 400,404 {object} HTTPError
 // @Failure 500 {object} HTTPError
 // @Failure default {object} HTTPError
@@ -34,17 +36,20 @@ func (h *Handler) createList(c *gin.Context) {
 		return
 	}
 
-	id, err := h.services.TodoList.Create(userId, input)
+	id, token, err := h.services.TodoList.Create(userId, input)
 	if err != nil {
 		newResponseError(c, http.StatusInternalServerError, err)
 		return
 	}
+	setUndoToken(c, token)
 
 	c.JSON(http.StatusOK, gin.H{"id": id})
 }
 
 type getAllListResponse struct {
-	Data []structs.List `json:"data"`
+	Data       []structs.List `json:"data"`
+	Total      int            `json:"total"`
+	NextCursor string         `json:"next_cursor,omitempty"`
 }
 
 // @Summary Get All Lists
@@ -54,7 +59,29 @@ type getAllListResponse struct {
 // @ID get-all-lists
 // @Accept  json
 // @Produce  json
+// @Param sort query string false "comma separated keys among id (default), title, created_at, updated_at and completed_at, descending when prefixed with -"
+// @Param order query string false "direction of the keys without a prefix, asc (default) or desc"
+// @Param done query bool false "only the lists whose items are all done, or only the others"
+// @Param title~ query string false "text the title contains, ignoring case"
+// @Param limit query int false "page size, 1 to 500; all lists when omitted"
+// @Param cursor query string false "next_cursor of the previous page"
+// @Param created_after query string false "RFC 3339 time"
+// @Param created_before query string false "RFC 3339 time"
+// @Param updated_after query string false "RFC 3339 time"
+// @Param updated_before query string false "RFC 3339 time"
+// @Param completed_after query string false "RFC 3339 time"
+// @Param completed_before query string false "RFC 3339 time"
+// @Param created_by query int false "creator user id"
+// @Param updated_by query int false "last editor user id"
+// @Param archived query bool false "list the archived lists instead"
+// @Param views query bool false "append the saved views as smart lists"
+// @Param render query string false "html to add the description rendered from Markdown"
+// @Param If-None-Match header string false "ETag of the copy the client holds"
+// @Param If-Modified-Since header string false "Last-Modified of the copy the client holds"
 // @Success 200 {object} getAllListResponse
+// @Header 200 {string} ETag "state of the lists and the query"
+// @Header 200 {string} Last-Modified "time of the last change"
+// @Success 304 {string} string "not modified"
 // @Failure 400,404 {object} HTTPError
 // @Failure 500 {object} HTTPError
 // @Failure default {object} HTTPError
@@ -65,14 +92,42 @@ func (h *Handler) getAllList(c *gin.Context) {
 		return
 	}
 
-	lists, err := h.services.TodoList.GetAll(userId)
+	var filter structs.ListFilter
+	if err := c.BindQuery(&filter); err != nil {
+		newResponseError(c, http.StatusBadRequest, errors.New("invalid query params"))
+		return
+	}
+
+	render, err := bindRender(c)
+	if err != nil {
+		newResponseError(c, http.StatusBadRequest, errors.New("invalid query params"))
+		return
+	}
+
+	stamp, err := h.services.TodoList.Stamp(userId)
 	if err != nil {
 		newResponseError(c, http.StatusInternalServerError, err)
 		return
 	}
+	if notModified(c, collectionETag(c, stamp), stamp.LastModified) {
+		return
+	}
+
+	lists, page, err := h.services.TodoList.GetAll(userId, filter)
+	if err != nil {
+		newResponseError(c, serviceErrorStatus(err), err)
+		return
+	}
+	if render {
+		for i := range lists {
+			renderList(&lists[i])
+		}
+	}
 
 	c.JSON(http.StatusOK, getAllListResponse{
-		Data: lists,
+		Data:       lists,
+		Total:      page.Total,
+		NextCursor: page.NextCursor,
 	})
 }
 
@@ -88,7 +143,13 @@ type getListResponse struct {
 // @Accept  json
 // @Produce  json
 // @Param id path int true "List id"
+// @Param render query string false "html to add the description rendered from Markdown"
+// @Param If-None-Match header string false "ETag of the copy the client holds"
+// @Param If-Modified-Since header string false "Last-Modified of the copy the client holds"
 // @Success 200 {object} getListResponse
+// @Header 200 {string} ETag "version of the list and digest of the response"
+// @Header 200 {string} Last-Modified "time of the last change"
+// @Success 304 {string} string "not modified"
 // @Failure 400,404 {object} HTTPError
 // @Failure 500 {object} HTTPError
 // @Failure default {object} HTTPError
@@ -105,32 +166,49 @@ func (h *Handler) getListById(c *gin.Context) {
 		return
 	}
 
+	render, err := bindRender(c)
+	if err != nil {
+		newResponseError(c, http.StatusBadRequest, errors.New("invalid query params"))
+		return
+	}
+
 	list, err := h.services.TodoList.GetById(listId, userId)
 	if err != nil {
-		newResponseError(c, http.StatusInternalServerError, err)
+		newResponseError(c, serviceErrorStatus(err), err)
 		return
 	}
+	if render {
+		renderList(&list)
+	}
 
-	c.JSON(http.StatusOK, getListResponse{
+	response := getListResponse{
 		Data: list,
-	})
+	}
+	if notModified(c, representationETag(list.Version, render, response), latest(list.UpdatedAt, list.ArchivedAt, list.CompletedAt)) {
+		return
+	}
+	c.JSON(http.StatusOK, response)
 }
 
 // @Summary Update todo list
 // @Security ApiKeyAuth
 // @Tags lists
-// @Description update todo list
+// @Description replace all the editable fields of todo list, clearing the ones left out
 // @ID update-list
 // @Accept  json
 // @Produce  json
-// @Param input body structs.UpdateListInput true "list info"
+// @Param id path int true "list id"
+// @Param input body structs.ReplaceListInput true "list info"
+// @Param If-Match header string false "ETag of the version to change"
 // @Success 200 {string} string Ok
+// @Header 200 {string} Undo-Token "token undoing the changes"
 // @Failure 400,404 {object} HTTPError
+// @Failure 412 {object} getListResponse
 // @Failure 500 {object} HTTPError
 // @Failure default {object} HTTPError
-// @Router /api/lists [put]
+// @Router /api/lists/:id [put]
 func (h *Handler) updateList(c *gin.Context) {
-	var input structs.UpdateListInput
+	var input structs.ReplaceListInput
 	userId, err := getUserId(c)
 	if err != nil {
 		return
@@ -147,11 +225,87 @@ func (h *Handler) updateList(c *gin.Context) {
 		return
 	}
 
-	if err := h.services.TodoList.Update(listId, userId, input); err != nil {
-		newResponseError(c, http.StatusInternalServerError, err)
+	var ok bool
+	if input.Version, ok = h.matchList(c, userId, listId); !ok {
+		return
+	}
+
+	token, err := h.services.TodoList.Replace(listId, userId, input)
+	if err != nil {
+		if errors.Is(err, service.ErrVersionMismatch) {
+			h.listChanged(c, userId, listId)
+			return
+		}
+		newResponseError(c, serviceErrorStatus(err), err)
+		return
+	}
+	setUndoToken(c, token)
+
+	c.JSON(http.StatusOK, StatusResponse{
+		Status: "ok",
+	})
+}
+
+// @Summary Patch todo list
+// @Security ApiKeyAuth
+// @Tags lists
+// @Description update some fields of todo list with a JSON merge patch (RFC 7396) or a JSON patch (RFC 6902) of its editable fields, as PUT takes them
+// @ID patch-list
+// @Accept  application/merge-patch+json,application/json-patch+json
+// @Produce  json
+// @Param id path int true "list id"
+// @Param input body object true "patch"
+// @Param If-Match header string false "ETag of the version to change"
+// @Success 200 {string} string Ok
+// @Header 200 {string} Undo-Token "token undoing the changes"
+// @Failure 400,404 {object} HTTPError
+// @Failure 409 {object} HTTPError
+// @Failure 415 {object} HTTPError
+// @Failure 422 {object} HTTPError
+// @Failure 412 {object} getListResponse
+// @Failure 500 {object} HTTPError
+// @Failure default {object} HTTPError
+// @Router /api/lists/:id [patch]
+func (h *Handler) patchList(c *gin.Context) {
+	var input structs.ReplaceListInput
+	userId, err := getUserId(c)
+	if err != nil {
 		return
 	}
 
+	listId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	list, err := h.services.TodoList.GetById(listId, userId)
+	if err != nil {
+		newResponseError(c, serviceErrorStatus(err), err)
+		return
+	}
+	if _, ok := ifMatch(c, list.Version); !ok {
+		preconditionFailed(c, list.Version, getListResponse{Data: list})
+		return
+	}
+	if !bindPatch(c, list.Replacement(), &input) {
+		return
+	}
+
+	// The patch applies to this version; another change in the meantime
+	// would be undone by replacing the fields it left alone.
+	input.Version = &list.Version
+	token, err := h.services.TodoList.Replace(listId, userId, input)
+	if err != nil {
+		if errors.Is(err, service.ErrVersionMismatch) {
+			h.listChanged(c, userId, listId)
+			return
+		}
+		newResponseError(c, serviceErrorStatus(err), err)
+		return
+	}
+	setUndoToken(c, token)
+
 	c.JSON(http.StatusOK, StatusResponse{
 		Status: "ok",
 	})
@@ -165,8 +319,11 @@ func (h *Handler) updateList(c *gin.Context) {
 // @Accept  json
 // @Produce  json
 // @Param id path int true "List id"
+// @Param If-Match header string false "ETag of the version to change"
 // @Success 200 {string} Ok
+// @Header 200 {string} Undo-Token "token undoing the changes"
 // @Failure 400,404 {object} HTTPError
+// @Failure 412 {object} getListResponse
 // @Failure 500 {object} HTTPError
 // @Failure default {object} HTTPError
 // @Router /api/lists/:id [delete]
@@ -182,14 +339,132 @@ func (h *Handler) deleteList(c *gin.Context) {
 		return
 	}
 
-	err = h.services.TodoList.Delete(listId, userId)
+	version, ok := h.matchList(c, userId, listId)
+	if !ok {
+		return
+	}
+
+	token, err := h.services.TodoList.Delete(listId, userId, version)
+
+	if err != nil {
+		if errors.Is(err, service.ErrVersionMismatch) {
+			h.listChanged(c, userId, listId)
+			return
+		}
+		newResponseError(c, serviceErrorStatus(err), err)
+		return
+	}
+	setUndoToken(c, token)
+
+	c.JSON(http.StatusOK, StatusResponse{
+		Status: "ok",
+	})
+}
+
+// @Summary Archive List
+// @Security ApiKeyAuth
+// @Tags lists
+// @Description hide the list from the list overview without deleting it
+// @ID archive-list
+// @Accept  json
+// @Produce  json
+// @Param id path int true "List id"
+// @Success 200 {object} StatusResponse
+// @Header 200 {string} Undo-Token "token undoing the changes"
+// @Failure 400,404 {object} HTTPError
+// @Failure 500 {object} HTTPError
+// @Failure default {object} HTTPError
+// @Router /api/lists/:id/archive [post]
+func (h *Handler) archiveList(c *gin.Context) {
+	h.setListArchived(c, true)
+}
+
+// @Summary Unarchive List
+// @Security ApiKeyAuth
+// @Tags lists
+// @Description bring an archived list back to the list overview
+// @ID unarchive-list
+// @Accept  json
+// @Produce  json
+// @Param id path int true "List id"
+// @Success 200 {object} StatusResponse
+// @Header 200 {string} Undo-Token "token undoing the changes"
+// @Failure 400,404 {object} HTTPError
+// @Failure 500 {object} HTTPError
+// @Failure default {object} HTTPError
+// @Router /api/lists/:id/unarchive [post]
+func (h *Handler) unarchiveList(c *gin.Context) {
+	h.setListArchived(c, false)
+}
+
+func (h *Handler) setListArchived(c *gin.Context, archived bool) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	listId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newResponseError(c, http.StatusBadRequest, err)
+		return
+	}
 
+	var token string
+	if archived {
+		token, err = h.services.TodoList.Archive(listId, userId)
+	} else {
+		token, err = h.services.TodoList.Unarchive(listId, userId)
+	}
 	if err != nil {
 		newResponseError(c, http.StatusInternalServerError, err)
 		return
 	}
+	setUndoToken(c, token)
 
 	c.JSON(http.StatusOK, StatusResponse{
 		Status: "ok",
 	})
 }
+
+// @Summary Duplicate List
+// @Security ApiKeyAuth
+// @Tags lists
+// @Description copy the list with its statuses and, optionally, its items, their completion state, labels and attachments
+// @ID duplicate-list
+// @Accept  json
+// @Produce  json
+// @Param id path int true "List id"
+// @Param input body structs.DuplicateListInput true "what to copy"
+// @Success 200 {integer} integer 1
+// @Header 200 {string} Undo-Token "token undoing the changes"
+// @Failure 400,404,413 {object} HTTPError
+// @Failure 500 {object} HTTPError
+// @Failure default {object} HTTPError
+// @Router /api/lists/:id/duplicate [post]
+func (h *Handler) duplicateList(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	listId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	var input structs.DuplicateListInput
+	if err := c.BindJSON(&input); err != nil {
+		newResponseError(c, http.StatusBadRequest, errors.New("invalid input body"))
+		return
+	}
+
+	id, token, err := h.services.TodoList.Duplicate(userId, listId, input)
+	if err != nil {
+		newResponseError(c, serviceErrorStatus(err), err)
+		return
+	}
+	setUndoToken(c, token)
+
+	c.JSON(http.StatusOK, gin.H{"id": id})
+}
@@ -5,7 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/fr13n8/todo-app/pkg/service"
 	mockservice "github.com/fr13n8/todo-app/pkg/service/mocks"
@@ -30,6 +32,7 @@ func TestHandler_createList(t *testing.T) {
 		inputBody            string
 		expectedStatusCode   int
 		expectedResponseBody string
+		expectedUndoToken    string
 	}{
 		{
 			name: "Ok",
@@ -44,8 +47,9 @@ func TestHandler_createList(t *testing.T) {
 			expectedStatusCode:   200,
 			expectedResponseBody: `{"id":1}`,
 			mockBehavior: func(r *mockservice.MockTodoList, input input) {
-				r.EXPECT().Create(input.userId, input.list).Return(1, nil)
+				r.EXPECT().Create(input.userId, input.list).Return(1, "d6d5c5a1-8b53-4c1e-9a0b-3c3d2b6f0e11", nil)
 			},
+			expectedUndoToken: "d6d5c5a1-8b53-4c1e-9a0b-3c3d2b6f0e11",
 		},
 		{
 			name: "OK_WithoutDescription",
@@ -59,7 +63,7 @@ func TestHandler_createList(t *testing.T) {
 			expectedStatusCode:   200,
 			expectedResponseBody: `{"id":1}`,
 			mockBehavior: func(r *mockservice.MockTodoList, input input) {
-				r.EXPECT().Create(input.userId, input.list).Return(1, nil)
+				r.EXPECT().Create(input.userId, input.list).Return(1, "", nil)
 			},
 		},
 		{
@@ -71,6 +75,16 @@ func TestHandler_createList(t *testing.T) {
 			expectedResponseBody: `{"message":"invalid input body"}`,
 			mockBehavior:         func(r *mockservice.MockTodoList, input input) {},
 		},
+		{
+			name: "Title too long",
+			input: input{
+				userId: 1,
+			},
+			inputBody:            `{"title":"` + strings.Repeat("a", 256) + `"}`,
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"message":"invalid input body"}`,
+			mockBehavior:         func(r *mockservice.MockTodoList, input input) {},
+		},
 		{
 			name: "Service failure",
 			input: input{
@@ -84,7 +98,7 @@ func TestHandler_createList(t *testing.T) {
 			expectedStatusCode:   500,
 			expectedResponseBody: `{"message":"service failure"}`,
 			mockBehavior: func(r *mockservice.MockTodoList, input input) {
-				r.EXPECT().Create(input.userId, input.list).Return(0, errors.New("service failure"))
+				r.EXPECT().Create(input.userId, input.list).Return(0, "", errors.New("service failure"))
 			},
 		},
 	}
@@ -112,6 +126,7 @@ func TestHandler_createList(t *testing.T) {
 
 			assert.Equal(t, testCase.expectedStatusCode, w.Code)
 			assert.Equal(t, testCase.expectedResponseBody, w.Body.String())
+			assert.Equal(t, testCase.expectedUndoToken, w.Header().Get("Undo-Token"))
 		})
 	}
 }
@@ -119,10 +134,16 @@ func TestHandler_createList(t *testing.T) {
 func TestHandler_getAllList(t *testing.T) {
 	type input struct {
 		userId int
+		query  string
+		filter structs.ListFilter
 	}
 
 	type mockBehavior func(mockservice *mockservice.MockTodoList, input input)
 
+	createdAfter := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	createdAt := time.Date(2021, 6, 2, 10, 0, 0, 0, time.UTC)
+	updatedAt := time.Date(2021, 6, 3, 10, 0, 0, 0, time.UTC)
+
 	testTable := []struct {
 		name                 string
 		input                input
@@ -136,9 +157,10 @@ func TestHandler_getAllList(t *testing.T) {
 				userId: 1,
 			},
 			expectedStatusCode:   200,
-			expectedResponseBody: `{"data":[{"id":1,"title":"title","description":"description"},{"id":2,"title":"title2","description":"description2"}]}`,
+			expectedResponseBody: `{"data":[{"id":1,"title":"title","description":"description"},{"id":2,"title":"title2","description":"description2"}],"total":2}`,
 			mockBehavior: func(r *mockservice.MockTodoList, input input) {
-				r.EXPECT().GetAll(input.userId).Return([]structs.List{
+				r.EXPECT().Stamp(input.userId).Return(structs.CollectionStamp{}, nil)
+				r.EXPECT().GetAll(input.userId, input.filter).Return([]structs.List{
 					{
 						Id:          1,
 						Title:       "title",
@@ -149,9 +171,111 @@ func TestHandler_getAllList(t *testing.T) {
 						Title:       "title2",
 						Description: "description2",
 					},
-				}, nil)
+				}, structs.PageInfo{Total: 2}, nil)
 			},
 		},
+		{
+			name: "Filtered and sorted",
+			input: input{
+				userId: 1,
+				query:  "?sort=updated_at&order=desc&created_after=2021-06-01T00:00:00Z&created_by=1",
+				filter: structs.ListFilter{AuditFilter: structs.AuditFilter{
+					Sort:         "updated_at",
+					Order:        "desc",
+					CreatedAfter: &createdAfter,
+					CreatedBy:    intPointer(1),
+				}},
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"data":[{"id":1,"title":"title","description":"description","created_at":"2021-06-02T10:00:00Z","updated_at":"2021-06-03T10:00:00Z","created_by":1,"updated_by":1}],"total":1}`,
+			mockBehavior: func(r *mockservice.MockTodoList, input input) {
+				r.EXPECT().Stamp(input.userId).Return(structs.CollectionStamp{}, nil)
+				r.EXPECT().GetAll(input.userId, input.filter).Return([]structs.List{
+					{
+						Id:          1,
+						Title:       "title",
+						Description: "description",
+						CreatedAt:   &createdAt,
+						UpdatedAt:   &updatedAt,
+						CreatedBy:   intPointer(1),
+						UpdatedBy:   intPointer(1),
+					},
+				}, structs.PageInfo{Total: 1}, nil)
+			},
+		},
+		{
+			name: "With views",
+			input: input{
+				userId: 1,
+				query:  "?views=true",
+				filter: structs.ListFilter{Views: true},
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"data":[{"id":1,"title":"title","description":"description"},{"id":3,"title":"this week","description":"","smart":true}],"total":2}`,
+			mockBehavior: func(r *mockservice.MockTodoList, input input) {
+				r.EXPECT().Stamp(input.userId).Return(structs.CollectionStamp{}, nil)
+				r.EXPECT().GetAll(input.userId, input.filter).Return([]structs.List{
+					{
+						Id:          1,
+						Title:       "title",
+						Description: "description",
+					},
+					{
+						Id:    3,
+						Title: "this week",
+						Smart: true,
+					},
+				}, structs.PageInfo{Total: 2}, nil)
+			},
+		},
+		{
+			name: "Paged",
+			input: input{
+				userId: 1,
+				query:  "?sort=-updated_at,title&done=false&title~=work&limit=1&cursor=abc",
+				filter: structs.ListFilter{
+					AuditFilter:   structs.AuditFilter{Sort: "-updated_at,title"},
+					Done:          boolPointer(false),
+					TitleContains: "work",
+					PageFilter:    structs.PageFilter{Cursor: "abc", Limit: 1},
+				},
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"data":[{"id":1,"title":"work","description":""}],"total":3,"next_cursor":"def"}`,
+			mockBehavior: func(r *mockservice.MockTodoList, input input) {
+				r.EXPECT().Stamp(input.userId).Return(structs.CollectionStamp{}, nil)
+				r.EXPECT().GetAll(input.userId, input.filter).Return([]structs.List{
+					{
+						Id:    1,
+						Title: "work",
+					},
+				}, structs.PageInfo{Total: 3, NextCursor: "def"}, nil)
+			},
+		},
+		{
+			name: "Unknown sort",
+			input: input{
+				userId: 1,
+				query:  "?sort=color",
+				filter: structs.ListFilter{AuditFilter: structs.AuditFilter{Sort: "color"}},
+			},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"message":"invalid sort key \"color\""}`,
+			mockBehavior: func(r *mockservice.MockTodoList, input input) {
+				r.EXPECT().Stamp(input.userId).Return(structs.CollectionStamp{}, nil)
+				r.EXPECT().GetAll(input.userId, input.filter).Return(nil, structs.PageInfo{}, fmt.Errorf("%w %q", service.ErrInvalidSort, "color"))
+			},
+		},
+		{
+			name: "Limit too large",
+			input: input{
+				userId: 1,
+				query:  "?limit=501",
+			},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"message":"invalid query params"}`,
+			mockBehavior:         func(r *mockservice.MockTodoList, input input) {},
+		},
 		{
 			name: "Not found",
 			input: input{
@@ -160,7 +284,8 @@ func TestHandler_getAllList(t *testing.T) {
 			expectedStatusCode:   500,
 			expectedResponseBody: `{"message":"record not found"}`,
 			mockBehavior: func(r *mockservice.MockTodoList, input input) {
-				r.EXPECT().GetAll(input.userId).Return(nil, errors.New("record not found"))
+				r.EXPECT().Stamp(input.userId).Return(structs.CollectionStamp{}, nil)
+				r.EXPECT().GetAll(input.userId, input.filter).Return(nil, structs.PageInfo{}, errors.New("record not found"))
 			},
 		},
 		{
@@ -171,7 +296,8 @@ func TestHandler_getAllList(t *testing.T) {
 			expectedStatusCode:   500,
 			expectedResponseBody: `{"message":"service failure"}`,
 			mockBehavior: func(r *mockservice.MockTodoList, input input) {
-				r.EXPECT().GetAll(input.userId).Return(nil, errors.New("service failure"))
+				r.EXPECT().Stamp(input.userId).Return(structs.CollectionStamp{}, nil)
+				r.EXPECT().GetAll(input.userId, input.filter).Return(nil, structs.PageInfo{}, errors.New("service failure"))
 			},
 		},
 	}
@@ -193,7 +319,7 @@ func TestHandler_getAllList(t *testing.T) {
 			}, handler.getAllList)
 
 			w := httptest.NewRecorder()
-			req := httptest.NewRequest("GET", "/api/lists/", nil)
+			req := httptest.NewRequest("GET", "/api/lists/"+testCase.input.query, nil)
 
 			r.ServeHTTP(w, req)
 
@@ -207,6 +333,7 @@ func TestHandler_getListById(t *testing.T) {
 	type input struct {
 		listId int
 		userId int
+		query  string
 	}
 
 	type mockBehavior func(mockservice *mockservice.MockTodoList, input input)
@@ -234,6 +361,34 @@ func TestHandler_getListById(t *testing.T) {
 				}, nil)
 			},
 		},
+		{
+			name: "Rendered",
+			input: input{
+				userId: 1,
+				listId: 1,
+				query:  "?render=html",
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"data":{"id":1,"title":"title","description":"- [x] **done**","description_html":"\u003cul\u003e\n\u003cli\u003e\u003cinput type=\"checkbox\" checked disabled\u003e \u003cstrong\u003edone\u003c/strong\u003e\u003c/li\u003e\n\u003c/ul\u003e\n"}}`,
+			mockBehavior: func(r *mockservice.MockTodoList, input input) {
+				r.EXPECT().GetById(input.listId, input.userId).Return(structs.List{
+					Id:          1,
+					Title:       "title",
+					Description: "- [x] **done**",
+				}, nil)
+			},
+		},
+		{
+			name: "Unknown render",
+			input: input{
+				userId: 1,
+				listId: 1,
+				query:  "?render=pdf",
+			},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"message":"invalid query params"}`,
+			mockBehavior:         func(r *mockservice.MockTodoList, input input) {},
+		},
 		{
 			name: "Not found",
 			input: input{
@@ -276,7 +431,7 @@ func TestHandler_getListById(t *testing.T) {
 			}, handler.getListById)
 
 			w := httptest.NewRecorder()
-			req := httptest.NewRequest("GET", fmt.Sprintf("/api/lists/%d", testCase.input.listId), nil)
+			req := httptest.NewRequest("GET", fmt.Sprintf("/api/lists/%d%s", testCase.input.listId, testCase.input.query), nil)
 
 			r.ServeHTTP(w, req)
 
@@ -300,6 +455,7 @@ func TestHandler_deleteList(t *testing.T) {
 		mockBehavior         mockBehavior
 		expectedStatusCode   int
 		expectedResponseBody string
+		expectedUndoToken    string
 	}{
 		{
 			name: "Ok",
@@ -309,8 +465,9 @@ func TestHandler_deleteList(t *testing.T) {
 			},
 			expectedStatusCode:   200,
 			expectedResponseBody: `{"status":"ok"}`,
+			expectedUndoToken:    "d6d5c5a1-8b53-4c1e-9a0b-3c3d2b6f0e11",
 			mockBehavior: func(r *mockservice.MockTodoList, input input) {
-				r.EXPECT().Delete(input.listId, input.userId).Return(nil)
+				r.EXPECT().Delete(input.listId, input.userId, nil).Return("d6d5c5a1-8b53-4c1e-9a0b-3c3d2b6f0e11", nil)
 			},
 		},
 		{
@@ -322,7 +479,7 @@ func TestHandler_deleteList(t *testing.T) {
 			expectedStatusCode:   500,
 			expectedResponseBody: `{"message":"service failure"}`,
 			mockBehavior: func(r *mockservice.MockTodoList, input input) {
-				r.EXPECT().Delete(input.listId, input.userId).Return(errors.New("service failure"))
+				r.EXPECT().Delete(input.listId, input.userId, nil).Return("", errors.New("service failure"))
 			},
 		},
 		{
@@ -330,10 +487,10 @@ func TestHandler_deleteList(t *testing.T) {
 			input: input{
 				userId: 1,
 			},
-			expectedStatusCode:   500,
+			expectedStatusCode:   404,
 			expectedResponseBody: `{"message":"record not found"}`,
 			mockBehavior: func(r *mockservice.MockTodoList, input input) {
-				r.EXPECT().Delete(input.listId, input.userId).Return(errors.New("record not found"))
+				r.EXPECT().Delete(input.listId, input.userId, nil).Return("", service.ErrRecordNotFound)
 			},
 		},
 	}
@@ -361,6 +518,7 @@ func TestHandler_deleteList(t *testing.T) {
 
 			assert.Equal(t, testCase.expectedStatusCode, w.Code)
 			assert.Equal(t, testCase.expectedResponseBody, w.Body.String())
+			assert.Equal(t, testCase.expectedUndoToken, w.Header().Get("Undo-Token"))
 		})
 	}
 }
@@ -369,7 +527,7 @@ func TestHandler_updateList(t *testing.T) {
 	type input struct {
 		listId int
 		userId int
-		list   structs.UpdateListInput
+		list   structs.ReplaceListInput
 	}
 
 	type mockBehavior func(mockservice *mockservice.MockTodoList, input input)
@@ -381,54 +539,68 @@ func TestHandler_updateList(t *testing.T) {
 		inputBody            string
 		expectedStatusCode   int
 		expectedResponseBody string
+		expectedUndoToken    string
 	}{
 		{
 			name: "Ok",
 			input: input{
 				userId: 1,
 				listId: 1,
-				list: structs.UpdateListInput{
-					Title:       stringPointer("title"),
-					Description: stringPointer("description"),
+				list: structs.ReplaceListInput{
+					Title:       "title",
+					Description: "description",
 				},
 			},
 			expectedStatusCode:   200,
 			expectedResponseBody: `{"status":"ok"}`,
 			inputBody:            `{"title":"title","description":"description"}`,
 			mockBehavior: func(r *mockservice.MockTodoList, input input) {
-				r.EXPECT().Update(input.listId, input.userId, input.list).Return(nil)
+				r.EXPECT().Replace(input.listId, input.userId, input.list).Return("d6d5c5a1-8b53-4c1e-9a0b-3c3d2b6f0e11", nil)
 			},
+			expectedUndoToken: "d6d5c5a1-8b53-4c1e-9a0b-3c3d2b6f0e11",
 		},
 		{
-			name: "Ok_WithoutTitle",
+			name: "Ok_AutoArchive",
 			input: input{
 				userId: 1,
 				listId: 1,
-				list: structs.UpdateListInput{
-					Description: stringPointer("description"),
+				list: structs.ReplaceListInput{
+					Title:           "title",
+					AutoArchiveDays: intPointer(7),
 				},
 			},
 			expectedStatusCode:   200,
 			expectedResponseBody: `{"status":"ok"}`,
-			inputBody:            `{"description":"description"}`,
+			inputBody:            `{"title":"title","auto_archive_days":7}`,
 			mockBehavior: func(r *mockservice.MockTodoList, input input) {
-				r.EXPECT().Update(input.listId, input.userId, input.list).Return(nil)
+				r.EXPECT().Replace(input.listId, input.userId, input.list).Return("", nil)
+			},
+		},
+		{
+			name: "Without title",
+			input: input{
+				userId: 1,
+				listId: 1,
 			},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"message":"invalid input body"}`,
+			inputBody:            `{"description":"description"}`,
+			mockBehavior:         func(r *mockservice.MockTodoList, input input) {},
 		},
 		{
 			name: "Ok_WithoutDescription",
 			input: input{
 				userId: 1,
 				listId: 1,
-				list: structs.UpdateListInput{
-					Title: stringPointer("title"),
+				list: structs.ReplaceListInput{
+					Title: "title",
 				},
 			},
 			expectedStatusCode:   200,
 			expectedResponseBody: `{"status":"ok"}`,
 			inputBody:            `{"title":"title"}`,
 			mockBehavior: func(r *mockservice.MockTodoList, input input) {
-				r.EXPECT().Update(input.listId, input.userId, input.list).Return(nil)
+				r.EXPECT().Replace(input.listId, input.userId, input.list).Return("", nil)
 			},
 		},
 		{
@@ -436,16 +608,16 @@ func TestHandler_updateList(t *testing.T) {
 			input: input{
 				userId: 1,
 				listId: 1,
-				list: structs.UpdateListInput{
-					Description: stringPointer("description"),
-					Title:       stringPointer("title"),
+				list: structs.ReplaceListInput{
+					Title:       "title",
+					Description: "description",
 				},
 			},
 			expectedStatusCode:   500,
 			expectedResponseBody: `{"message":"not found"}`,
 			inputBody:            `{"description":"description","title":"title"}`,
 			mockBehavior: func(r *mockservice.MockTodoList, input input) {
-				r.EXPECT().Update(input.listId, input.userId, input.list).Return(errors.New("not found"))
+				r.EXPECT().Replace(input.listId, input.userId, input.list).Return("", errors.New("not found"))
 			},
 		},
 		{
@@ -463,16 +635,16 @@ func TestHandler_updateList(t *testing.T) {
 			input: input{
 				userId: 1,
 				listId: 1,
-				list: structs.UpdateListInput{
-					Title:       stringPointer("title"),
-					Description: stringPointer("description"),
+				list: structs.ReplaceListInput{
+					Title:       "title",
+					Description: "description",
 				},
 			},
 			expectedStatusCode:   500,
 			expectedResponseBody: `{"message":"service failure"}`,
 			inputBody:            `{"title":"title","description":"description","done":true}`,
 			mockBehavior: func(r *mockservice.MockTodoList, input input) {
-				r.EXPECT().Update(input.listId, input.userId, input.list).Return(errors.New("service failure"))
+				r.EXPECT().Replace(input.listId, input.userId, input.list).Return("", errors.New("service failure"))
 			},
 		},
 	}
@@ -500,6 +672,187 @@ func TestHandler_updateList(t *testing.T) {
 
 			assert.Equal(t, testCase.expectedStatusCode, w.Code)
 			assert.Equal(t, testCase.expectedResponseBody, w.Body.String())
+			assert.Equal(t, testCase.expectedUndoToken, w.Header().Get("Undo-Token"))
+		})
+	}
+}
+
+func TestHandler_archiveList(t *testing.T) {
+	type mockBehavior func(mockservice *mockservice.MockTodoList, listId int)
+
+	testTable := []struct {
+		name                 string
+		path                 string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+		expectedUndoToken    string
+	}{
+		{
+			name:                 "Archive",
+			path:                 "/api/lists/1/archive",
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"status":"ok"}`,
+			mockBehavior: func(r *mockservice.MockTodoList, listId int) {
+				r.EXPECT().Archive(listId, 1).Return("d6d5c5a1-8b53-4c1e-9a0b-3c3d2b6f0e11", nil)
+			},
+			expectedUndoToken: "d6d5c5a1-8b53-4c1e-9a0b-3c3d2b6f0e11",
+		},
+		{
+			name:                 "Unarchive",
+			path:                 "/api/lists/1/unarchive",
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"status":"ok"}`,
+			mockBehavior: func(r *mockservice.MockTodoList, listId int) {
+				r.EXPECT().Unarchive(listId, 1).Return("", nil)
+			},
+		},
+		{
+			name:                 "Not found",
+			path:                 "/api/lists/1/archive",
+			expectedStatusCode:   500,
+			expectedResponseBody: `{"message":"record not found"}`,
+			mockBehavior: func(r *mockservice.MockTodoList, listId int) {
+				r.EXPECT().Archive(listId, 1).Return("", errors.New("record not found"))
+			},
+		},
+		{
+			name:                 "Invalid id",
+			path:                 "/api/lists/abc/archive",
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"message":"strconv.Atoi: parsing \"abc\": invalid syntax"}`,
+			mockBehavior:         func(r *mockservice.MockTodoList, listId int) {},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			list := mockservice.NewMockTodoList(c)
+			testCase.mockBehavior(list, 1)
+
+			services := &service.Service{TodoList: list}
+			handler := NewHandler(services)
+
+			r := gin.New()
+			setUser := func(c *gin.Context) {
+				c.Set(userCtx, 1)
+			}
+			r.POST("/api/lists/:id/archive", setUser, handler.archiveList)
+			r.POST("/api/lists/:id/unarchive", setUser, handler.unarchiveList)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", testCase.path, nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedResponseBody, w.Body.String())
+			assert.Equal(t, testCase.expectedUndoToken, w.Header().Get("Undo-Token"))
+		})
+	}
+}
+
+func TestHandler_duplicateList(t *testing.T) {
+	type mockBehavior func(s *mockservice.MockTodoList, input structs.DuplicateListInput)
+
+	testTable := []struct {
+		name                 string
+		path                 string
+		inputBody            string
+		input                structs.DuplicateListInput
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+		expectedUndoToken    string
+	}{
+		{
+			name:                 "Ok",
+			path:                 "/api/lists/1/duplicate",
+			inputBody:            `{"title":"copy","items":true,"labels":true}`,
+			input:                structs.DuplicateListInput{Title: "copy", Items: true, Labels: true},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"id":2}`,
+			mockBehavior: func(r *mockservice.MockTodoList, input structs.DuplicateListInput) {
+				r.EXPECT().Duplicate(1, 1, input).Return(2, "d6d5c5a1-8b53-4c1e-9a0b-3c3d2b6f0e11", nil)
+			},
+			expectedUndoToken: "d6d5c5a1-8b53-4c1e-9a0b-3c3d2b6f0e11",
+		},
+		{
+			name:                 "Empty options",
+			path:                 "/api/lists/1/duplicate",
+			inputBody:            `{}`,
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"id":2}`,
+			mockBehavior: func(r *mockservice.MockTodoList, input structs.DuplicateListInput) {
+				r.EXPECT().Duplicate(1, 1, input).Return(2, "", nil)
+			},
+		},
+		{
+			name:                 "Quota exceeded",
+			path:                 "/api/lists/1/duplicate",
+			inputBody:            `{"items":true,"attachments":true}`,
+			input:                structs.DuplicateListInput{Items: true, Attachments: true},
+			expectedStatusCode:   413,
+			expectedResponseBody: fmt.Sprintf(`{"message":"%s"}`, service.ErrQuotaExceeded.Error()),
+			mockBehavior: func(r *mockservice.MockTodoList, input structs.DuplicateListInput) {
+				r.EXPECT().Duplicate(1, 1, input).Return(0, "", service.ErrQuotaExceeded)
+			},
+		},
+		{
+			name:                 "Not found",
+			path:                 "/api/lists/1/duplicate",
+			inputBody:            `{}`,
+			expectedStatusCode:   500,
+			expectedResponseBody: `{"message":"record not found"}`,
+			mockBehavior: func(r *mockservice.MockTodoList, input structs.DuplicateListInput) {
+				r.EXPECT().Duplicate(1, 1, input).Return(0, "", errors.New("record not found"))
+			},
+		},
+		{
+			name:                 "Invalid input",
+			path:                 "/api/lists/1/duplicate",
+			inputBody:            fmt.Sprintf(`{"title":"%s"}`, strings.Repeat("a", 256)),
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"message":"invalid input body"}`,
+			mockBehavior:         func(r *mockservice.MockTodoList, input structs.DuplicateListInput) {},
+		},
+		{
+			name:                 "Invalid id",
+			path:                 "/api/lists/abc/duplicate",
+			inputBody:            `{}`,
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"message":"strconv.Atoi: parsing \"abc\": invalid syntax"}`,
+			mockBehavior:         func(r *mockservice.MockTodoList, input structs.DuplicateListInput) {},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			list := mockservice.NewMockTodoList(c)
+			testCase.mockBehavior(list, testCase.input)
+
+			services := &service.Service{TodoList: list}
+			handler := NewHandler(services)
+
+			r := gin.New()
+			r.POST("/api/lists/:id/duplicate", func(c *gin.Context) {
+				c.Set(userCtx, 1)
+			}, handler.duplicateList)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", testCase.path, bytes.NewBufferString(testCase.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedResponseBody, w.Body.String())
+			assert.Equal(t, testCase.expectedUndoToken, w.Header().Get("Undo-Token"))
 		})
 	}
 }
@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/fr13n8/todo-app/pkg/jsonpatch"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+const (
+	mergePatchType = "application/merge-patch+json"
+	jsonPatchType  = "application/json-patch+json"
+)
+
+var errUnsupportedPatch = errors.New("unsupported patch type, send " + mergePatchType + " or " + jsonPatchType)
+
+// bindPatch applies the patch in the request body to doc, the editable fields
+// of the resource, and binds the result to obj. A patch that can't be applied
+// answers with 409 and one leaving invalid fields with 422. It reports
+// whether binding succeeded; when it didn't the response is already written.
+func bindPatch(c *gin.Context, doc interface{}, obj interface{}) bool {
+	current, err := json.Marshal(doc)
+	if err != nil {
+		newResponseError(c, http.StatusInternalServerError, err)
+		return false
+	}
+	patch, err := c.GetRawData()
+	if err != nil {
+		newResponseError(c, http.StatusBadRequest, errors.New("invalid input body"))
+		return false
+	}
+
+	var patched []byte
+	switch c.ContentType() {
+	case mergePatchType:
+		patched, err = jsonpatch.MergePatch(current, patch)
+	case jsonPatchType:
+		patched, err = jsonpatch.Apply(current, patch)
+	default:
+		c.Header("Accept-Patch", mergePatchType+", "+jsonPatchType)
+		newResponseError(c, http.StatusUnsupportedMediaType, errUnsupportedPatch)
+		return false
+	}
+	switch {
+	case errors.Is(err, jsonpatch.ErrInvalid):
+		newResponseError(c, http.StatusBadRequest, err)
+		return false
+	case errors.Is(err, jsonpatch.ErrFailed):
+		newResponseError(c, http.StatusConflict, err)
+		return false
+	case err != nil:
+		newResponseError(c, http.StatusInternalServerError, err)
+		return false
+	}
+
+	if err := binding.JSON.BindBody(patched, obj); err != nil {
+		newResponseError(c, http.StatusUnprocessableEntity, errors.New("invalid patched fields"))
+		return false
+	}
+	return true
+}
@@ -0,0 +1,315 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fr13n8/todo-app/pkg/service"
+	mockservice "github.com/fr13n8/todo-app/pkg/service/mocks"
+	"github.com/fr13n8/todo-app/structs"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_patchItem(t *testing.T) {
+	type mockBehavior func(r *mockservice.MockTodoItem, input structs.ReplaceItemInput)
+
+	dueDate := time.Date(2026, 10, 20, 9, 0, 0, 0, time.UTC)
+	item := structs.Item{
+		Id:          1,
+		Title:       "title",
+		Description: "description",
+		DueDate:     &dueDate,
+		State:       structs.ItemStateReady,
+		Version:     3,
+	}
+
+	testTable := []struct {
+		name                 string
+		contentType          string
+		ifMatch              string
+		inputBody            string
+		input                structs.ReplaceItemInput
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+		expectedUndoToken    string
+	}{
+		{
+			name:                 "Merge patch",
+			contentType:          "application/merge-patch+json",
+			inputBody:            `{"description":null,"done":true}`,
+			input:                structs.ReplaceItemInput{Title: "title", Done: true, DueDate: &dueDate, Version: intPointer(3)},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"status":"ok"}`,
+			mockBehavior: func(r *mockservice.MockTodoItem, input structs.ReplaceItemInput) {
+				r.EXPECT().GetById(1, 1).Return(item, nil)
+				r.EXPECT().Replace(1, 1, input).Return("d6d5c5a1-8b53-4c1e-9a0b-3c3d2b6f0e11", nil)
+			},
+			expectedUndoToken: "d6d5c5a1-8b53-4c1e-9a0b-3c3d2b6f0e11",
+		},
+		{
+			name:                 "JSON patch",
+			contentType:          "application/json-patch+json",
+			inputBody:            `[{"op":"test","path":"/done","value":false},{"op":"replace","path":"/due_date","value":null}]`,
+			input:                structs.ReplaceItemInput{Title: "title", Description: "description", Version: intPointer(3)},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"status":"ok"}`,
+			mockBehavior: func(r *mockservice.MockTodoItem, input structs.ReplaceItemInput) {
+				r.EXPECT().GetById(1, 1).Return(item, nil)
+				r.EXPECT().Replace(1, 1, input).Return("", nil)
+			},
+		},
+		{
+			name:                 "If-Match",
+			contentType:          "application/merge-patch+json",
+			ifMatch:              `"2", "3"`,
+			inputBody:            `{"done":true}`,
+			input:                structs.ReplaceItemInput{Title: "title", Description: "description", Done: true, DueDate: &dueDate, Version: intPointer(3)},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"status":"ok"}`,
+			mockBehavior: func(r *mockservice.MockTodoItem, input structs.ReplaceItemInput) {
+				r.EXPECT().GetById(1, 1).Return(item, nil)
+				r.EXPECT().Replace(1, 1, input).Return("", nil)
+			},
+		},
+		{
+			name:               "Stale If-Match",
+			contentType:        "application/merge-patch+json",
+			ifMatch:            `"2"`,
+			inputBody:          `{"done":true}`,
+			expectedStatusCode: 412,
+			expectedResponseBody: `{"data":{"id":1,"title":"title","description":"description","done":false,` +
+				`"due_date":"2026-10-20T09:00:00Z","state":"ready"}}`,
+			mockBehavior: func(r *mockservice.MockTodoItem, input structs.ReplaceItemInput) {
+				r.EXPECT().GetById(1, 1).Return(item, nil)
+			},
+		},
+		{
+			name:               "Changed meanwhile",
+			contentType:        "application/merge-patch+json",
+			inputBody:          `{"done":true}`,
+			input:              structs.ReplaceItemInput{Title: "title", Description: "description", Done: true, DueDate: &dueDate, Version: intPointer(3)},
+			expectedStatusCode: 412,
+			expectedResponseBody: `{"data":{"id":1,"title":"title","description":"description","done":true,` +
+				`"due_date":"2026-10-20T09:00:00Z","state":"done"}}`,
+			mockBehavior: func(r *mockservice.MockTodoItem, input structs.ReplaceItemInput) {
+				r.EXPECT().GetById(1, 1).Return(item, nil)
+				r.EXPECT().Replace(1, 1, input).Return("", service.ErrVersionMismatch)
+				changed := item
+				changed.Done, changed.State, changed.Version = true, structs.ItemStateDone, 4
+				r.EXPECT().GetById(1, 1).Return(changed, nil)
+			},
+		},
+		{
+			name:                 "Unsupported type",
+			contentType:          "application/json",
+			inputBody:            `{"done":true}`,
+			expectedStatusCode:   415,
+			expectedResponseBody: `{"message":"unsupported patch type, send application/merge-patch+json or application/json-patch+json"}`,
+			mockBehavior: func(r *mockservice.MockTodoItem, input structs.ReplaceItemInput) {
+				r.EXPECT().GetById(1, 1).Return(item, nil)
+			},
+		},
+		{
+			name:                 "Malformed patch",
+			contentType:          "application/json-patch+json",
+			inputBody:            `[{"op":"increment","path":"/done"}]`,
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"message":"operation 0: invalid patch: unknown operation \"increment\""}`,
+			mockBehavior: func(r *mockservice.MockTodoItem, input structs.ReplaceItemInput) {
+				r.EXPECT().GetById(1, 1).Return(item, nil)
+			},
+		},
+		{
+			name:                 "Failed test",
+			contentType:          "application/json-patch+json",
+			inputBody:            `[{"op":"test","path":"/done","value":true},{"op":"replace","path":"/done","value":false}]`,
+			expectedStatusCode:   409,
+			expectedResponseBody: `{"message":"operation 0: patch can't be applied: test of \"/done\" failed"}`,
+			mockBehavior: func(r *mockservice.MockTodoItem, input structs.ReplaceItemInput) {
+				r.EXPECT().GetById(1, 1).Return(item, nil)
+			},
+		},
+		{
+			name:                 "Read only field",
+			contentType:          "application/json-patch+json",
+			inputBody:            `[{"op":"replace","path":"/id","value":2}]`,
+			expectedStatusCode:   409,
+			expectedResponseBody: `{"message":"operation 0: patch can't be applied: member \"id\" doesn't exist"}`,
+			mockBehavior: func(r *mockservice.MockTodoItem, input structs.ReplaceItemInput) {
+				r.EXPECT().GetById(1, 1).Return(item, nil)
+			},
+		},
+		{
+			name:                 "Title removed",
+			contentType:          "application/merge-patch+json",
+			inputBody:            `{"title":null}`,
+			expectedStatusCode:   422,
+			expectedResponseBody: `{"message":"invalid patched fields"}`,
+			mockBehavior: func(r *mockservice.MockTodoItem, input structs.ReplaceItemInput) {
+				r.EXPECT().GetById(1, 1).Return(item, nil)
+			},
+		},
+		{
+			name:                 "Blocked",
+			contentType:          "application/merge-patch+json",
+			inputBody:            `{"done":true}`,
+			input:                structs.ReplaceItemInput{Title: "title", Description: "description", Done: true, DueDate: &dueDate, Version: intPointer(3)},
+			expectedStatusCode:   409,
+			expectedResponseBody: `{"message":"item is blocked by open items"}`,
+			mockBehavior: func(r *mockservice.MockTodoItem, input structs.ReplaceItemInput) {
+				r.EXPECT().GetById(1, 1).Return(item, nil)
+				r.EXPECT().Replace(1, 1, input).Return("", service.ErrItemBlocked)
+			},
+		},
+		{
+			name:                 "Not found",
+			contentType:          "application/merge-patch+json",
+			inputBody:            `{"done":true}`,
+			expectedStatusCode:   500,
+			expectedResponseBody: `{"message":"sql: no rows in result set"}`,
+			mockBehavior: func(r *mockservice.MockTodoItem, input structs.ReplaceItemInput) {
+				r.EXPECT().GetById(1, 1).Return(structs.Item{}, errors.New("sql: no rows in result set"))
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			item := mockservice.NewMockTodoItem(c)
+			testCase.mockBehavior(item, testCase.input)
+
+			services := &service.Service{TodoItem: item}
+			handler := NewHandler(services)
+
+			r := gin.New()
+			r.PATCH("/api/items/:id", func(c *gin.Context) {
+				c.Set(userCtx, 1)
+			}, handler.patchItem)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("PATCH", "/api/items/1", bytes.NewBufferString(testCase.inputBody))
+			req.Header.Set("Content-Type", testCase.contentType)
+			if testCase.ifMatch != "" {
+				req.Header.Set("If-Match", testCase.ifMatch)
+			}
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedResponseBody, w.Body.String())
+			assert.Equal(t, testCase.expectedUndoToken, w.Header().Get("Undo-Token"))
+		})
+	}
+}
+
+func TestHandler_patchList(t *testing.T) {
+	type mockBehavior func(r *mockservice.MockTodoList, input structs.ReplaceListInput)
+
+	list := structs.List{
+		Id:              1,
+		Title:           "title",
+		Description:     "description",
+		AutoArchiveDays: intPointer(7),
+		Version:         3,
+	}
+
+	testTable := []struct {
+		name                 string
+		contentType          string
+		ifMatch              string
+		inputBody            string
+		input                structs.ReplaceListInput
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+		expectedUndoToken    string
+	}{
+		{
+			name:                 "Merge patch",
+			contentType:          "application/merge-patch+json; charset=utf-8",
+			inputBody:            `{"title":"new","auto_archive_days":null}`,
+			input:                structs.ReplaceListInput{Title: "new", Description: "description", Version: intPointer(3)},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"status":"ok"}`,
+			mockBehavior: func(r *mockservice.MockTodoList, input structs.ReplaceListInput) {
+				r.EXPECT().GetById(1, 1).Return(list, nil)
+				r.EXPECT().Replace(1, 1, input).Return("d6d5c5a1-8b53-4c1e-9a0b-3c3d2b6f0e11", nil)
+			},
+			expectedUndoToken: "d6d5c5a1-8b53-4c1e-9a0b-3c3d2b6f0e11",
+		},
+		{
+			name:                 "JSON patch",
+			contentType:          "application/json-patch+json",
+			inputBody:            `[{"op":"copy","from":"/title","path":"/description"}]`,
+			input:                structs.ReplaceListInput{Title: "title", Description: "title", AutoArchiveDays: intPointer(7), Version: intPointer(3)},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"status":"ok"}`,
+			mockBehavior: func(r *mockservice.MockTodoList, input structs.ReplaceListInput) {
+				r.EXPECT().GetById(1, 1).Return(list, nil)
+				r.EXPECT().Replace(1, 1, input).Return("", nil)
+			},
+		},
+		{
+			name:                 "Invalid days",
+			contentType:          "application/merge-patch+json",
+			inputBody:            `{"auto_archive_days":0}`,
+			expectedStatusCode:   422,
+			expectedResponseBody: `{"message":"invalid patched fields"}`,
+			mockBehavior: func(r *mockservice.MockTodoList, input structs.ReplaceListInput) {
+				r.EXPECT().GetById(1, 1).Return(list, nil)
+			},
+		},
+		{
+			name:                 "Service failure",
+			contentType:          "application/merge-patch+json",
+			inputBody:            `{"title":"new"}`,
+			input:                structs.ReplaceListInput{Title: "new", Description: "description", AutoArchiveDays: intPointer(7), Version: intPointer(3)},
+			expectedStatusCode:   500,
+			expectedResponseBody: `{"message":"service failure"}`,
+			mockBehavior: func(r *mockservice.MockTodoList, input structs.ReplaceListInput) {
+				r.EXPECT().GetById(1, 1).Return(list, nil)
+				r.EXPECT().Replace(1, 1, input).Return("", errors.New("service failure"))
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			list := mockservice.NewMockTodoList(c)
+			testCase.mockBehavior(list, testCase.input)
+
+			services := &service.Service{TodoList: list}
+			handler := NewHandler(services)
+
+			r := gin.New()
+			r.PATCH("/api/lists/:id", func(c *gin.Context) {
+				c.Set(userCtx, 1)
+			}, handler.patchList)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("PATCH", "/api/lists/1", bytes.NewBufferString(testCase.inputBody))
+			req.Header.Set("Content-Type", testCase.contentType)
+			if testCase.ifMatch != "" {
+				req.Header.Set("If-Match", testCase.ifMatch)
+			}
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedResponseBody, w.Body.String())
+			assert.Equal(t, testCase.expectedUndoToken, w.Header().Get("Undo-Token"))
+		})
+	}
+}
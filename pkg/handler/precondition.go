@@ -0,0 +1,184 @@
+package handler
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fr13n8/todo-app/structs"
+	"github.com/gin-gonic/gin"
+)
+
+// etag is the entity tag of a version of a list or an item.
+func etag(version int) string {
+	return strconv.Quote(strconv.Itoa(version))
+}
+
+// representationETag is the entity tag of a list or an item as sent: its
+// version, which is what If-Match checks, followed by a digest of the body
+// and the render mode. The body shows more than the version counts, like
+// labels, blockers, tracked time and archiving.
+func representationETag(version int, render bool, body interface{}) string {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return etag(version)
+	}
+	sum := sha256.Sum256(append([]byte(fmt.Sprintf("%t\n", render)), data...))
+	return strconv.Quote(strconv.Itoa(version) + "-" + hex.EncodeToString(sum[:8]))
+}
+
+// collectionETag is the entity tag of a page of a listing: the stamp of the
+// collection along with the query picking the page. It is weak, as the stamp
+// leaves some fields out.
+func collectionETag(c *gin.Context, stamp structs.CollectionStamp) string {
+	var modified int64
+	if stamp.LastModified != nil {
+		modified = stamp.LastModified.UnixNano()
+	}
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%d %d %d %d %s",
+		stamp.Count, stamp.IdSum, stamp.Live, modified, c.Request.URL.RawQuery)))
+	return `W/"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// latest is the latest of the times that are set, or nil.
+func latest(times ...*time.Time) *time.Time {
+	var last *time.Time
+	for _, t := range times {
+		if t != nil && (last == nil || t.After(*last)) {
+			last = t
+		}
+	}
+	return last
+}
+
+// notModified sets the validators of a GET response and answers with 304
+// when the If-None-Match or If-Modified-Since header says the copy of the
+// client is current, reporting whether it did. If-None-Match takes
+// precedence, as Last-Modified is only exact to the second.
+func notModified(c *gin.Context, tag string, lastModified *time.Time) bool {
+	c.Header("Cache-Control", "private, no-cache")
+	c.Header("ETag", tag)
+	if lastModified != nil {
+		c.Header("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	if header := c.GetHeader("If-None-Match"); header != "" {
+		if !etagListed(header, tag) {
+			return false
+		}
+	} else {
+		since, err := http.ParseTime(c.GetHeader("If-Modified-Since"))
+		if err != nil || lastModified == nil || lastModified.Truncate(time.Second).After(since) {
+			return false
+		}
+	}
+	c.AbortWithStatus(http.StatusNotModified)
+	return true
+}
+
+// etagListed tells whether an If-None-Match header lists the tag, comparing
+// weakly.
+func etagListed(header string, tag string) bool {
+	tag = strings.TrimPrefix(tag, "W/")
+	for _, listed := range strings.Split(header, ",") {
+		listed = strings.TrimSpace(listed)
+		if listed == "*" || strings.TrimPrefix(listed, "W/") == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// ifMatch checks the If-Match header of the request against the current
+// version of a list or an item. It returns the version a change must be made
+// to, nil when any version goes, and whether the header matches.
+func ifMatch(c *gin.Context, version int) (*int, bool) {
+	header := c.GetHeader("If-Match")
+	if header == "" {
+		return nil, true
+	}
+	for _, tag := range strings.Split(header, ",") {
+		switch strings.TrimSpace(tag) {
+		case "*":
+			return nil, true
+		case etag(version):
+			return &version, true
+		}
+		if strings.HasPrefix(strings.TrimSpace(tag), `"`+strconv.Itoa(version)+"-") {
+			return &version, true
+		}
+	}
+	return nil, false
+}
+
+// preconditionFailed answers a change made to another version than the
+// current one with the current representation.
+func preconditionFailed(c *gin.Context, version int, current interface{}) {
+	c.Header("ETag", representationETag(version, false, current))
+	c.AbortWithStatusJSON(http.StatusPreconditionFailed, current)
+}
+
+// matchItem checks the If-Match header of a request changing an item,
+// answering with 412 when it doesn't match. It returns the version the change
+// must be made to and whether the request may go on.
+func (h *Handler) matchItem(c *gin.Context, userId int, itemId int) (*int, bool) {
+	if c.GetHeader("If-Match") == "" {
+		return nil, true
+	}
+	item, err := h.services.TodoItem.GetById(userId, itemId)
+	if err != nil {
+		newResponseError(c, serviceErrorStatus(err), err)
+		return nil, false
+	}
+	version, ok := ifMatch(c, item.Version)
+	if !ok {
+		preconditionFailed(c, item.Version, getItemResponse{Data: item})
+	}
+	return version, ok
+}
+
+// itemChanged answers a change that lost the race against another one with
+// 412 and the item as it is now.
+func (h *Handler) itemChanged(c *gin.Context, userId int, itemId int) {
+	item, err := h.services.TodoItem.GetById(userId, itemId)
+	if err != nil {
+		newResponseError(c, serviceErrorStatus(err), err)
+		return
+	}
+	preconditionFailed(c, item.Version, getItemResponse{Data: item})
+}
+
+// matchList checks the If-Match header of a request changing a list,
+// answering with 412 when it doesn't match. It returns the version the change
+// must be made to and whether the request may go on.
+func (h *Handler) matchList(c *gin.Context, userId int, listId int) (*int, bool) {
+	if c.GetHeader("If-Match") == "" {
+		return nil, true
+	}
+	list, err := h.services.TodoList.GetById(listId, userId)
+	if err != nil {
+		newResponseError(c, serviceErrorStatus(err), err)
+		return nil, false
+	}
+	version, ok := ifMatch(c, list.Version)
+	if !ok {
+		preconditionFailed(c, list.Version, getListResponse{Data: list})
+	}
+	return version, ok
+}
+
+// listChanged answers a change that lost the race against another one with
+// 412 and the list as it is now.
+func (h *Handler) listChanged(c *gin.Context, userId int, listId int) {
+	list, err := h.services.TodoList.GetById(listId, userId)
+	if err != nil {
+		newResponseError(c, serviceErrorStatus(err), err)
+		return
+	}
+	preconditionFailed(c, list.Version, getListResponse{Data: list})
+}
@@ -0,0 +1,506 @@
+package handler
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fr13n8/todo-app/pkg/service"
+	mockservice "github.com/fr13n8/todo-app/pkg/service/mocks"
+	"github.com/fr13n8/todo-app/structs"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_itemPreconditions(t *testing.T) {
+	type mockBehavior func(r *mockservice.MockTodoItem)
+
+	item := structs.Item{Id: 1, Title: "title", State: structs.ItemStateReady, Version: 3}
+
+	testTable := []struct {
+		name                 string
+		method               string
+		ifMatch              string
+		inputBody            string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedETag         string
+		expectedResponseBody string
+	}{
+		{
+			name:                 "Get",
+			method:               "GET",
+			expectedStatusCode:   200,
+			expectedETag:         `^"3-[0-9a-f]{16}"$`,
+			expectedResponseBody: `{"data":{"id":1,"title":"title","description":"","done":false,"state":"ready"}}`,
+			mockBehavior: func(r *mockservice.MockTodoItem) {
+				r.EXPECT().GetById(1, 1).Return(item, nil)
+			},
+		},
+		{
+			name:                 "Put",
+			method:               "PUT",
+			ifMatch:              `"3"`,
+			inputBody:            `{"title":"new"}`,
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"status":"ok"}`,
+			mockBehavior: func(r *mockservice.MockTodoItem) {
+				r.EXPECT().GetById(1, 1).Return(item, nil)
+				r.EXPECT().Replace(1, 1, structs.ReplaceItemInput{Title: "new", Version: intPointer(3)}).Return("", nil)
+			},
+		},
+		{
+			name:                 "Put with the tag of a GET",
+			method:               "PUT",
+			ifMatch:              `"3-0123456789abcdef"`,
+			inputBody:            `{"title":"new"}`,
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"status":"ok"}`,
+			mockBehavior: func(r *mockservice.MockTodoItem) {
+				r.EXPECT().GetById(1, 1).Return(item, nil)
+				r.EXPECT().Replace(1, 1, structs.ReplaceItemInput{Title: "new", Version: intPointer(3)}).Return("", nil)
+			},
+		},
+		{
+			name:                 "Put any version",
+			method:               "PUT",
+			ifMatch:              `*`,
+			inputBody:            `{"title":"new"}`,
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"status":"ok"}`,
+			mockBehavior: func(r *mockservice.MockTodoItem) {
+				r.EXPECT().GetById(1, 1).Return(item, nil)
+				r.EXPECT().Replace(1, 1, structs.ReplaceItemInput{Title: "new"}).Return("", nil)
+			},
+		},
+		{
+			name:                 "Put stale",
+			method:               "PUT",
+			ifMatch:              `"2"`,
+			inputBody:            `{"title":"new"}`,
+			expectedStatusCode:   412,
+			expectedETag:         `^"3-[0-9a-f]{16}"$`,
+			expectedResponseBody: `{"data":{"id":1,"title":"title","description":"","done":false,"state":"ready"}}`,
+			mockBehavior: func(r *mockservice.MockTodoItem) {
+				r.EXPECT().GetById(1, 1).Return(item, nil)
+			},
+		},
+		{
+			name:                 "Put lost race",
+			method:               "PUT",
+			ifMatch:              `"3"`,
+			inputBody:            `{"title":"new"}`,
+			expectedStatusCode:   412,
+			expectedETag:         `^"4-[0-9a-f]{16}"$`,
+			expectedResponseBody: `{"data":{"id":1,"title":"other","description":"","done":false,"state":"ready"}}`,
+			mockBehavior: func(r *mockservice.MockTodoItem) {
+				r.EXPECT().GetById(1, 1).Return(item, nil)
+				r.EXPECT().Replace(1, 1, structs.ReplaceItemInput{Title: "new", Version: intPointer(3)}).
+					Return("", service.ErrVersionMismatch)
+				r.EXPECT().GetById(1, 1).Return(structs.Item{Id: 1, Title: "other", State: structs.ItemStateReady, Version: 4}, nil)
+			},
+		},
+		{
+			name:                 "Delete",
+			method:               "DELETE",
+			ifMatch:              `"3"`,
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"status":"ok"}`,
+			mockBehavior: func(r *mockservice.MockTodoItem) {
+				r.EXPECT().GetById(1, 1).Return(item, nil)
+				r.EXPECT().Delete(1, 1, intPointer(3)).Return("", nil)
+			},
+		},
+		{
+			name:                 "Delete lost race",
+			method:               "DELETE",
+			ifMatch:              `"3"`,
+			expectedStatusCode:   412,
+			expectedETag:         `^"4-[0-9a-f]{16}"$`,
+			expectedResponseBody: `{"data":{"id":1,"title":"other","description":"","done":false,"state":"ready"}}`,
+			mockBehavior: func(r *mockservice.MockTodoItem) {
+				r.EXPECT().GetById(1, 1).Return(item, nil)
+				r.EXPECT().Delete(1, 1, intPointer(3)).Return("", service.ErrVersionMismatch)
+				r.EXPECT().GetById(1, 1).Return(structs.Item{Id: 1, Title: "other", State: structs.ItemStateReady, Version: 4}, nil)
+			},
+		},
+		{
+			name:                 "Delete stale",
+			method:               "DELETE",
+			ifMatch:              `W/"3"`,
+			expectedStatusCode:   412,
+			expectedETag:         `^"3-[0-9a-f]{16}"$`,
+			expectedResponseBody: `{"data":{"id":1,"title":"title","description":"","done":false,"state":"ready"}}`,
+			mockBehavior: func(r *mockservice.MockTodoItem) {
+				r.EXPECT().GetById(1, 1).Return(item, nil)
+			},
+		}, {
+			name:                 "Put missing",
+			method:               "PUT",
+			ifMatch:              `"3"`,
+			inputBody:            `{"title":"new"}`,
+			expectedStatusCode:   404,
+			expectedResponseBody: `{"message":"record not found"}`,
+			mockBehavior: func(r *mockservice.MockTodoItem) {
+				r.EXPECT().GetById(1, 1).Return(structs.Item{}, service.ErrRecordNotFound)
+			},
+		},
+		{
+			name:                 "Delete gone in the race",
+			method:               "DELETE",
+			ifMatch:              `"3"`,
+			expectedStatusCode:   404,
+			expectedResponseBody: `{"message":"record not found"}`,
+			mockBehavior: func(r *mockservice.MockTodoItem) {
+				r.EXPECT().GetById(1, 1).Return(item, nil)
+				r.EXPECT().Delete(1, 1, intPointer(3)).Return("", service.ErrVersionMismatch)
+				r.EXPECT().GetById(1, 1).Return(structs.Item{}, service.ErrRecordNotFound)
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			item := mockservice.NewMockTodoItem(c)
+			testCase.mockBehavior(item)
+
+			services := &service.Service{TodoItem: item}
+			handler := NewHandler(services)
+
+			r := gin.New()
+			setUser := func(c *gin.Context) {
+				c.Set(userCtx, 1)
+			}
+			r.GET("/api/items/:id", setUser, handler.getItemById)
+			r.PUT("/api/items/:id", setUser, handler.updateItem)
+			r.DELETE("/api/items/:id", setUser, handler.deleteItem)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(testCase.method, "/api/items/1", bytes.NewBufferString(testCase.inputBody))
+			if testCase.ifMatch != "" {
+				req.Header.Set("If-Match", testCase.ifMatch)
+			}
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			if testCase.expectedETag == "" {
+				assert.Empty(t, w.Header().Get("ETag"))
+			} else {
+				assert.Regexp(t, testCase.expectedETag, w.Header().Get("ETag"))
+			}
+			assert.Equal(t, testCase.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_listPreconditions(t *testing.T) {
+	type mockBehavior func(r *mockservice.MockTodoList)
+
+	list := structs.List{Id: 1, Title: "title", Version: 5}
+
+	testTable := []struct {
+		name                 string
+		method               string
+		ifMatch              string
+		inputBody            string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedETag         string
+		expectedResponseBody string
+	}{
+		{
+			name:                 "Get",
+			method:               "GET",
+			expectedStatusCode:   200,
+			expectedETag:         `^"5-[0-9a-f]{16}"$`,
+			expectedResponseBody: `{"data":{"id":1,"title":"title","description":""}}`,
+			mockBehavior: func(r *mockservice.MockTodoList) {
+				r.EXPECT().GetById(1, 1).Return(list, nil)
+			},
+		},
+		{
+			name:                 "Put",
+			method:               "PUT",
+			ifMatch:              `"5"`,
+			inputBody:            `{"title":"new"}`,
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"status":"ok"}`,
+			mockBehavior: func(r *mockservice.MockTodoList) {
+				r.EXPECT().GetById(1, 1).Return(list, nil)
+				r.EXPECT().Replace(1, 1, structs.ReplaceListInput{Title: "new", Version: intPointer(5)}).Return("", nil)
+			},
+		},
+		{
+			name:                 "Put stale",
+			method:               "PUT",
+			ifMatch:              `"4"`,
+			inputBody:            `{"title":"new"}`,
+			expectedStatusCode:   412,
+			expectedETag:         `^"5-[0-9a-f]{16}"$`,
+			expectedResponseBody: `{"data":{"id":1,"title":"title","description":""}}`,
+			mockBehavior: func(r *mockservice.MockTodoList) {
+				r.EXPECT().GetById(1, 1).Return(list, nil)
+			},
+		},
+		{
+			name:                 "Delete stale",
+			method:               "DELETE",
+			ifMatch:              `"4"`,
+			expectedStatusCode:   412,
+			expectedETag:         `^"5-[0-9a-f]{16}"$`,
+			expectedResponseBody: `{"data":{"id":1,"title":"title","description":""}}`,
+			mockBehavior: func(r *mockservice.MockTodoList) {
+				r.EXPECT().GetById(1, 1).Return(list, nil)
+			},
+		},
+		{
+			name:                 "Delete without If-Match",
+			method:               "DELETE",
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"status":"ok"}`,
+			mockBehavior: func(r *mockservice.MockTodoList) {
+				r.EXPECT().Delete(1, 1, nil).Return("", nil)
+			},
+		},
+		{
+			name:                 "Delete lost race",
+			method:               "DELETE",
+			ifMatch:              `"5"`,
+			expectedStatusCode:   412,
+			expectedETag:         `^"6-[0-9a-f]{16}"$`,
+			expectedResponseBody: `{"data":{"id":1,"title":"other","description":""}}`,
+			mockBehavior: func(r *mockservice.MockTodoList) {
+				r.EXPECT().GetById(1, 1).Return(list, nil)
+				r.EXPECT().Delete(1, 1, intPointer(5)).Return("", service.ErrVersionMismatch)
+				r.EXPECT().GetById(1, 1).Return(structs.List{Id: 1, Title: "other", Version: 6}, nil)
+			},
+		}, {
+			name:                 "Delete missing",
+			method:               "DELETE",
+			ifMatch:              `"5"`,
+			expectedStatusCode:   404,
+			expectedResponseBody: `{"message":"record not found"}`,
+			mockBehavior: func(r *mockservice.MockTodoList) {
+				r.EXPECT().GetById(1, 1).Return(structs.List{}, service.ErrRecordNotFound)
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			list := mockservice.NewMockTodoList(c)
+			testCase.mockBehavior(list)
+
+			services := &service.Service{TodoList: list}
+			handler := NewHandler(services)
+
+			r := gin.New()
+			setUser := func(c *gin.Context) {
+				c.Set(userCtx, 1)
+			}
+			r.GET("/api/lists/:id", setUser, handler.getListById)
+			r.PUT("/api/lists/:id", setUser, handler.updateList)
+			r.DELETE("/api/lists/:id", setUser, handler.deleteList)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(testCase.method, "/api/lists/1", bytes.NewBufferString(testCase.inputBody))
+			if testCase.ifMatch != "" {
+				req.Header.Set("If-Match", testCase.ifMatch)
+			}
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			if testCase.expectedETag == "" {
+				assert.Empty(t, w.Header().Get("ETag"))
+			} else {
+				assert.Regexp(t, testCase.expectedETag, w.Header().Get("ETag"))
+			}
+			assert.Equal(t, testCase.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_itemNotModified(t *testing.T) {
+	updatedAt := time.Date(2021, 6, 2, 10, 0, 0, 500, time.UTC)
+	item := structs.Item{Id: 1, Title: "title", State: structs.ItemStateReady, Version: 3, UpdatedAt: &updatedAt}
+
+	tag := representationETag(3, false, getItemResponse{Data: item})
+	tracked := item
+	tracked.TrackedSeconds = 60
+
+	testTable := []struct {
+		name               string
+		target             string
+		item               *structs.Item
+		ifNoneMatch        string
+		ifModifiedSince    string
+		expectedStatusCode int
+	}{
+		{
+			name:               "Current tag",
+			ifNoneMatch:        `"2", W/` + tag,
+			expectedStatusCode: 304,
+		},
+		{
+			name:               "Rendered",
+			target:             "/api/items/1?render=html",
+			ifNoneMatch:        tag,
+			expectedStatusCode: 200,
+		},
+		{
+			name:               "Changed without a new version",
+			item:               &tracked,
+			ifNoneMatch:        tag,
+			expectedStatusCode: 200,
+		},
+		{
+			name:               "Any tag",
+			ifNoneMatch:        `*`,
+			expectedStatusCode: 304,
+		},
+		{
+			name:               "Stale tag",
+			ifNoneMatch:        `"2"`,
+			expectedStatusCode: 200,
+		},
+		{
+			name:               "Not modified since",
+			ifModifiedSince:    "Wed, 02 Jun 2021 10:00:00 GMT",
+			expectedStatusCode: 304,
+		},
+		{
+			name:               "Modified since",
+			ifModifiedSince:    "Wed, 02 Jun 2021 09:59:59 GMT",
+			expectedStatusCode: 200,
+		},
+		{
+			name:               "Tag takes precedence",
+			ifNoneMatch:        `"2"`,
+			ifModifiedSince:    "Wed, 02 Jun 2021 10:00:00 GMT",
+			expectedStatusCode: 200,
+		},
+		{
+			name:               "Invalid date",
+			ifModifiedSince:    "yesterday",
+			expectedStatusCode: 200,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			current := item
+			if testCase.item != nil {
+				current = *testCase.item
+			}
+			items := mockservice.NewMockTodoItem(c)
+			items.EXPECT().GetById(1, 1).Return(current, nil)
+
+			services := &service.Service{TodoItem: items}
+			handler := NewHandler(services)
+
+			r := gin.New()
+			r.GET("/api/items/:id", func(c *gin.Context) {
+				c.Set(userCtx, 1)
+			}, handler.getItemById)
+
+			w := httptest.NewRecorder()
+			target := testCase.target
+			if target == "" {
+				target = "/api/items/1"
+			}
+			req := httptest.NewRequest("GET", target, nil)
+			if testCase.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", testCase.ifNoneMatch)
+			}
+			if testCase.ifModifiedSince != "" {
+				req.Header.Set("If-Modified-Since", testCase.ifModifiedSince)
+			}
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Regexp(t, `^"3-[0-9a-f]{16}"$`, w.Header().Get("ETag"))
+			assert.Equal(t, "Wed, 02 Jun 2021 10:00:00 GMT", w.Header().Get("Last-Modified"))
+			assert.Equal(t, "private, no-cache", w.Header().Get("Cache-Control"))
+			if testCase.expectedStatusCode == 304 {
+				assert.Empty(t, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandler_listsNotModified(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	modified := time.Date(2021, 6, 2, 10, 0, 0, 0, time.UTC)
+	stamp := structs.CollectionStamp{Count: 2, IdSum: 3, Live: 2, LastModified: &modified}
+
+	lists := mockservice.NewMockTodoList(c)
+	lists.EXPECT().Stamp(1).Return(stamp, nil).Times(4)
+	lists.EXPECT().GetAll(1, structs.ListFilter{}).Return([]structs.List{{Id: 1, Title: "a"}, {Id: 2, Title: "b"}},
+		structs.PageInfo{Total: 2}, nil)
+	lists.EXPECT().GetAll(1, structs.ListFilter{TitleContains: "a"}).Return([]structs.List{{Id: 1, Title: "a"}},
+		structs.PageInfo{Total: 1}, nil)
+	lists.EXPECT().Stamp(1).Return(structs.CollectionStamp{Count: 3, IdSum: 6, Live: 3, LastModified: &modified}, nil)
+	lists.EXPECT().GetAll(1, structs.ListFilter{}).Return([]structs.List{{Id: 1, Title: "a"}, {Id: 2, Title: "b"},
+		{Id: 3, Title: "c"}}, structs.PageInfo{Total: 3}, nil)
+
+	services := &service.Service{TodoList: lists}
+	handler := NewHandler(services)
+
+	r := gin.New()
+	r.GET("/api/lists", func(c *gin.Context) {
+		c.Set(userCtx, 1)
+	}, handler.getAllList)
+
+	get := func(target string, ifNoneMatch string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", target, nil)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	first := get("/api/lists", "")
+	assert.Equal(t, 200, first.Code)
+	assert.Equal(t, `{"data":[{"id":1,"title":"a","description":""},{"id":2,"title":"b","description":""}],"total":2}`,
+		first.Body.String())
+	tag := first.Header().Get("ETag")
+	assert.Regexp(t, `^W/"[0-9a-f]{32}"$`, tag)
+	assert.Equal(t, "Wed, 02 Jun 2021 10:00:00 GMT", first.Header().Get("Last-Modified"))
+
+	again := get("/api/lists", tag)
+	assert.Equal(t, 304, again.Code)
+	assert.Equal(t, tag, again.Header().Get("ETag"))
+	assert.Empty(t, again.Body.String())
+
+	since := httptest.NewRequest("GET", "/api/lists", nil)
+	since.Header.Set("If-Modified-Since", "Wed, 02 Jun 2021 10:00:00 GMT")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, since)
+	assert.Equal(t, 304, w.Code)
+
+	filtered := get("/api/lists?title~=a", tag)
+	assert.Equal(t, 200, filtered.Code)
+	assert.NotEqual(t, tag, filtered.Header().Get("ETag"))
+
+	changed := get("/api/lists", tag)
+	assert.Equal(t, 200, changed.Code)
+	assert.NotEqual(t, tag, changed.Header().Get("ETag"))
+}
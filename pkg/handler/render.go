@@ -0,0 +1,29 @@
+package handler
+
+import (
+	"github.com/fr13n8/todo-app/pkg/markdown"
+	"github.com/fr13n8/todo-app/structs"
+	"github.com/gin-gonic/gin"
+)
+
+// renderQuery asks for the Markdown descriptions to be rendered as well;
+// the sanitized HTML is returned in description_html.
+type renderQuery struct {
+	Render string `form:"render" binding:"omitempty,oneof=html"`
+}
+
+func bindRender(c *gin.Context) (bool, error) {
+	var query renderQuery
+	if err := c.BindQuery(&query); err != nil {
+		return false, err
+	}
+	return query.Render == "html", nil
+}
+
+func renderList(list *structs.List) {
+	list.DescriptionHTML = markdown.Render(list.Description)
+}
+
+func renderItem(item *structs.Item) {
+	item.DescriptionHTML = markdown.Render(item.Description)
+}
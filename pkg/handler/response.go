@@ -1,6 +1,11 @@
 package handler
 
 import (
+	"errors"
+	"net/http"
+
+	"github.com/fr13n8/todo-app/pkg/querylang"
+	"github.com/fr13n8/todo-app/pkg/service"
 	"github.com/gin-gonic/gin"
 )
 
@@ -8,11 +13,47 @@ func newResponseError(c *gin.Context, status int, err error) {
 	er := HTTPError{
 		Message: err.Error(),
 	}
+	var queryErr *querylang.Error
+	if errors.As(err, &queryErr) {
+		er.Position = queryErr.Pos
+	}
 	c.AbortWithStatusJSON(status, er)
 }
 
+// serviceErrorStatus maps the service errors a client can act on to their
+// status code; anything else is reported as an internal error.
+func serviceErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrDependencyCycle), errors.Is(err, service.ErrItemBlocked),
+		errors.Is(err, service.ErrNoRunningTimer), errors.Is(err, service.ErrTransitionNotAllowed),
+		errors.Is(err, service.ErrWipLimitReached), errors.Is(err, service.ErrListInTrash),
+		errors.Is(err, service.ErrUndoConflict), errors.Is(err, service.ErrLabelExists),
+		errors.Is(err, service.ErrListArchived):
+		return http.StatusConflict
+	case errors.Is(err, service.ErrInvalidStatus), errors.Is(err, service.ErrNoMatchingStatus),
+		errors.Is(err, service.ErrInvalidSort), errors.Is(err, service.ErrInvalidCursor),
+		errors.Is(err, service.ErrInvalidOperation), errors.Is(err, service.ErrInvalidAssignee),
+		errors.Is(err, service.ErrInvalidSearchLanguage):
+		return http.StatusBadRequest
+	case errors.As(err, new(*querylang.Error)):
+		return http.StatusBadRequest
+	case errors.Is(err, service.ErrAttachmentTooLarge), errors.Is(err, service.ErrQuotaExceeded):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, service.ErrBatchAborted):
+		return http.StatusFailedDependency
+	case errors.Is(err, service.ErrVersionMismatch):
+		return http.StatusPreconditionFailed
+	case errors.Is(err, service.ErrUndoExpired), errors.Is(err, service.ErrRecordNotFound):
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
+
 type HTTPError struct {
 	Message string `json:"message"`
+	// Position points at the problem in a filter expression, counting
+	// characters from 1.
+	Position int `json:"position,omitempty"`
 }
 
 type StatusResponse struct {
@@ -0,0 +1,201 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/fr13n8/todo-app/structs"
+	"github.com/gin-gonic/gin"
+)
+
+type getAllRevisionsResponse struct {
+	Data []structs.ItemRevision `json:"data"`
+}
+
+type getRevisionResponse struct {
+	Data structs.ItemRevision `json:"data"`
+}
+
+type getRevisionDiffResponse struct {
+	Data structs.RevisionDiff `json:"data"`
+}
+
+// @Summary Get item revisions
+// @Security ApiKeyAuth
+// @Tags revisions
+// @Description get the change history of the item, oldest first
+// @ID get-item-revisions
+// @Accept  json
+// @Produce  json
+// @Param id path int true "item id"
+// @Success 200 {object} getAllRevisionsResponse
+// @Failure 400,404 {object} HTTPError
+// @Failure 500 {object} HTTPError
+// @Failure default {object} HTTPError
+// @Router /api/items/:id/revisions [get]
+func (h *Handler) getRevisions(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	itemId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	revisions, err := h.services.Revision.GetAll(userId, itemId)
+	if err != nil {
+		newResponseError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, getAllRevisionsResponse{
+		Data: revisions,
+	})
+}
+
+// @Summary Get item revision
+// @Security ApiKeyAuth
+// @Tags revisions
+// @Description get the item as it was in one revision
+// @ID get-item-revision
+// @Accept  json
+// @Produce  json
+// @Param id path int true "item id"
+// @Param revision path int true "revision number"
+// @Success 200 {object} getRevisionResponse
+// @Failure 400,404 {object} HTTPError
+// @Failure 500 {object} HTTPError
+// @Failure default {object} HTTPError
+// @Router /api/items/:id/revisions/:revision [get]
+func (h *Handler) getRevision(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	itemId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	revision, err := strconv.Atoi(c.Param("revision"))
+	if err != nil {
+		newResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	rev, err := h.services.Revision.GetByNumber(userId, itemId, revision)
+	if err != nil {
+		newResponseError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, getRevisionResponse{
+		Data: rev,
+	})
+}
+
+// @Summary Diff item revisions
+// @Security ApiKeyAuth
+// @Tags revisions
+// @Description compare a revision with another one, field by field
+// @ID diff-item-revisions
+// @Accept  json
+// @Produce  json
+// @Param id path int true "item id"
+// @Param revision path int true "revision number"
+// @Param from query int false "revision to compare with, the one before by default; 0 for the empty item"
+// @Success 200 {object} getRevisionDiffResponse
+// @Failure 400,404 {object} HTTPError
+// @Failure 500 {object} HTTPError
+// @Failure default {object} HTTPError
+// @Router /api/items/:id/revisions/:revision/diff [get]
+func (h *Handler) diffRevisions(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	itemId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	revision, err := strconv.Atoi(c.Param("revision"))
+	if err != nil {
+		newResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	var query structs.RevisionDiffQuery
+	if err := c.BindQuery(&query); err != nil {
+		newResponseError(c, http.StatusBadRequest, errors.New("invalid query params"))
+		return
+	}
+	from := revision - 1
+	if query.From != nil {
+		from = *query.From
+	}
+
+	diff, err := h.services.Revision.Diff(userId, itemId, from, revision)
+	if err != nil {
+		newResponseError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, getRevisionDiffResponse{
+		Data: diff,
+	})
+}
+
+// @Summary Revert item to revision
+// @Security ApiKeyAuth
+// @Tags revisions
+// @Description restore the item's content from a revision, recorded as a new revision
+// @ID revert-item-revision
+// @Accept  json
+// @Produce  json
+// @Param id path int true "item id"
+// @Param revision path int true "revision number"
+// @Success 200 {object} StatusResponse
+// @Header 200 {string} Undo-Token "token undoing the changes"
+// @Failure 400,404 {object} HTTPError
+// @Failure 409 {object} HTTPError
+// @Failure 500 {object} HTTPError
+// @Failure default {object} HTTPError
+// @Router /api/items/:id/revisions/:revision/revert [post]
+func (h *Handler) revertRevision(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	itemId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	revision, err := strconv.Atoi(c.Param("revision"))
+	if err != nil {
+		newResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	token, err := h.services.Revision.Revert(userId, itemId, revision)
+	if err != nil {
+		newResponseError(c, serviceErrorStatus(err), err)
+		return
+	}
+	setUndoToken(c, token)
+
+	c.JSON(http.StatusOK, StatusResponse{
+		Status: "ok",
+	})
+}
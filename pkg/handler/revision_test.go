@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fr13n8/todo-app/pkg/service"
+	mockservice "github.com/fr13n8/todo-app/pkg/service/mocks"
+	"github.com/fr13n8/todo-app/structs"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_diffRevisions(t *testing.T) {
+	type mockBehavior func(s *mockservice.MockRevision)
+
+	testTable := []struct {
+		name                 string
+		path                 string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "Against previous",
+			path: "/api/items/2/revisions/3/diff",
+			mockBehavior: func(s *mockservice.MockRevision) {
+				s.EXPECT().Diff(1, 2, 2, 3).Return(structs.RevisionDiff{
+					From: 2,
+					To:   3,
+					Changes: []structs.RevisionChange{
+						{Field: "title", From: "old", To: "new"},
+						{Field: "status_id", From: (*int)(nil), To: intPointer(4)},
+					},
+				}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"data":{"from":2,"to":3,"changes":[{"field":"title","from":"old","to":"new"},{"field":"status_id","from":null,"to":4}]}}`,
+		},
+		{
+			name: "From given revision",
+			path: "/api/items/2/revisions/3/diff?from=0",
+			mockBehavior: func(s *mockservice.MockRevision) {
+				s.EXPECT().Diff(1, 2, 0, 3).Return(structs.RevisionDiff{To: 3, Changes: []structs.RevisionChange{}}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"data":{"from":0,"to":3,"changes":[]}}`,
+		},
+		{
+			name:                 "Invalid from",
+			path:                 "/api/items/2/revisions/3/diff?from=-1",
+			mockBehavior:         func(s *mockservice.MockRevision) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"message":"invalid query params"}`,
+		},
+		{
+			name: "Not found",
+			path: "/api/items/2/revisions/9/diff",
+			mockBehavior: func(s *mockservice.MockRevision) {
+				s.EXPECT().Diff(1, 2, 8, 9).Return(structs.RevisionDiff{}, errors.New("record not found"))
+			},
+			expectedStatusCode:   500,
+			expectedResponseBody: `{"message":"record not found"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			revision := mockservice.NewMockRevision(c)
+			testCase.mockBehavior(revision)
+
+			services := &service.Service{Revision: revision}
+			handler := NewHandler(services)
+
+			r := gin.New()
+			r.GET("/api/items/:id/revisions/:revision/diff", func(c *gin.Context) {
+				c.Set(userCtx, 1)
+			}, handler.diffRevisions)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", testCase.path, nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_revertRevision(t *testing.T) {
+	type mockBehavior func(s *mockservice.MockRevision)
+
+	testTable := []struct {
+		name                 string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+		expectedUndoToken    string
+	}{
+		{
+			name: "Ok",
+			mockBehavior: func(s *mockservice.MockRevision) {
+				s.EXPECT().Revert(1, 2, 3).Return("d6d5c5a1-8b53-4c1e-9a0b-3c3d2b6f0e11", nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"status":"ok"}`,
+			expectedUndoToken:    "d6d5c5a1-8b53-4c1e-9a0b-3c3d2b6f0e11",
+		},
+		{
+			name: "Blocked",
+			mockBehavior: func(s *mockservice.MockRevision) {
+				s.EXPECT().Revert(1, 2, 3).Return("", service.ErrItemBlocked)
+			},
+			expectedStatusCode:   409,
+			expectedResponseBody: `{"message":"` + service.ErrItemBlocked.Error() + `"}`,
+		},
+		{
+			name: "Service failure",
+			mockBehavior: func(s *mockservice.MockRevision) {
+				s.EXPECT().Revert(1, 2, 3).Return("", errors.New("record not found"))
+			},
+			expectedStatusCode:   500,
+			expectedResponseBody: `{"message":"record not found"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			revision := mockservice.NewMockRevision(c)
+			testCase.mockBehavior(revision)
+
+			services := &service.Service{Revision: revision}
+			handler := NewHandler(services)
+
+			r := gin.New()
+			r.POST("/api/items/:id/revisions/:revision/revert", func(c *gin.Context) {
+				c.Set(userCtx, 1)
+			}, handler.revertRevision)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/api/items/2/revisions/3/revert", nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedResponseBody, w.Body.String())
+			assert.Equal(t, testCase.expectedUndoToken, w.Header().Get("Undo-Token"))
+		})
+	}
+}
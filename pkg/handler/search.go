@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/fr13n8/todo-app/structs"
+	"github.com/gin-gonic/gin"
+)
+
+type searchResponse struct {
+	Data structs.SearchResults `json:"data"`
+}
+
+// @Summary Search
+// @Security ApiKeyAuth
+// @Tags search
+// @Description full-text search of the titles and descriptions of the lists and items the user can see, grouped by type
+// @ID search
+// @Accept  json
+// @Produce  json
+// @Param q query string true "words, quoted phrases, or and -excluded words"
+// @Param type query string false "list or item, both by default"
+// @Param limit query int false "hits per type, 20 by default, at most 100"
+// @Param offset query int false "hits to skip per type"
+// @Success 200 {object} searchResponse
+// @Failure 400,404 {object} HTTPError
+// @Failure 500 {object} HTTPError
+// @Failure default {object} HTTPError
+// @Router /api/search [get]
+func (h *Handler) search(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	var query structs.SearchQuery
+	if err := c.BindQuery(&query); err != nil {
+		newResponseError(c, http.StatusBadRequest, errors.New("invalid query params"))
+		return
+	}
+
+	results, err := h.services.Search.Search(userId, query)
+	if err != nil {
+		newResponseError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, searchResponse{
+		Data: results,
+	})
+}
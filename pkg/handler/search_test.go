@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fr13n8/todo-app/pkg/service"
+	mockservice "github.com/fr13n8/todo-app/pkg/service/mocks"
+	"github.com/fr13n8/todo-app/structs"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_search(t *testing.T) {
+	type mockBehavior func(s *mockservice.MockSearch)
+
+	listId := 3
+
+	testTable := []struct {
+		name                 string
+		query                string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:  "Ok",
+			query: "?q=release&type=item&limit=10&offset=10",
+			mockBehavior: func(s *mockservice.MockSearch) {
+				s.EXPECT().Search(1, structs.SearchQuery{Q: "release", Type: "item", Limit: 10, Offset: 10}).Return(structs.SearchResults{
+					Items: &structs.SearchGroup{Total: 11, Hits: []structs.SearchHit{
+						{Id: 7, ListId: &listId, Title: "Release notes", Snippet: "<mark>Release</mark> notes", Rank: 0.6},
+					}},
+				}, nil)
+			},
+			expectedStatusCode: 200,
+			expectedResponseBody: `{"data":{"items":{"total":11,"hits":[{"id":7,"list_id":3,"title":"Release notes",` +
+				`"snippet":"\u003cmark\u003eRelease\u003c/mark\u003e notes","rank":0.6}]}}}`,
+		},
+		{
+			name:                 "No query",
+			query:                "?type=list",
+			mockBehavior:         func(s *mockservice.MockSearch) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"message":"invalid query params"}`,
+		},
+		{
+			name:                 "Unknown type",
+			query:                "?q=release&type=comment",
+			mockBehavior:         func(s *mockservice.MockSearch) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"message":"invalid query params"}`,
+		},
+		{
+			name:                 "Limit too high",
+			query:                "?q=release&limit=1000",
+			mockBehavior:         func(s *mockservice.MockSearch) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"message":"invalid query params"}`,
+		},
+		{
+			name:  "Service failure",
+			query: "?q=release",
+			mockBehavior: func(s *mockservice.MockSearch) {
+				s.EXPECT().Search(1, structs.SearchQuery{Q: "release"}).Return(structs.SearchResults{}, errors.New("service failure"))
+			},
+			expectedStatusCode:   500,
+			expectedResponseBody: `{"message":"service failure"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			search := mockservice.NewMockSearch(c)
+			testCase.mockBehavior(search)
+
+			services := &service.Service{Search: search}
+			handler := NewHandler(services)
+
+			r := gin.New()
+			r.GET("/api/search", func(c *gin.Context) {
+				c.Set(userCtx, 1)
+			}, handler.search)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/api/search"+testCase.query, nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedResponseBody, w.Body.String())
+		})
+	}
+}
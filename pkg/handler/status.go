@@ -0,0 +1,216 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/fr13n8/todo-app/structs"
+	"github.com/gin-gonic/gin"
+)
+
+type getAllStatusesResponse struct {
+	Data []structs.Status `json:"data"`
+}
+
+type getStatusResponse struct {
+	Data structs.Status `json:"data"`
+}
+
+// @Summary Create status
+// @Security ApiKeyAuth
+// @Tags statuses
+// @Description add a workflow status at the end of the list's workflow
+// @ID create-status
+// @Accept  json
+// @Produce  json
+// @Param id path int true "list id"
+// @Param input body structs.StatusInput true "status info"
+// @Success 200 {integer} integer 1
+// @Header 200 {string} Undo-Token "token undoing the changes"
+// @Failure 400,404 {object} HTTPError
+// @Failure 500 {object} HTTPError
+// @Failure default {object} HTTPError
+// @Router /api/lists/:id/statuses [post]
+func (h *Handler) createStatus(c *gin.Context) {
+	var input structs.StatusInput
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	listId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := c.BindJSON(&input); err != nil {
+		newResponseError(c, http.StatusBadRequest, errors.New("invalid input body"))
+		return
+	}
+
+	id, token, err := h.services.Status.Create(userId, listId, input)
+	if err != nil {
+		newResponseError(c, http.StatusInternalServerError, err)
+		return
+	}
+	setUndoToken(c, token)
+
+	c.JSON(http.StatusOK, gin.H{"id": id})
+}
+
+// @Summary Get list statuses
+// @Security ApiKeyAuth
+// @Tags statuses
+// @Description get the workflow statuses of the list in order
+// @ID get-all-statuses
+// @Accept  json
+// @Produce  json
+// @Param id path int true "list id"
+// @Success 200 {object} getAllStatusesResponse
+// @Failure 400,404 {object} HTTPError
+// @Failure 500 {object} HTTPError
+// @Failure default {object} HTTPError
+// @Router /api/lists/:id/statuses [get]
+func (h *Handler) getAllStatuses(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	listId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	statuses, err := h.services.Status.GetAll(userId, listId)
+	if err != nil {
+		newResponseError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, getAllStatusesResponse{
+		Data: statuses,
+	})
+}
+
+// @Summary Get status by id
+// @Security ApiKeyAuth
+// @Tags statuses
+// @Description get status by id
+// @ID get-status-by-id
+// @Accept  json
+// @Produce  json
+// @Param id path int true "status id"
+// @Success 200 {object} getStatusResponse
+// @Failure 400,404 {object} HTTPError
+// @Failure 500 {object} HTTPError
+// @Failure default {object} HTTPError
+// @Router /api/statuses/:id [get]
+func (h *Handler) getStatusById(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	statusId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	status, err := h.services.Status.GetById(userId, statusId)
+	if err != nil {
+		newResponseError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, getStatusResponse{
+		Data: status,
+	})
+}
+
+// @Summary Update status
+// @Security ApiKeyAuth
+// @Tags statuses
+// @Description update status; changing is_done updates the items in it
+// @ID update-status
+// @Accept  json
+// @Produce  json
+// @Param id path int true "status id"
+// @Param input body structs.UpdateStatusInput true "status info"
+// @Success 200 {string} string Ok
+// @Header 200 {string} Undo-Token "token undoing the changes"
+// @Failure 400,404 {object} HTTPError
+// @Failure 500 {object} HTTPError
+// @Failure default {object} HTTPError
+// @Router /api/statuses/:id [put]
+func (h *Handler) updateStatus(c *gin.Context) {
+	var input structs.UpdateStatusInput
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	statusId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := c.BindJSON(&input); err != nil {
+		newResponseError(c, http.StatusBadRequest, errors.New("invalid input body"))
+		return
+	}
+
+	token, err := h.services.Status.Update(userId, statusId, input)
+	if err != nil {
+		newResponseError(c, http.StatusInternalServerError, err)
+		return
+	}
+	setUndoToken(c, token)
+
+	c.JSON(http.StatusOK, StatusResponse{
+		Status: "ok",
+	})
+}
+
+// @Summary Delete status
+// @Security ApiKeyAuth
+// @Tags statuses
+// @Description delete status; its items are left without a status
+// @ID delete-status
+// @Accept  json
+// @Produce  json
+// @Param id path int true "status id"
+// @Success 200 {string} string Ok
+// @Header 200 {string} Undo-Token "token undoing the changes"
+// @Failure 400,404 {object} HTTPError
+// @Failure 500 {object} HTTPError
+// @Failure default {object} HTTPError
+// @Router /api/statuses/:id [delete]
+func (h *Handler) deleteStatus(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	statusId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	token, err := h.services.Status.Delete(userId, statusId)
+	if err != nil {
+		newResponseError(c, http.StatusInternalServerError, err)
+		return
+	}
+	setUndoToken(c, token)
+
+	c.JSON(http.StatusOK, StatusResponse{
+		Status: "ok",
+	})
+}
@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fr13n8/todo-app/pkg/service"
+	mockservice "github.com/fr13n8/todo-app/pkg/service/mocks"
+	"github.com/fr13n8/todo-app/structs"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_createStatus(t *testing.T) {
+	type mockBehavior func(s *mockservice.MockStatus)
+
+	testTable := []struct {
+		name                 string
+		inputBody            string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+		expectedUndoToken    string
+	}{
+		{
+			name:      "Ok",
+			inputBody: `{"name":"Done","is_done":true,"transitions":[1]}`,
+			mockBehavior: func(s *mockservice.MockStatus) {
+				s.EXPECT().Create(1, 2, structs.StatusInput{Name: "Done", IsDone: true, Transitions: []int{1}}).Return(3, "d6d5c5a1-8b53-4c1e-9a0b-3c3d2b6f0e11", nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"id":3}`,
+			expectedUndoToken:    "d6d5c5a1-8b53-4c1e-9a0b-3c3d2b6f0e11",
+		},
+		{
+			name:                 "No name",
+			inputBody:            `{"is_done":true}`,
+			mockBehavior:         func(s *mockservice.MockStatus) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"message":"invalid input body"}`,
+		},
+		{
+			name:      "Service error",
+			inputBody: `{"name":"Done"}`,
+			mockBehavior: func(s *mockservice.MockStatus) {
+				s.EXPECT().Create(1, 2, structs.StatusInput{Name: "Done"}).Return(0, "", errors.New("record not found"))
+			},
+			expectedStatusCode:   500,
+			expectedResponseBody: `{"message":"record not found"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			status := mockservice.NewMockStatus(c)
+			testCase.mockBehavior(status)
+
+			services := &service.Service{Status: status}
+			handler := NewHandler(services)
+
+			r := gin.New()
+			r.POST("/api/lists/:id/statuses", func(c *gin.Context) {
+				c.Set(userCtx, 1)
+			}, handler.createStatus)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/api/lists/2/statuses", bytes.NewBufferString(testCase.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedResponseBody, w.Body.String())
+			assert.Equal(t, testCase.expectedUndoToken, w.Header().Get("Undo-Token"))
+		})
+	}
+}
+
+func TestHandler_getAllStatuses(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	status := mockservice.NewMockStatus(c)
+	status.EXPECT().GetAll(1, 2).Return([]structs.Status{
+		{Id: 1, ListId: 2, Name: "Backlog", Transitions: []int{2}},
+		{Id: 2, ListId: 2, Name: "Done", Position: 1, IsDone: true},
+	}, nil)
+
+	services := &service.Service{Status: status}
+	handler := NewHandler(services)
+
+	r := gin.New()
+	r.GET("/api/lists/:id/statuses", func(c *gin.Context) {
+		c.Set(userCtx, 1)
+	}, handler.getAllStatuses)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/lists/2/statuses", nil)
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, 200, w.Code)
+	assert.Equal(t, `{"data":[{"id":1,"list_id":2,"name":"Backlog","position":0,"is_done":false,"transitions":[2]},`+
+		`{"id":2,"list_id":2,"name":"Done","position":1,"is_done":true}]}`, w.Body.String())
+}
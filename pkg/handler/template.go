@@ -0,0 +1,207 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/fr13n8/todo-app/structs"
+	"github.com/gin-gonic/gin"
+)
+
+type getAllTemplatesResponse struct {
+	Data []structs.Template `json:"data"`
+}
+
+type getTemplateResponse struct {
+	Data structs.Template `json:"data"`
+}
+
+// @Summary Create template
+// @Security ApiKeyAuth
+// @Tags templates
+// @Description save a list with its items and labels as a template
+// @ID create-template
+// @Accept  json
+// @Produce  json
+// @Param input body structs.TemplateInput true "template info"
+// @Success 200 {integer} integer 1
+// @Header 200 {string} Undo-Token "token undoing the changes"
+// @Failure 400,404 {object} HTTPError
+// @Failure 500 {object} HTTPError
+// @Failure default {object} HTTPError
+// @Router /api/templates [post]
+func (h *Handler) createTemplate(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	var input structs.TemplateInput
+	if err := c.BindJSON(&input); err != nil {
+		newResponseError(c, http.StatusBadRequest, errors.New("invalid input body"))
+		return
+	}
+
+	id, token, err := h.services.Template.Create(userId, input)
+	if err != nil {
+		newResponseError(c, http.StatusInternalServerError, err)
+		return
+	}
+	setUndoToken(c, token)
+
+	c.JSON(http.StatusOK, gin.H{"id": id})
+}
+
+// @Summary Get All Templates
+// @Security ApiKeyAuth
+// @Tags templates
+// @Description get own and shared templates
+// @ID get-all-templates
+// @Accept  json
+// @Produce  json
+// @Param scope query string false "own or workspace, both by default"
+// @Success 200 {object} getAllTemplatesResponse
+// @Failure 400,404 {object} HTTPError
+// @Failure 500 {object} HTTPError
+// @Failure default {object} HTTPError
+// @Router /api/templates [get]
+func (h *Handler) getAllTemplates(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	var filter structs.TemplateFilter
+	if err := c.BindQuery(&filter); err != nil {
+		newResponseError(c, http.StatusBadRequest, errors.New("invalid query params"))
+		return
+	}
+
+	templates, err := h.services.Template.GetAll(userId, filter)
+	if err != nil {
+		newResponseError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, getAllTemplatesResponse{
+		Data: templates,
+	})
+}
+
+// @Summary Get Template By Id
+// @Security ApiKeyAuth
+// @Tags templates
+// @Description get template with its items
+// @ID get-template-by-id
+// @Accept  json
+// @Produce  json
+// @Param id path int true "template id"
+// @Success 200 {object} getTemplateResponse
+// @Failure 400,404 {object} HTTPError
+// @Failure 500 {object} HTTPError
+// @Failure default {object} HTTPError
+// @Router /api/templates/:id [get]
+func (h *Handler) getTemplateById(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	templateId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	template, err := h.services.Template.GetById(userId, templateId)
+	if err != nil {
+		newResponseError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, getTemplateResponse{
+		Data: template,
+	})
+}
+
+// @Summary Delete Template
+// @Security ApiKeyAuth
+// @Tags templates
+// @Description delete own template
+// @ID delete-template
+// @Accept  json
+// @Produce  json
+// @Param id path int true "template id"
+// @Success 200 {object} StatusResponse
+// @Header 200 {string} Undo-Token "token undoing the changes"
+// @Failure 400,404 {object} HTTPError
+// @Failure 500 {object} HTTPError
+// @Failure default {object} HTTPError
+// @Router /api/templates/:id [delete]
+func (h *Handler) deleteTemplate(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	templateId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	token, err := h.services.Template.Delete(userId, templateId)
+	if err != nil {
+		newResponseError(c, http.StatusInternalServerError, err)
+		return
+	}
+	setUndoToken(c, token)
+
+	c.JSON(http.StatusOK, StatusResponse{
+		Status: "ok",
+	})
+}
+
+// @Summary Instantiate Template
+// @Security ApiKeyAuth
+// @Tags templates
+// @Description create a list from the template, due dates counted from the start date
+// @ID instantiate-template
+// @Accept  json
+// @Produce  json
+// @Param id path int true "template id"
+// @Param input body structs.InstantiateTemplateInput true "list info"
+// @Success 200 {integer} integer 1
+// @Header 200 {string} Undo-Token "token undoing the changes"
+// @Failure 400,404 {object} HTTPError
+// @Failure 500 {object} HTTPError
+// @Failure default {object} HTTPError
+// @Router /api/templates/:id/instantiate [post]
+func (h *Handler) instantiateTemplate(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	templateId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	var input structs.InstantiateTemplateInput
+	if err := c.BindJSON(&input); err != nil {
+		newResponseError(c, http.StatusBadRequest, errors.New("invalid input body"))
+		return
+	}
+
+	id, token, err := h.services.Template.Instantiate(userId, templateId, input)
+	if err != nil {
+		newResponseError(c, http.StatusInternalServerError, err)
+		return
+	}
+	setUndoToken(c, token)
+
+	c.JSON(http.StatusOK, gin.H{"id": id})
+}
@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fr13n8/todo-app/pkg/service"
+	mockservice "github.com/fr13n8/todo-app/pkg/service/mocks"
+	"github.com/fr13n8/todo-app/structs"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_getAllTemplates(t *testing.T) {
+	type mockBehavior func(s *mockservice.MockTemplate)
+
+	createdAt := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	testTable := []struct {
+		name                 string
+		query                string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:  "Ok",
+			query: "?scope=workspace",
+			mockBehavior: func(s *mockservice.MockTemplate) {
+				s.EXPECT().GetAll(1, structs.TemplateFilter{Scope: "workspace"}).Return([]structs.Template{
+					{Id: 2, UserId: 3, Title: "release", Shared: true, CreatedAt: createdAt},
+				}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"data":[{"id":2,"user_id":3,"title":"release","description":"","shared":true,"created_at":"2021-06-01T00:00:00Z"}]}`,
+		},
+		{
+			name:                 "Unknown scope",
+			query:                "?scope=team",
+			mockBehavior:         func(s *mockservice.MockTemplate) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"message":"invalid query params"}`,
+		},
+		{
+			name: "Service failure",
+			mockBehavior: func(s *mockservice.MockTemplate) {
+				s.EXPECT().GetAll(1, structs.TemplateFilter{}).Return(nil, errors.New("service failure"))
+			},
+			expectedStatusCode:   500,
+			expectedResponseBody: `{"message":"service failure"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			template := mockservice.NewMockTemplate(c)
+			testCase.mockBehavior(template)
+
+			services := &service.Service{Template: template}
+			handler := NewHandler(services)
+
+			r := gin.New()
+			r.GET("/api/templates/", func(c *gin.Context) {
+				c.Set(userCtx, 1)
+			}, handler.getAllTemplates)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/api/templates/"+testCase.query, nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_instantiateTemplate(t *testing.T) {
+	type mockBehavior func(s *mockservice.MockTemplate)
+
+	start := time.Date(2021, 6, 1, 9, 0, 0, 0, time.UTC)
+
+	testTable := []struct {
+		name                 string
+		inputBody            string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+		expectedUndoToken    string
+	}{
+		{
+			name:      "Ok",
+			inputBody: `{"title":"release 1.2","start_date":"2021-06-01T09:00:00Z"}`,
+			mockBehavior: func(s *mockservice.MockTemplate) {
+				s.EXPECT().Instantiate(1, 2, structs.InstantiateTemplateInput{Title: "release 1.2", StartDate: start}).Return(5, "d6d5c5a1-8b53-4c1e-9a0b-3c3d2b6f0e11", nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"id":5}`,
+			expectedUndoToken:    "d6d5c5a1-8b53-4c1e-9a0b-3c3d2b6f0e11",
+		},
+		{
+			name:                 "No start date",
+			inputBody:            `{"title":"release 1.2"}`,
+			mockBehavior:         func(s *mockservice.MockTemplate) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"message":"invalid input body"}`,
+		},
+		{
+			name:      "Not found",
+			inputBody: `{"start_date":"2021-06-01T09:00:00Z"}`,
+			mockBehavior: func(s *mockservice.MockTemplate) {
+				s.EXPECT().Instantiate(1, 2, structs.InstantiateTemplateInput{StartDate: start}).Return(0, "", errors.New("record not found"))
+			},
+			expectedStatusCode:   500,
+			expectedResponseBody: `{"message":"record not found"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			template := mockservice.NewMockTemplate(c)
+			testCase.mockBehavior(template)
+
+			services := &service.Service{Template: template}
+			handler := NewHandler(services)
+
+			r := gin.New()
+			r.POST("/api/templates/:id/instantiate", func(c *gin.Context) {
+				c.Set(userCtx, 1)
+			}, handler.instantiateTemplate)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/api/templates/2/instantiate", bytes.NewBufferString(testCase.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedResponseBody, w.Body.String())
+			assert.Equal(t, testCase.expectedUndoToken, w.Header().Get("Undo-Token"))
+		})
+	}
+}
@@ -0,0 +1,316 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/fr13n8/todo-app/structs"
+	"github.com/gin-gonic/gin"
+)
+
+type getAllTimeEntriesResponse struct {
+	Data []structs.TimeEntry `json:"data"`
+}
+
+type getTimeReportResponse struct {
+	Data []structs.TimeReportRow `json:"data"`
+}
+
+type timeReportQuery struct {
+	From     string `form:"from" binding:"required"`
+	To       string `form:"to" binding:"required"`
+	GroupBy  string `form:"group_by" binding:"omitempty,oneof=list label day"`
+	TimeZone string `form:"tz"`
+	Format   string `form:"format" binding:"omitempty,oneof=json csv"`
+}
+
+// @Summary Start timer
+// @Security ApiKeyAuth
+// @Tags time
+// @Description start a timer on the item, stopping the one already running
+// @ID start-timer
+// @Accept  json
+// @Produce  json
+// @Param id path int true "item id"
+// @Success 200 {integer} integer 1
+// @Header 200 {string} Undo-Token "token undoing the changes"
+// @Failure 400,404 {object} HTTPError
+// @Failure 500 {object} HTTPError
+// @Failure default {object} HTTPError
+// @Router /api/items/:id/timer/start [post]
+func (h *Handler) startTimer(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	itemId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	id, token, err := h.services.TimeEntry.Start(userId, itemId)
+	if err != nil {
+		newResponseError(c, http.StatusInternalServerError, err)
+		return
+	}
+	setUndoToken(c, token)
+
+	c.JSON(http.StatusOK, gin.H{
+		"id": id,
+	})
+}
+
+// @Summary Stop timer
+// @Security ApiKeyAuth
+// @Tags time
+// @Description stop the timer running on the item
+// @ID stop-timer
+// @Accept  json
+// @Produce  json
+// @Param id path int true "item id"
+// @Success 200 {string} string Ok
+// @Header 200 {string} Undo-Token "token undoing the changes"
+// @Failure 400,404 {object} HTTPError
+// @Failure 409 {object} HTTPError
+// @Failure 500 {object} HTTPError
+// @Failure default {object} HTTPError
+// @Router /api/items/:id/timer/stop [post]
+func (h *Handler) stopTimer(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	itemId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	token, err := h.services.TimeEntry.Stop(userId, itemId)
+	if err != nil {
+		newResponseError(c, serviceErrorStatus(err), err)
+		return
+	}
+	setUndoToken(c, token)
+
+	c.JSON(http.StatusOK, StatusResponse{
+		Status: "ok",
+	})
+}
+
+// @Summary Add time entry
+// @Security ApiKeyAuth
+// @Tags time
+// @Description add a manual time entry to the item
+// @ID create-time-entry
+// @Accept  json
+// @Produce  json
+// @Param id path int true "item id"
+// @Param input body structs.TimeEntryInput true "time entry"
+// @Success 200 {integer} integer 1
+// @Header 200 {string} Undo-Token "token undoing the changes"
+// @Failure 400,404 {object} HTTPError
+// @Failure 500 {object} HTTPError
+// @Failure default {object} HTTPError
+// @Router /api/items/:id/time-entries [post]
+func (h *Handler) createTimeEntry(c *gin.Context) {
+	var input structs.TimeEntryInput
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	itemId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := c.BindJSON(&input); err != nil {
+		newResponseError(c, http.StatusBadRequest, errors.New("invalid input body"))
+		return
+	}
+	if err := input.Validate(); err != nil {
+		newResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	id, token, err := h.services.TimeEntry.Create(userId, itemId, input)
+	if err != nil {
+		newResponseError(c, http.StatusInternalServerError, err)
+		return
+	}
+	setUndoToken(c, token)
+
+	c.JSON(http.StatusOK, gin.H{
+		"id": id,
+	})
+}
+
+// @Summary Get time entries
+// @Security ApiKeyAuth
+// @Tags time
+// @Description get time entries of the item
+// @ID get-time-entries
+// @Accept  json
+// @Produce  json
+// @Param id path int true "item id"
+// @Success 200 {object} getAllTimeEntriesResponse
+// @Failure 400,404 {object} HTTPError
+// @Failure 500 {object} HTTPError
+// @Failure default {object} HTTPError
+// @Router /api/items/:id/time-entries [get]
+func (h *Handler) getTimeEntries(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	itemId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	entries, err := h.services.TimeEntry.GetAll(userId, itemId)
+	if err != nil {
+		newResponseError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, getAllTimeEntriesResponse{
+		Data: entries,
+	})
+}
+
+// @Summary Delete time entry
+// @Security ApiKeyAuth
+// @Tags time
+// @Description delete own time entry of the item
+// @ID delete-time-entry
+// @Accept  json
+// @Produce  json
+// @Param id path int true "item id"
+// @Param entry_id path int true "time entry id"
+// @Success 200 {string} string Ok
+// @Header 200 {string} Undo-Token "token undoing the changes"
+// @Failure 400,404 {object} HTTPError
+// @Failure 500 {object} HTTPError
+// @Failure default {object} HTTPError
+// @Router /api/items/:id/time-entries/:entry_id [delete]
+func (h *Handler) deleteTimeEntry(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	itemId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	entryId, err := strconv.Atoi(c.Param("entry_id"))
+	if err != nil {
+		newResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	token, err := h.services.TimeEntry.Delete(userId, itemId, entryId)
+	if err != nil {
+		newResponseError(c, http.StatusInternalServerError, err)
+		return
+	}
+	setUndoToken(c, token)
+
+	c.JSON(http.StatusOK, StatusResponse{
+		Status: "ok",
+	})
+}
+
+// @Summary Get time report
+// @Security ApiKeyAuth
+// @Tags time
+// @Description sum tracked time of the user per list, label or day
+// @ID get-time-report
+// @Accept  json
+// @Produce  json,text/csv
+// @Param from query string true "first day, YYYY-MM-DD"
+// @Param to query string true "last day, YYYY-MM-DD"
+// @Param group_by query string false "list (default), label or day"
+// @Param tz query string false "IANA time zone of the days, UTC by default"
+// @Param format query string false "json (default) or csv"
+// @Success 200 {object} getTimeReportResponse
+// @Failure 400,404 {object} HTTPError
+// @Failure 500 {object} HTTPError
+// @Failure default {object} HTTPError
+// @Router /api/reports/time [get]
+func (h *Handler) getTimeReport(c *gin.Context) {
+	var query timeReportQuery
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	if err := c.BindQuery(&query); err != nil {
+		newResponseError(c, http.StatusBadRequest, errors.New("invalid query params"))
+		return
+	}
+
+	// Local is the zone of the server, which the database doesn't know by
+	// that name.
+	location, err := time.LoadLocation(query.TimeZone)
+	if err != nil || query.TimeZone == "Local" {
+		newResponseError(c, http.StatusBadRequest, errors.New("invalid time zone"))
+		return
+	}
+	from, err := time.ParseInLocation("2006-01-02", query.From, location)
+	if err != nil {
+		newResponseError(c, http.StatusBadRequest, errors.New("invalid from date"))
+		return
+	}
+	to, err := time.ParseInLocation("2006-01-02", query.To, location)
+	if err != nil {
+		newResponseError(c, http.StatusBadRequest, errors.New("invalid to date"))
+		return
+	}
+
+	rows, err := h.services.TimeEntry.GetReport(userId, structs.TimeReportFilter{
+		From:    from,
+		To:      to.AddDate(0, 0, 1),
+		GroupBy: query.GroupBy,
+	})
+	if err != nil {
+		newResponseError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	if query.Format == "csv" {
+		var buf bytes.Buffer
+		w := csv.NewWriter(&buf)
+		w.Write([]string{"id", "name", "seconds"})
+		for _, row := range rows {
+			id := ""
+			if row.Id != nil {
+				id = strconv.Itoa(*row.Id)
+			}
+			w.Write([]string{id, row.Name, strconv.FormatInt(row.Seconds, 10)})
+		}
+		w.Flush()
+
+		c.Header("Content-Disposition", `attachment; filename="time-report.csv"`)
+		c.Data(http.StatusOK, "text/csv; charset=utf-8", buf.Bytes())
+		return
+	}
+
+	c.JSON(http.StatusOK, getTimeReportResponse{
+		Data: rows,
+	})
+}
@@ -0,0 +1,177 @@
+package handler
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fr13n8/todo-app/pkg/service"
+	mockservice "github.com/fr13n8/todo-app/pkg/service/mocks"
+	"github.com/fr13n8/todo-app/structs"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_stopTimer(t *testing.T) {
+	type mockBehavior func(s *mockservice.MockTimeEntry)
+
+	testTable := []struct {
+		name                 string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+		expectedUndoToken    string
+	}{
+		{
+			name: "Ok",
+			mockBehavior: func(s *mockservice.MockTimeEntry) {
+				s.EXPECT().Stop(1, 2).Return("d6d5c5a1-8b53-4c1e-9a0b-3c3d2b6f0e11", nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"status":"ok"}`,
+			expectedUndoToken:    "d6d5c5a1-8b53-4c1e-9a0b-3c3d2b6f0e11",
+		},
+		{
+			name: "No running timer",
+			mockBehavior: func(s *mockservice.MockTimeEntry) {
+				s.EXPECT().Stop(1, 2).Return("", service.ErrNoRunningTimer)
+			},
+			expectedStatusCode:   409,
+			expectedResponseBody: `{"message":"no running timer on the item"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			timeEntry := mockservice.NewMockTimeEntry(c)
+			testCase.mockBehavior(timeEntry)
+
+			services := &service.Service{TimeEntry: timeEntry}
+			handler := NewHandler(services)
+
+			r := gin.New()
+			r.POST("/api/items/:id/timer/stop", func(c *gin.Context) {
+				c.Set(userCtx, 1)
+			}, handler.stopTimer)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/api/items/2/timer/stop", nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedResponseBody, w.Body.String())
+			assert.Equal(t, testCase.expectedUndoToken, w.Header().Get("Undo-Token"))
+		})
+	}
+}
+
+func TestHandler_getTimeReport(t *testing.T) {
+	type mockBehavior func(s *mockservice.MockTimeEntry)
+
+	location, _ := time.LoadLocation("Europe/Berlin")
+	rows := []structs.TimeReportRow{
+		{Id: intPointer(1), Name: "work, misc", Seconds: 3600},
+		{Name: "", Seconds: 60},
+	}
+
+	testTable := []struct {
+		name                 string
+		query                string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedContentType  string
+		expectedResponseBody string
+	}{
+		{
+			name:  "Ok",
+			query: "from=2021-06-01&to=2021-06-07&group_by=label&tz=Europe/Berlin",
+			mockBehavior: func(s *mockservice.MockTimeEntry) {
+				s.EXPECT().GetReport(1, structs.TimeReportFilter{
+					From:    time.Date(2021, 6, 1, 0, 0, 0, 0, location),
+					To:      time.Date(2021, 6, 8, 0, 0, 0, 0, location),
+					GroupBy: structs.TimeReportByLabel,
+				}).Return(rows, nil)
+			},
+			expectedStatusCode:   200,
+			expectedContentType:  "application/json; charset=utf-8",
+			expectedResponseBody: `{"data":[{"id":1,"name":"work, misc","seconds":3600},{"name":"","seconds":60}]}`,
+		},
+		{
+			name:  "CSV",
+			query: "from=2021-06-01&to=2021-06-07&format=csv",
+			mockBehavior: func(s *mockservice.MockTimeEntry) {
+				s.EXPECT().GetReport(1, gomock.Any()).Return(rows, nil)
+			},
+			expectedStatusCode:   200,
+			expectedContentType:  "text/csv; charset=utf-8",
+			expectedResponseBody: "id,name,seconds\n1,\"work, misc\",3600\n,,60\n",
+		},
+		{
+			name:                 "Invalid group",
+			query:                "from=2021-06-01&to=2021-06-07&group_by=item",
+			mockBehavior:         func(s *mockservice.MockTimeEntry) {},
+			expectedStatusCode:   400,
+			expectedContentType:  "application/json; charset=utf-8",
+			expectedResponseBody: `{"message":"invalid query params"}`,
+		},
+		{
+			name:                 "Invalid time zone",
+			query:                "from=2021-06-01&to=2021-06-07&tz=Mars/Olympus",
+			mockBehavior:         func(s *mockservice.MockTimeEntry) {},
+			expectedStatusCode:   400,
+			expectedContentType:  "application/json; charset=utf-8",
+			expectedResponseBody: `{"message":"invalid time zone"}`,
+		},
+		{
+			name:                 "Local time zone",
+			query:                "from=2021-06-01&to=2021-06-07&group_by=day&tz=Local",
+			mockBehavior:         func(s *mockservice.MockTimeEntry) {},
+			expectedStatusCode:   400,
+			expectedContentType:  "application/json; charset=utf-8",
+			expectedResponseBody: `{"message":"invalid time zone"}`,
+		},
+		{
+			name:  "Service error",
+			query: "from=2021-06-07&to=2021-06-01",
+			mockBehavior: func(s *mockservice.MockTimeEntry) {
+				s.EXPECT().GetReport(1, gomock.Any()).Return(nil, errors.New("report range is empty"))
+			},
+			expectedStatusCode:   500,
+			expectedContentType:  "application/json; charset=utf-8",
+			expectedResponseBody: `{"message":"report range is empty"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			timeEntry := mockservice.NewMockTimeEntry(c)
+			testCase.mockBehavior(timeEntry)
+
+			services := &service.Service{TimeEntry: timeEntry}
+			handler := NewHandler(services)
+
+			r := gin.New()
+			r.GET("/api/reports/time", func(c *gin.Context) {
+				c.Set(userCtx, 1)
+			}, handler.getTimeReport)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/api/reports/time?"+testCase.query, nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedContentType, w.Header().Get("Content-Type"))
+			assert.Equal(t, testCase.expectedResponseBody, w.Body.String())
+		})
+	}
+}
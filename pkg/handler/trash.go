@@ -0,0 +1,224 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/fr13n8/todo-app/structs"
+	"github.com/gin-gonic/gin"
+)
+
+type getTrashResponse struct {
+	Data []structs.TrashEntry `json:"data"`
+}
+
+// @Summary Get trash
+// @Security ApiKeyAuth
+// @Tags trash
+// @Description get deleted lists and items, most recently deleted first
+// @ID get-trash
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} getTrashResponse
+// @Failure 400,404 {object} HTTPError
+// @Failure 500 {object} HTTPError
+// @Failure default {object} HTTPError
+// @Router /api/trash [get]
+func (h *Handler) getTrash(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	entries, err := h.services.Trash.GetAll(userId)
+	if err != nil {
+		newResponseError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, getTrashResponse{
+		Data: entries,
+	})
+}
+
+// @Summary Empty trash
+// @Security ApiKeyAuth
+// @Tags trash
+// @Description permanently delete everything in the trash
+// @ID empty-trash
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} structs.TrashPurge
+// @Header 200 {string} Undo-Token "token undoing the changes"
+// @Failure 400,404 {object} HTTPError
+// @Failure 500 {object} HTTPError
+// @Failure default {object} HTTPError
+// @Router /api/trash [delete]
+func (h *Handler) emptyTrash(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	purge, token, err := h.services.Trash.Empty(userId)
+	if err != nil {
+		newResponseError(c, http.StatusInternalServerError, err)
+		return
+	}
+	setUndoToken(c, token)
+
+	c.JSON(http.StatusOK, purge)
+}
+
+// @Summary Restore list
+// @Security ApiKeyAuth
+// @Tags trash
+// @Description restore a deleted list together with its items
+// @ID restore-list
+// @Accept  json
+// @Produce  json
+// @Param id path int true "list id"
+// @Success 200 {string} string Ok
+// @Header 200 {string} Undo-Token "token undoing the changes"
+// @Failure 400,404 {object} HTTPError
+// @Failure 500 {object} HTTPError
+// @Failure default {object} HTTPError
+// @Router /api/trash/lists/:id/restore [post]
+func (h *Handler) restoreList(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	listId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	token, err := h.services.Trash.RestoreList(userId, listId)
+	if err != nil {
+		newResponseError(c, http.StatusInternalServerError, err)
+		return
+	}
+	setUndoToken(c, token)
+
+	c.JSON(http.StatusOK, StatusResponse{
+		Status: "ok",
+	})
+}
+
+// @Summary Purge list
+// @Security ApiKeyAuth
+// @Tags trash
+// @Description permanently delete a list in the trash with all of its items
+// @ID purge-list
+// @Accept  json
+// @Produce  json
+// @Param id path int true "list id"
+// @Success 200 {string} string Ok
+// @Header 200 {string} Undo-Token "token undoing the changes"
+// @Failure 400,404 {object} HTTPError
+// @Failure 500 {object} HTTPError
+// @Failure default {object} HTTPError
+// @Router /api/trash/lists/:id [delete]
+func (h *Handler) purgeList(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	listId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	token, err := h.services.Trash.PurgeList(userId, listId)
+	if err != nil {
+		newResponseError(c, http.StatusInternalServerError, err)
+		return
+	}
+	setUndoToken(c, token)
+
+	c.JSON(http.StatusOK, StatusResponse{
+		Status: "ok",
+	})
+}
+
+// @Summary Restore item
+// @Security ApiKeyAuth
+// @Tags trash
+// @Description restore a deleted item; its list must not be in the trash
+// @ID restore-item
+// @Accept  json
+// @Produce  json
+// @Param id path int true "item id"
+// @Success 200 {string} string Ok
+// @Header 200 {string} Undo-Token "token undoing the changes"
+// @Failure 400,404 {object} HTTPError
+// @Failure 409 {object} HTTPError
+// @Failure 500 {object} HTTPError
+// @Failure default {object} HTTPError
+// @Router /api/trash/items/:id/restore [post]
+func (h *Handler) restoreItem(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	itemId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	token, err := h.services.Trash.RestoreItem(userId, itemId)
+	if err != nil {
+		newResponseError(c, serviceErrorStatus(err), err)
+		return
+	}
+	setUndoToken(c, token)
+
+	c.JSON(http.StatusOK, StatusResponse{
+		Status: "ok",
+	})
+}
+
+// @Summary Purge item
+// @Security ApiKeyAuth
+// @Tags trash
+// @Description permanently delete an item in the trash
+// @ID purge-item
+// @Accept  json
+// @Produce  json
+// @Param id path int true "item id"
+// @Success 200 {string} string Ok
+// @Header 200 {string} Undo-Token "token undoing the changes"
+// @Failure 400,404 {object} HTTPError
+// @Failure 500 {object} HTTPError
+// @Failure default {object} HTTPError
+// @Router /api/trash/items/:id [delete]
+func (h *Handler) purgeItem(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	itemId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	token, err := h.services.Trash.PurgeItem(userId, itemId)
+	if err != nil {
+		newResponseError(c, http.StatusInternalServerError, err)
+		return
+	}
+	setUndoToken(c, token)
+
+	c.JSON(http.StatusOK, StatusResponse{
+		Status: "ok",
+	})
+}
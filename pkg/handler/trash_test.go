@@ -0,0 +1,143 @@
+package handler
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fr13n8/todo-app/pkg/service"
+	mockservice "github.com/fr13n8/todo-app/pkg/service/mocks"
+	"github.com/fr13n8/todo-app/structs"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_getTrash(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	trash := mockservice.NewMockTrash(c)
+	trash.EXPECT().GetAll(1).Return([]structs.TrashEntry{
+		{Type: "item", Id: 4, Title: "task", ListId: intPointer(2), DeletedAt: time.Date(2021, 6, 2, 10, 0, 0, 0, time.UTC), DeletedBy: intPointer(1)},
+		{Type: "list", Id: 3, Title: "groceries", DeletedAt: time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)},
+	}, nil)
+
+	services := &service.Service{Trash: trash}
+	handler := NewHandler(services)
+
+	r := gin.New()
+	r.GET("/api/trash", func(c *gin.Context) {
+		c.Set(userCtx, 1)
+	}, handler.getTrash)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/trash", nil)
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, 200, w.Code)
+	assert.Equal(t, `{"data":[{"type":"item","id":4,"title":"task","list_id":2,"deleted_at":"2021-06-02T10:00:00Z","deleted_by":1},{"type":"list","id":3,"title":"groceries","deleted_at":"2021-06-01T10:00:00Z"}]}`, w.Body.String())
+}
+
+func TestHandler_restoreItem(t *testing.T) {
+	type mockBehavior func(s *mockservice.MockTrash)
+
+	testTable := []struct {
+		name                 string
+		itemId               string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+		expectedUndoToken    string
+	}{
+		{
+			name:   "Ok",
+			itemId: "4",
+			mockBehavior: func(s *mockservice.MockTrash) {
+				s.EXPECT().RestoreItem(1, 4).Return("d6d5c5a1-8b53-4c1e-9a0b-3c3d2b6f0e11", nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"status":"ok"}`,
+			expectedUndoToken:    "d6d5c5a1-8b53-4c1e-9a0b-3c3d2b6f0e11",
+		},
+		{
+			name:   "List in trash",
+			itemId: "4",
+			mockBehavior: func(s *mockservice.MockTrash) {
+				s.EXPECT().RestoreItem(1, 4).Return("", service.ErrListInTrash)
+			},
+			expectedStatusCode:   409,
+			expectedResponseBody: `{"message":"the item's list is in the trash, restore the list first"}`,
+		},
+		{
+			name:   "Not in trash",
+			itemId: "4",
+			mockBehavior: func(s *mockservice.MockTrash) {
+				s.EXPECT().RestoreItem(1, 4).Return("", errors.New("record not found"))
+			},
+			expectedStatusCode:   500,
+			expectedResponseBody: `{"message":"record not found"}`,
+		},
+		{
+			name:                 "Invalid id",
+			itemId:               "task",
+			mockBehavior:         func(s *mockservice.MockTrash) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"message":"strconv.Atoi: parsing \"task\": invalid syntax"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			trash := mockservice.NewMockTrash(c)
+			testCase.mockBehavior(trash)
+
+			services := &service.Service{Trash: trash}
+			handler := NewHandler(services)
+
+			r := gin.New()
+			r.POST("/api/trash/items/:id/restore", func(c *gin.Context) {
+				c.Set(userCtx, 1)
+			}, handler.restoreItem)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/api/trash/items/"+testCase.itemId+"/restore", nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedResponseBody, w.Body.String())
+			assert.Equal(t, testCase.expectedUndoToken, w.Header().Get("Undo-Token"))
+		})
+	}
+}
+
+func TestHandler_emptyTrash(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	trash := mockservice.NewMockTrash(c)
+	trash.EXPECT().Empty(1).Return(structs.TrashPurge{Lists: 1, Items: 5, StorageKeys: []string{"1/a"}}, "d6d5c5a1-8b53-4c1e-9a0b-3c3d2b6f0e11", nil)
+
+	services := &service.Service{Trash: trash}
+	handler := NewHandler(services)
+
+	r := gin.New()
+	r.DELETE("/api/trash", func(c *gin.Context) {
+		c.Set(userCtx, 1)
+	}, handler.emptyTrash)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("DELETE", "/api/trash", nil)
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, 200, w.Code)
+	assert.Equal(t, `{"lists":1,"items":5}`, w.Body.String())
+	assert.Equal(t, "d6d5c5a1-8b53-4c1e-9a0b-3c3d2b6f0e11", w.Header().Get("Undo-Token"))
+}
@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+const undoTokenHeader = "Undo-Token"
+
+// setUndoToken hands the client the token undoing the changes of the
+// request, if any were journaled.
+func setUndoToken(c *gin.Context, token string) {
+	if token != "" {
+		c.Header(undoTokenHeader, token)
+	}
+}
+
+// @Summary Undo
+// @Security ApiKeyAuth
+// @Tags undo
+// @Description reverse the changes of a request by the Undo-Token it answered with, as long as the rows are still as it left them
+// @ID undo
+// @Accept  json
+// @Produce  json
+// @Param token path string true "undo token"
+// @Success 200 {object} StatusResponse
+// @Header 200 {string} Undo-Token "token redoing the changes"
+// @Failure 404 {object} HTTPError
+// @Failure 409 {object} HTTPError
+// @Failure 500 {object} HTTPError
+// @Failure default {object} HTTPError
+// @Router /api/undo/:token [post]
+func (h *Handler) undo(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	token, err := h.services.Undo.Undo(userId, c.Param("token"))
+	if err != nil {
+		newResponseError(c, serviceErrorStatus(err), err)
+		return
+	}
+	setUndoToken(c, token)
+
+	c.JSON(http.StatusOK, StatusResponse{
+		Status: "ok",
+	})
+}
@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fr13n8/todo-app/pkg/service"
+	mockservice "github.com/fr13n8/todo-app/pkg/service/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_undo(t *testing.T) {
+	type mockBehavior func(s *mockservice.MockUndo)
+
+	token := "0f8fad5b-d9cb-469f-a165-70867728950e"
+	redoToken := "d6d5c5a1-8b53-4c1e-9a0b-3c3d2b6f0e11"
+
+	testTable := []struct {
+		name                 string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+		expectedUndoToken    string
+	}{
+		{
+			name: "Ok",
+			mockBehavior: func(s *mockservice.MockUndo) {
+				s.EXPECT().Undo(1, token).Return(redoToken, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"status":"ok"}`,
+			expectedUndoToken:    redoToken,
+		},
+		{
+			name: "Expired",
+			mockBehavior: func(s *mockservice.MockUndo) {
+				s.EXPECT().Undo(1, token).Return("", service.ErrUndoExpired)
+			},
+			expectedStatusCode:   404,
+			expectedResponseBody: `{"message":"the undo token is unknown or has expired"}`,
+		},
+		{
+			name: "Changed since",
+			mockBehavior: func(s *mockservice.MockUndo) {
+				s.EXPECT().Undo(1, token).Return("", service.ErrUndoConflict)
+			},
+			expectedStatusCode:   409,
+			expectedResponseBody: `{"message":"the changes can't be undone, the rows were changed since"}`,
+		},
+		{
+			name: "Service failure",
+			mockBehavior: func(s *mockservice.MockUndo) {
+				s.EXPECT().Undo(1, token).Return("", errors.New("service failure"))
+			},
+			expectedStatusCode:   500,
+			expectedResponseBody: `{"message":"service failure"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			undo := mockservice.NewMockUndo(c)
+			testCase.mockBehavior(undo)
+
+			services := &service.Service{Undo: undo}
+			handler := NewHandler(services)
+
+			r := gin.New()
+			r.POST("/api/undo/:token", func(c *gin.Context) {
+				c.Set(userCtx, 1)
+			}, handler.undo)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/api/undo/"+token, nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedResponseBody, w.Body.String())
+			assert.Equal(t, testCase.expectedUndoToken, w.Header().Get("Undo-Token"))
+		})
+	}
+}
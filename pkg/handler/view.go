@@ -0,0 +1,257 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/fr13n8/todo-app/structs"
+	"github.com/gin-gonic/gin"
+)
+
+type getAllViewsResponse struct {
+	Data []structs.View `json:"data"`
+}
+
+type getViewResponse struct {
+	Data structs.View `json:"data"`
+}
+
+// @Summary Create view
+// @Security ApiKeyAuth
+// @Tags views
+// @Description save an item query as a view
+// @ID create-view
+// @Accept  json
+// @Produce  json
+// @Param input body structs.ViewInput true "view info"
+// @Success 200 {integer} integer 1
+// @Header 200 {string} Undo-Token "token undoing the changes"
+// @Failure 400,404 {object} HTTPError
+// @Failure 500 {object} HTTPError
+// @Failure default {object} HTTPError
+// @Router /api/views [post]
+func (h *Handler) createView(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	var input structs.ViewInput
+	if err := c.BindJSON(&input); err != nil {
+		newResponseError(c, http.StatusBadRequest, errors.New("invalid input body"))
+		return
+	}
+
+	id, token, err := h.services.View.Create(userId, input)
+	if err != nil {
+		newResponseError(c, serviceErrorStatus(err), err)
+		return
+	}
+	setUndoToken(c, token)
+
+	c.JSON(http.StatusOK, gin.H{"id": id})
+}
+
+// @Summary Get All Views
+// @Security ApiKeyAuth
+// @Tags views
+// @Description get the saved views of the user
+// @ID get-all-views
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} getAllViewsResponse
+// @Failure 400,404 {object} HTTPError
+// @Failure 500 {object} HTTPError
+// @Failure default {object} HTTPError
+// @Router /api/views [get]
+func (h *Handler) getAllViews(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	views, err := h.services.View.GetAll(userId)
+	if err != nil {
+		newResponseError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, getAllViewsResponse{
+		Data: views,
+	})
+}
+
+// @Summary Get View By Id
+// @Security ApiKeyAuth
+// @Tags views
+// @Description get view by id
+// @ID get-view-by-id
+// @Accept  json
+// @Produce  json
+// @Param id path int true "view id"
+// @Success 200 {object} getViewResponse
+// @Failure 400,404 {object} HTTPError
+// @Failure 500 {object} HTTPError
+// @Failure default {object} HTTPError
+// @Router /api/views/:id [get]
+func (h *Handler) getViewById(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	viewId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	view, err := h.services.View.GetById(userId, viewId)
+	if err != nil {
+		newResponseError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, getViewResponse{
+		Data: view,
+	})
+}
+
+// @Summary Update view
+// @Security ApiKeyAuth
+// @Tags views
+// @Description rename the view or replace its query
+// @ID update-view
+// @Accept  json
+// @Produce  json
+// @Param id path int true "view id"
+// @Param input body structs.UpdateViewInput true "view info"
+// @Success 200 {object} StatusResponse
+// @Header 200 {string} Undo-Token "token undoing the changes"
+// @Failure 400,404 {object} HTTPError
+// @Failure 500 {object} HTTPError
+// @Failure default {object} HTTPError
+// @Router /api/views/:id [put]
+func (h *Handler) updateView(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	viewId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	var input structs.UpdateViewInput
+	if err := c.BindJSON(&input); err != nil {
+		newResponseError(c, http.StatusBadRequest, errors.New("invalid input body"))
+		return
+	}
+
+	token, err := h.services.View.Update(userId, viewId, input)
+	if err != nil {
+		newResponseError(c, serviceErrorStatus(err), err)
+		return
+	}
+	setUndoToken(c, token)
+
+	c.JSON(http.StatusOK, StatusResponse{
+		Status: "ok",
+	})
+}
+
+// @Summary Delete view
+// @Security ApiKeyAuth
+// @Tags views
+// @Description delete view by id
+// @ID delete-view
+// @Accept  json
+// @Produce  json
+// @Param id path int true "view id"
+// @Success 200 {object} StatusResponse
+// @Header 200 {string} Undo-Token "token undoing the changes"
+// @Failure 400,404 {object} HTTPError
+// @Failure 500 {object} HTTPError
+// @Failure default {object} HTTPError
+// @Router /api/views/:id [delete]
+func (h *Handler) deleteView(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	viewId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	token, err := h.services.View.Delete(userId, viewId)
+	if err != nil {
+		newResponseError(c, http.StatusInternalServerError, err)
+		return
+	}
+	setUndoToken(c, token)
+
+	c.JSON(http.StatusOK, StatusResponse{
+		Status: "ok",
+	})
+}
+
+// @Summary Get view items
+// @Security ApiKeyAuth
+// @Tags views
+// @Description get the items matching the view across all lists of the user
+// @ID get-view-items
+// @Accept  json
+// @Produce  json
+// @Param id path int true "view id"
+// @Param q query string false "filter expression, e.g. done:false priority>=high assignee:me due<2021-07-01 label:work -label:someday"
+// @Param render query string false "html to add the description rendered from Markdown"
+// @Success 200 {object} getAllItemsResponse
+// @Failure 400,404 {object} HTTPError
+// @Failure 500 {object} HTTPError
+// @Failure default {object} HTTPError
+// @Router /api/views/:id/items [get]
+func (h *Handler) getViewItems(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	viewId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newResponseError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	var filter structs.ViewItemsFilter
+	if err := c.BindQuery(&filter); err != nil {
+		newResponseError(c, http.StatusBadRequest, errors.New("invalid query params"))
+		return
+	}
+
+	render, err := bindRender(c)
+	if err != nil {
+		newResponseError(c, http.StatusBadRequest, errors.New("invalid query params"))
+		return
+	}
+
+	items, err := h.services.View.GetItems(userId, viewId, filter)
+	if err != nil {
+		newResponseError(c, serviceErrorStatus(err), err)
+		return
+	}
+	if render {
+		for i := range items {
+			renderItem(&items[i])
+		}
+	}
+
+	c.JSON(http.StatusOK, getAllItemsResponse{
+		Data: items,
+	})
+}
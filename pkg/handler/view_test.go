@@ -0,0 +1,209 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fr13n8/todo-app/pkg/querylang"
+	"github.com/fr13n8/todo-app/pkg/service"
+	mockservice "github.com/fr13n8/todo-app/pkg/service/mocks"
+	"github.com/fr13n8/todo-app/structs"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_createView(t *testing.T) {
+	type mockBehavior func(s *mockservice.MockView)
+
+	done := false
+	days := 7
+
+	testTable := []struct {
+		name                 string
+		inputBody            string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+		expectedUndoToken    string
+	}{
+		{
+			name:      "Ok",
+			inputBody: `{"name":"this week","query":{"done":false,"label_ids":[3],"due_within_days":7,"mine":true,"sort":"due_date"}}`,
+			mockBehavior: func(s *mockservice.MockView) {
+				s.EXPECT().Create(1, structs.ViewInput{
+					Name: "this week",
+					Query: structs.ViewQuery{
+						Done:          &done,
+						LabelIds:      []int{3},
+						DueWithinDays: &days,
+						Mine:          true,
+						Sort:          "due_date",
+					},
+				}).Return(2, "d6d5c5a1-8b53-4c1e-9a0b-3c3d2b6f0e11", nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"id":2}`,
+			expectedUndoToken:    "d6d5c5a1-8b53-4c1e-9a0b-3c3d2b6f0e11",
+		},
+		{
+			name:                 "No name",
+			inputBody:            `{"query":{}}`,
+			mockBehavior:         func(s *mockservice.MockView) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"message":"invalid input body"}`,
+		},
+		{
+			name:      "Unknown sort",
+			inputBody: `{"name":"view","query":{"sort":"rank"}}`,
+			mockBehavior: func(s *mockservice.MockView) {
+				s.EXPECT().Create(1, structs.ViewInput{Name: "view", Query: structs.ViewQuery{Sort: "rank"}}).Return(0, "", service.ErrInvalidSort)
+			},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"message":"` + service.ErrInvalidSort.Error() + `"}`,
+		},
+		{
+			name:                 "Negative due window",
+			inputBody:            `{"name":"view","query":{"due_within_days":-1}}`,
+			mockBehavior:         func(s *mockservice.MockView) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"message":"invalid input body"}`,
+		},
+		{
+			name:      "Service failure",
+			inputBody: `{"name":"view"}`,
+			mockBehavior: func(s *mockservice.MockView) {
+				s.EXPECT().Create(1, structs.ViewInput{Name: "view"}).Return(0, "", errors.New("service failure"))
+			},
+			expectedStatusCode:   500,
+			expectedResponseBody: `{"message":"service failure"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			view := mockservice.NewMockView(c)
+			testCase.mockBehavior(view)
+
+			services := &service.Service{View: view}
+			handler := NewHandler(services)
+
+			r := gin.New()
+			r.POST("/api/views/", func(c *gin.Context) {
+				c.Set(userCtx, 1)
+			}, handler.createView)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/api/views/", bytes.NewBufferString(testCase.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedResponseBody, w.Body.String())
+			assert.Equal(t, testCase.expectedUndoToken, w.Header().Get("Undo-Token"))
+		})
+	}
+}
+
+func TestHandler_getViewItems(t *testing.T) {
+	type mockBehavior func(s *mockservice.MockView)
+
+	testTable := []struct {
+		name                 string
+		path                 string
+		mockBehavior         mockBehavior
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "Ok",
+			path: "/api/views/2/items",
+			mockBehavior: func(s *mockservice.MockView) {
+				s.EXPECT().GetItems(1, 2, structs.ViewItemsFilter{}).Return([]structs.Item{
+					{Id: 4, Title: "first", State: structs.ItemStateReady},
+					{Id: 9, Title: "second", State: structs.ItemStateReady},
+				}, nil)
+			},
+			expectedStatusCode: 200,
+			expectedResponseBody: `{"data":[{"id":4,"title":"first","description":"","done":false,"state":"ready"},` +
+				`{"id":9,"title":"second","description":"","done":false,"state":"ready"}]}`,
+		},
+		{
+			name: "Rendered",
+			path: "/api/views/2/items?render=html",
+			mockBehavior: func(s *mockservice.MockView) {
+				s.EXPECT().GetItems(1, 2, structs.ViewItemsFilter{}).Return([]structs.Item{
+					{Id: 4, Title: "first", Description: "*soon*", State: structs.ItemStateReady},
+				}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"data":[{"id":4,"title":"first","description":"*soon*","description_html":"\u003cp\u003e\u003cem\u003esoon\u003c/em\u003e\u003c/p\u003e\n","done":false,"state":"ready"}]}`,
+		},
+		{
+			name: "Filtered",
+			path: "/api/views/2/items?q=label:work",
+			mockBehavior: func(s *mockservice.MockView) {
+				s.EXPECT().GetItems(1, 2, structs.ViewItemsFilter{Query: "label:work"}).Return([]structs.Item{}, nil)
+			},
+			expectedStatusCode:   200,
+			expectedResponseBody: `{"data":[]}`,
+		},
+		{
+			name: "Invalid filter",
+			path: "/api/views/2/items?q=priority%3E%3Dcritical",
+			mockBehavior: func(s *mockservice.MockView) {
+				s.EXPECT().GetItems(1, 2, structs.ViewItemsFilter{Query: "priority>=critical"}).
+					Return(nil, &querylang.Error{Pos: 11, Msg: `invalid priority "critical"`})
+			},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"message":"invalid priority \"critical\" at position 11","position":11}`,
+		},
+		{
+			name: "Not found",
+			path: "/api/views/2/items",
+			mockBehavior: func(s *mockservice.MockView) {
+				s.EXPECT().GetItems(1, 2, structs.ViewItemsFilter{}).Return(nil, errors.New("record not found"))
+			},
+			expectedStatusCode:   500,
+			expectedResponseBody: `{"message":"record not found"}`,
+		},
+		{
+			name:                 "Invalid id",
+			path:                 "/api/views/abc/items",
+			mockBehavior:         func(s *mockservice.MockView) {},
+			expectedStatusCode:   400,
+			expectedResponseBody: `{"message":"strconv.Atoi: parsing \"abc\": invalid syntax"}`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			view := mockservice.NewMockView(c)
+			testCase.mockBehavior(view)
+
+			services := &service.Service{View: view}
+			handler := NewHandler(services)
+
+			r := gin.New()
+			r.GET("/api/views/:id/items", func(c *gin.Context) {
+				c.Set(userCtx, 1)
+			}, handler.getViewItems)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", testCase.path, nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.expectedStatusCode, w.Code)
+			assert.Equal(t, testCase.expectedResponseBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,289 @@
+// Package jsonpatch applies JSON merge patches (RFC 7396) and JSON patches
+// (RFC 6902) to JSON documents.
+package jsonpatch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrInvalid is returned for patches that aren't well formed.
+	ErrInvalid = errors.New("invalid patch")
+	// ErrFailed is returned for well formed patches that can't be applied
+	// to the document, such as one removing a member it doesn't have or
+	// one whose test operation fails.
+	ErrFailed = errors.New("patch can't be applied")
+)
+
+// MergePatch applies a JSON merge patch to a document: members of the patch
+// replace the ones of the document, objects are merged recursively and null
+// removes a member.
+func MergePatch(doc []byte, patch []byte) ([]byte, error) {
+	var target, changes interface{}
+	if err := json.Unmarshal(doc, &target); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(patch, &changes); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalid, err)
+	}
+	return json.Marshal(mergePatch(target, changes))
+}
+
+func mergePatch(target interface{}, patch interface{}) interface{} {
+	changes, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	object, ok := target.(map[string]interface{})
+	if !ok {
+		object = make(map[string]interface{}, len(changes))
+	}
+	for key, value := range changes {
+		if value == nil {
+			delete(object, key)
+			continue
+		}
+		object[key] = mergePatch(object[key], value)
+	}
+	return object
+}
+
+// Operation is an operation of a JSON patch.
+type Operation struct {
+	Op    string          `json:"op"`
+	Path  *string         `json:"path"`
+	From  *string         `json:"from"`
+	Value json.RawMessage `json:"value"`
+}
+
+// Apply applies a JSON patch, a list of operations made in order, to a
+// document. Either all the operations apply or none of them.
+func Apply(doc []byte, patch []byte) ([]byte, error) {
+	var target interface{}
+	if err := json.Unmarshal(doc, &target); err != nil {
+		return nil, err
+	}
+	var ops []Operation
+	if err := json.Unmarshal(patch, &ops); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalid, err)
+	}
+
+	for i, op := range ops {
+		var err error
+		if target, err = apply(target, op); err != nil {
+			return nil, fmt.Errorf("operation %d: %w", i, err)
+		}
+	}
+	return json.Marshal(target)
+}
+
+func apply(doc interface{}, op Operation) (interface{}, error) {
+	if op.Path == nil {
+		return nil, fmt.Errorf("%w: %s needs a path", ErrInvalid, op.Op)
+	}
+	path, err := parsePointer(*op.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch op.Op {
+	case "add", "replace", "test":
+		if len(op.Value) == 0 {
+			return nil, fmt.Errorf("%w: %s needs a value", ErrInvalid, op.Op)
+		}
+		var value interface{}
+		if err := json.Unmarshal(op.Value, &value); err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalid, err)
+		}
+		switch op.Op {
+		case "add":
+			return add(doc, path, value)
+		case "replace":
+			if doc, err = remove(doc, path); err != nil {
+				return nil, err
+			}
+			return add(doc, path, value)
+		}
+		current, err := get(doc, path)
+		if err != nil {
+			return nil, err
+		}
+		if !reflect.DeepEqual(current, value) {
+			return nil, fmt.Errorf("%w: test of %q failed", ErrFailed, *op.Path)
+		}
+		return doc, nil
+	case "remove":
+		return remove(doc, path)
+	case "move", "copy":
+		if op.From == nil {
+			return nil, fmt.Errorf("%w: %s needs from", ErrInvalid, op.Op)
+		}
+		from, err := parsePointer(*op.From)
+		if err != nil {
+			return nil, err
+		}
+		value, err := get(doc, from)
+		if err != nil {
+			return nil, err
+		}
+		if op.Op == "copy" {
+			return add(doc, path, deepCopy(value))
+		}
+		if len(path) > len(from) && reflect.DeepEqual(path[:len(from)], from) {
+			return nil, fmt.Errorf("%w: can't move %q into itself", ErrInvalid, *op.From)
+		}
+		if doc, err = remove(doc, from); err != nil {
+			return nil, err
+		}
+		return add(doc, path, value)
+	}
+	return nil, fmt.Errorf("%w: unknown operation %q", ErrInvalid, op.Op)
+}
+
+// parsePointer splits a JSON pointer (RFC 6901) into its reference tokens.
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return []string{}, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("%w: path %q doesn't start with /", ErrInvalid, pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+	}
+	return tokens, nil
+}
+
+func get(doc interface{}, path []string) (interface{}, error) {
+	for _, token := range path {
+		switch node := doc.(type) {
+		case map[string]interface{}:
+			value, ok := node[token]
+			if !ok {
+				return nil, fmt.Errorf("%w: member %q doesn't exist", ErrFailed, token)
+			}
+			doc = value
+		case []interface{}:
+			i, err := index(token, len(node)-1)
+			if err != nil {
+				return nil, err
+			}
+			doc = node[i]
+		default:
+			return nil, fmt.Errorf("%w: %q isn't in an object or an array", ErrFailed, token)
+		}
+	}
+	return doc, nil
+}
+
+// add sets the value at path, inserting it when the path ends in an array,
+// and returns the document, which is the value itself for the root.
+func add(doc interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	parent, err := get(doc, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	token := path[len(path)-1]
+	switch node := parent.(type) {
+	case map[string]interface{}:
+		node[token] = value
+		return doc, nil
+	case []interface{}:
+		i := len(node)
+		if token != "-" {
+			if i, err = index(token, len(node)); err != nil {
+				return nil, err
+			}
+		}
+		node = append(node, nil)
+		copy(node[i+1:], node[i:])
+		node[i] = value
+		return set(doc, path[:len(path)-1], node)
+	}
+	return nil, fmt.Errorf("%w: %q isn't in an object or an array", ErrFailed, token)
+}
+
+func remove(doc interface{}, path []string) (interface{}, error) {
+	if len(path) == 0 {
+		return nil, nil
+	}
+	parent, err := get(doc, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	token := path[len(path)-1]
+	switch node := parent.(type) {
+	case map[string]interface{}:
+		if _, ok := node[token]; !ok {
+			return nil, fmt.Errorf("%w: member %q doesn't exist", ErrFailed, token)
+		}
+		delete(node, token)
+		return doc, nil
+	case []interface{}:
+		i, err := index(token, len(node)-1)
+		if err != nil {
+			return nil, err
+		}
+		node = append(node[:i], node[i+1:]...)
+		return set(doc, path[:len(path)-1], node)
+	}
+	return nil, fmt.Errorf("%w: %q isn't in an object or an array", ErrFailed, token)
+}
+
+// set puts a grown or shrunk array back in place of the old one.
+func set(doc interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	parent, err := get(doc, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	switch node := parent.(type) {
+	case map[string]interface{}:
+		node[path[len(path)-1]] = value
+	case []interface{}:
+		i, _ := strconv.Atoi(path[len(path)-1])
+		node[i] = value
+	}
+	return doc, nil
+}
+
+// index parses an array index of at most max.
+func index(token string, max int) (int, error) {
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("%w: %q isn't an array index", ErrInvalid, token)
+	}
+	if i > max {
+		return 0, fmt.Errorf("%w: index %d is out of range", ErrFailed, i)
+	}
+	return i, nil
+}
+
+func deepCopy(value interface{}) interface{} {
+	switch node := value.(type) {
+	case map[string]interface{}:
+		object := make(map[string]interface{}, len(node))
+		for key, value := range node {
+			object[key] = deepCopy(value)
+		}
+		return object
+	case []interface{}:
+		array := make([]interface{}, len(node))
+		for i, value := range node {
+			array[i] = deepCopy(value)
+		}
+		return array
+	}
+	return value
+}
@@ -0,0 +1,173 @@
+package jsonpatch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergePatch(t *testing.T) {
+	testTable := []struct {
+		name    string
+		doc     string
+		patch   string
+		want    string
+		wantErr error
+	}{
+		{
+			name:  "Replace and add",
+			doc:   `{"title":"old","done":false}`,
+			patch: `{"title":"new","due_date":"2021-07-01T00:00:00Z"}`,
+			want:  `{"done":false,"due_date":"2021-07-01T00:00:00Z","title":"new"}`,
+		},
+		{
+			name:  "Null removes",
+			doc:   `{"title":"old","description":"text"}`,
+			patch: `{"description":null,"missing":null}`,
+			want:  `{"title":"old"}`,
+		},
+		{
+			name:  "Nested objects merge",
+			doc:   `{"a":{"b":1,"c":2}}`,
+			patch: `{"a":{"c":null,"d":{"e":3}}}`,
+			want:  `{"a":{"b":1,"d":{"e":3}}}`,
+		},
+		{
+			name:  "Arrays are replaced",
+			doc:   `{"a":[1,2,3]}`,
+			patch: `{"a":[4]}`,
+			want:  `{"a":[4]}`,
+		},
+		{
+			name:  "Non object patch replaces the document",
+			doc:   `{"a":1}`,
+			patch: `"text"`,
+			want:  `"text"`,
+		},
+		{
+			name:    "Malformed patch",
+			doc:     `{"a":1}`,
+			patch:   `{"a":`,
+			wantErr: ErrInvalid,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			got, err := MergePatch([]byte(testCase.doc), []byte(testCase.patch))
+			if testCase.wantErr != nil {
+				assert.ErrorIs(t, err, testCase.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.JSONEq(t, testCase.want, string(got))
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	testTable := []struct {
+		name    string
+		doc     string
+		patch   string
+		want    string
+		wantErr error
+	}{
+		{
+			name:  "Add, replace and remove",
+			doc:   `{"title":"old","description":"text"}`,
+			patch: `[{"op":"add","path":"/done","value":true},{"op":"replace","path":"/title","value":"new"},{"op":"remove","path":"/description"}]`,
+			want:  `{"title":"new","done":true}`,
+		},
+		{
+			name:  "Replace with null",
+			doc:   `{"due_date":"2021-07-01T00:00:00Z"}`,
+			patch: `[{"op":"replace","path":"/due_date","value":null}]`,
+			want:  `{"due_date":null}`,
+		},
+		{
+			name:  "Arrays",
+			doc:   `{"a":[1,2,3]}`,
+			patch: `[{"op":"add","path":"/a/1","value":9},{"op":"add","path":"/a/-","value":4},{"op":"remove","path":"/a/0"}]`,
+			want:  `{"a":[9,2,3,4]}`,
+		},
+		{
+			name:  "Move and copy",
+			doc:   `{"a":{"b":1},"c":2}`,
+			patch: `[{"op":"move","from":"/c","path":"/a/c"},{"op":"copy","from":"/a","path":"/d"}]`,
+			want:  `{"a":{"b":1,"c":2},"d":{"b":1,"c":2}}`,
+		},
+		{
+			name:  "Escaped pointer",
+			doc:   `{"a/b":1,"m~n":2}`,
+			patch: `[{"op":"test","path":"/a~1b","value":1},{"op":"replace","path":"/m~0n","value":3}]`,
+			want:  `{"a/b":1,"m~n":3}`,
+		},
+		{
+			name:  "Passing test",
+			doc:   `{"title":"old","done":false}`,
+			patch: `[{"op":"test","path":"/done","value":false},{"op":"replace","path":"/done","value":true}]`,
+			want:  `{"title":"old","done":true}`,
+		},
+		{
+			name:    "Failing test",
+			doc:     `{"title":"old","done":false}`,
+			patch:   `[{"op":"replace","path":"/title","value":"new"},{"op":"test","path":"/done","value":true}]`,
+			wantErr: ErrFailed,
+		},
+		{
+			name:    "Replace missing member",
+			doc:     `{"title":"old"}`,
+			patch:   `[{"op":"replace","path":"/id","value":2}]`,
+			wantErr: ErrFailed,
+		},
+		{
+			name:    "Index out of range",
+			doc:     `{"a":[1]}`,
+			patch:   `[{"op":"add","path":"/a/2","value":2}]`,
+			wantErr: ErrFailed,
+		},
+		{
+			name:    "Move into itself",
+			doc:     `{"a":{"b":1}}`,
+			patch:   `[{"op":"move","from":"/a","path":"/a/b/c"}]`,
+			wantErr: ErrInvalid,
+		},
+		{
+			name:    "Unknown operation",
+			doc:     `{"a":1}`,
+			patch:   `[{"op":"increment","path":"/a","value":1}]`,
+			wantErr: ErrInvalid,
+		},
+		{
+			name:    "Missing value",
+			doc:     `{"a":1}`,
+			patch:   `[{"op":"add","path":"/b"}]`,
+			wantErr: ErrInvalid,
+		},
+		{
+			name:    "Relative path",
+			doc:     `{"a":1}`,
+			patch:   `[{"op":"remove","path":"a"}]`,
+			wantErr: ErrInvalid,
+		},
+		{
+			name:    "Not a list of operations",
+			doc:     `{"a":1}`,
+			patch:   `{"op":"remove","path":"/a"}`,
+			wantErr: ErrInvalid,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			got, err := Apply([]byte(testCase.doc), []byte(testCase.patch))
+			if testCase.wantErr != nil {
+				assert.ErrorIs(t, err, testCase.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.JSONEq(t, testCase.want, string(got))
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package markdown
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/russross/blackfriday/v2"
+)
+
+const htmlFlags = blackfriday.SkipHTML | blackfriday.Safelink | blackfriday.NofollowLinks |
+	blackfriday.NoreferrerLinks | blackfriday.NoopenerLinks
+
+// Render turns Markdown into HTML that is safe to embed in a page. Raw HTML
+// in the source is dropped, and the output goes through Sanitize anyway.
+// List items starting with "[ ]" or "[x]" become read-only checkboxes.
+func Render(src string) string {
+	r := taskListRenderer{blackfriday.NewHTMLRenderer(blackfriday.HTMLRendererParameters{Flags: htmlFlags})}
+	out := blackfriday.Run([]byte(src),
+		blackfriday.WithExtensions(blackfriday.CommonExtensions),
+		blackfriday.WithRenderer(r))
+	return Sanitize(string(out))
+}
+
+type taskListRenderer struct {
+	*blackfriday.HTMLRenderer
+}
+
+func (r taskListRenderer) RenderNode(w io.Writer, node *blackfriday.Node, entering bool) blackfriday.WalkStatus {
+	if checked, ok := taskMarker(node); ok {
+		if checked {
+			io.WriteString(w, `<input type="checkbox" checked disabled> `)
+		} else {
+			io.WriteString(w, `<input type="checkbox" disabled> `)
+		}
+		node.Literal = bytes.TrimLeft(node.Literal[3:], " ")
+	}
+	return r.HTMLRenderer.RenderNode(w, node, entering)
+}
+
+// taskMarker reports whether node is the text a list item opens with and
+// starts with a task marker, and if so whether the task is checked.
+func taskMarker(node *blackfriday.Node) (bool, bool) {
+	if node.Type != blackfriday.Text || node.Parent == nil || node.Parent.FirstChild != node {
+		return false, false
+	}
+	paragraph := node.Parent
+	if paragraph.Type != blackfriday.Paragraph || paragraph.Parent == nil ||
+		paragraph.Parent.Type != blackfriday.Item || paragraph.Parent.FirstChild != paragraph {
+		return false, false
+	}
+
+	text := node.Literal
+	if len(text) < 3 || text[0] != '[' || text[2] != ']' || len(text) > 3 && text[3] != ' ' {
+		return false, false
+	}
+	switch text[1] {
+	case ' ':
+		return false, true
+	case 'x', 'X':
+		return true, true
+	}
+	return false, false
+}
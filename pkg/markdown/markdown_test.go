@@ -0,0 +1,89 @@
+package markdown
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRender(t *testing.T) {
+	testTable := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{
+			name: "Emphasis",
+			src:  "some *emphasis* and **strong** text",
+			want: "<p>some <em>emphasis</em> and <strong>strong</strong> text</p>\n",
+		},
+		{
+			name: "Task list",
+			src:  "- [ ] open\n- [x] done\n- [link](https://example.com)\n",
+			want: "<ul>\n<li><input type=\"checkbox\" disabled> open</li>\n" +
+				"<li><input type=\"checkbox\" checked disabled> done</li>\n" +
+				"<li><a href=\"https://example.com\" rel=\"nofollow noreferrer noopener\">link</a></li>\n</ul>\n",
+		},
+		{
+			name: "Raw HTML",
+			src:  "<script>alert(1)</script>\n\ntext <b onclick=\"alert(1)\">bold</b>",
+			want: "<p>text bold</p>\n",
+		},
+		{
+			name: "Script link",
+			src:  "[click](javascript:void)",
+			want: "<p>click</p>\n",
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.want, Render(testCase.src))
+		})
+	}
+}
+
+func TestSanitize(t *testing.T) {
+	testTable := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{
+			name: "Allowed",
+			src:  `<p>a <a href="/items/1" title="t">link</a><br><img src="https://example.com/a.png" alt="a"></p>`,
+			want: `<p>a <a href="/items/1" title="t" rel="nofollow noreferrer noopener">link</a><br><img src="https://example.com/a.png" alt="a"></p>`,
+		},
+		{
+			name: "Dropped content",
+			src:  `<div>text<script>alert("x")</script><style>p{}</style></div>`,
+			want: `text`,
+		},
+		{
+			name: "Unsafe attributes",
+			src:  `<a href=" javascript:alert(1)" onclick="x()">a</a><img src="data:image/png;base64,AA"><p style="x">p</p>`,
+			want: `<a rel="nofollow noreferrer noopener">a</a><p>p</p>`,
+		},
+		{
+			name: "Encoded scheme",
+			src:  `<a href="java&#10;script:alert(1)">a</a>`,
+			want: `<a rel="nofollow noreferrer noopener">a</a>`,
+		},
+		{
+			name: "Inputs",
+			src:  `<input type="checkbox" checked><input type="text" value="x">`,
+			want: `<input type="checkbox" checked disabled>`,
+		},
+		{
+			name: "Escaped text",
+			src:  `&lt;script&gt; &amp; "quotes"`,
+			want: `&lt;script&gt; &amp; &#34;quotes&#34;`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.want, Sanitize(testCase.src))
+		})
+	}
+}
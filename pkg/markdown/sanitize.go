@@ -0,0 +1,157 @@
+package markdown
+
+import (
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// allowedTags lists the elements Sanitize keeps and the attributes each of
+// them may carry. Everything else is dropped, its text content kept.
+var allowedTags = map[string][]string{
+	"a": {"href", "title"}, "img": {"src", "alt", "title"},
+	"input": {"type", "checked", "disabled"},
+	"p":     nil, "br": nil, "hr": nil, "blockquote": nil, "pre": nil, "code": nil,
+	"h1": nil, "h2": nil, "h3": nil, "h4": nil, "h5": nil, "h6": nil,
+	"em": nil, "strong": nil, "del": nil, "sup": nil, "sub": nil,
+	"ul": nil, "ol": {"start"}, "li": nil, "dl": nil, "dt": nil, "dd": nil,
+	"table": nil, "thead": nil, "tbody": nil, "tr": nil, "th": {"align"}, "td": {"align"},
+}
+
+// droppedTags are removed along with everything inside them.
+var droppedTags = map[string]bool{
+	"script": true, "style": true, "iframe": true, "object": true, "embed": true,
+	"noscript": true, "template": true, "textarea": true, "title": true, "svg": true, "math": true,
+}
+
+var urlSchemes = map[string]bool{"http": true, "https": true, "mailto": true, "": true}
+
+// Sanitize strips HTML down to the allowedTags. Links may only point to
+// http, https, mailto or relative URLs, images only to http or https, and
+// the only input kept is a disabled checkbox.
+func Sanitize(src string) string {
+	var b strings.Builder
+	z := html.NewTokenizer(strings.NewReader(src))
+	dropped := 0
+
+	for {
+		tt := z.Next()
+		switch tt {
+		case html.ErrorToken:
+			return b.String()
+		case html.TextToken:
+			if dropped == 0 {
+				b.WriteString(html.EscapeString(string(z.Text())))
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			token := z.Token()
+			if droppedTags[token.Data] {
+				if tt == html.StartTagToken {
+					dropped++
+				}
+				continue
+			}
+			if dropped == 0 {
+				writeStartTag(&b, token)
+			}
+		case html.EndTagToken:
+			token := z.Token()
+			if droppedTags[token.Data] {
+				if dropped > 0 {
+					dropped--
+				}
+				continue
+			}
+			if _, ok := allowedTags[token.Data]; ok && dropped == 0 && !isVoid(token.Data) {
+				b.WriteString("</" + token.Data + ">")
+			}
+		}
+	}
+}
+
+func writeStartTag(b *strings.Builder, token html.Token) {
+	allowed, ok := allowedTags[token.Data]
+	if !ok {
+		return
+	}
+
+	attrs := make([]html.Attribute, 0, len(token.Attr))
+	for _, attr := range token.Attr {
+		if attr.Namespace != "" || !contains(allowed, attr.Key) {
+			continue
+		}
+		switch attr.Key {
+		case "href":
+			if !safeURL(attr.Val, false) {
+				continue
+			}
+		case "src":
+			if !safeURL(attr.Val, true) {
+				continue
+			}
+		}
+		attrs = append(attrs, html.Attribute{Key: attr.Key, Val: strings.TrimSpace(attr.Val)})
+	}
+
+	switch token.Data {
+	case "input":
+		if value, _ := attrValue(attrs, "type"); value != "checkbox" {
+			return
+		}
+		if _, ok := attrValue(attrs, "disabled"); !ok {
+			attrs = append(attrs, html.Attribute{Key: "disabled"})
+		}
+	case "a":
+		attrs = append(attrs, html.Attribute{Key: "rel", Val: "nofollow noreferrer noopener"})
+	case "img":
+		if _, ok := attrValue(attrs, "src"); !ok {
+			return
+		}
+	}
+
+	b.WriteString("<" + token.Data)
+	for _, attr := range attrs {
+		b.WriteString(" " + attr.Key)
+		if attr.Val != "" {
+			b.WriteString(`="` + html.EscapeString(attr.Val) + `"`)
+		}
+	}
+	b.WriteString(">")
+}
+
+// safeURL keeps out javascript: and other schemes that run code or embed
+// data; images must be absolute http or https URLs.
+func safeURL(value string, image bool) bool {
+	u, err := url.Parse(strings.TrimSpace(value))
+	if err != nil {
+		return false
+	}
+	scheme := strings.ToLower(u.Scheme)
+	if image {
+		return scheme == "http" || scheme == "https"
+	}
+	return urlSchemes[scheme]
+}
+
+func attrValue(attrs []html.Attribute, key string) (string, bool) {
+	for _, attr := range attrs {
+		if attr.Key == key {
+			return attr.Val, true
+		}
+	}
+	return "", false
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func isVoid(tag string) bool {
+	return tag == "br" || tag == "hr" || tag == "img" || tag == "input"
+}
@@ -0,0 +1,110 @@
+// Package querylang parses the filter expressions accepted on item listings,
+// such as `done:false priority>=high due<2021-07-01 label:work -label:someday "exact phrase"`.
+//
+// Terms next to each other must all match, OR between terms lets either
+// match, a leading - negates a term and parentheses group terms. A term is
+// either free text, matched against titles and descriptions, or a field, an
+// operator and a value. Values with spaces or colons are quoted.
+package querylang
+
+import "time"
+
+// Node is a node of a parsed expression: And, Or, Not or Term.
+type Node interface {
+	// Pos is the position of the node in the expression, counting
+	// characters from 1.
+	Pos() int
+}
+
+type And struct {
+	Nodes []Node
+}
+
+type Or struct {
+	Nodes []Node
+}
+
+type Not struct {
+	Node     Node
+	Position int
+}
+
+// Term compares a field with a value. Field is empty for free text. The
+// value is held in the member matching the kind of the field.
+type Term struct {
+	Field string
+	Op    Op
+	Text  string
+	Bool  bool
+	Time  time.Time
+	// Day is set when a date was given without a time; the term then
+	// covers the whole day.
+	Day bool
+	// Number is the rank of a priority, lowest first, or a user id.
+	Number int
+	// Me stands for the user filtering, as in assignee:me.
+	Me bool
+	// None matches items where the field isn't set, as in due:none.
+	None     bool
+	Position int
+}
+
+func (n And) Pos() int  { return n.Nodes[0].Pos() }
+func (n Or) Pos() int   { return n.Nodes[0].Pos() }
+func (n Not) Pos() int  { return n.Position }
+func (n Term) Pos() int { return n.Position }
+
+type Op string
+
+const (
+	OpMatch Op = ":"
+	OpEq    Op = "="
+	OpNe    Op = "!="
+	OpLt    Op = "<"
+	OpLe    Op = "<="
+	OpGt    Op = ">"
+	OpGe    Op = ">="
+)
+
+// Longer operators come first so they win over their prefixes.
+var ops = []Op{OpNe, OpLe, OpGe, OpMatch, OpEq, OpLt, OpGt}
+
+type Kind int
+
+const (
+	KindText Kind = iota
+	KindBool
+	KindName
+	KindDate
+	KindPriority
+	KindUser
+)
+
+// Priorities are the names of the priorities from the lowest up.
+var Priorities = []string{"none", "low", "medium", "high", "urgent"}
+
+// Fields are the fields a term can name, by kind. Text fields match
+// substrings with : and whole values with =, name fields match names of
+// labels or statuses, date fields compare dates or times, priority fields
+// compare priorities by rank and user fields take me, none or a user id.
+var Fields = map[string]Kind{
+	"title":     KindText,
+	"done":      KindBool,
+	"label":     KindName,
+	"status":    KindName,
+	"due":       KindDate,
+	"created":   KindDate,
+	"updated":   KindDate,
+	"completed": KindDate,
+	"priority":  KindPriority,
+	"assignee":  KindUser,
+}
+
+var kindOps = map[Kind][]Op{
+	KindText:     {OpMatch, OpEq, OpNe},
+	KindBool:     {OpMatch, OpEq, OpNe},
+	KindName:     {OpMatch, OpEq, OpNe},
+	KindDate:     {OpMatch, OpEq, OpNe, OpLt, OpLe, OpGt, OpGe},
+	KindPriority: {OpMatch, OpEq, OpNe, OpLt, OpLe, OpGt, OpGe},
+	KindUser:     {OpMatch, OpEq, OpNe},
+}
@@ -0,0 +1,339 @@
+package querylang
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Error is a problem with an expression at a position, counting characters
+// from 1.
+type Error struct {
+	Pos int
+	Msg string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s at position %d", e.Msg, e.Pos)
+}
+
+const dateLayout = "2006-01-02"
+
+// Parse parses an expression. An empty expression gives a nil node, which
+// matches everything.
+func Parse(input string) (Node, error) {
+	p := &parser{input: input}
+	if offset := invalidUTF8(input); offset < len(input) {
+		return nil, p.errorf(offset, "invalid UTF-8")
+	}
+	p.skipSpace()
+	if p.done() {
+		return nil, nil
+	}
+
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if !p.done() {
+		return nil, p.errorf(p.pos, "unexpected %q", p.peek())
+	}
+	return node, nil
+}
+
+type parser struct {
+	input string
+	pos   int
+}
+
+func (p *parser) done() bool {
+	return p.pos >= len(p.input)
+}
+
+func (p *parser) peek() rune {
+	r, _ := utf8.DecodeRuneInString(p.input[p.pos:])
+	return r
+}
+
+// next reads the character at the position and moves past it.
+func (p *parser) next() rune {
+	r, size := utf8.DecodeRuneInString(p.input[p.pos:])
+	p.pos += size
+	return r
+}
+
+func (p *parser) skipSpace() {
+	for !p.done() && unicode.IsSpace(p.peek()) {
+		p.next()
+	}
+}
+
+// invalidUTF8 returns the offset of the first byte of input that isn't
+// part of a valid UTF-8 sequence, or the length of input if there is none.
+func invalidUTF8(input string) int {
+	for offset, r := range input {
+		if r == utf8.RuneError {
+			if _, size := utf8.DecodeRuneInString(input[offset:]); size == 1 {
+				return offset
+			}
+		}
+	}
+	return len(input)
+}
+
+func (p *parser) errorf(offset int, format string, args ...interface{}) error {
+	return &Error{Pos: p.column(offset), Msg: fmt.Sprintf(format, args...)}
+}
+
+// column turns a byte offset into the position of the character.
+func (p *parser) column(offset int) int {
+	return utf8.RuneCountInString(p.input[:offset]) + 1
+}
+
+// atOr tells whether the next word is the OR keyword.
+func (p *parser) atOr() bool {
+	if !strings.HasPrefix(p.input[p.pos:], "OR") {
+		return false
+	}
+	rest := p.input[p.pos+2:]
+	return rest == "" || unicode.IsSpace(rune(rest[0])) || rest[0] == '(' || rest[0] == '"' || rest[0] == '-'
+}
+
+func (p *parser) parseOr() (Node, error) {
+	var nodes []Node
+	for {
+		node, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, node)
+
+		if !p.atOr() {
+			break
+		}
+		orPos := p.pos
+		p.pos += 2
+		p.skipSpace()
+		if p.done() || p.peek() == ')' {
+			return nil, p.errorf(orPos, "missing term after OR")
+		}
+	}
+
+	if len(nodes) == 1 {
+		return nodes[0], nil
+	}
+	return Or{Nodes: nodes}, nil
+}
+
+func (p *parser) parseAnd() (Node, error) {
+	var nodes []Node
+	for !p.done() && p.peek() != ')' && !p.atOr() {
+		node, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, node)
+		p.skipSpace()
+	}
+
+	switch len(nodes) {
+	case 0:
+		if p.done() {
+			return nil, p.errorf(p.pos, "missing term")
+		}
+		if p.atOr() {
+			return nil, p.errorf(p.pos, "missing term before OR")
+		}
+		return nil, p.errorf(p.pos, "unexpected %q", p.peek())
+	case 1:
+		return nodes[0], nil
+	}
+	return And{Nodes: nodes}, nil
+}
+
+func (p *parser) parseUnary() (Node, error) {
+	start := p.pos
+	if p.peek() != '-' {
+		return p.parsePrimary()
+	}
+
+	p.pos++
+	if p.done() || unicode.IsSpace(p.peek()) || p.peek() == ')' {
+		return nil, p.errorf(start, "nothing to negate")
+	}
+	node, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	return Not{Node: node, Position: p.column(start)}, nil
+}
+
+func (p *parser) parsePrimary() (Node, error) {
+	start := p.pos
+	switch p.peek() {
+	case '(':
+		p.pos++
+		p.skipSpace()
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.done() {
+			return nil, p.errorf(start, "missing closing parenthesis")
+		}
+		p.pos++
+		return node, nil
+	case '"':
+		text, err := p.parseQuoted()
+		if err != nil {
+			return nil, err
+		}
+		return Term{Op: OpMatch, Text: text, Position: p.column(start)}, nil
+	}
+
+	for !p.done() && isFieldChar(p.peek()) {
+		p.pos++
+	}
+	if p.pos > start && !p.done() {
+		for _, op := range ops {
+			if strings.HasPrefix(p.input[p.pos:], string(op)) {
+				field := p.input[start:p.pos]
+				p.pos += len(op)
+				return p.parseTerm(start, field, op)
+			}
+		}
+	}
+
+	p.pos = start
+	return Term{Op: OpMatch, Text: p.parseBare(), Position: p.column(start)}, nil
+}
+
+func (p *parser) parseTerm(start int, field string, op Op) (Node, error) {
+	kind, ok := Fields[strings.ToLower(field)]
+	if !ok {
+		return nil, p.errorf(start, "unknown field %q", field)
+	}
+	if !hasOp(kindOps[kind], op) {
+		return nil, p.errorf(start+len(field), "operator %s can't be used with %s", op, field)
+	}
+
+	valuePos := p.pos
+	var value string
+	if !p.done() && p.peek() == '"' {
+		text, err := p.parseQuoted()
+		if err != nil {
+			return nil, err
+		}
+		value = text
+	} else {
+		value = p.parseBare()
+	}
+	if value == "" {
+		return nil, p.errorf(valuePos, "missing value for %s", field)
+	}
+
+	term := Term{Field: strings.ToLower(field), Op: op, Position: p.column(start)}
+	switch kind {
+	case KindText, KindName:
+		term.Text = value
+	case KindBool:
+		switch strings.ToLower(value) {
+		case "true":
+			term.Bool = true
+		case "false":
+		default:
+			return nil, p.errorf(valuePos, "%s takes true or false", field)
+		}
+	case KindDate:
+		if strings.EqualFold(value, "none") {
+			if op != OpMatch && op != OpEq && op != OpNe {
+				return nil, p.errorf(start+len(field), "operator %s can't be used with none", op)
+			}
+			term.None = true
+		} else if day, err := time.Parse(dateLayout, value); err == nil {
+			term.Time, term.Day = day, true
+		} else if at, err := time.Parse(time.RFC3339, value); err == nil {
+			term.Time = at
+		} else {
+			return nil, p.errorf(valuePos, "invalid date %q, expected YYYY-MM-DD, an RFC 3339 time or none", value)
+		}
+	case KindPriority:
+		rank := indexFold(Priorities, value)
+		if rank < 0 {
+			return nil, p.errorf(valuePos, "invalid priority %q, expected one of %s", value, strings.Join(Priorities, ", "))
+		}
+		term.Number = rank
+	case KindUser:
+		switch id, err := strconv.Atoi(value); {
+		case strings.EqualFold(value, "me"):
+			term.Me = true
+		case strings.EqualFold(value, "none"):
+			term.None = true
+		case err == nil && id > 0:
+			term.Number = id
+		default:
+			return nil, p.errorf(valuePos, "%s takes me, none or a user id", field)
+		}
+	}
+	return term, nil
+}
+
+// parseQuoted reads a quoted string; \" and \\ stand for themselves.
+func (p *parser) parseQuoted() (string, error) {
+	start := p.pos
+	p.pos++
+
+	var b strings.Builder
+	for !p.done() {
+		r := p.next()
+		switch r {
+		case '"':
+			return b.String(), nil
+		case '\\':
+			if !p.done() && (p.peek() == '"' || p.peek() == '\\') {
+				r = p.peek()
+				p.pos++
+			}
+		}
+		b.WriteRune(r)
+	}
+	return "", p.errorf(start, "missing closing quote")
+}
+
+// parseBare reads a value up to the next space or parenthesis.
+func (p *parser) parseBare() string {
+	start := p.pos
+	for !p.done() {
+		r := p.peek()
+		if unicode.IsSpace(r) || r == '(' || r == ')' || r == '"' {
+			break
+		}
+		p.next()
+	}
+	return p.input[start:p.pos]
+}
+
+func isFieldChar(r rune) bool {
+	return r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z'
+}
+
+func indexFold(names []string, name string) int {
+	for i, n := range names {
+		if strings.EqualFold(n, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+func hasOp(ops []Op, op Op) bool {
+	for _, o := range ops {
+		if o == op {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,222 @@
+package querylang
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	day := time.Date(2021, 7, 1, 0, 0, 0, 0, time.UTC)
+	at := time.Date(2021, 7, 1, 9, 30, 0, 0, time.UTC)
+
+	testTable := []struct {
+		name  string
+		input string
+		want  Node
+	}{
+		{
+			name:  "Empty",
+			input: "   ",
+		},
+		{
+			name:  "Terms",
+			input: `done:false priority>=high due<2021-07-01 label:work -label:someday "exact phrase"`,
+			want: And{Nodes: []Node{
+				Term{Field: "done", Op: OpMatch, Position: 1},
+				Term{Field: "priority", Op: OpGe, Number: 3, Position: 12},
+				Term{Field: "due", Op: OpLt, Time: day, Day: true, Position: 27},
+				Term{Field: "label", Op: OpMatch, Text: "work", Position: 42},
+				Not{Node: Term{Field: "label", Op: OpMatch, Text: "someday", Position: 54}, Position: 53},
+				Term{Op: OpMatch, Text: "exact phrase", Position: 68},
+			}},
+		},
+		{
+			name:  "Assignees",
+			input: "assignee:me assignee!=NONE assignee=42 priority:Urgent",
+			want: And{Nodes: []Node{
+				Term{Field: "assignee", Op: OpMatch, Me: true, Position: 1},
+				Term{Field: "assignee", Op: OpNe, None: true, Position: 13},
+				Term{Field: "assignee", Op: OpEq, Number: 42, Position: 28},
+				Term{Field: "priority", Op: OpMatch, Number: 4, Position: 40},
+			}},
+		},
+		{
+			name:  "Or and groups",
+			input: `(label:work OR label:home) created>=2021-07-01T09:30:00Z`,
+			want: And{Nodes: []Node{
+				Or{Nodes: []Node{
+					Term{Field: "label", Op: OpMatch, Text: "work", Position: 2},
+					Term{Field: "label", Op: OpMatch, Text: "home", Position: 16},
+				}},
+				Term{Field: "created", Op: OpGe, Time: at, Position: 28},
+			}},
+		},
+		{
+			name:  "Quoted values",
+			input: `status:"in review" title="say \"hi\""`,
+			want: And{Nodes: []Node{
+				Term{Field: "status", Op: OpMatch, Text: "in review", Position: 1},
+				Term{Field: "title", Op: OpEq, Text: `say "hi"`, Position: 20},
+			}},
+		},
+		{
+			name:  "None and case",
+			input: "Due!=none DONE:True",
+			want: And{Nodes: []Node{
+				Term{Field: "due", Op: OpNe, None: true, Position: 1},
+				Term{Field: "done", Op: OpMatch, Bool: true, Position: 11},
+			}},
+		},
+		{
+			name:  "Free text",
+			input: `a-b "http://x.y/z"`,
+			want: And{Nodes: []Node{
+				Term{Op: OpMatch, Text: "a-b", Position: 1},
+				Term{Op: OpMatch, Text: "http://x.y/z", Position: 5},
+			}},
+		},
+		{
+			name:  "Positions count characters",
+			input: "ünï done:true",
+			want: And{Nodes: []Node{
+				Term{Op: OpMatch, Text: "ünï", Position: 1},
+				Term{Field: "done", Op: OpMatch, Bool: true, Position: 5},
+			}},
+		},
+		{
+			name:  "Replacement character",
+			input: "\uFFFD label:a",
+			want: And{Nodes: []Node{
+				Term{Op: OpMatch, Text: "\uFFFD", Position: 1},
+				Term{Field: "label", Op: OpMatch, Text: "a", Position: 3},
+			}},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			got, err := Parse(testCase.input)
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.want, got)
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	testTable := []struct {
+		name  string
+		input string
+		want  *Error
+	}{
+		{
+			name:  "Unknown field",
+			input: "done:false size>=large",
+			want:  &Error{Pos: 12, Msg: `unknown field "size"`},
+		},
+		{
+			name:  "Invalid priority",
+			input: "priority>=critical",
+			want:  &Error{Pos: 11, Msg: `invalid priority "critical", expected one of none, low, medium, high, urgent`},
+		},
+		{
+			name:  "Invalid assignee",
+			input: "assignee:bob",
+			want:  &Error{Pos: 10, Msg: "assignee takes me, none or a user id"},
+		},
+		{
+			name:  "Ordering assignees",
+			input: "assignee>3",
+			want:  &Error{Pos: 9, Msg: "operator > can't be used with assignee"},
+		},
+		{
+			name:  "Operator of another kind",
+			input: "label>work",
+			want:  &Error{Pos: 6, Msg: "operator > can't be used with label"},
+		},
+		{
+			name:  "Invalid date",
+			input: "due<2021-13-01",
+			want:  &Error{Pos: 5, Msg: `invalid date "2021-13-01", expected YYYY-MM-DD, an RFC 3339 time or none`},
+		},
+		{
+			name:  "Ordering none",
+			input: "due>none",
+			want:  &Error{Pos: 4, Msg: "operator > can't be used with none"},
+		},
+		{
+			name:  "Invalid bool",
+			input: "done:maybe",
+			want:  &Error{Pos: 6, Msg: "done takes true or false"},
+		},
+		{
+			name:  "Missing value",
+			input: "label: work",
+			want:  &Error{Pos: 7, Msg: "missing value for label"},
+		},
+		{
+			name:  "Missing quote",
+			input: `title:"open`,
+			want:  &Error{Pos: 7, Msg: "missing closing quote"},
+		},
+		{
+			name:  "Missing parenthesis",
+			input: "done:true (label:a OR label:b",
+			want:  &Error{Pos: 11, Msg: "missing closing parenthesis"},
+		},
+		{
+			name:  "Unexpected parenthesis",
+			input: "done:true )",
+			want:  &Error{Pos: 11, Msg: `unexpected ')'`},
+		},
+		{
+			name:  "Empty group",
+			input: "()",
+			want:  &Error{Pos: 2, Msg: `unexpected ')'`},
+		},
+		{
+			name:  "Dangling or",
+			input: "label:a OR",
+			want:  &Error{Pos: 9, Msg: "missing term after OR"},
+		},
+		{
+			name:  "Invalid UTF-8",
+			input: "\xff",
+			want:  &Error{Pos: 1, Msg: "invalid UTF-8"},
+		},
+		{
+			name:  "Invalid UTF-8 value",
+			input: "title:\xff",
+			want:  &Error{Pos: 7, Msg: "invalid UTF-8"},
+		},
+		{
+			name:  "Invalid UTF-8 after minus",
+			input: "-\xff",
+			want:  &Error{Pos: 2, Msg: "invalid UTF-8"},
+		},
+		{
+			name:  "Invalid UTF-8 in a word",
+			input: "a\xffb",
+			want:  &Error{Pos: 2, Msg: "invalid UTF-8"},
+		},
+		{
+			name:  "Leading or",
+			input: "OR label:a",
+			want:  &Error{Pos: 1, Msg: "missing term before OR"},
+		},
+		{
+			name:  "Nothing to negate",
+			input: "done:true - label:a",
+			want:  &Error{Pos: 11, Msg: "nothing to negate"},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			got, err := Parse(testCase.input)
+			assert.Nil(t, got)
+			assert.Equal(t, testCase.want, err)
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/fr13n8/todo-app/structs"
+	"github.com/jmoiron/sqlx"
+)
+
+var ErrQuotaExceeded = errors.New("attachment quota exceeded")
+
+const attachmentColumns = "a.id, a.item_id, a.user_id, a.filename, a.content_type, a.size, a.storage_key, a.created_at"
+
+type AttachmentPostgres struct {
+	db *sqlx.DB
+}
+
+func NewAttachmentPostgres(db *sqlx.DB) *AttachmentPostgres {
+	return &AttachmentPostgres{db: db}
+}
+
+func (r *AttachmentPostgres) Create(userId int, attachment structs.Attachment, quota int64) (int, string, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, "", err
+	}
+
+	token, err := beginJournal(tx, userId)
+	if err != nil {
+		rolError := tx.Rollback()
+		if rolError != nil {
+			return 0, "", rolError
+		}
+		return 0, "", err
+	}
+
+	// Parallel uploads of one user are serialized so they can't overshoot the
+	// quota together.
+	if _, err := tx.Exec("SELECT pg_advisory_xact_lock($1)", userId); err != nil {
+		rolError := tx.Rollback()
+		if rolError != nil {
+			return 0, "", rolError
+		}
+		return 0, "", err
+	}
+
+	if quota > 0 {
+		var usage int64
+		usageQuery := fmt.Sprintf("SELECT COALESCE(SUM(a.size), 0) FROM %s a WHERE a.user_id=$1", attachmentsTable)
+		row := tx.QueryRow(usageQuery, userId)
+		if err := row.Scan(&usage); err != nil {
+			rolError := tx.Rollback()
+			if rolError != nil {
+				return 0, "", rolError
+			}
+			return 0, "", err
+		}
+		if usage+attachment.Size > quota {
+			rolError := tx.Rollback()
+			if rolError != nil {
+				return 0, "", rolError
+			}
+			return 0, "", ErrQuotaExceeded
+		}
+	}
+
+	var id int
+	createQuery := fmt.Sprintf(`INSERT INTO %s (item_id, user_id, filename, content_type, size, storage_key)
+								VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`, attachmentsTable)
+	row := tx.QueryRow(createQuery, attachment.ItemId, userId, attachment.Filename,
+		attachment.ContentType, attachment.Size, attachment.StorageKey)
+	if err := row.Scan(&id); err != nil {
+		rolError := tx.Rollback()
+		if rolError != nil {
+			return 0, "", rolError
+		}
+		return 0, "", err
+	}
+
+	return id, token, tx.Commit()
+}
+
+func (r *AttachmentPostgres) GetUsage(userId int) (int64, error) {
+	var usage int64
+	query := fmt.Sprintf("SELECT COALESCE(SUM(a.size), 0) FROM %s a WHERE a.user_id=$1", attachmentsTable)
+	err := r.db.Get(&usage, query, userId)
+
+	return usage, err
+}
+
+func (r *AttachmentPostgres) GetAll(itemId int) ([]structs.Attachment, error) {
+	var attachments []structs.Attachment
+	query := fmt.Sprintf("SELECT %s FROM %s a WHERE a.item_id=$1 ORDER BY a.id", attachmentColumns, attachmentsTable)
+	if err := r.db.Select(&attachments, query, itemId); err != nil {
+		return nil, err
+	}
+
+	return attachments, nil
+}
+
+// GetAllByList returns the attachments of the list's items, archived ones
+// included, leaving out items in the trash.
+func (r *AttachmentPostgres) GetAllByList(listId int) ([]structs.Attachment, error) {
+	var attachments []structs.Attachment
+	query := fmt.Sprintf(`SELECT %s FROM %s a
+							INNER JOIN %s ti on ti.id=a.item_id
+							INNER JOIN %s li on li.item_id=ti.id
+							WHERE li.list_id=$1 AND %s
+							ORDER BY a.id`, attachmentColumns, attachmentsTable, todoItemsTable, listsItemsTable,
+		liveItemCondition("ti", "li"))
+	if err := r.db.Select(&attachments, query, listId); err != nil {
+		return nil, err
+	}
+
+	return attachments, nil
+}
+
+func (r *AttachmentPostgres) GetById(itemId int, attachmentId int) (structs.Attachment, error) {
+	var attachment structs.Attachment
+	query := fmt.Sprintf("SELECT %s FROM %s a WHERE a.item_id=$1 AND a.id=$2", attachmentColumns, attachmentsTable)
+	err := r.db.Get(&attachment, query, itemId, attachmentId)
+
+	return attachment, err
+}
+
+// Delete removes the attachment and returns the undo token. The blob is
+// kept for as long as the deletion can be undone.
+func (r *AttachmentPostgres) Delete(userId int, itemId int, attachmentId int) (string, error) {
+	return journal(r.db, userId, func(tx *sql.Tx) error {
+		query := fmt.Sprintf("DELETE FROM %s a WHERE a.item_id=$1 AND a.id=$2", attachmentsTable)
+		_, err := tx.Exec(query, itemId, attachmentId)
+		return err
+	})
+}
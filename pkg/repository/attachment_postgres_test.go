@@ -0,0 +1,287 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/fr13n8/todo-app/structs"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttachmentPostgres_Create(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "smock")
+
+	r := NewAttachmentPostgres(db)
+
+	type input struct {
+		userId     int
+		attachment structs.Attachment
+		quota      int64
+	}
+
+	type mockBehavior func(input input)
+
+	attachment := structs.Attachment{
+		ItemId:      1,
+		Filename:    "report.pdf",
+		ContentType: "application/pdf",
+		Size:        100,
+		StorageKey:  "1/key",
+	}
+
+	testTable := []struct {
+		name         string
+		input        input
+		mockBehavior mockBehavior
+		wantId       int
+		wantErr      error
+	}{
+		{
+			name: "Ok",
+			input: input{
+				userId:     1,
+				attachment: attachment,
+				quota:      1000,
+			},
+			mockBehavior: func(input input) {
+				mock.ExpectBegin()
+				expectJournal(mock, input.userId)
+
+				mock.ExpectExec(`SELECT pg_advisory_xact_lock\(\$1\)`).
+					WithArgs(input.userId).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+
+				mock.ExpectQuery("SELECT COALESCE(.+) FROM attachments a WHERE (.+)").
+					WithArgs(input.userId).
+					WillReturnRows(sqlmock.NewRows([]string{"usage"}).AddRow(900))
+
+				mock.ExpectQuery("INSERT INTO attachments").
+					WithArgs(input.attachment.ItemId, input.userId, input.attachment.Filename,
+						input.attachment.ContentType, input.attachment.Size, input.attachment.StorageKey).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+				mock.ExpectCommit()
+			},
+			wantId: 1,
+		},
+		{
+			name: "Without quota",
+			input: input{
+				userId:     1,
+				attachment: attachment,
+			},
+			mockBehavior: func(input input) {
+				mock.ExpectBegin()
+				expectJournal(mock, input.userId)
+
+				mock.ExpectExec(`SELECT pg_advisory_xact_lock\(\$1\)`).
+					WithArgs(input.userId).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+
+				mock.ExpectQuery("INSERT INTO attachments").
+					WithArgs(input.attachment.ItemId, input.userId, input.attachment.Filename,
+						input.attachment.ContentType, input.attachment.Size, input.attachment.StorageKey).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+
+				mock.ExpectCommit()
+			},
+			wantId: 2,
+		},
+		{
+			name: "Quota exceeded",
+			input: input{
+				userId:     1,
+				attachment: attachment,
+				quota:      1000,
+			},
+			mockBehavior: func(input input) {
+				mock.ExpectBegin()
+				expectJournal(mock, input.userId)
+
+				mock.ExpectExec(`SELECT pg_advisory_xact_lock\(\$1\)`).
+					WithArgs(input.userId).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+
+				mock.ExpectQuery("SELECT COALESCE(.+) FROM attachments a WHERE (.+)").
+					WithArgs(input.userId).
+					WillReturnRows(sqlmock.NewRows([]string{"usage"}).AddRow(901))
+
+				mock.ExpectRollback()
+			},
+			wantErr: ErrQuotaExceeded,
+		},
+		{
+			name: "Failed Insert",
+			input: input{
+				userId:     1,
+				attachment: attachment,
+			},
+			mockBehavior: func(input input) {
+				mock.ExpectBegin()
+				expectJournal(mock, input.userId)
+
+				mock.ExpectExec(`SELECT pg_advisory_xact_lock\(\$1\)`).
+					WithArgs(input.userId).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+
+				mock.ExpectQuery("INSERT INTO attachments").
+					WillReturnError(errors.New("insert error"))
+
+				mock.ExpectRollback()
+			},
+			wantErr: errors.New("insert error"),
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior(testCase.input)
+
+			got, token, err := r.Create(testCase.input.userId, testCase.input.attachment, testCase.input.quota)
+			if testCase.wantErr != nil {
+				assert.EqualError(t, err, testCase.wantErr.Error())
+				assert.Empty(t, token)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testCase.wantId, got)
+				assert.NotEmpty(t, token)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestAttachmentPostgres_GetAll(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "smock")
+
+	r := NewAttachmentPostgres(db)
+
+	createdAt := time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	testTable := []struct {
+		name         string
+		itemId       int
+		mockBehavior func(itemId int)
+		want         []structs.Attachment
+		wantErr      bool
+	}{
+		{
+			name:   "Ok",
+			itemId: 1,
+			mockBehavior: func(itemId int) {
+				rows := sqlmock.NewRows([]string{"id", "item_id", "user_id", "filename", "content_type", "size", "storage_key", "created_at"}).
+					AddRow(1, itemId, 1, "report.pdf", "application/pdf", 100, "1/key", createdAt)
+
+				mock.ExpectQuery("SELECT (.+) FROM attachments a WHERE (.+)").
+					WithArgs(itemId).
+					WillReturnRows(rows)
+			},
+			want: []structs.Attachment{
+				{Id: 1, ItemId: 1, UserId: 1, Filename: "report.pdf", ContentType: "application/pdf", Size: 100, StorageKey: "1/key", CreatedAt: createdAt},
+			},
+		},
+		{
+			name:   "Query error",
+			itemId: 1,
+			mockBehavior: func(itemId int) {
+				mock.ExpectQuery("SELECT (.+) FROM attachments a WHERE (.+)").
+					WithArgs(itemId).
+					WillReturnError(errors.New("query error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior(testCase.itemId)
+
+			got, err := r.GetAll(testCase.itemId)
+			if testCase.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testCase.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestAttachmentPostgres_GetAllByList(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "smock")
+
+	r := NewAttachmentPostgres(db)
+
+	createdAt := time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	testTable := []struct {
+		name         string
+		listId       int
+		mockBehavior func(listId int)
+		want         []structs.Attachment
+		wantErr      bool
+	}{
+		{
+			name:   "Ok",
+			listId: 1,
+			mockBehavior: func(listId int) {
+				rows := sqlmock.NewRows([]string{"id", "item_id", "user_id", "filename", "content_type", "size", "storage_key", "created_at"}).
+					AddRow(1, 2, 1, "report.pdf", "application/pdf", 100, "1/key", createdAt).
+					AddRow(2, 3, 1, "notes.txt", "text/plain", 10, "1/other", createdAt)
+
+				mock.ExpectQuery(`SELECT (.+) FROM attachments a INNER JOIN todo_items ti on ti.id=a.item_id
+									INNER JOIN lists_items li on li.item_id=ti.id WHERE li.list_id=\$1 AND (.+)`).
+					WithArgs(listId).
+					WillReturnRows(rows)
+			},
+			want: []structs.Attachment{
+				{Id: 1, ItemId: 2, UserId: 1, Filename: "report.pdf", ContentType: "application/pdf", Size: 100, StorageKey: "1/key", CreatedAt: createdAt},
+				{Id: 2, ItemId: 3, UserId: 1, Filename: "notes.txt", ContentType: "text/plain", Size: 10, StorageKey: "1/other", CreatedAt: createdAt},
+			},
+		},
+		{
+			name:   "Query error",
+			listId: 1,
+			mockBehavior: func(listId int) {
+				mock.ExpectQuery("SELECT (.+) FROM attachments a (.+)").
+					WithArgs(listId).
+					WillReturnError(errors.New("query error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior(testCase.listId)
+
+			got, err := r.GetAllByList(testCase.listId)
+			if testCase.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testCase.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
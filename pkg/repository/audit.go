@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/fr13n8/todo-app/structs"
+)
+
+// auditColumns names the audit columns of a table for filtering.
+// CompletedAt may be an expression, as lists derive theirs from their items.
+type auditColumns struct {
+	Id          string
+	CreatedAt   string
+	UpdatedAt   string
+	CompletedAt string
+	CreatedBy   string
+	UpdatedBy   string
+}
+
+func newAuditColumns(alias string, completedAt string) auditColumns {
+	return auditColumns{
+		Id:          alias + ".id",
+		CreatedAt:   alias + ".created_at",
+		UpdatedAt:   alias + ".updated_at",
+		CompletedAt: completedAt,
+		CreatedBy:   alias + ".created_by",
+		UpdatedBy:   alias + ".updated_by",
+	}
+}
+
+// where appends the conditions of the filter to conditions, numbering their
+// parameters after the ones already in args.
+func (c auditColumns) where(filter structs.AuditFilter, conditions []string, args []interface{}) ([]string, []interface{}) {
+	add := func(format string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(format, len(args)))
+	}
+
+	if filter.CreatedAfter != nil {
+		add(c.CreatedAt+">=$%d", *filter.CreatedAfter)
+	}
+	if filter.CreatedBefore != nil {
+		add(c.CreatedAt+"<$%d", *filter.CreatedBefore)
+	}
+	if filter.UpdatedAfter != nil {
+		add(c.UpdatedAt+">=$%d", *filter.UpdatedAfter)
+	}
+	if filter.UpdatedBefore != nil {
+		add(c.UpdatedAt+"<$%d", *filter.UpdatedBefore)
+	}
+	if filter.CompletedAfter != nil {
+		add(c.CompletedAt+">=$%d", *filter.CompletedAfter)
+	}
+	if filter.CompletedBefore != nil {
+		add(c.CompletedAt+"<$%d", *filter.CompletedBefore)
+	}
+	if filter.CreatedBy != nil {
+		add(c.CreatedBy+"=$%d", *filter.CreatedBy)
+	}
+	if filter.UpdatedBy != nil {
+		add(c.UpdatedBy+"=$%d", *filter.UpdatedBy)
+	}
+
+	return conditions, args
+}
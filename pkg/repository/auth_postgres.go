@@ -1,12 +1,20 @@
 package repository
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/fr13n8/todo-app/structs"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
+var ErrInvalidSearchLanguage = errors.New("unknown search language")
+
+// undefinedObject is the SQLSTATE of a name that doesn't resolve, such as an
+// unknown text search configuration.
+const undefinedObject = "42704"
+
 type AuthPostgres struct {
 	db *sqlx.DB
 }
@@ -15,11 +23,23 @@ func NewAuthPostgres(db *sqlx.DB) *AuthPostgres {
 	return &AuthPostgres{db: db}
 }
 
+// CreateUser leaves the search language to the column default unless the
+// input names one.
 func (r *AuthPostgres) CreateUser(user structs.SignUpInput) (int, error) {
 	var id int
 	query := fmt.Sprintf("INSERT INTO %s (name, username, password) VALUES ($1, $2, $3) RETURNING id", usersTable)
-	row := r.db.QueryRow(query, user.Name, user.UserName, user.Password)
+	args := []interface{}{user.Name, user.UserName, user.Password}
+	if user.SearchLanguage != "" {
+		query = fmt.Sprintf("INSERT INTO %s (name, username, password, search_language) VALUES ($1, $2, $3, $4) RETURNING id", usersTable)
+		args = append(args, user.SearchLanguage)
+	}
+
+	row := r.db.QueryRow(query, args...)
 	if err := row.Scan(&id); err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == undefinedObject {
+			return 0, ErrInvalidSearchLanguage
+		}
 		return 0, err
 	}
 	return id, nil
@@ -7,6 +7,7 @@ import (
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/fr13n8/todo-app/structs"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -118,6 +119,41 @@ func TestTodoAuth_CreateUser(t *testing.T) {
 					WillReturnRows(rows)
 			},
 		},
+		{
+			name: "Ok_SearchLanguage",
+			input: input{
+				user: structs.SignUpInput{
+					Name:           "name",
+					UserName:       "username",
+					Password:       "password",
+					SearchLanguage: "german",
+				},
+			},
+			wantId: 2,
+			mockBehavior: func(input input, id int) {
+				rows := sqlmock.NewRows([]string{"id"}).AddRow(id)
+				mock.ExpectQuery(`INSERT INTO users \(name, username, password, search_language\) VALUES \(\$1, \$2, \$3, \$4\)`).
+					WithArgs(input.user.Name, input.user.UserName, input.user.Password, input.user.SearchLanguage).
+					WillReturnRows(rows)
+			},
+		},
+		{
+			name: "Unknown search language",
+			input: input{
+				user: structs.SignUpInput{
+					Name:           "name",
+					UserName:       "username",
+					Password:       "password",
+					SearchLanguage: "klingon",
+				},
+			},
+			wantErr: true,
+			mockBehavior: func(input input, id int) {
+				mock.ExpectQuery("INSERT INTO users").
+					WithArgs(input.user.Name, input.user.UserName, input.user.Password, input.user.SearchLanguage).
+					WillReturnError(&pq.Error{Code: "42704"})
+			},
+		},
 	}
 
 	for _, testCase := range testTable {
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/fr13n8/todo-app/structs"
+	"github.com/jmoiron/sqlx"
+)
+
+// ErrRecordNotFound is returned by the changes that found nothing to change,
+// such as an update of an item an earlier change of the batch deleted.
+var ErrRecordNotFound = errors.New("record not found")
+
+// checkAffected tells a statement that changed no row.
+func checkAffected(result sql.Result) error {
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrRecordNotFound
+	}
+	return nil
+}
+
+// execer runs statements on the database or inside a transaction.
+type execer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// runBatch applies n changes in one transaction, apply making the i-th. In
+// atomic mode the first failure rolls the transaction back and ends the
+// batch, leaving the results of the changes after it empty. Otherwise each
+// change runs under a savepoint, so a failure only undoes that change. The
+// changes made are journaled under the undo token returned.
+func runBatch(db *sqlx.DB, userId int, n int, atomic bool,
+	apply func(tx *sql.Tx, i int) (int, error)) ([]structs.BatchResult, string, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, "", err
+	}
+
+	token, err := beginJournal(tx, userId)
+	if err != nil {
+		rollErr := tx.Rollback()
+		if rollErr != nil {
+			return nil, "", rollErr
+		}
+		return nil, "", err
+	}
+
+	results := make([]structs.BatchResult, n)
+	for i := 0; i < n; i++ {
+		if !atomic {
+			if _, err := tx.Exec("SAVEPOINT batch_change"); err != nil {
+				rollErr := tx.Rollback()
+				if rollErr != nil {
+					return nil, "", rollErr
+				}
+				return nil, "", err
+			}
+		}
+
+		id, err := apply(tx, i)
+		if err == nil {
+			results[i].Id = id
+			if !atomic {
+				if _, err := tx.Exec("RELEASE SAVEPOINT batch_change"); err != nil {
+					rollErr := tx.Rollback()
+					if rollErr != nil {
+						return nil, "", rollErr
+					}
+					return nil, "", err
+				}
+			}
+			continue
+		}
+
+		results[i].Err = err
+		if atomic {
+			return results, "", tx.Rollback()
+		}
+		if _, err := tx.Exec("ROLLBACK TO SAVEPOINT batch_change"); err != nil {
+			rollErr := tx.Rollback()
+			if rollErr != nil {
+				return nil, "", rollErr
+			}
+			return nil, "", err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return results, "", err
+	}
+	return results, token, nil
+}
@@ -0,0 +1,197 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/fr13n8/todo-app/structs"
+	"github.com/jmoiron/sqlx"
+)
+
+var ErrWipLimitReached = errors.New("column is at its WIP limit")
+
+// boardItemCondition keeps the items shown on the board, ti being the item
+// and li its link to the list: trashed and archived items take no slot of a
+// column and keep their positions.
+var boardItemCondition = "ti.archived_at IS NULL AND " + liveItemCondition("ti", "li")
+
+type BoardPostgres struct {
+	db    *sqlx.DB
+	items *TodoItemPostgres
+}
+
+func NewBoardPostgres(db *sqlx.DB) *BoardPostgres {
+	return &BoardPostgres{db: db, items: NewTodoItemPostgres(db)}
+}
+
+func (r *BoardPostgres) GetLabelPositions(userId int, listId int) ([]structs.BoardPosition, error) {
+	var positions []structs.BoardPosition
+
+	query := fmt.Sprintf(`SELECT il.item_id, il.label_id AS column_id, il.position FROM %s il
+							INNER JOIN %s l on l.id=il.label_id
+							INNER JOIN %s li on li.item_id=il.item_id
+							WHERE l.user_id=$1 AND li.list_id=$2`, itemsLabelsTable, labelsTable, listsItemsTable)
+	if err := r.db.Select(&positions, query, userId, listId); err != nil {
+		return nil, err
+	}
+
+	return positions, nil
+}
+
+// MoveToStatus puts the item into the column of the status at the position
+// and returns the undo token. A completion, if any, is recorded first, in
+// the same transaction; the status is then the one the item lands in.
+func (r *BoardPostgres) MoveToStatus(userId int, listId int, itemId int, status structs.Status, position int,
+	completion *structs.ItemChange) (string, error) {
+	tx, token, err := r.lockBoard(userId, listId, itemId)
+	if err != nil {
+		return "", err
+	}
+
+	if completion != nil {
+		if err := r.items.completeChange(tx, userId, *completion); err != nil {
+			rolError := tx.Rollback()
+			if rolError != nil {
+				return "", rolError
+			}
+			return "", err
+		}
+	}
+
+	countQuery := fmt.Sprintf(`SELECT COUNT(*) FROM %s ti
+								INNER JOIN %s li on li.item_id=ti.id
+								WHERE li.list_id=$1 AND ti.status_id=$2 AND ti.id<>$3 AND %s`,
+		todoItemsTable, listsItemsTable, boardItemCondition)
+	if err := checkWipLimit(tx, status.WipLimit, countQuery, listId, status.Id, itemId); err != nil {
+		rolError := tx.Rollback()
+		if rolError != nil {
+			return "", rolError
+		}
+		return "", err
+	}
+
+	shiftQuery := fmt.Sprintf(`UPDATE %s ti SET position=ti.position+1 FROM %s li
+								WHERE li.item_id=ti.id AND li.list_id=$1 AND ti.status_id=$2
+								AND ti.position>=$3 AND ti.id<>$4 AND %s`, todoItemsTable, listsItemsTable, boardItemCondition)
+	if _, err := tx.Exec(shiftQuery, listId, status.Id, position, itemId); err != nil {
+		rolError := tx.Rollback()
+		if rolError != nil {
+			return "", rolError
+		}
+		return "", err
+	}
+
+	moveQuery := fmt.Sprintf("UPDATE %s ti SET status_id=$1, done=$2, position=$3, updated_by=$4 WHERE ti.id=$5", todoItemsTable)
+	if _, err := tx.Exec(moveQuery, status.Id, status.IsDone, position, userId, itemId); err != nil {
+		rolError := tx.Rollback()
+		if rolError != nil {
+			return "", rolError
+		}
+		return "", err
+	}
+
+	return token, tx.Commit()
+}
+
+func (r *BoardPostgres) MoveToLabel(userId int, listId int, itemId int, fromLabelId *int, label structs.Label,
+	position int) (string, error) {
+	tx, token, err := r.lockBoard(userId, listId, itemId)
+	if err != nil {
+		return "", err
+	}
+
+	countQuery := fmt.Sprintf(`SELECT COUNT(*) FROM %s il
+								INNER JOIN %s li on li.item_id=il.item_id
+								INNER JOIN %s ti on ti.id=il.item_id
+								WHERE li.list_id=$1 AND il.label_id=$2 AND il.item_id<>$3 AND %s`,
+		itemsLabelsTable, listsItemsTable, todoItemsTable, boardItemCondition)
+	if err := checkWipLimit(tx, label.WipLimit, countQuery, listId, label.Id, itemId); err != nil {
+		rolError := tx.Rollback()
+		if rolError != nil {
+			return "", rolError
+		}
+		return "", err
+	}
+
+	if fromLabelId != nil && *fromLabelId != label.Id {
+		detachQuery := fmt.Sprintf("DELETE FROM %s il WHERE il.item_id=$1 AND il.label_id=$2", itemsLabelsTable)
+		if _, err := tx.Exec(detachQuery, itemId, *fromLabelId); err != nil {
+			rolError := tx.Rollback()
+			if rolError != nil {
+				return "", rolError
+			}
+			return "", err
+		}
+	}
+
+	shiftQuery := fmt.Sprintf(`UPDATE %s il SET position=il.position+1 FROM %s li, %s ti
+								WHERE li.item_id=il.item_id AND ti.id=il.item_id AND li.list_id=$1 AND il.label_id=$2
+								AND il.position>=$3 AND il.item_id<>$4 AND %s`,
+		itemsLabelsTable, listsItemsTable, todoItemsTable, boardItemCondition)
+	if _, err := tx.Exec(shiftQuery, listId, label.Id, position, itemId); err != nil {
+		rolError := tx.Rollback()
+		if rolError != nil {
+			return "", rolError
+		}
+		return "", err
+	}
+
+	moveQuery := fmt.Sprintf(`INSERT INTO %s (item_id, label_id, position) VALUES ($1, $2, $3)
+								ON CONFLICT (item_id, label_id) DO UPDATE SET position=EXCLUDED.position`, itemsLabelsTable)
+	if _, err := tx.Exec(moveQuery, itemId, label.Id, position); err != nil {
+		rolError := tx.Rollback()
+		if rolError != nil {
+			return "", rolError
+		}
+		return "", err
+	}
+
+	return token, tx.Commit()
+}
+
+// lockBoard starts the move transaction, journaled under the undo token it
+// returns. Moves on one list are serialized by locking the list row, so two
+// moves can't both squeeze into the last free slot of a column.
+func (r *BoardPostgres) lockBoard(userId int, listId int, itemId int) (*sql.Tx, string, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, "", err
+	}
+
+	var found bool
+	lockQuery := fmt.Sprintf(`SELECT EXISTS (SELECT 1 FROM %s li WHERE li.list_id=tl.id AND li.item_id=$2)
+								FROM %s tl WHERE tl.id=$1 FOR UPDATE`, listsItemsTable, todoListsTable)
+	err = tx.QueryRow(lockQuery, listId, itemId).Scan(&found)
+	if err == nil && !found {
+		err = errors.New("record not found")
+	}
+	var token string
+	if err == nil {
+		token, err = beginJournal(tx, userId)
+	}
+	if err != nil {
+		rolError := tx.Rollback()
+		if rolError != nil {
+			return nil, "", rolError
+		}
+		return nil, "", err
+	}
+
+	return tx, token, nil
+}
+
+func checkWipLimit(tx *sql.Tx, limit *int, countQuery string, args ...interface{}) error {
+	if limit == nil {
+		return nil
+	}
+
+	var count int
+	if err := tx.QueryRow(countQuery, args...).Scan(&count); err != nil {
+		return err
+	}
+	if count >= *limit {
+		return ErrWipLimitReached
+	}
+	return nil
+}
@@ -0,0 +1,216 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/fr13n8/todo-app/structs"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBoardPostgres_MoveToStatus(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "smock")
+
+	r := NewBoardPostgres(db)
+
+	type input struct {
+		userId     int
+		listId     int
+		itemId     int
+		status     structs.Status
+		position   int
+		completion *structs.ItemChange
+	}
+
+	type mockBehavior func(input input)
+
+	next := time.Date(2021, 6, 9, 0, 0, 0, 0, time.UTC)
+
+	testTable := []struct {
+		name         string
+		input        input
+		mockBehavior mockBehavior
+		wantErr      error
+	}{
+		{
+			name: "Ok",
+			input: input{
+				userId:   5,
+				listId:   1,
+				itemId:   2,
+				status:   structs.Status{Id: 3, IsDone: true, WipLimit: intPointer(2)},
+				position: 0,
+			},
+			mockBehavior: func(input input) {
+				mock.ExpectBegin()
+
+				mock.ExpectQuery(`SELECT EXISTS \(SELECT 1 FROM lists_items li WHERE (.+)\) FROM todo_lists tl WHERE tl.id=\$1 FOR UPDATE`).
+					WithArgs(input.listId, input.itemId).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+				expectJournal(mock, input.userId)
+
+				mock.ExpectQuery(`SELECT COUNT\(\*\) FROM todo_items ti INNER JOIN lists_items li (.+) WHERE (.+) AND ti.archived_at IS NULL AND ti.deleted_at IS NULL (.+)`).
+					WithArgs(input.listId, input.status.Id, input.itemId).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+				mock.ExpectExec(`UPDATE todo_items ti SET position=ti.position\+1 FROM lists_items li WHERE (.+) AND ti.archived_at IS NULL AND ti.deleted_at IS NULL (.+)`).
+					WithArgs(input.listId, input.status.Id, input.position, input.itemId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
+				mock.ExpectExec(`UPDATE todo_items ti SET status_id=\$1, done=\$2, position=\$3, updated_by=\$4 WHERE ti.id=\$5`).
+					WithArgs(input.status.Id, input.status.IsDone, input.position, input.userId, input.itemId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Recurring item completed",
+			input: input{
+				userId:   5,
+				listId:   1,
+				itemId:   2,
+				status:   structs.Status{Id: 4},
+				position: 1,
+				completion: &structs.ItemChange{Op: structs.OpUpdate, ItemId: 2, Complete: true, Next: &next,
+					Update: &structs.UpdateItemInput{StatusId: intPointer(4)}},
+			},
+			mockBehavior: func(input input) {
+				mock.ExpectBegin()
+
+				mock.ExpectQuery("SELECT EXISTS").
+					WithArgs(input.listId, input.itemId).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+				expectJournal(mock, input.userId)
+
+				mock.ExpectExec(`UPDATE todo_items ti SET status_id=\$1,updated_by=\$2 FROM lists_items li, users_lists ul WHERE (.+)`).
+					WithArgs(4, input.userId, input.itemId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO items_completions").
+					WithArgs(input.userId, input.itemId).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(`UPDATE todo_items SET done=false, due_date=\$1, updated_by=\$2 WHERE id=\$3`).
+					WithArgs(next, input.userId, input.itemId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
+				mock.ExpectExec(`UPDATE todo_items ti SET position=ti.position\+1`).
+					WithArgs(input.listId, input.status.Id, input.position, input.itemId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`UPDATE todo_items ti SET status_id=\$1, done=\$2, position=\$3, updated_by=\$4 WHERE ti.id=\$5`).
+					WithArgs(input.status.Id, false, input.position, input.userId, input.itemId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "WIP limit reached",
+			input: input{
+				userId:   5,
+				listId:   1,
+				itemId:   2,
+				status:   structs.Status{Id: 3, WipLimit: intPointer(2)},
+				position: 0,
+			},
+			mockBehavior: func(input input) {
+				mock.ExpectBegin()
+
+				mock.ExpectQuery("SELECT EXISTS").
+					WithArgs(input.listId, input.itemId).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+				expectJournal(mock, input.userId)
+
+				mock.ExpectQuery(`SELECT COUNT\(\*\) FROM todo_items`).
+					WithArgs(input.listId, input.status.Id, input.itemId).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+				mock.ExpectRollback()
+			},
+			wantErr: ErrWipLimitReached,
+		},
+		{
+			name: "Item not in list",
+			input: input{
+				userId:   5,
+				listId:   1,
+				itemId:   2,
+				status:   structs.Status{Id: 3},
+				position: 0,
+			},
+			mockBehavior: func(input input) {
+				mock.ExpectBegin()
+
+				mock.ExpectQuery("SELECT EXISTS").
+					WithArgs(input.listId, input.itemId).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+				mock.ExpectRollback()
+			},
+			wantErr: errors.New("record not found"),
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior(testCase.input)
+
+			token, err := r.MoveToStatus(testCase.input.userId, testCase.input.listId, testCase.input.itemId, testCase.input.status, testCase.input.position,
+				testCase.input.completion)
+			if testCase.wantErr != nil {
+				assert.Equal(t, testCase.wantErr, err)
+				assert.Empty(t, token)
+			} else {
+				assert.NoError(t, err)
+				assert.NotEmpty(t, token)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestBoardPostgres_MoveToLabel(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "smock")
+
+	r := NewBoardPostgres(db)
+
+	label := structs.Label{Id: 3, Name: "urgent"}
+
+	mock.ExpectBegin()
+
+	mock.ExpectQuery("SELECT EXISTS").
+		WithArgs(1, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	expectJournal(mock, 6)
+
+	mock.ExpectExec(`DELETE FROM items_labels il WHERE il.item_id=\$1 AND il.label_id=\$2`).
+		WithArgs(2, 5).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	mock.ExpectExec(`UPDATE items_labels il SET position=il.position\+1 FROM lists_items li, todo_items ti WHERE (.+) AND ti.archived_at IS NULL AND ti.deleted_at IS NULL (.+)`).
+		WithArgs(1, label.Id, 4, 2).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	mock.ExpectExec(`INSERT INTO items_labels \(item_id, label_id, position\) VALUES (.+) ON CONFLICT (.+) DO UPDATE SET position=EXCLUDED.position`).
+		WithArgs(2, label.Id, 4).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	mock.ExpectCommit()
+
+	token, err := r.MoveToLabel(6, 1, 2, intPointer(5), label, 4)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, token)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/fr13n8/todo-app/structs"
+	"github.com/jmoiron/sqlx"
+)
+
+var ErrDependencyCycle = errors.New("dependency would create a cycle")
+
+type DependencyPostgres struct {
+	db *sqlx.DB
+}
+
+func NewDependencyPostgres(db *sqlx.DB) *DependencyPostgres {
+	return &DependencyPostgres{db: db}
+}
+
+func (r *DependencyPostgres) Create(userId int, itemId int, blockerId int) (string, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return "", err
+	}
+
+	token, err := beginJournal(tx, userId)
+	if err != nil {
+		rolError := tx.Rollback()
+		if rolError != nil {
+			return "", rolError
+		}
+		return "", err
+	}
+
+	cycle, err := dependencyCycle(tx, itemId, blockerId)
+	if err != nil {
+		rolError := tx.Rollback()
+		if rolError != nil {
+			return "", rolError
+		}
+		return "", err
+	}
+	if cycle {
+		rolError := tx.Rollback()
+		if rolError != nil {
+			return "", rolError
+		}
+		return "", ErrDependencyCycle
+	}
+
+	createQuery := fmt.Sprintf("INSERT INTO %s (item_id, blocker_id) VALUES ($1, $2) ON CONFLICT DO NOTHING", itemsDependenciesTable)
+	if _, err := tx.Exec(createQuery, itemId, blockerId); err != nil {
+		rolError := tx.Rollback()
+		if rolError != nil {
+			return "", rolError
+		}
+		return "", err
+	}
+
+	return token, tx.Commit()
+}
+
+// dependencyCycle tells whether the blocker blocking the item would close a
+// cycle. Concurrent inserts could close one between them, so writers are
+// serialized until the transaction ends.
+func dependencyCycle(tx *sql.Tx, itemId int, blockerId int) (bool, error) {
+	lockQuery := fmt.Sprintf("LOCK TABLE %s IN SHARE ROW EXCLUSIVE MODE", itemsDependenciesTable)
+	if _, err := tx.Exec(lockQuery); err != nil {
+		return false, err
+	}
+
+	var cycle bool
+	cycleQuery := fmt.Sprintf(`WITH RECURSIVE blockers(id) AS (
+								SELECT d.blocker_id FROM %s d WHERE d.item_id=$1
+								UNION
+								SELECT d.blocker_id FROM %s d INNER JOIN blockers b on d.item_id=b.id
+							)
+							SELECT $1=$2 OR EXISTS (SELECT 1 FROM blockers WHERE id=$2)`, itemsDependenciesTable, itemsDependenciesTable)
+	err := tx.QueryRow(cycleQuery, blockerId, itemId).Scan(&cycle)
+
+	return cycle, err
+}
+
+func (r *DependencyPostgres) GetBlockers(userId int, itemId int) ([]structs.Item, error) {
+	var items []structs.Item
+
+	query := fmt.Sprintf(`SELECT %s FROM %s ti
+							INNER JOIN %s d on d.blocker_id=ti.id
+							INNER JOIN %s li on li.item_id=ti.id
+							INNER JOIN %s ul on ul.list_id=li.list_id
+							WHERE d.item_id=$1 AND ul.user_id=$2 AND %s`,
+		itemColumns, todoItemsTable, itemsDependenciesTable, listsItemsTable, usersListsTable,
+		liveItemCondition("ti", "li"))
+	if err := r.db.Select(&items, query, itemId, userId); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+func (r *DependencyPostgres) Delete(userId int, itemId int, blockerId int) (string, error) {
+	return journal(r.db, userId, func(tx *sql.Tx) error {
+		query := fmt.Sprintf("DELETE FROM %s d WHERE d.item_id=$1 AND d.blocker_id=$2", itemsDependenciesTable)
+		_, err := tx.Exec(query, itemId, blockerId)
+		return err
+	})
+}
@@ -0,0 +1,202 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/fr13n8/todo-app/structs"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDependencyPostgres_Create(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "smock")
+
+	r := NewDependencyPostgres(db)
+
+	type input struct {
+		itemId    int
+		blockerId int
+	}
+
+	type mockBehavior func(input input)
+
+	testTable := []struct {
+		name         string
+		input        input
+		mockBehavior mockBehavior
+		wantErr      error
+	}{
+		{
+			name: "Ok",
+			input: input{
+				itemId:    1,
+				blockerId: 2,
+			},
+			mockBehavior: func(input input) {
+				mock.ExpectBegin()
+				expectJournal(mock, 1)
+
+				mock.ExpectExec("LOCK TABLE items_dependencies").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+
+				mock.ExpectQuery(`WITH RECURSIVE blockers\(id\) AS (.+) SELECT (.+)`).
+					WithArgs(input.blockerId, input.itemId).
+					WillReturnRows(sqlmock.NewRows([]string{"cycle"}).AddRow(false))
+
+				mock.ExpectExec("INSERT INTO items_dependencies").
+					WithArgs(input.itemId, input.blockerId).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Cycle",
+			input: input{
+				itemId:    1,
+				blockerId: 2,
+			},
+			mockBehavior: func(input input) {
+				mock.ExpectBegin()
+				expectJournal(mock, 1)
+
+				mock.ExpectExec("LOCK TABLE items_dependencies").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+
+				mock.ExpectQuery("WITH RECURSIVE blockers").
+					WithArgs(input.blockerId, input.itemId).
+					WillReturnRows(sqlmock.NewRows([]string{"cycle"}).AddRow(true))
+
+				mock.ExpectRollback()
+			},
+			wantErr: ErrDependencyCycle,
+		},
+		{
+			name: "Failed Insert",
+			input: input{
+				itemId:    1,
+				blockerId: 2,
+			},
+			mockBehavior: func(input input) {
+				mock.ExpectBegin()
+				expectJournal(mock, 1)
+
+				mock.ExpectExec("LOCK TABLE items_dependencies").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+
+				mock.ExpectQuery("WITH RECURSIVE blockers").
+					WithArgs(input.blockerId, input.itemId).
+					WillReturnRows(sqlmock.NewRows([]string{"cycle"}).AddRow(false))
+
+				mock.ExpectExec("INSERT INTO items_dependencies").
+					WithArgs(input.itemId, input.blockerId).
+					WillReturnError(errors.New("insert error"))
+
+				mock.ExpectRollback()
+			},
+			wantErr: errors.New("insert error"),
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior(testCase.input)
+
+			token, err := r.Create(1, testCase.input.itemId, testCase.input.blockerId)
+			if testCase.wantErr != nil {
+				assert.EqualError(t, err, testCase.wantErr.Error())
+				assert.Empty(t, token)
+			} else {
+				assert.NoError(t, err)
+				assert.NotEmpty(t, token)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestDependencyPostgres_GetBlockers(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "smock")
+
+	r := NewDependencyPostgres(db)
+
+	type input struct {
+		userId int
+		itemId int
+	}
+
+	type mockBehavior func(input input)
+
+	testTable := []struct {
+		name         string
+		input        input
+		mockBehavior mockBehavior
+		want         []structs.Item
+		wantErr      bool
+	}{
+		{
+			name: "Ok",
+			input: input{
+				userId: 1,
+				itemId: 1,
+			},
+			mockBehavior: func(input input) {
+				rows := sqlmock.NewRows([]string{"id", "title", "description", "done", "state"}).
+					AddRow(2, "title2", "description2", false, "blocked").
+					AddRow(3, "title3", "description3", true, "done")
+
+				mock.ExpectQuery(`SELECT (.+) FROM todo_items ti
+									INNER JOIN items_dependencies d on (.+)
+									INNER JOIN lists_items li on (.+)
+									INNER JOIN users_lists ul on (.+)
+									WHERE (.+)`).
+					WithArgs(input.itemId, input.userId).
+					WillReturnRows(rows)
+			},
+			want: []structs.Item{
+				{Id: 2, Title: "title2", Description: "description2", State: structs.ItemStateBlocked},
+				{Id: 3, Title: "title3", Description: "description3", Done: true, State: structs.ItemStateDone},
+			},
+		},
+		{
+			name: "Query error",
+			input: input{
+				userId: 1,
+				itemId: 1,
+			},
+			mockBehavior: func(input input) {
+				mock.ExpectQuery("SELECT (.+) FROM todo_items ti").
+					WithArgs(input.itemId, input.userId).
+					WillReturnError(errors.New("query error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior(testCase.input)
+
+			got, err := r.GetBlockers(testCase.input.userId, testCase.input.itemId)
+			if testCase.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testCase.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fr13n8/todo-app/structs"
+	"github.com/jmoiron/sqlx"
+)
+
+type IdempotencyPostgres struct {
+	db *sqlx.DB
+}
+
+func NewIdempotencyPostgres(db *sqlx.DB) *IdempotencyPostgres {
+	return &IdempotencyPostgres{db: db}
+}
+
+// Claim takes the key for a request, unless another request holds it: one
+// that got its response after expired or that is still in progress since
+// stale. Otherwise it returns the key as that request left it.
+func (r *IdempotencyPostgres) Claim(userId int, key string, fingerprint string, expired time.Time,
+	stale time.Time) (structs.IdempotencyKey, bool, error) {
+	var claimed structs.IdempotencyKey
+
+	query := fmt.Sprintf(`INSERT INTO %s AS ik (user_id, key, fingerprint) VALUES ($1, $2, $3)
+							ON CONFLICT (user_id, key) DO UPDATE
+							SET fingerprint=EXCLUDED.fingerprint, status=0, content_type='', body=NULL, headers='{}', created_at=now()
+							WHERE ik.created_at < $4 OR (ik.status=0 AND ik.created_at < $5)
+							RETURNING ik.key`, idempotencyKeysTable)
+	err := r.db.QueryRow(query, userId, key, fingerprint, expired, stale).Scan(&claimed.Key)
+	if err == nil {
+		claimed.Fingerprint = fingerprint
+		return claimed, true, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return claimed, false, err
+	}
+
+	query = fmt.Sprintf(`SELECT ik.key, ik.fingerprint, ik.created_at, ik.status, ik.content_type, ik.body, ik.headers
+							FROM %s ik WHERE ik.user_id=$1 AND ik.key=$2`, idempotencyKeysTable)
+	err = r.db.Get(&claimed, query, userId, key)
+	return claimed, false, err
+}
+
+// Store keeps the response to the request holding the key.
+func (r *IdempotencyPostgres) Store(userId int, key string, response structs.IdempotentResponse) error {
+	query := fmt.Sprintf(`UPDATE %s SET status=$3, content_type=$4, body=$5, headers=$6 WHERE user_id=$1 AND key=$2`,
+		idempotencyKeysTable)
+	_, err := r.db.Exec(query, userId, key, response.Status, response.ContentType, response.Body, response.Headers)
+	return err
+}
+
+// Release frees a key whose request got no response worth replaying.
+func (r *IdempotencyPostgres) Release(userId int, key string) error {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE user_id=$1 AND key=$2 AND status=0`, idempotencyKeysTable)
+	_, err := r.db.Exec(query, userId, key)
+	return err
+}
+
+func (r *IdempotencyPostgres) DeleteOlderThan(before time.Time) (int64, error) {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE created_at < $1`, idempotencyKeysTable)
+	result, err := r.db.Exec(query, before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
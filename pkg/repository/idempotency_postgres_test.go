@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/fr13n8/todo-app/structs"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdempotencyPostgres_Claim(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "smock")
+
+	r := NewIdempotencyPostgres(db)
+
+	expired := time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)
+	stale := time.Date(2021, 6, 2, 9, 50, 0, 0, time.UTC)
+
+	type mockBehavior func()
+
+	testTable := []struct {
+		name         string
+		mockBehavior mockBehavior
+		want         structs.IdempotencyKey
+		wantClaimed  bool
+	}{
+		{
+			name: "Claimed",
+			mockBehavior: func() {
+				mock.ExpectQuery(`INSERT INTO idempotency_keys AS ik \(user_id, key, fingerprint\) VALUES \(\$1, \$2, \$3\)
+									ON CONFLICT \(user_id, key\) DO UPDATE (.+)
+									WHERE ik.created_at < \$4 OR \(ik.status=0 AND ik.created_at < \$5\)
+									RETURNING ik.key`).
+					WithArgs(1, "key", "print", expired, stale).
+					WillReturnRows(sqlmock.NewRows([]string{"key"}).AddRow("key"))
+			},
+			want:        structs.IdempotencyKey{Key: "key", Fingerprint: "print"},
+			wantClaimed: true,
+		},
+		{
+			name: "Held",
+			mockBehavior: func() {
+				mock.ExpectQuery("INSERT INTO idempotency_keys").
+					WithArgs(1, "key", "print", expired, stale).
+					WillReturnRows(sqlmock.NewRows([]string{"key"}))
+				mock.ExpectQuery(`SELECT (.+) FROM idempotency_keys ik WHERE ik.user_id=\$1 AND ik.key=\$2`).
+					WithArgs(1, "key").
+					WillReturnRows(sqlmock.NewRows([]string{"key", "fingerprint", "created_at", "status",
+						"content_type", "body", "headers"}).
+						AddRow("key", "print", expired, 201, "application/json", []byte(`{"id":1}`), []byte(`{"Undo-Token":"token"}`)))
+			},
+			want: structs.IdempotencyKey{
+				Key:         "key",
+				Fingerprint: "print",
+				CreatedAt:   expired,
+				IdempotentResponse: structs.IdempotentResponse{
+					Status:      201,
+					ContentType: "application/json",
+					Body:        []byte(`{"id":1}`),
+					Headers:     structs.ResponseHeaders{"Undo-Token": "token"},
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior()
+
+			got, claimed, err := r.Claim(1, "key", "print", expired, stale)
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.wantClaimed, claimed)
+			assert.Equal(t, testCase.want, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestIdempotencyPostgres_Store(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "smock")
+
+	r := NewIdempotencyPostgres(db)
+
+	mock.ExpectExec(`UPDATE idempotency_keys SET status=\$3, content_type=\$4, body=\$5, headers=\$6 WHERE user_id=\$1 AND key=\$2`).
+		WithArgs(1, "key", 201, "application/json", []byte(`{"id":1}`), []byte(`{"Undo-Token":"token"}`)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = r.Store(1, "key", structs.IdempotentResponse{
+		Status:      201,
+		ContentType: "application/json",
+		Body:        []byte(`{"id":1}`),
+		Headers:     structs.ResponseHeaders{"Undo-Token": "token"},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIdempotencyPostgres_Release(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "smock")
+
+	r := NewIdempotencyPostgres(db)
+
+	mock.ExpectExec(`DELETE FROM idempotency_keys WHERE user_id=\$1 AND key=\$2 AND status=0`).
+		WithArgs(1, "key").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	assert.NoError(t, r.Release(1, "key"))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
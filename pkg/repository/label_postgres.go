@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/fr13n8/todo-app/structs"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+var ErrLabelExists = errors.New("a label with that name already exists")
+
+// uniqueViolation is the SQLSTATE of a unique constraint violation.
+const uniqueViolation = "23505"
+
+type LabelPostgres struct {
+	db *sqlx.DB
+}
+
+func NewLabelPostgres(db *sqlx.DB) *LabelPostgres {
+	return &LabelPostgres{db: db}
+}
+
+func (r *LabelPostgres) Create(userId int, label structs.Label) (int, string, error) {
+	var id int
+	token, err := journal(r.db, userId, func(tx *sql.Tx) error {
+		query := fmt.Sprintf("INSERT INTO %s (user_id, name, color, wip_limit) VALUES ($1, $2, $3, $4) RETURNING id", labelsTable)
+		row := tx.QueryRow(query, userId, label.Name, label.Color, label.WipLimit)
+		return row.Scan(&id)
+	})
+	if err != nil {
+		return 0, "", labelExistsOnViolation(err)
+	}
+	return id, token, nil
+}
+
+// labelExistsOnViolation turns the violation of the unique name of the
+// user's labels into ErrLabelExists.
+func labelExistsOnViolation(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+		return ErrLabelExists
+	}
+	return err
+}
+
+func (r *LabelPostgres) GetAll(userId int) ([]structs.Label, error) {
+	var labels []structs.Label
+
+	query := fmt.Sprintf("SELECT l.id, l.name, l.color, l.wip_limit FROM %s l WHERE l.user_id=$1 ORDER BY l.name", labelsTable)
+	err := r.db.Select(&labels, query, userId)
+
+	return labels, err
+}
+
+func (r *LabelPostgres) GetById(userId int, labelId int) (structs.Label, error) {
+	var label structs.Label
+
+	query := fmt.Sprintf("SELECT l.id, l.name, l.color, l.wip_limit FROM %s l WHERE l.user_id=$1 AND l.id=$2", labelsTable)
+	err := r.db.Get(&label, query, userId, labelId)
+
+	return label, err
+}
+
+func (r *LabelPostgres) GetByItemIds(userId int, itemIds []int) ([]structs.ItemLabel, error) {
+	var labels []structs.ItemLabel
+
+	query := fmt.Sprintf(`SELECT il.item_id, l.id, l.name, l.color FROM %s l
+							INNER JOIN %s il on il.label_id=l.id
+							WHERE l.user_id=$1 AND il.item_id = ANY($2)
+							ORDER BY l.name`, labelsTable, itemsLabelsTable)
+	err := r.db.Select(&labels, query, userId, pq.Array(itemIds))
+
+	return labels, err
+}
+
+func (r *LabelPostgres) GetItems(userId int, labelId int) ([]structs.Item, error) {
+	var items []structs.Item
+
+	query := fmt.Sprintf(`SELECT DISTINCT %s FROM %s ti
+							INNER JOIN %s il on il.item_id=ti.id
+							INNER JOIN %s l on l.id=il.label_id
+							INNER JOIN %s li on li.item_id=ti.id
+							INNER JOIN %s ul on ul.list_id=li.list_id
+							WHERE l.id=$1 AND l.user_id=$2 AND ul.user_id=$2 AND %s`,
+		itemColumns, todoItemsTable, itemsLabelsTable, labelsTable, listsItemsTable, usersListsTable,
+		liveItemCondition("ti", "li"))
+	if err := r.db.Select(&items, query, labelId, userId); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+func (r *LabelPostgres) Delete(userId int, labelId int) (string, error) {
+	return journal(r.db, userId, func(tx *sql.Tx) error {
+		query := fmt.Sprintf("DELETE FROM %s l WHERE l.user_id=$1 AND l.id=$2", labelsTable)
+		_, err := tx.Exec(query, userId, labelId)
+		return err
+	})
+}
+
+func (r *LabelPostgres) Update(userId int, labelId int, input structs.UpdateLabelInput) (string, error) {
+	setValues := make([]string, 0)
+	args := make([]interface{}, 0)
+	argId := 1
+
+	if input.Name != nil {
+		setValues = append(setValues, fmt.Sprintf("name=$%d", argId))
+		args = append(args, *input.Name)
+		argId++
+	}
+
+	if input.Color != nil {
+		setValues = append(setValues, fmt.Sprintf("color=$%d", argId))
+		args = append(args, *input.Color)
+		argId++
+	}
+
+	if input.WipLimit != nil {
+		setValues = append(setValues, fmt.Sprintf("wip_limit=NULLIF($%d, 0)", argId))
+		args = append(args, *input.WipLimit)
+		argId++
+	}
+
+	setQuery := strings.Join(setValues, ",")
+	query := fmt.Sprintf(`UPDATE %s l SET %s
+							WHERE l.user_id=$%d
+							AND l.id=$%d`, labelsTable, setQuery, argId, argId+1)
+	args = append(args, userId, labelId)
+
+	token, err := journal(r.db, userId, func(tx *sql.Tx) error {
+		_, err := tx.Exec(query, args...)
+		return err
+	})
+	return token, labelExistsOnViolation(err)
+}
+
+// Attach labels the item and returns the undo token. The labels show with
+// the item, so the item counts as updated for conditional requests.
+func (r *LabelPostgres) Attach(userId int, itemId int, labelId int) (string, error) {
+	return journal(r.db, userId, func(tx *sql.Tx) error {
+		query := fmt.Sprintf(`WITH attached AS (
+									INSERT INTO %s (item_id, label_id) VALUES ($1, $2) ON CONFLICT DO NOTHING
+									RETURNING item_id
+								)
+								UPDATE %s ti SET updated_at=now() FROM attached WHERE ti.id=attached.item_id`,
+			itemsLabelsTable, todoItemsTable)
+		_, err := tx.Exec(query, itemId, labelId)
+		return err
+	})
+}
+
+// Detach takes the label off the item, updating it like Attach.
+func (r *LabelPostgres) Detach(userId int, itemId int, labelId int) (string, error) {
+	return journal(r.db, userId, func(tx *sql.Tx) error {
+		query := fmt.Sprintf(`WITH detached AS (
+									DELETE FROM %s il WHERE il.item_id=$1 AND il.label_id=$2
+									RETURNING il.item_id
+								)
+								UPDATE %s ti SET updated_at=now() FROM detached WHERE ti.id=detached.item_id`,
+			itemsLabelsTable, todoItemsTable)
+		_, err := tx.Exec(query, itemId, labelId)
+		return err
+	})
+}
@@ -0,0 +1,411 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/fr13n8/todo-app/structs"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLabelPostgres_Create(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "smock")
+
+	r := NewLabelPostgres(db)
+
+	type input struct {
+		userId int
+		label  structs.Label
+	}
+
+	type mockBehavior func(input input, id int)
+
+	testTable := []struct {
+		name         string
+		input        input
+		wantErr      bool
+		wantId       int
+		mockBehavior mockBehavior
+	}{
+		{
+			name: "Ok",
+			input: input{
+				userId: 1,
+				label: structs.Label{
+					Name:  "work",
+					Color: "#ff0000",
+				},
+			},
+			wantId: 1,
+			mockBehavior: func(input input, id int) {
+				rows := sqlmock.NewRows([]string{"id"}).AddRow(id)
+				mock.ExpectQuery("INSERT INTO labels").
+					WithArgs(input.userId, input.label.Name, input.label.Color, input.label.WipLimit).
+					WillReturnRows(rows)
+			},
+		},
+		{
+			name: "Duplicate name",
+			input: input{
+				userId: 1,
+				label: structs.Label{
+					Name:  "work",
+					Color: "#ff0000",
+				},
+			},
+			wantErr: true,
+			mockBehavior: func(input input, id int) {
+				mock.ExpectQuery("INSERT INTO labels").
+					WithArgs(input.userId, input.label.Name, input.label.Color, input.label.WipLimit).
+					WillReturnError(&pq.Error{Code: "23505"})
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			mock.ExpectBegin()
+			expectJournal(mock, testCase.input.userId)
+			testCase.mockBehavior(testCase.input, testCase.wantId)
+			if testCase.wantErr {
+				mock.ExpectRollback()
+			} else {
+				mock.ExpectCommit()
+			}
+
+			got, token, err := r.Create(testCase.input.userId, testCase.input.label)
+			if testCase.wantErr {
+				assert.Equal(t, ErrLabelExists, err)
+				assert.Empty(t, token)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testCase.wantId, got)
+				assert.NotEmpty(t, token)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestLabelPostgres_GetByItemIds(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "smock")
+
+	r := NewLabelPostgres(db)
+
+	type input struct {
+		userId  int
+		itemIds []int
+	}
+
+	type mockBehavior func(input input)
+
+	testTable := []struct {
+		name         string
+		input        input
+		mockBehavior mockBehavior
+		want         []structs.ItemLabel
+		wantErr      bool
+	}{
+		{
+			name: "Ok",
+			input: input{
+				userId:  1,
+				itemIds: []int{1, 2},
+			},
+			mockBehavior: func(input input) {
+				rows := sqlmock.NewRows([]string{"item_id", "id", "name", "color"}).
+					AddRow(1, 1, "home", "#00ff00").
+					AddRow(2, 2, "work", "#ff0000")
+
+				mock.ExpectQuery(`SELECT (.+) FROM labels l
+									INNER JOIN items_labels il on (.+)
+									WHERE (.+)`).
+					WithArgs(input.userId, pq.Array(input.itemIds)).
+					WillReturnRows(rows)
+			},
+			want: []structs.ItemLabel{
+				{ItemId: 1, Label: structs.Label{Id: 1, Name: "home", Color: "#00ff00"}},
+				{ItemId: 2, Label: structs.Label{Id: 2, Name: "work", Color: "#ff0000"}},
+			},
+		},
+		{
+			name: "Query error",
+			input: input{
+				userId:  1,
+				itemIds: []int{1},
+			},
+			mockBehavior: func(input input) {
+				mock.ExpectQuery(`SELECT (.+) FROM labels l`).
+					WithArgs(input.userId, pq.Array(input.itemIds)).
+					WillReturnError(errors.New("query error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior(testCase.input)
+
+			got, err := r.GetByItemIds(testCase.input.userId, testCase.input.itemIds)
+			if testCase.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testCase.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestLabelPostgres_GetItems(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "smock")
+
+	r := NewLabelPostgres(db)
+
+	type input struct {
+		userId  int
+		labelId int
+	}
+
+	type mockBehavior func(input input)
+
+	testTable := []struct {
+		name         string
+		input        input
+		mockBehavior mockBehavior
+		want         []structs.Item
+		wantErr      bool
+	}{
+		{
+			name: "Ok",
+			input: input{
+				userId:  1,
+				labelId: 1,
+			},
+			mockBehavior: func(input input) {
+				rows := sqlmock.NewRows([]string{"id", "title", "description", "done"}).
+					AddRow(1, "title", "description", false).
+					AddRow(5, "title5", "description5", true)
+
+				mock.ExpectQuery(`SELECT DISTINCT (.+) FROM todo_items ti
+									INNER JOIN items_labels il on (.+)
+									INNER JOIN labels l on (.+)
+									INNER JOIN lists_items li on (.+)
+									INNER JOIN users_lists ul on (.+)
+									WHERE (.+)`).
+					WithArgs(input.labelId, input.userId).
+					WillReturnRows(rows)
+			},
+			want: []structs.Item{
+				{Id: 1, Title: "title", Description: "description"},
+				{Id: 5, Title: "title5", Description: "description5", Done: true},
+			},
+		},
+		{
+			name: "Query error",
+			input: input{
+				userId:  1,
+				labelId: 1,
+			},
+			mockBehavior: func(input input) {
+				mock.ExpectQuery(`SELECT DISTINCT (.+) FROM todo_items ti`).
+					WithArgs(input.labelId, input.userId).
+					WillReturnError(errors.New("query error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior(testCase.input)
+
+			got, err := r.GetItems(testCase.input.userId, testCase.input.labelId)
+			if testCase.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testCase.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestLabelPostgres_Update(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "smock")
+
+	r := NewLabelPostgres(db)
+
+	type input struct {
+		userId  int
+		labelId int
+		label   structs.UpdateLabelInput
+	}
+
+	type mockBehavior func(input input)
+
+	testTable := []struct {
+		name         string
+		input        input
+		mockBehavior mockBehavior
+		wantErr      bool
+	}{
+		{
+			name: "Ok",
+			input: input{
+				userId:  1,
+				labelId: 2,
+				label: structs.UpdateLabelInput{
+					Name:  stringPointer("work"),
+					Color: stringPointer("#ff0000"),
+				},
+			},
+			mockBehavior: func(input input) {
+				mock.ExpectExec("UPDATE labels l SET (.+) WHERE (.+)").
+					WithArgs(input.label.Name, input.label.Color, input.userId, input.labelId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "Ok_WithoutColor",
+			input: input{
+				userId:  1,
+				labelId: 2,
+				label: structs.UpdateLabelInput{
+					Name: stringPointer("work"),
+				},
+			},
+			mockBehavior: func(input input) {
+				mock.ExpectExec("UPDATE labels l SET (.+) WHERE (.+)").
+					WithArgs(input.label.Name, input.userId, input.labelId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			mock.ExpectBegin()
+			expectJournal(mock, testCase.input.userId)
+			testCase.mockBehavior(testCase.input)
+			if testCase.wantErr {
+				mock.ExpectRollback()
+			} else {
+				mock.ExpectCommit()
+			}
+
+			token, err := r.Update(testCase.input.userId, testCase.input.labelId, testCase.input.label)
+			if testCase.wantErr {
+				assert.Error(t, err)
+				assert.Empty(t, token)
+			} else {
+				assert.NoError(t, err)
+				assert.NotEmpty(t, token)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestLabelPostgres_Attach(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "smock")
+
+	r := NewLabelPostgres(db)
+
+	type input struct {
+		userId  int
+		itemId  int
+		labelId int
+	}
+
+	type mockBehavior func(input input)
+
+	testTable := []struct {
+		name         string
+		input        input
+		mockBehavior mockBehavior
+		wantErr      bool
+	}{
+		{
+			name: "Ok",
+			input: input{
+				userId:  3,
+				itemId:  1,
+				labelId: 2,
+			},
+			mockBehavior: func(input input) {
+				mock.ExpectBegin()
+				expectJournal(mock, input.userId)
+				mock.ExpectExec(`WITH attached AS \(\s*INSERT INTO items_labels \(item_id, label_id\) VALUES (.+) ON CONFLICT DO NOTHING`+
+					`\s*RETURNING item_id\s*\)\s*UPDATE todo_items ti SET updated_at=now\(\) FROM attached WHERE ti.id=attached.item_id`).
+					WithArgs(input.itemId, input.labelId).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Insert error",
+			input: input{
+				userId:  3,
+				itemId:  1,
+				labelId: 2,
+			},
+			mockBehavior: func(input input) {
+				mock.ExpectBegin()
+				expectJournal(mock, input.userId)
+				mock.ExpectExec("INSERT INTO items_labels").
+					WithArgs(input.itemId, input.labelId).
+					WillReturnError(errors.New("insert error"))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior(testCase.input)
+
+			token, err := r.Attach(testCase.input.userId, testCase.input.itemId, testCase.input.labelId)
+			if testCase.wantErr {
+				assert.Error(t, err)
+				assert.Empty(t, token)
+			} else {
+				assert.NoError(t, err)
+				assert.NotEmpty(t, token)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
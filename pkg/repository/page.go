@@ -0,0 +1,217 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fr13n8/todo-app/structs"
+)
+
+var (
+	ErrInvalidSort   = errors.New("invalid sort key")
+	ErrInvalidCursor = errors.New("invalid cursor")
+)
+
+// sortColumn is a column a listing can be sorted by. Rows where a nullable
+// column isn't set come last whichever way it's sorted.
+type sortColumn struct {
+	Expr     string
+	Nullable bool
+}
+
+var itemSortColumns = map[string]sortColumn{
+	"id":           {Expr: "ti.id"},
+	"title":        {Expr: "ti.title"},
+	"created_at":   {Expr: "ti.created_at"},
+	"updated_at":   {Expr: "ti.updated_at"},
+	"completed_at": {Expr: "ti.completed_at", Nullable: true},
+	"due_date":     {Expr: "ti.due_date", Nullable: true},
+	"priority":     {Expr: "ti.priority"},
+}
+
+var listSortColumns = map[string]sortColumn{
+	"id":           {Expr: "tl.id"},
+	"title":        {Expr: "tl.title"},
+	"created_at":   {Expr: "tl.created_at"},
+	"updated_at":   {Expr: "tl.updated_at"},
+	"completed_at": {Expr: listCompletedAtQuery, Nullable: true},
+}
+
+type sortKey struct {
+	Name string
+	Desc bool
+	sortColumn
+}
+
+// keyset orders a listing by its sort keys, the last of which is always the
+// id, and pages through it by the values of the keys on the last row of the
+// previous page. Rows added or removed before the cursor don't shift later
+// pages, unlike with an offset.
+type keyset []sortKey
+
+// newKeyset parses a sort of comma separated keys, each of them descending
+// when prefixed with -. order is the direction of the keys without a prefix
+// and of the id breaking ties.
+func newKeyset(sort string, order string, columns map[string]sortColumn) (keyset, error) {
+	desc := strings.EqualFold(order, "desc")
+
+	var keys keyset
+	seen := make(map[string]bool)
+	if sort != "" {
+		for _, field := range strings.Split(sort, ",") {
+			key := sortKey{Name: strings.TrimSpace(field), Desc: desc}
+			if strings.HasPrefix(key.Name, "-") {
+				key.Name, key.Desc = key.Name[1:], true
+			}
+			column, ok := columns[key.Name]
+			if !ok || seen[key.Name] {
+				return nil, fmt.Errorf("%w %q", ErrInvalidSort, field)
+			}
+			seen[key.Name] = true
+			key.sortColumn = column
+			keys = append(keys, key)
+		}
+	}
+	if !seen["id"] {
+		keys = append(keys, sortKey{Name: "id", Desc: desc, sortColumn: columns["id"]})
+	}
+	return keys, nil
+}
+
+func (k keyset) orderBy() string {
+	terms := make([]string, len(k))
+	for i, key := range k {
+		direction := "ASC"
+		if key.Desc {
+			direction = "DESC"
+		}
+		terms[i] = key.Expr + " " + direction
+		if key.Name != "id" {
+			terms[i] += " NULLS LAST"
+		}
+	}
+	return "ORDER BY " + strings.Join(terms, ", ")
+}
+
+func (k keyset) String() string {
+	names := make([]string, len(k))
+	for i, key := range k {
+		names[i] = key.Name
+		if key.Desc {
+			names[i] = "-" + key.Name
+		}
+	}
+	return strings.Join(names, ",")
+}
+
+type cursor struct {
+	Sort   string        `json:"s"`
+	Values []interface{} `json:"v"`
+}
+
+// encodeCursor makes the cursor pointing after a row, given the values of
+// its sort keys.
+func (k keyset) encodeCursor(values []interface{}) string {
+	data, _ := json.Marshal(cursor{Sort: k.String(), Values: values})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// after appends the condition keeping the rows that come after the cursor,
+// numbering its parameters after the ones already in args. A cursor made
+// for another sort is refused, as its values would mean something else.
+func (k keyset) after(encoded string, conditions []string, args []interface{}) ([]string, []interface{}, error) {
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, nil, ErrInvalidCursor
+	}
+	var c cursor
+	if err := json.Unmarshal(data, &c); err != nil || c.Sort != k.String() || len(c.Values) != len(k) {
+		return nil, nil, ErrInvalidCursor
+	}
+
+	// A nullable key compares whether it's set before comparing its value,
+	// so the rows without one stay last.
+	var exprs, ops []string
+	var values []interface{}
+	for i, key := range k {
+		op := ">"
+		if key.Desc {
+			op = "<"
+		}
+		if key.Nullable {
+			exprs = append(exprs, fmt.Sprintf("(%s IS NULL)", key.Expr))
+			ops = append(ops, ">")
+			values = append(values, c.Values[i] == nil)
+		}
+		exprs = append(exprs, key.Expr)
+		ops = append(ops, op)
+		values = append(values, c.Values[i])
+	}
+
+	alternatives := make([]string, len(exprs))
+	for i := range exprs {
+		terms := make([]string, 0, i+1)
+		for j := 0; j < i; j++ {
+			args = append(args, values[j])
+			terms = append(terms, fmt.Sprintf("%s IS NOT DISTINCT FROM $%d", exprs[j], len(args)))
+		}
+		args = append(args, values[i])
+		terms = append(terms, fmt.Sprintf("%s%s$%d", exprs[i], ops[i], len(args)))
+		alternatives[i] = "(" + strings.Join(terms, " AND ") + ")"
+	}
+	return append(conditions, "("+strings.Join(alternatives, " OR ")+")"), args, nil
+}
+
+// timeValue keeps a time in a cursor without losing precision.
+func timeValue(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return t.Format(time.RFC3339Nano)
+}
+
+func itemSortValues(k keyset, item structs.Item) []interface{} {
+	values := make([]interface{}, len(k))
+	for i, key := range k {
+		switch key.Name {
+		case "id":
+			values[i] = item.Id
+		case "title":
+			values[i] = item.Title
+		case "created_at":
+			values[i] = timeValue(item.CreatedAt)
+		case "updated_at":
+			values[i] = timeValue(item.UpdatedAt)
+		case "completed_at":
+			values[i] = timeValue(item.CompletedAt)
+		case "due_date":
+			values[i] = timeValue(item.DueDate)
+		case "priority":
+			values[i] = int(item.Priority)
+		}
+	}
+	return values
+}
+
+func listSortValues(k keyset, list structs.List) []interface{} {
+	values := make([]interface{}, len(k))
+	for i, key := range k {
+		switch key.Name {
+		case "id":
+			values[i] = list.Id
+		case "title":
+			values[i] = list.Title
+		case "created_at":
+			values[i] = timeValue(list.CreatedAt)
+		case "updated_at":
+			values[i] = timeValue(list.UpdatedAt)
+		case "completed_at":
+			values[i] = timeValue(list.CompletedAt)
+		}
+	}
+	return values
+}
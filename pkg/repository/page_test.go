@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewKeyset(t *testing.T) {
+	testTable := []struct {
+		name        string
+		sort        string
+		order       string
+		wantOrderBy string
+		wantString  string
+		wantErr     bool
+	}{
+		{
+			name:        "Default",
+			wantOrderBy: "ORDER BY ti.id ASC",
+			wantString:  "id",
+		},
+		{
+			name:        "Order applies to keys without a prefix",
+			sort:        "completed_at, -title",
+			order:       "desc",
+			wantOrderBy: "ORDER BY ti.completed_at DESC NULLS LAST, ti.title DESC NULLS LAST, ti.id DESC",
+			wantString:  "-completed_at,-title,-id",
+		},
+		{
+			name:        "Id breaks ties where it's sorted",
+			sort:        "-id,due_date",
+			wantOrderBy: "ORDER BY ti.id DESC, ti.due_date ASC NULLS LAST",
+			wantString:  "-id,due_date",
+		},
+		{
+			name:    "Unknown key",
+			sort:    "created_at,color",
+			wantErr: true,
+		},
+		{
+			name:    "Repeated key",
+			sort:    "title,-title",
+			wantErr: true,
+		},
+		{
+			name:    "Empty key",
+			sort:    "title,",
+			wantErr: true,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			keys, err := newKeyset(testCase.sort, testCase.order, itemSortColumns)
+			if testCase.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidSort)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.wantOrderBy, keys.orderBy())
+			assert.Equal(t, testCase.wantString, keys.String())
+		})
+	}
+}
+
+func TestKeysetAfter(t *testing.T) {
+	keys, _ := newKeyset("title", "", itemSortColumns)
+	cursor := keys.encodeCursor([]interface{}{"b", 2})
+
+	conditions, args, err := keys.after(cursor, []string{"ti.done"}, []interface{}{1})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ti.done", "((ti.title>$2) OR (ti.title IS NOT DISTINCT FROM $3 AND ti.id>$4))"}, conditions)
+	assert.Equal(t, []interface{}{1, "b", "b", float64(2)}, args)
+
+	other, _ := newKeyset("-title", "", itemSortColumns)
+	_, _, err = other.after(cursor, nil, nil)
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+
+	_, _, err = keys.after("%%%", nil, nil)
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+}
@@ -7,12 +7,28 @@ import (
 )
 
 const (
-	usersTable         = "users"
-	todoListsTable     = "todo_lists"
-	usersListsTable    = "users_lists"
-	todoItemsTable     = "todo_items"
-	listsItemsTable    = "lists_items"
-	usersSessionsTable = "users_sessions"
+	usersTable               = "users"
+	todoListsTable           = "todo_lists"
+	usersListsTable          = "users_lists"
+	todoItemsTable           = "todo_items"
+	listsItemsTable          = "lists_items"
+	usersSessionsTable       = "users_sessions"
+	labelsTable              = "labels"
+	itemsLabelsTable         = "items_labels"
+	itemsCompletionsTable    = "items_completions"
+	itemsDependenciesTable   = "items_dependencies"
+	attachmentsTable         = "attachments"
+	timeEntriesTable         = "time_entries"
+	statusesTable            = "statuses"
+	statusesTransitionsTable = "statuses_transitions"
+	itemsRevisionsTable      = "items_revisions"
+	templatesTable           = "templates"
+	templateItemsTable       = "template_items"
+	templateItemsLabelsTable = "template_items_labels"
+	viewsTable               = "views"
+	idempotencyKeysTable     = "idempotency_keys"
+	undoActionsTable         = "undo_actions"
+	undoChangesTable         = "undo_changes"
 )
 
 type Config struct {
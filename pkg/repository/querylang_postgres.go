@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fr13n8/todo-app/pkg/querylang"
+	"github.com/fr13n8/todo-app/structs"
+)
+
+var itemDateColumns = map[string]string{
+	"due":       "ti.due_date",
+	"created":   "ti.created_at",
+	"updated":   "ti.updated_at",
+	"completed": "ti.completed_at",
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// itemQueryCompiler turns a filter expression into a condition on the item
+// aliased ti. Values only ever reach the query as parameters, numbered after
+// the ones already in args; userArg is the parameter holding the user id.
+type itemQueryCompiler struct {
+	userArg int
+	args    []interface{}
+}
+
+func compileItemQuery(node querylang.Node, userArg int, args []interface{}) (string, []interface{}) {
+	c := &itemQueryCompiler{userArg: userArg, args: args}
+	return c.compile(node), c.args
+}
+
+func (c *itemQueryCompiler) arg(value interface{}) string {
+	c.args = append(c.args, value)
+	return fmt.Sprintf("$%d", len(c.args))
+}
+
+func (c *itemQueryCompiler) compile(node querylang.Node) string {
+	switch n := node.(type) {
+	case querylang.And:
+		return c.join(n.Nodes, " AND ")
+	case querylang.Or:
+		return c.join(n.Nodes, " OR ")
+	case querylang.Not:
+		// IS NOT TRUE keeps the items the condition is unknown for, so
+		// -due<2021-07-01 includes items without a due date.
+		return fmt.Sprintf("(%s) IS NOT TRUE", c.compile(n.Node))
+	case querylang.Term:
+		return c.term(n)
+	}
+	panic(fmt.Sprintf("unexpected query node %T", node))
+}
+
+func (c *itemQueryCompiler) join(nodes []querylang.Node, separator string) string {
+	conditions := make([]string, len(nodes))
+	for i, node := range nodes {
+		conditions[i] = c.compile(node)
+	}
+	return "(" + strings.Join(conditions, separator) + ")"
+}
+
+func (c *itemQueryCompiler) term(t querylang.Term) string {
+	var condition string
+	switch t.Field {
+	case "":
+		pattern := c.arg("%" + likeEscaper.Replace(t.Text) + "%")
+		return fmt.Sprintf("(ti.title ILIKE %s OR ti.description ILIKE %s)", pattern, pattern)
+	case "title":
+		if t.Op == querylang.OpMatch {
+			return fmt.Sprintf("ti.title ILIKE %s", c.arg("%"+likeEscaper.Replace(t.Text)+"%"))
+		}
+		condition = fmt.Sprintf("lower(ti.title)=lower(%s)", c.arg(t.Text))
+	case "done":
+		condition = fmt.Sprintf("ti.done=%s", c.arg(t.Bool))
+	case "label":
+		condition = fmt.Sprintf(`EXISTS (SELECT 1 FROM %s il INNER JOIN %s l on l.id=il.label_id
+							WHERE il.item_id=ti.id AND l.user_id=$%d AND lower(l.name)=lower(%s))`,
+			itemsLabelsTable, labelsTable, c.userArg, c.arg(t.Text))
+	case "status":
+		condition = fmt.Sprintf("EXISTS (SELECT 1 FROM %s s WHERE s.id=ti.status_id AND lower(s.name)=lower(%s))",
+			statusesTable, c.arg(t.Text))
+	case "priority":
+		op := t.Op
+		if op == querylang.OpMatch {
+			op = querylang.OpEq
+		}
+		return fmt.Sprintf("ti.priority%s%s", op, c.arg(structs.Priority(t.Number)))
+	case "assignee":
+		switch {
+		case t.None && t.Op == querylang.OpNe:
+			return "ti.assignee_id IS NOT NULL"
+		case t.None:
+			return "ti.assignee_id IS NULL"
+		case t.Me:
+			condition = fmt.Sprintf("ti.assignee_id=$%d", c.userArg)
+		default:
+			condition = fmt.Sprintf("ti.assignee_id=%s", c.arg(t.Number))
+		}
+	default:
+		return c.date(itemDateColumns[t.Field], t)
+	}
+
+	if t.Op == querylang.OpNe {
+		return fmt.Sprintf("(%s) IS NOT TRUE", condition)
+	}
+	return condition
+}
+
+// date compares a date column. A day stands for the range from its start
+// to the start of the next day.
+func (c *itemQueryCompiler) date(column string, t querylang.Term) string {
+	if t.None {
+		if t.Op == querylang.OpNe {
+			return column + " IS NOT NULL"
+		}
+		return column + " IS NULL"
+	}
+
+	if !t.Day {
+		switch t.Op {
+		case querylang.OpMatch, querylang.OpEq:
+			return fmt.Sprintf("%s=%s", column, c.arg(t.Time))
+		case querylang.OpNe:
+			return fmt.Sprintf("%s IS DISTINCT FROM %s", column, c.arg(t.Time))
+		}
+		return fmt.Sprintf("%s%s%s", column, t.Op, c.arg(t.Time))
+	}
+
+	next := t.Time.Add(24 * time.Hour)
+	switch t.Op {
+	case querylang.OpLt:
+		return fmt.Sprintf("%s<%s", column, c.arg(t.Time))
+	case querylang.OpLe:
+		return fmt.Sprintf("%s<%s", column, c.arg(next))
+	case querylang.OpGt:
+		return fmt.Sprintf("%s>=%s", column, c.arg(next))
+	case querylang.OpGe:
+		return fmt.Sprintf("%s>=%s", column, c.arg(t.Time))
+	}
+	condition := fmt.Sprintf("(%s>=%s AND %s<%s)", column, c.arg(t.Time), column, c.arg(next))
+	if t.Op == querylang.OpNe {
+		return fmt.Sprintf("%s IS NOT TRUE", condition)
+	}
+	return condition
+}
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fr13n8/todo-app/pkg/querylang"
+	"github.com/fr13n8/todo-app/structs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompileItemQuery(t *testing.T) {
+	day := time.Date(2021, 7, 1, 0, 0, 0, 0, time.UTC)
+	next := day.Add(24 * time.Hour)
+
+	testTable := []struct {
+		name          string
+		input         string
+		wantCondition string
+		wantArgs      []interface{}
+	}{
+		{
+			name:  "Terms",
+			input: `done:false due<2021-07-01 label:work -label:someday "50% off"`,
+			wantCondition: `(ti.done=$3 AND ti.due_date<$4 AND EXISTS (SELECT 1 FROM items_labels il INNER JOIN labels l on l.id=il.label_id
+							WHERE il.item_id=ti.id AND l.user_id=$2 AND lower(l.name)=lower($5)) AND ` +
+				`(EXISTS (SELECT 1 FROM items_labels il INNER JOIN labels l on l.id=il.label_id
+							WHERE il.item_id=ti.id AND l.user_id=$2 AND lower(l.name)=lower($6))) IS NOT TRUE AND ` +
+				`(ti.title ILIKE $7 OR ti.description ILIKE $7))`,
+			wantArgs: []interface{}{1, 2, false, day, "work", "someday", `%50\% off%`},
+		},
+		{
+			name:          "Days",
+			input:         "due:2021-07-01 OR due<=2021-07-01 OR due>2021-07-01 OR due!=2021-07-01",
+			wantCondition: `((ti.due_date>=$3 AND ti.due_date<$4) OR ti.due_date<$5 OR ti.due_date>=$6 OR (ti.due_date>=$7 AND ti.due_date<$8) IS NOT TRUE)`,
+			wantArgs:      []interface{}{1, 2, day, next, next, next, day, next},
+		},
+		{
+			name:          "Times and none",
+			input:         "created>2021-07-01T00:00:00Z updated!=2021-07-01T00:00:00Z completed:none -due:none",
+			wantCondition: `(ti.created_at>$3 AND ti.updated_at IS DISTINCT FROM $4 AND ti.completed_at IS NULL AND (ti.due_date IS NULL) IS NOT TRUE)`,
+			wantArgs:      []interface{}{1, 2, day, day},
+		},
+		{
+			name:          "Titles and statuses",
+			input:         `title:a_b title!=Plan status:"in review"`,
+			wantCondition: `(ti.title ILIKE $3 AND (lower(ti.title)=lower($4)) IS NOT TRUE AND EXISTS (SELECT 1 FROM statuses s WHERE s.id=ti.status_id AND lower(s.name)=lower($5)))`,
+			wantArgs:      []interface{}{1, 2, `%a\_b%`, "Plan", "in review"},
+		},
+		{
+			name:          "Priorities",
+			input:         "priority>=high OR priority:none OR priority!=low",
+			wantCondition: `(ti.priority>=$3 OR ti.priority=$4 OR ti.priority!=$5)`,
+			wantArgs:      []interface{}{1, 2, structs.PriorityHigh, structs.PriorityNone, structs.PriorityLow},
+		},
+		{
+			name:          "Assignees",
+			input:         "assignee:me OR assignee=7 OR assignee!=me OR assignee:none OR assignee!=none",
+			wantCondition: `(ti.assignee_id=$2 OR ti.assignee_id=$3 OR (ti.assignee_id=$2) IS NOT TRUE OR ti.assignee_id IS NULL OR ti.assignee_id IS NOT NULL)`,
+			wantArgs:      []interface{}{1, 2, 7},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			node, err := querylang.Parse(testCase.input)
+			assert.NoError(t, err)
+
+			condition, args := compileItemQuery(node, 2, []interface{}{1, 2})
+			assert.Equal(t, testCase.wantCondition, condition)
+			assert.Equal(t, testCase.wantArgs, args)
+		})
+	}
+}
@@ -1,6 +1,9 @@
 package repository
 
 import (
+	"time"
+
+	"github.com/fr13n8/todo-app/pkg/querylang"
 	"github.com/fr13n8/todo-app/structs"
 	"github.com/jmoiron/sqlx"
 )
@@ -12,25 +15,151 @@ type Authorization interface {
 }
 
 type TodoList interface {
-	Create(userId int, list structs.List) (int, error)
-	GetAll(userId int) ([]structs.List, error)
+	Create(userId int, list structs.List) (int, string, error)
+	GetAll(userId int, filter structs.ListFilter) ([]structs.List, structs.PageInfo, error)
 	GetById(listId int, userId int) (structs.List, error)
-	Delete(listId int, userId int) error
-	Update(listId int, userId int, input structs.UpdateListInput) error
+	Stamp(userId int) (structs.CollectionStamp, error)
+	Delete(listId int, userId int, version *int) (string, error)
+	Update(listId int, userId int, input structs.UpdateListInput) (string, error)
+	SetArchived(listId int, userId int, archived bool) (string, error)
+	Duplicate(userId int, listId int, input structs.DuplicateListInput, storageKeys map[string]string, quota int64) (int, string, error)
+	Batch(userId int, changes []structs.ListChange, atomic bool) ([]structs.BatchResult, string, error)
 }
 
 type TodoItem interface {
-	Create(listId int, input structs.Item) (int, error)
-	GetAll(listId int, userId int) ([]structs.Item, error)
+	Create(listId int, userId int, input structs.Item) (int, string, error)
+	GetAll(listId int, userId int, filter structs.ItemFilter) ([]structs.Item, structs.PageInfo, error)
 	GetById(userId int, itemId int) (structs.Item, error)
-	Delete(userId int, itemId int) error
-	Update(userId int, itemId int, input structs.UpdateItemInput) error
+	Stamp(listId int, userId int) (structs.CollectionStamp, error)
+	Delete(userId int, itemId int, version *int) (string, error)
+	Update(userId int, itemId int, input structs.UpdateItemInput) (string, error)
+	Complete(userId int, change structs.ItemChange) (string, error)
+	GetCompletions(userId int, itemId int) ([]structs.ItemCompletion, error)
+	Move(userId int, itemId int, listId int) (string, error)
+	Copy(userId int, itemId int, input structs.CopyItemInput) (int, string, error)
+	ArchiveCompleted() (int64, error)
+	Batch(userId int, changes []structs.ItemChange, atomic bool) ([]structs.BatchResult, string, error)
+}
+
+type Label interface {
+	Create(userId int, label structs.Label) (int, string, error)
+	GetAll(userId int) ([]structs.Label, error)
+	GetById(userId int, labelId int) (structs.Label, error)
+	GetByItemIds(userId int, itemIds []int) ([]structs.ItemLabel, error)
+	GetItems(userId int, labelId int) ([]structs.Item, error)
+	Delete(userId int, labelId int) (string, error)
+	Update(userId int, labelId int, input structs.UpdateLabelInput) (string, error)
+	Attach(userId int, itemId int, labelId int) (string, error)
+	Detach(userId int, itemId int, labelId int) (string, error)
+}
+
+type Dependency interface {
+	Create(userId int, itemId int, blockerId int) (string, error)
+	GetBlockers(userId int, itemId int) ([]structs.Item, error)
+	Delete(userId int, itemId int, blockerId int) (string, error)
+}
+
+type Attachment interface {
+	Create(userId int, attachment structs.Attachment, quota int64) (int, string, error)
+	GetUsage(userId int) (int64, error)
+	GetAll(itemId int) ([]structs.Attachment, error)
+	GetAllByList(listId int) ([]structs.Attachment, error)
+	GetById(itemId int, attachmentId int) (structs.Attachment, error)
+	Delete(userId int, itemId int, attachmentId int) (string, error)
+}
+
+type TimeEntry interface {
+	Start(userId int, itemId int) (int, string, error)
+	Stop(userId int, itemId int) (string, error)
+	Create(userId int, entry structs.TimeEntry) (int, string, error)
+	GetAll(itemId int) ([]structs.TimeEntry, error)
+	Delete(userId int, itemId int, entryId int) (string, error)
+	GetReport(userId int, filter structs.TimeReportFilter) ([]structs.TimeReportRow, error)
+}
+
+type Status interface {
+	Create(userId int, listId int, input structs.StatusInput) (int, string, error)
+	GetAll(listId int) ([]structs.Status, error)
+	GetById(userId int, statusId int) (structs.Status, error)
+	GetByItemId(itemId int) ([]structs.Status, error)
+	Update(userId int, statusId int, input structs.UpdateStatusInput) (string, error)
+	Delete(userId int, statusId int) (string, error)
+}
+
+type Board interface {
+	GetLabelPositions(userId int, listId int) ([]structs.BoardPosition, error)
+	MoveToStatus(userId int, listId int, itemId int, status structs.Status, position int,
+		completion *structs.ItemChange) (string, error)
+	MoveToLabel(userId int, listId int, itemId int, fromLabelId *int, label structs.Label, position int) (string, error)
+}
+
+type Trash interface {
+	GetAll(userId int) ([]structs.TrashEntry, error)
+	RestoreList(userId int, listId int) (string, error)
+	RestoreItem(userId int, itemId int) (string, error)
+	PurgeList(userId int, listId int) (string, error)
+	PurgeItem(userId int, itemId int) (string, error)
+	Empty(userId int) (structs.TrashPurge, string, error)
+	PurgeOlderThan(before time.Time) (structs.TrashPurge, error)
+}
+
+type Revision interface {
+	GetAll(itemId int) ([]structs.ItemRevision, error)
+	GetByNumber(itemId int, revision int) (structs.ItemRevision, error)
+	Revert(userId int, itemId int, revision int) (string, error)
+}
+
+type Template interface {
+	Create(userId int, input structs.TemplateInput) (int, string, error)
+	GetAll(userId int, scope string) ([]structs.Template, error)
+	GetById(userId int, templateId int) (structs.Template, error)
+	Delete(userId int, templateId int) (string, error)
+	Instantiate(userId int, templateId int, title string, start time.Time) (int, string, error)
+}
+
+type View interface {
+	Create(userId int, input structs.ViewInput) (int, string, error)
+	GetAll(userId int) ([]structs.View, error)
+	GetById(userId int, viewId int) (structs.View, error)
+	Update(userId int, viewId int, input structs.UpdateViewInput) (string, error)
+	Delete(userId int, viewId int) (string, error)
+	GetItems(userId int, query structs.ViewQuery, expr querylang.Node) ([]structs.Item, error)
+}
+
+type Search interface {
+	SearchLists(userId int, query string, limit int, offset int) (structs.SearchGroup, error)
+	SearchItems(userId int, query string, limit int, offset int) (structs.SearchGroup, error)
+}
+
+type Idempotency interface {
+	Claim(userId int, key string, fingerprint string, expired time.Time, stale time.Time) (structs.IdempotencyKey, bool, error)
+	Store(userId int, key string, response structs.IdempotentResponse) error
+	Release(userId int, key string) error
+	DeleteOlderThan(before time.Time) (int64, error)
+}
+
+type Undo interface {
+	Undo(userId int, token string, since time.Time) (string, error)
+	DeleteOlderThan(before time.Time) (int64, []string, error)
 }
 
 type Repository struct {
 	Authorization
 	TodoList
 	TodoItem
+	Label
+	Dependency
+	Attachment
+	TimeEntry
+	Status
+	Board
+	Trash
+	Revision
+	Template
+	View
+	Search
+	Idempotency
+	Undo
 }
 
 func NewRepository(db *sqlx.DB) *Repository {
@@ -38,5 +167,18 @@ func NewRepository(db *sqlx.DB) *Repository {
 		Authorization: NewAuthPostgres(db),
 		TodoList:      NewTodoListPostgres(db),
 		TodoItem:      NewTodoItemPostgres(db),
+		Label:         NewLabelPostgres(db),
+		Dependency:    NewDependencyPostgres(db),
+		Attachment:    NewAttachmentPostgres(db),
+		TimeEntry:     NewTimeEntryPostgres(db),
+		Status:        NewStatusPostgres(db),
+		Board:         NewBoardPostgres(db),
+		Trash:         NewTrashPostgres(db),
+		Revision:      NewRevisionPostgres(db),
+		Template:      NewTemplatePostgres(db),
+		View:          NewViewPostgres(db),
+		Search:        NewSearchPostgres(db),
+		Idempotency:   NewIdempotencyPostgres(db),
+		Undo:          NewUndoPostgres(db),
 	}
 }
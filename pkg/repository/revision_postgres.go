@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/fr13n8/todo-app/structs"
+	"github.com/jmoiron/sqlx"
+)
+
+const revisionColumns = `ir.item_id, ir.revision, ir.title, COALESCE(ir.description, '') AS description, ir.done,
+							ir.status_id, ir.due_date, ir.recurrence, ir.recurrence_start, ir.priority, ir.assignee_id,
+							ir.created_by, ir.created_at`
+
+// Revisions are written by the todo_items_revise trigger whenever an item's
+// content changes, so there is nothing to create here.
+type RevisionPostgres struct {
+	db *sqlx.DB
+}
+
+func NewRevisionPostgres(db *sqlx.DB) *RevisionPostgres {
+	return &RevisionPostgres{db: db}
+}
+
+func (r *RevisionPostgres) GetAll(itemId int) ([]structs.ItemRevision, error) {
+	var revisions []structs.ItemRevision
+
+	query := fmt.Sprintf(`SELECT %s FROM %s ir WHERE ir.item_id=$1 ORDER BY ir.revision`,
+		revisionColumns, itemsRevisionsTable)
+	if err := r.db.Select(&revisions, query, itemId); err != nil {
+		return nil, err
+	}
+
+	return revisions, nil
+}
+
+func (r *RevisionPostgres) GetByNumber(itemId int, revision int) (structs.ItemRevision, error) {
+	var rev structs.ItemRevision
+
+	query := fmt.Sprintf(`SELECT %s FROM %s ir WHERE ir.item_id=$1 AND ir.revision=$2`,
+		revisionColumns, itemsRevisionsTable)
+	err := r.db.Get(&rev, query, itemId, revision)
+
+	return rev, err
+}
+
+// Revert puts the content of an earlier revision back into the item, which
+// the trigger records as a new revision. The item keeps the revision's
+// status if it is still in the item's list, otherwise it takes the first
+// status of the same done category. The assignee is dropped if they lost
+// access to the list since.
+func (r *RevisionPostgres) Revert(userId int, itemId int, revision int) (string, error) {
+	query := fmt.Sprintf(`UPDATE %s ti SET title=ir.title, description=ir.description, done=ir.done,
+							status_id=(SELECT s.id FROM %s s WHERE s.list_id=li.list_id AND s.is_done=ir.done
+								ORDER BY (s.id=ir.status_id) IS TRUE DESC, s.position, s.id LIMIT 1),
+							due_date=ir.due_date, recurrence=ir.recurrence, recurrence_start=ir.recurrence_start,
+							priority=ir.priority, assignee_id=(SELECT au.user_id FROM %s au
+								WHERE au.list_id=li.list_id AND au.user_id=ir.assignee_id),
+							updated_by=$1
+							FROM %s ir, %s li, %s ul
+							WHERE ir.item_id=ti.id AND ir.revision=$3
+							AND li.item_id=ti.id AND ul.list_id=li.list_id
+							AND ul.user_id=$1 AND ti.id=$2 AND %s`,
+		todoItemsTable, statusesTable, usersListsTable, itemsRevisionsTable, listsItemsTable, usersListsTable,
+		liveItemCondition("ti", "li"))
+	return journal(r.db, userId, func(tx *sql.Tx) error {
+		result, err := tx.Exec(query, userId, itemId, revision)
+		if err != nil {
+			return err
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return errors.New("record not found")
+		}
+		return nil
+	})
+}
@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/fr13n8/todo-app/structs"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRevisionPostgres_GetAll(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "smock")
+
+	r := NewRevisionPostgres(db)
+
+	createdAt := time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	testTable := []struct {
+		name         string
+		mockBehavior func()
+		want         []structs.ItemRevision
+		wantErr      bool
+	}{
+		{
+			name: "Ok",
+			mockBehavior: func() {
+				rows := sqlmock.NewRows([]string{"item_id", "revision", "title", "description", "done", "status_id", "created_by", "created_at"}).
+					AddRow(2, 1, "title", "", false, nil, 1, createdAt).
+					AddRow(2, 2, "title", "description", true, 3, 1, createdAt)
+
+				mock.ExpectQuery(`SELECT (.+) FROM items_revisions ir WHERE ir.item_id=\$1 ORDER BY ir.revision`).
+					WithArgs(2).
+					WillReturnRows(rows)
+			},
+			want: []structs.ItemRevision{
+				{ItemId: 2, Revision: 1, Title: "title", CreatedBy: intPointer(1), CreatedAt: createdAt},
+				{ItemId: 2, Revision: 2, Title: "title", Description: "description", Done: true, StatusId: intPointer(3),
+					CreatedBy: intPointer(1), CreatedAt: createdAt},
+			},
+		},
+		{
+			name: "Failed",
+			mockBehavior: func() {
+				mock.ExpectQuery(`SELECT (.+) FROM items_revisions ir`).
+					WithArgs(2).
+					WillReturnError(errors.New("some error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior()
+
+			got, err := r.GetAll(2)
+			if testCase.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testCase.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRevisionPostgres_Revert(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "smock")
+
+	r := NewRevisionPostgres(db)
+
+	testTable := []struct {
+		name         string
+		mockBehavior func()
+		wantErr      bool
+	}{
+		{
+			name: "Ok",
+			mockBehavior: func() {
+				mock.ExpectExec(`UPDATE todo_items ti SET title=ir.title, description=ir.description, done=ir.done,
+									status_id=\(SELECT s.id FROM statuses s WHERE s.list_id=li.list_id AND s.is_done=ir.done (.+)\),
+									due_date=ir.due_date, recurrence=ir.recurrence, recurrence_start=ir.recurrence_start,
+									priority=ir.priority, assignee_id=\(SELECT au.user_id FROM users_lists au
+										WHERE au.list_id=li.list_id AND au.user_id=ir.assignee_id\),
+									updated_by=\$1
+									FROM items_revisions ir, lists_items li, users_lists ul
+									WHERE ir.item_id=ti.id AND ir.revision=\$3 (.+) AND ul.user_id=\$1 AND ti.id=\$2 AND (.+)`).
+					WithArgs(1, 2, 3).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "Not found",
+			mockBehavior: func() {
+				mock.ExpectExec(`UPDATE todo_items ti SET (.+) FROM items_revisions ir`).
+					WithArgs(1, 2, 3).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: true,
+		},
+		{
+			name: "Failed",
+			mockBehavior: func() {
+				mock.ExpectExec(`UPDATE todo_items ti SET (.+) FROM items_revisions ir`).
+					WithArgs(1, 2, 3).
+					WillReturnError(errors.New("some error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			mock.ExpectBegin()
+			expectJournal(mock, 1)
+			testCase.mockBehavior()
+			if testCase.wantErr {
+				mock.ExpectRollback()
+			} else {
+				mock.ExpectCommit()
+			}
+
+			token, err := r.Revert(1, 2, 3)
+			if testCase.wantErr {
+				assert.Error(t, err)
+				assert.Empty(t, token)
+			} else {
+				assert.NoError(t, err)
+				assert.NotEmpty(t, token)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
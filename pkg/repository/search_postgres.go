@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/fr13n8/todo-app/structs"
+	"github.com/jmoiron/sqlx"
+)
+
+// Matches come back between these control characters, which can't be
+// mistaken for markup, and are turned into <mark> tags once the snippet is
+// escaped.
+const (
+	snippetStart = "\x02"
+	snippetStop  = "\x03"
+)
+
+var snippetOptions = fmt.Sprintf("StartSel=%s, StopSel=%s, MinWords=15, MaxWords=35, MaxFragments=2, FragmentDelimiter=\" … \"",
+	snippetStart, snippetStop)
+
+var snippetReplacer = strings.NewReplacer(snippetStart, "<mark>", snippetStop, "</mark>")
+
+type SearchPostgres struct {
+	db *sqlx.DB
+}
+
+func NewSearchPostgres(db *sqlx.DB) *SearchPostgres {
+	return &SearchPostgres{db: db}
+}
+
+// SearchLists ranks the lists of the user matching the query and returns a
+// page of them with the number of all matches. Each document is matched with
+// the query stemmed in its own search_language.
+func (r *SearchPostgres) SearchLists(userId int, query string, limit int, offset int) (structs.SearchGroup, error) {
+	from := fmt.Sprintf(`FROM %s tl
+							INNER JOIN %s ul on ul.list_id=tl.id,
+							websearch_to_tsquery(tl.search_language, $2) AS q(query)
+							WHERE ul.user_id=$1
+							AND tl.deleted_at IS NULL
+							AND tl.search_vector @@ q.query`, todoListsTable, usersListsTable)
+	hitsQuery := fmt.Sprintf(`SELECT h.id, h.title, ts_headline(h.search_language, concat_ws(' ', h.title, h.description), h.query, $5) AS snippet, h.rank
+							FROM (SELECT tl.id, tl.title, tl.description, tl.search_language, q.query, ts_rank(tl.search_vector, q.query) AS rank %s
+								ORDER BY rank DESC, tl.id LIMIT $3 OFFSET $4) h
+							ORDER BY h.rank DESC, h.id`, from)
+
+	return r.search(fmt.Sprintf("SELECT count(*) %s", from), hitsQuery, userId, query, limit, offset)
+}
+
+// SearchItems does the same for the items in the lists of the user, archived
+// ones included.
+func (r *SearchPostgres) SearchItems(userId int, query string, limit int, offset int) (structs.SearchGroup, error) {
+	from := fmt.Sprintf(`FROM %s ti
+							INNER JOIN %s li on li.item_id=ti.id
+							INNER JOIN %s ul on ul.list_id=li.list_id,
+							websearch_to_tsquery(ti.search_language, $2) AS q(query)
+							WHERE ul.user_id=$1
+							AND %s
+							AND ti.search_vector @@ q.query`, todoItemsTable, listsItemsTable, usersListsTable,
+		liveItemCondition("ti", "li"))
+	hitsQuery := fmt.Sprintf(`SELECT h.id, h.list_id, h.title, ts_headline(h.search_language, concat_ws(' ', h.title, h.description), h.query, $5) AS snippet, h.rank
+							FROM (SELECT ti.id, li.list_id, ti.title, ti.description, ti.search_language, q.query, ts_rank(ti.search_vector, q.query) AS rank %s
+								ORDER BY rank DESC, ti.id LIMIT $3 OFFSET $4) h
+							ORDER BY h.rank DESC, h.id`, from)
+
+	return r.search(fmt.Sprintf("SELECT count(*) %s", from), hitsQuery, userId, query, limit, offset)
+}
+
+func (r *SearchPostgres) search(countQuery string, hitsQuery string, userId int, query string, limit int, offset int) (structs.SearchGroup, error) {
+	group := structs.SearchGroup{Hits: []structs.SearchHit{}}
+	if err := r.db.Get(&group.Total, countQuery, userId, query); err != nil {
+		return group, err
+	}
+	if group.Total <= offset {
+		return group, nil
+	}
+
+	if err := r.db.Select(&group.Hits, hitsQuery, userId, query, limit, offset, snippetOptions); err != nil {
+		return group, err
+	}
+	for i := range group.Hits {
+		group.Hits[i].Snippet = snippetReplacer.Replace(html.EscapeString(group.Hits[i].Snippet))
+	}
+
+	return group, nil
+}
@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/fr13n8/todo-app/structs"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchPostgres_SearchLists(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "smock")
+
+	r := NewSearchPostgres(db)
+
+	testTable := []struct {
+		name         string
+		offset       int
+		mockBehavior func(offset int)
+		want         structs.SearchGroup
+		wantErr      bool
+	}{
+		{
+			name: "Ok",
+			mockBehavior: func(offset int) {
+				mock.ExpectQuery(`SELECT count\(\*\) FROM todo_lists tl INNER JOIN users_lists ul on ul.list_id=tl.id,
+										websearch_to_tsquery\(tl.search_language, \$2\) AS q\(query\)
+										WHERE ul.user_id=\$1 AND tl.deleted_at IS NULL AND tl.search_vector @@ q.query`).
+					WithArgs(1, "plan").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+				rows := sqlmock.NewRows([]string{"id", "title", "snippet", "rank"}).
+					AddRow(4, "Planning", "\x02Planning\x03 for <b>Q3</b>", 0.6).
+					AddRow(2, "Groceries", "\x02plan\x03 meals", 0.2)
+				mock.ExpectQuery(`SELECT h.id, h.title, ts_headline\(h.search_language, concat_ws\(' ', h.title, h.description\), h.query, \$5\) AS snippet, h.rank
+										FROM \(SELECT tl.id, tl.title, tl.description, tl.search_language, (.+) ORDER BY rank DESC, tl.id LIMIT \$3 OFFSET \$4\) h
+										ORDER BY h.rank DESC, h.id`).
+					WithArgs(1, "plan", 20, offset, snippetOptions).
+					WillReturnRows(rows)
+			},
+			want: structs.SearchGroup{Total: 2, Hits: []structs.SearchHit{
+				{Id: 4, Title: "Planning", Snippet: "<mark>Planning</mark> for &lt;b&gt;Q3&lt;/b&gt;", Rank: 0.6},
+				{Id: 2, Title: "Groceries", Snippet: "<mark>plan</mark> meals", Rank: 0.2},
+			}},
+		},
+		{
+			name:   "Past the last page",
+			offset: 20,
+			mockBehavior: func(offset int) {
+				mock.ExpectQuery(`SELECT count\(\*\) FROM todo_lists tl (.+)`).
+					WithArgs(1, "plan").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+			},
+			want: structs.SearchGroup{Total: 2, Hits: []structs.SearchHit{}},
+		},
+		{
+			name: "Query error",
+			mockBehavior: func(offset int) {
+				mock.ExpectQuery(`SELECT count\(\*\) FROM todo_lists tl (.+)`).
+					WithArgs(1, "plan").
+					WillReturnError(errors.New("query error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior(testCase.offset)
+
+			got, err := r.SearchLists(1, "plan", 20, testCase.offset)
+			if testCase.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testCase.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSearchPostgres_SearchItems(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "smock")
+
+	r := NewSearchPostgres(db)
+
+	listId := 3
+
+	testTable := []struct {
+		name         string
+		mockBehavior func()
+		want         structs.SearchGroup
+		wantErr      bool
+	}{
+		{
+			name: "Ok",
+			mockBehavior: func() {
+				mock.ExpectQuery(`SELECT count\(\*\) FROM todo_items ti INNER JOIN lists_items li on li.item_id=ti.id
+										INNER JOIN users_lists ul on ul.list_id=li.list_id,
+										websearch_to_tsquery\(ti.search_language, \$2\) AS q\(query\)
+										WHERE ul.user_id=\$1 AND ti.deleted_at IS NULL AND (.+) AND ti.search_vector @@ q.query`).
+					WithArgs(1, "release").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+				rows := sqlmock.NewRows([]string{"id", "list_id", "title", "snippet", "rank"}).
+					AddRow(7, listId, "Release notes", "\x02Release\x03 notes", 0.6)
+				mock.ExpectQuery(`SELECT h.id, h.list_id, h.title, (.+) FROM \(SELECT ti.id, li.list_id, (.+)
+										ORDER BY rank DESC, ti.id LIMIT \$3 OFFSET \$4\) h`).
+					WithArgs(1, "release", 20, 0, snippetOptions).
+					WillReturnRows(rows)
+			},
+			want: structs.SearchGroup{Total: 1, Hits: []structs.SearchHit{
+				{Id: 7, ListId: &listId, Title: "Release notes", Snippet: "<mark>Release</mark> notes", Rank: 0.6},
+			}},
+		},
+		{
+			name: "No matches",
+			mockBehavior: func() {
+				mock.ExpectQuery(`SELECT count\(\*\) FROM todo_items ti (.+)`).
+					WithArgs(1, "release").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+			},
+			want: structs.SearchGroup{Hits: []structs.SearchHit{}},
+		},
+		{
+			name: "Hits error",
+			mockBehavior: func() {
+				mock.ExpectQuery(`SELECT count\(\*\) FROM todo_items ti (.+)`).
+					WithArgs(1, "release").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+				mock.ExpectQuery(`SELECT h.id, (.+)`).
+					WithArgs(1, "release", 20, 0, snippetOptions).
+					WillReturnError(errors.New("query error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior()
+
+			got, err := r.SearchItems(1, "release", 20, 0)
+			if testCase.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testCase.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
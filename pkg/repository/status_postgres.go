@@ -0,0 +1,245 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/fr13n8/todo-app/structs"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+const statusColumns = "s.id, s.list_id, s.name, s.position, s.is_done, s.wip_limit"
+
+type StatusPostgres struct {
+	db *sqlx.DB
+}
+
+func NewStatusPostgres(db *sqlx.DB) *StatusPostgres {
+	return &StatusPostgres{db: db}
+}
+
+func (r *StatusPostgres) Create(userId int, listId int, input structs.StatusInput) (int, string, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, "", err
+	}
+
+	token, err := beginJournal(tx, userId)
+	if err != nil {
+		rolError := tx.Rollback()
+		if rolError != nil {
+			return 0, "", rolError
+		}
+		return 0, "", err
+	}
+
+	var id int
+	createQuery := fmt.Sprintf(`INSERT INTO %s (list_id, name, is_done, wip_limit, position)
+								SELECT $1, $2, $3, $4, COALESCE(MAX(s.position) + 1, 0) FROM %s s WHERE s.list_id=$1
+								RETURNING id`, statusesTable, statusesTable)
+	row := tx.QueryRow(createQuery, listId, input.Name, input.IsDone, input.WipLimit)
+	if err := row.Scan(&id); err != nil {
+		rolError := tx.Rollback()
+		if rolError != nil {
+			return 0, "", rolError
+		}
+		return 0, "", err
+	}
+
+	if len(input.Transitions) > 0 {
+		if err := setTransitions(tx, id, input.Transitions); err != nil {
+			rolError := tx.Rollback()
+			if rolError != nil {
+				return 0, "", rolError
+			}
+			return 0, "", err
+		}
+	}
+
+	return id, token, tx.Commit()
+}
+
+func (r *StatusPostgres) GetAll(listId int) ([]structs.Status, error) {
+	var statuses []structs.Status
+
+	query := fmt.Sprintf("SELECT %s FROM %s s WHERE s.list_id=$1 ORDER BY s.position, s.id", statusColumns, statusesTable)
+	if err := r.db.Select(&statuses, query, listId); err != nil {
+		return nil, err
+	}
+
+	return statuses, r.fillTransitions(statuses)
+}
+
+func (r *StatusPostgres) GetById(userId int, statusId int) (structs.Status, error) {
+	var status structs.Status
+
+	query := fmt.Sprintf(`SELECT %s FROM %s s
+							INNER JOIN %s ul on ul.list_id=s.list_id
+							INNER JOIN %s tl on tl.id=s.list_id
+							WHERE s.id=$1 AND ul.user_id=$2 AND tl.deleted_at IS NULL`,
+		statusColumns, statusesTable, usersListsTable, todoListsTable)
+	if err := r.db.Get(&status, query, statusId, userId); err != nil {
+		return status, err
+	}
+
+	statuses := []structs.Status{status}
+	if err := r.fillTransitions(statuses); err != nil {
+		return status, err
+	}
+	return statuses[0], nil
+}
+
+func (r *StatusPostgres) GetByItemId(itemId int) ([]structs.Status, error) {
+	var statuses []structs.Status
+
+	query := fmt.Sprintf(`SELECT %s FROM %s s
+							INNER JOIN %s li on li.list_id=s.list_id
+							WHERE li.item_id=$1
+							ORDER BY s.position, s.id`, statusColumns, statusesTable, listsItemsTable)
+	if err := r.db.Select(&statuses, query, itemId); err != nil {
+		return nil, err
+	}
+
+	return statuses, r.fillTransitions(statuses)
+}
+
+func (r *StatusPostgres) Update(userId int, statusId int, input structs.UpdateStatusInput) (string, error) {
+	setValues := make([]string, 0)
+	args := make([]interface{}, 0)
+	argId := 1
+
+	if input.Name != nil {
+		setValues = append(setValues, fmt.Sprintf("name=$%d", argId))
+		args = append(args, *input.Name)
+		argId++
+	}
+
+	if input.Position != nil {
+		setValues = append(setValues, fmt.Sprintf("position=$%d", argId))
+		args = append(args, *input.Position)
+		argId++
+	}
+
+	if input.IsDone != nil {
+		setValues = append(setValues, fmt.Sprintf("is_done=$%d", argId))
+		args = append(args, *input.IsDone)
+		argId++
+	}
+
+	if input.WipLimit != nil {
+		setValues = append(setValues, fmt.Sprintf("wip_limit=NULLIF($%d, 0)", argId))
+		args = append(args, *input.WipLimit)
+		argId++
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return "", err
+	}
+
+	token, err := beginJournal(tx, userId)
+	if err != nil {
+		rolError := tx.Rollback()
+		if rolError != nil {
+			return "", rolError
+		}
+		return "", err
+	}
+
+	if len(setValues) > 0 {
+		setQuery := strings.Join(setValues, ",")
+		query := fmt.Sprintf("UPDATE %s s SET %s WHERE s.id=$%d", statusesTable, setQuery, argId)
+		args = append(args, statusId)
+		if _, err := tx.Exec(query, args...); err != nil {
+			rolError := tx.Rollback()
+			if rolError != nil {
+				return "", rolError
+			}
+			return "", err
+		}
+	}
+
+	// Done is derived from the status category, so the items follow when
+	// the category changes.
+	if input.IsDone != nil {
+		doneQuery := fmt.Sprintf("UPDATE %s ti SET done=$1 WHERE ti.status_id=$2", todoItemsTable)
+		if _, err := tx.Exec(doneQuery, *input.IsDone, statusId); err != nil {
+			rolError := tx.Rollback()
+			if rolError != nil {
+				return "", rolError
+			}
+			return "", err
+		}
+	}
+
+	if input.Transitions != nil {
+		if err := setTransitions(tx, statusId, *input.Transitions); err != nil {
+			rolError := tx.Rollback()
+			if rolError != nil {
+				return "", rolError
+			}
+			return "", err
+		}
+	}
+
+	return token, tx.Commit()
+}
+
+func (r *StatusPostgres) Delete(userId int, statusId int) (string, error) {
+	return journal(r.db, userId, func(tx *sql.Tx) error {
+		query := fmt.Sprintf("DELETE FROM %s s WHERE s.id=$1", statusesTable)
+		_, err := tx.Exec(query, statusId)
+		return err
+	})
+}
+
+func (r *StatusPostgres) fillTransitions(statuses []structs.Status) error {
+	if len(statuses) == 0 {
+		return nil
+	}
+
+	ids := make([]int, 0, len(statuses))
+	for _, status := range statuses {
+		ids = append(ids, status.Id)
+	}
+
+	var transitions []structs.StatusTransition
+	query := fmt.Sprintf(`SELECT st.from_status_id, st.to_status_id FROM %s st
+							WHERE st.from_status_id = ANY($1)
+							ORDER BY st.to_status_id`, statusesTransitionsTable)
+	if err := r.db.Select(&transitions, query, pq.Array(ids)); err != nil {
+		return err
+	}
+
+	byStatus := make(map[int][]int, len(statuses))
+	for _, transition := range transitions {
+		byStatus[transition.FromStatusId] = append(byStatus[transition.FromStatusId], transition.ToStatusId)
+	}
+	for i := range statuses {
+		statuses[i].Transitions = byStatus[statuses[i].Id]
+	}
+	return nil
+}
+
+// setTransitions replaces the allowed targets of a status; targets outside
+// the status's own list are skipped.
+func setTransitions(tx *sql.Tx, statusId int, to []int) error {
+	deleteQuery := fmt.Sprintf("DELETE FROM %s st WHERE st.from_status_id=$1", statusesTransitionsTable)
+	if _, err := tx.Exec(deleteQuery, statusId); err != nil {
+		return err
+	}
+	if len(to) == 0 {
+		return nil
+	}
+
+	createQuery := fmt.Sprintf(`INSERT INTO %s (from_status_id, to_status_id)
+								SELECT f.id, s.id FROM %s f
+								INNER JOIN %s s on s.list_id=f.list_id
+								WHERE f.id=$1 AND s.id = ANY($2) AND s.id<>f.id`,
+		statusesTransitionsTable, statusesTable, statusesTable)
+	_, err := tx.Exec(createQuery, statusId, pq.Array(to))
+
+	return err
+}
@@ -0,0 +1,220 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/fr13n8/todo-app/structs"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatusPostgres_Create(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "smock")
+
+	r := NewStatusPostgres(db)
+
+	type input struct {
+		listId int
+		status structs.StatusInput
+	}
+
+	type mockBehavior func(input input, id int)
+
+	testTable := []struct {
+		name         string
+		input        input
+		mockBehavior mockBehavior
+		wantId       int
+		wantErr      bool
+	}{
+		{
+			name: "Ok",
+			input: input{
+				listId: 1,
+				status: structs.StatusInput{Name: "Review"},
+			},
+			wantId: 2,
+			mockBehavior: func(input input, id int) {
+				mock.ExpectBegin()
+				expectJournal(mock, 1)
+
+				mock.ExpectQuery(`INSERT INTO statuses (.+) SELECT (.+) COALESCE\(MAX\(s.position\) \+ 1, 0\) FROM statuses s`).
+					WithArgs(input.listId, input.status.Name, input.status.IsDone, input.status.WipLimit).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(id))
+
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Ok_WithTransitions",
+			input: input{
+				listId: 1,
+				status: structs.StatusInput{Name: "Done", IsDone: true, Transitions: []int{1}},
+			},
+			wantId: 3,
+			mockBehavior: func(input input, id int) {
+				mock.ExpectBegin()
+				expectJournal(mock, 1)
+
+				mock.ExpectQuery("INSERT INTO statuses").
+					WithArgs(input.listId, input.status.Name, input.status.IsDone, input.status.WipLimit).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(id))
+
+				mock.ExpectExec("DELETE FROM statuses_transitions st WHERE (.+)").
+					WithArgs(id).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+
+				mock.ExpectExec(`INSERT INTO statuses_transitions (.+) SELECT (.+) FROM statuses f
+									INNER JOIN statuses s on (.+) WHERE (.+)`).
+					WithArgs(id, pq.Array(input.status.Transitions)).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Duplicate name",
+			input: input{
+				listId: 1,
+				status: structs.StatusInput{Name: "Review"},
+			},
+			mockBehavior: func(input input, id int) {
+				mock.ExpectBegin()
+				expectJournal(mock, 1)
+
+				mock.ExpectQuery("INSERT INTO statuses").
+					WithArgs(input.listId, input.status.Name, input.status.IsDone, input.status.WipLimit).
+					WillReturnError(errors.New("duplicate key value"))
+
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior(testCase.input, testCase.wantId)
+
+			got, token, err := r.Create(1, testCase.input.listId, testCase.input.status)
+			if testCase.wantErr {
+				assert.Error(t, err)
+				assert.Empty(t, token)
+			} else {
+				assert.NoError(t, err)
+				assert.NotEmpty(t, token)
+				assert.Equal(t, testCase.wantId, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestStatusPostgres_GetAll(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "smock")
+
+	r := NewStatusPostgres(db)
+
+	rows := sqlmock.NewRows([]string{"id", "list_id", "name", "position", "is_done"}).
+		AddRow(1, 1, "Backlog", 0, false).
+		AddRow(2, 1, "Done", 1, true)
+	mock.ExpectQuery("SELECT (.+) FROM statuses s WHERE s.list_id=\\$1 ORDER BY s.position, s.id").
+		WithArgs(1).
+		WillReturnRows(rows)
+
+	transitions := sqlmock.NewRows([]string{"from_status_id", "to_status_id"}).AddRow(1, 2)
+	mock.ExpectQuery("SELECT st.from_status_id, st.to_status_id FROM statuses_transitions st WHERE (.+)").
+		WithArgs(pq.Array([]int{1, 2})).
+		WillReturnRows(transitions)
+
+	got, err := r.GetAll(1)
+	assert.NoError(t, err)
+	assert.Equal(t, []structs.Status{
+		{Id: 1, ListId: 1, Name: "Backlog", Position: 0, Transitions: []int{2}},
+		{Id: 2, ListId: 1, Name: "Done", Position: 1, IsDone: true},
+	}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStatusPostgres_Update(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "smock")
+
+	r := NewStatusPostgres(db)
+
+	type mockBehavior func(statusId int, input structs.UpdateStatusInput)
+
+	testTable := []struct {
+		name         string
+		input        structs.UpdateStatusInput
+		mockBehavior mockBehavior
+	}{
+		{
+			name:  "Ok_Name",
+			input: structs.UpdateStatusInput{Name: stringPointer("Review")},
+			mockBehavior: func(statusId int, input structs.UpdateStatusInput) {
+				mock.ExpectBegin()
+				expectJournal(mock, 1)
+				mock.ExpectExec("UPDATE statuses s SET name=\\$1 WHERE s.id=\\$2").
+					WithArgs(*input.Name, statusId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:  "Ok_IsDone",
+			input: structs.UpdateStatusInput{IsDone: boolPointer(true)},
+			mockBehavior: func(statusId int, input structs.UpdateStatusInput) {
+				mock.ExpectBegin()
+				expectJournal(mock, 1)
+				mock.ExpectExec("UPDATE statuses s SET is_done=\\$1 WHERE s.id=\\$2").
+					WithArgs(*input.IsDone, statusId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE todo_items ti SET done=\\$1 WHERE ti.status_id=\\$2").
+					WithArgs(*input.IsDone, statusId).
+					WillReturnResult(sqlmock.NewResult(0, 3))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:  "Ok_ClearTransitions",
+			input: structs.UpdateStatusInput{Transitions: &[]int{}},
+			mockBehavior: func(statusId int, input structs.UpdateStatusInput) {
+				mock.ExpectBegin()
+				expectJournal(mock, 1)
+				mock.ExpectExec("DELETE FROM statuses_transitions st WHERE (.+)").
+					WithArgs(statusId).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior(1, testCase.input)
+
+			token, err := r.Update(1, 1, testCase.input)
+			assert.NoError(t, err)
+			assert.NotEmpty(t, token)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
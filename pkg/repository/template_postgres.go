@@ -0,0 +1,228 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/fr13n8/todo-app/structs"
+	"github.com/jmoiron/sqlx"
+)
+
+type TemplatePostgres struct {
+	db *sqlx.DB
+}
+
+func NewTemplatePostgres(db *sqlx.DB) *TemplatePostgres {
+	return &TemplatePostgres{db: db}
+}
+
+// Create saves the live, unarchived items of the list along with their
+// labels. Items keep their board order as the template's positions.
+func (r *TemplatePostgres) Create(userId int, input structs.TemplateInput) (int, string, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, "", err
+	}
+
+	token, err := beginJournal(tx, userId)
+	if err != nil {
+		rolError := tx.Rollback()
+		if rolError != nil {
+			return 0, "", rolError
+		}
+		return 0, "", err
+	}
+
+	var templateId int
+	createTemplateQuery := fmt.Sprintf(`INSERT INTO %s (user_id, title, description, shared)
+							SELECT $1, COALESCE(NULLIF($3, ''), tl.title), COALESCE(tl.description, ''), $4 FROM %s tl
+							INNER JOIN %s ul on ul.list_id=tl.id
+							WHERE tl.id=$2 AND ul.user_id=$1 AND tl.deleted_at IS NULL
+							RETURNING id`, templatesTable, todoListsTable, usersListsTable)
+	row := tx.QueryRow(createTemplateQuery, userId, input.ListId, input.Title, input.Shared)
+	if err := row.Scan(&templateId); err != nil {
+		rolError := tx.Rollback()
+		if rolError != nil {
+			return 0, "", rolError
+		}
+		return 0, "", err
+	}
+
+	createItemsQuery := fmt.Sprintf(`WITH src AS (
+								SELECT ti.id, ti.title, COALESCE(ti.description, '') AS description, ti.due_date, ti.recurrence,
+								row_number() OVER (ORDER BY ti.position, ti.id) AS position
+								FROM %s ti INNER JOIN %s li on li.item_id=ti.id
+								WHERE li.list_id=$2 AND ti.archived_at IS NULL AND %s
+							), start AS (
+								SELECT COALESCE($3::timestamptz, MIN(src.due_date)) AS at FROM src
+							), created AS (
+								INSERT INTO %s (template_id, position, title, description, due_offset, recurrence)
+								SELECT $1, src.position, src.title, src.description,
+								EXTRACT(EPOCH FROM src.due_date - start.at)::bigint, src.recurrence FROM src, start
+								RETURNING id, position
+							)
+							INSERT INTO %s (template_item_id, name, color)
+							SELECT created.id, l.name, l.color FROM created
+							INNER JOIN src on src.position=created.position
+							INNER JOIN %s il on il.item_id=src.id
+							INNER JOIN %s l on l.id=il.label_id`,
+		todoItemsTable, listsItemsTable, liveItemCondition("ti", "li"), templateItemsTable,
+		templateItemsLabelsTable, itemsLabelsTable, labelsTable)
+	if _, err := tx.Exec(createItemsQuery, templateId, input.ListId, input.StartDate); err != nil {
+		rolError := tx.Rollback()
+		if rolError != nil {
+			return 0, "", rolError
+		}
+		return 0, "", err
+	}
+
+	return templateId, token, tx.Commit()
+}
+
+// GetAll returns the templates of the user and the shared ones; scope
+// narrows them down to either.
+func (r *TemplatePostgres) GetAll(userId int, scope string) ([]structs.Template, error) {
+	var templates []structs.Template
+
+	condition, args := "(t.user_id=$1 OR t.shared)", []interface{}{userId}
+	switch scope {
+	case structs.TemplateScopeOwn:
+		condition = "t.user_id=$1"
+	case structs.TemplateScopeWorkspace:
+		condition, args = "t.shared", nil
+	}
+	query := fmt.Sprintf(`SELECT t.id, t.user_id, t.title, t.description, t.shared, t.created_at FROM %s t
+							WHERE %s ORDER BY t.id`, templatesTable, condition)
+	if err := r.db.Select(&templates, query, args...); err != nil {
+		return nil, err
+	}
+
+	return templates, nil
+}
+
+// GetById returns a template the user may use, with its items and labels.
+func (r *TemplatePostgres) GetById(userId int, templateId int) (structs.Template, error) {
+	var template structs.Template
+
+	query := fmt.Sprintf(`SELECT t.id, t.user_id, t.title, t.description, t.shared, t.created_at FROM %s t
+							WHERE t.id=$1 AND (t.user_id=$2 OR t.shared)`, templatesTable)
+	if err := r.db.Get(&template, query, templateId, userId); err != nil {
+		return template, err
+	}
+
+	itemsQuery := fmt.Sprintf(`SELECT tpi.id, tpi.position, tpi.title, tpi.description, tpi.due_offset, tpi.recurrence
+							FROM %s tpi WHERE tpi.template_id=$1 ORDER BY tpi.position`, templateItemsTable)
+	if err := r.db.Select(&template.Items, itemsQuery, templateId); err != nil {
+		return template, err
+	}
+
+	var labels []structs.TemplateLabel
+	labelsQuery := fmt.Sprintf(`SELECT til.template_item_id, til.name, til.color FROM %s til
+							INNER JOIN %s tpi on tpi.id=til.template_item_id
+							WHERE tpi.template_id=$1 ORDER BY til.name`, templateItemsLabelsTable, templateItemsTable)
+	if err := r.db.Select(&labels, labelsQuery, templateId); err != nil {
+		return template, err
+	}
+	for i := range template.Items {
+		for _, label := range labels {
+			if label.TemplateItemId == template.Items[i].Id {
+				template.Items[i].Labels = append(template.Items[i].Labels, label)
+			}
+		}
+	}
+
+	return template, nil
+}
+
+func (r *TemplatePostgres) Delete(userId int, templateId int) (string, error) {
+	return journal(r.db, userId, func(tx *sql.Tx) error {
+		query := fmt.Sprintf("DELETE FROM %s t WHERE t.id=$1 AND t.user_id=$2", templatesTable)
+		_, err := tx.Exec(query, templateId, userId)
+		return err
+	})
+}
+
+// Instantiate creates a list of the user from the template. Labels the
+// user doesn't have yet are created, and due dates are resolved from start.
+func (r *TemplatePostgres) Instantiate(userId int, templateId int, title string, start time.Time) (int, string, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, "", err
+	}
+
+	token, err := beginJournal(tx, userId)
+	if err != nil {
+		rolError := tx.Rollback()
+		if rolError != nil {
+			return 0, "", rolError
+		}
+		return 0, "", err
+	}
+
+	var listId int
+	createListQuery := fmt.Sprintf(`INSERT INTO %s (title, description, created_by, updated_by)
+							SELECT COALESCE(NULLIF($2, ''), t.title), t.description, $3, $3 FROM %s t
+							WHERE t.id=$1 AND (t.user_id=$3 OR t.shared)
+							RETURNING id`, todoListsTable, templatesTable)
+	row := tx.QueryRow(createListQuery, templateId, title, userId)
+	if err := row.Scan(&listId); err != nil {
+		rolError := tx.Rollback()
+		if rolError != nil {
+			return 0, "", rolError
+		}
+		return 0, "", err
+	}
+
+	createUsersListQuery := fmt.Sprintf("INSERT INTO %s (user_id, list_id) VALUES($1, $2)", usersListsTable)
+	if _, err := tx.Exec(createUsersListQuery, userId, listId); err != nil {
+		rolError := tx.Rollback()
+		if rolError != nil {
+			return 0, "", rolError
+		}
+		return 0, "", err
+	}
+
+	createLabelsQuery := fmt.Sprintf(`INSERT INTO %s (user_id, name, color)
+							SELECT DISTINCT ON (til.name) $1, til.name, til.color FROM %s til
+							INNER JOIN %s tpi on tpi.id=til.template_item_id
+							WHERE tpi.template_id=$2
+							ON CONFLICT (user_id, name) DO NOTHING`, labelsTable, templateItemsLabelsTable, templateItemsTable)
+	if _, err := tx.Exec(createLabelsQuery, userId, templateId); err != nil {
+		rolError := tx.Rollback()
+		if rolError != nil {
+			return 0, "", rolError
+		}
+		return 0, "", err
+	}
+
+	createItemsQuery := fmt.Sprintf(`WITH created AS (
+								INSERT INTO %s (title, description, due_date, recurrence, recurrence_start, position,
+								created_by, updated_by)
+								SELECT tpi.title, tpi.description, $3::timestamptz + tpi.due_offset * interval '1 second',
+								tpi.recurrence,
+								CASE WHEN tpi.recurrence IS NOT NULL
+									THEN $3::timestamptz + COALESCE(tpi.due_offset, 0) * interval '1 second' END,
+								tpi.position, $4, $4 FROM %s tpi
+								WHERE tpi.template_id=$1
+								RETURNING id, position
+							), linked AS (
+								INSERT INTO %s (list_id, item_id) SELECT $2, created.id FROM created
+							)
+							INSERT INTO %s (item_id, label_id)
+							SELECT created.id, l.id FROM created
+							INNER JOIN %s tpi on tpi.template_id=$1 AND tpi.position=created.position
+							INNER JOIN %s til on til.template_item_id=tpi.id
+							INNER JOIN %s l on l.user_id=$4 AND l.name=til.name`,
+		todoItemsTable, templateItemsTable, listsItemsTable, itemsLabelsTable,
+		templateItemsTable, templateItemsLabelsTable, labelsTable)
+	if _, err := tx.Exec(createItemsQuery, templateId, listId, start, userId); err != nil {
+		rolError := tx.Rollback()
+		if rolError != nil {
+			return 0, "", rolError
+		}
+		return 0, "", err
+	}
+
+	return listId, token, tx.Commit()
+}
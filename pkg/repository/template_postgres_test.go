@@ -0,0 +1,279 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/fr13n8/todo-app/structs"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTemplatePostgres_Create(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "smock")
+
+	r := NewTemplatePostgres(db)
+
+	start := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	testTable := []struct {
+		name         string
+		input        structs.TemplateInput
+		mockBehavior func(input structs.TemplateInput)
+		wantId       int
+		wantErr      bool
+	}{
+		{
+			name:  "Ok",
+			input: structs.TemplateInput{ListId: 2, Title: "release", Shared: true, StartDate: &start},
+			mockBehavior: func(input structs.TemplateInput) {
+				mock.ExpectBegin()
+				expectJournal(mock, 1)
+
+				mock.ExpectQuery(`INSERT INTO templates \(user_id, title, description, shared\)
+										SELECT \$1, COALESCE\(NULLIF\(\$3, ''\), tl.title\), (.+) FROM todo_lists tl (.+) RETURNING id`).
+					WithArgs(1, input.ListId, input.Title, input.Shared).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+
+				mock.ExpectExec(`WITH src AS \((.+)\), start AS \(
+										SELECT COALESCE\(\$3::timestamptz, MIN\(src.due_date\)\) AS at FROM src
+										\), created AS \(
+										INSERT INTO template_items (.+) RETURNING id, position
+										\)
+										INSERT INTO template_items_labels \(template_item_id, name, color\) (.+)`).
+					WithArgs(3, input.ListId, input.StartDate).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+
+				mock.ExpectCommit()
+			},
+			wantId: 3,
+		},
+		{
+			name:  "List not found",
+			input: structs.TemplateInput{ListId: 2},
+			mockBehavior: func(input structs.TemplateInput) {
+				mock.ExpectBegin()
+				expectJournal(mock, 1)
+
+				mock.ExpectQuery(`INSERT INTO templates`).
+					WithArgs(1, input.ListId, input.Title, input.Shared).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name:  "Failed items",
+			input: structs.TemplateInput{ListId: 2},
+			mockBehavior: func(input structs.TemplateInput) {
+				mock.ExpectBegin()
+				expectJournal(mock, 1)
+
+				mock.ExpectQuery(`INSERT INTO templates`).
+					WithArgs(1, input.ListId, input.Title, input.Shared).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+
+				mock.ExpectExec(`WITH src AS`).
+					WithArgs(3, input.ListId, input.StartDate).
+					WillReturnError(errors.New("some error"))
+
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior(testCase.input)
+
+			got, token, err := r.Create(1, testCase.input)
+			if testCase.wantErr {
+				assert.Error(t, err)
+				assert.Empty(t, token)
+			} else {
+				assert.NoError(t, err)
+				assert.NotEmpty(t, token)
+				assert.Equal(t, testCase.wantId, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTemplatePostgres_GetAll(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "smock")
+
+	r := NewTemplatePostgres(db)
+
+	createdAt := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	columns := []string{"id", "user_id", "title", "description", "shared", "created_at"}
+
+	testTable := []struct {
+		name         string
+		scope        string
+		mockBehavior func()
+		want         []structs.Template
+	}{
+		{
+			name: "All",
+			mockBehavior: func() {
+				mock.ExpectQuery(`SELECT (.+) FROM templates t WHERE \(t.user_id=\$1 OR t.shared\) ORDER BY t.id`).
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(1, 1, "onboarding", "", false, createdAt).
+						AddRow(2, 2, "release", "", true, createdAt))
+			},
+			want: []structs.Template{
+				{Id: 1, UserId: 1, Title: "onboarding", CreatedAt: createdAt},
+				{Id: 2, UserId: 2, Title: "release", Shared: true, CreatedAt: createdAt},
+			},
+		},
+		{
+			name:  "Own",
+			scope: structs.TemplateScopeOwn,
+			mockBehavior: func() {
+				mock.ExpectQuery(`SELECT (.+) FROM templates t WHERE t.user_id=\$1 ORDER BY t.id`).
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(1, 1, "onboarding", "", false, createdAt))
+			},
+			want: []structs.Template{
+				{Id: 1, UserId: 1, Title: "onboarding", CreatedAt: createdAt},
+			},
+		},
+		{
+			name:  "Workspace",
+			scope: structs.TemplateScopeWorkspace,
+			mockBehavior: func() {
+				mock.ExpectQuery(`SELECT (.+) FROM templates t WHERE t.shared ORDER BY t.id`).
+					WithArgs().
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(2, 2, "release", "", true, createdAt))
+			},
+			want: []structs.Template{
+				{Id: 2, UserId: 2, Title: "release", Shared: true, CreatedAt: createdAt},
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior()
+
+			got, err := r.GetAll(1, testCase.scope)
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.want, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTemplatePostgres_Instantiate(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "smock")
+
+	r := NewTemplatePostgres(db)
+
+	start := time.Date(2021, 6, 1, 9, 0, 0, 0, time.UTC)
+
+	testTable := []struct {
+		name         string
+		mockBehavior func()
+		wantId       int
+		wantErr      bool
+	}{
+		{
+			name: "Ok",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				expectJournal(mock, 1)
+
+				mock.ExpectQuery(`INSERT INTO todo_lists \(title, description, created_by, updated_by\)
+										SELECT COALESCE\(NULLIF\(\$2, ''\), t.title\), t.description, \$3, \$3 FROM templates t
+										WHERE t.id=\$1 AND \(t.user_id=\$3 OR t.shared\) RETURNING id`).
+					WithArgs(2, "release 1.2", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+
+				mock.ExpectExec(`INSERT INTO users_lists`).
+					WithArgs(1, 5).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				mock.ExpectExec(`INSERT INTO labels \(user_id, name, color\) (.+) ON CONFLICT \(user_id, name\) DO NOTHING`).
+					WithArgs(1, 2).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
+				mock.ExpectExec(`WITH created AS \(
+										INSERT INTO todo_items (.+)
+										SELECT tpi.title, tpi.description, \$3::timestamptz \+ tpi.due_offset \* interval '1 second', (.+)
+										\), linked AS \(
+										INSERT INTO lists_items \(list_id, item_id\) SELECT \$2, created.id FROM created
+										\)
+										INSERT INTO items_labels \(item_id, label_id\) (.+)`).
+					WithArgs(2, 5, start, 1).
+					WillReturnResult(sqlmock.NewResult(0, 3))
+
+				mock.ExpectCommit()
+			},
+			wantId: 5,
+		},
+		{
+			name: "Failed items",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				expectJournal(mock, 1)
+
+				mock.ExpectQuery(`INSERT INTO todo_lists`).
+					WithArgs(2, "release 1.2", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+
+				mock.ExpectExec(`INSERT INTO users_lists`).
+					WithArgs(1, 5).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				mock.ExpectExec(`INSERT INTO labels`).
+					WithArgs(1, 2).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+
+				mock.ExpectExec(`WITH created AS`).
+					WithArgs(2, 5, start, 1).
+					WillReturnError(errors.New("some error"))
+
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior()
+
+			got, token, err := r.Instantiate(1, 2, "release 1.2", start)
+			if testCase.wantErr {
+				assert.Error(t, err)
+				assert.Empty(t, token)
+			} else {
+				assert.NoError(t, err)
+				assert.NotEmpty(t, token)
+				assert.Equal(t, testCase.wantId, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/fr13n8/todo-app/structs"
+	"github.com/jmoiron/sqlx"
+)
+
+var ErrNoRunningTimer = errors.New("no running timer on the item")
+
+const (
+	timeEntryColumns = "te.id, te.item_id, te.user_id, te.started_at, te.stopped_at, te.note"
+	trackedSeconds   = "EXTRACT(EPOCH FROM COALESCE(te.stopped_at, now()) - te.started_at)"
+)
+
+type TimeEntryPostgres struct {
+	db *sqlx.DB
+}
+
+func NewTimeEntryPostgres(db *sqlx.DB) *TimeEntryPostgres {
+	return &TimeEntryPostgres{db: db}
+}
+
+func (r *TimeEntryPostgres) Start(userId int, itemId int) (int, string, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, "", err
+	}
+
+	token, err := beginJournal(tx, userId)
+	if err != nil {
+		rolError := tx.Rollback()
+		if rolError != nil {
+			return 0, "", rolError
+		}
+		return 0, "", err
+	}
+
+	// Locking the user row serializes concurrent starts, so stopping the
+	// running timer and starting the next one can't interleave.
+	lockQuery := fmt.Sprintf("SELECT id FROM %s WHERE id=$1 FOR UPDATE", usersTable)
+	if _, err := tx.Exec(lockQuery, userId); err != nil {
+		rolError := tx.Rollback()
+		if rolError != nil {
+			return 0, "", rolError
+		}
+		return 0, "", err
+	}
+
+	stopQuery := fmt.Sprintf("UPDATE %s SET stopped_at=now() WHERE user_id=$1 AND stopped_at IS NULL", timeEntriesTable)
+	if _, err := tx.Exec(stopQuery, userId); err != nil {
+		rolError := tx.Rollback()
+		if rolError != nil {
+			return 0, "", rolError
+		}
+		return 0, "", err
+	}
+
+	var id int
+	startQuery := fmt.Sprintf("INSERT INTO %s (item_id, user_id, started_at) VALUES ($1, $2, now()) RETURNING id", timeEntriesTable)
+	row := tx.QueryRow(startQuery, itemId, userId)
+	if err := row.Scan(&id); err != nil {
+		rolError := tx.Rollback()
+		if rolError != nil {
+			return 0, "", rolError
+		}
+		return 0, "", err
+	}
+
+	return id, token, tx.Commit()
+}
+
+func (r *TimeEntryPostgres) Stop(userId int, itemId int) (string, error) {
+	return journal(r.db, userId, func(tx *sql.Tx) error {
+		query := fmt.Sprintf("UPDATE %s SET stopped_at=now() WHERE user_id=$1 AND item_id=$2 AND stopped_at IS NULL", timeEntriesTable)
+		result, err := tx.Exec(query, userId, itemId)
+		if err != nil {
+			return err
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return ErrNoRunningTimer
+		}
+		return nil
+	})
+}
+
+func (r *TimeEntryPostgres) Create(userId int, entry structs.TimeEntry) (int, string, error) {
+	var id int
+	token, err := journal(r.db, userId, func(tx *sql.Tx) error {
+		query := fmt.Sprintf(`INSERT INTO %s (item_id, user_id, started_at, stopped_at, note)
+							VALUES ($1, $2, $3, $4, $5) RETURNING id`, timeEntriesTable)
+		row := tx.QueryRow(query, entry.ItemId, userId, entry.StartedAt, entry.StoppedAt, entry.Note)
+		return row.Scan(&id)
+	})
+	if err != nil {
+		return 0, "", err
+	}
+	return id, token, nil
+}
+
+func (r *TimeEntryPostgres) GetAll(itemId int) ([]structs.TimeEntry, error) {
+	var entries []structs.TimeEntry
+	query := fmt.Sprintf("SELECT %s FROM %s te WHERE te.item_id=$1 ORDER BY te.started_at", timeEntryColumns, timeEntriesTable)
+	if err := r.db.Select(&entries, query, itemId); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+func (r *TimeEntryPostgres) Delete(userId int, itemId int, entryId int) (string, error) {
+	return journal(r.db, userId, func(tx *sql.Tx) error {
+		query := fmt.Sprintf("DELETE FROM %s te WHERE te.user_id=$1 AND te.item_id=$2 AND te.id=$3", timeEntriesTable)
+		_, err := tx.Exec(query, userId, itemId, entryId)
+		return err
+	})
+}
+
+func (r *TimeEntryPostgres) GetReport(userId int, filter structs.TimeReportFilter) ([]structs.TimeReportRow, error) {
+	var rows []structs.TimeReportRow
+	args := []interface{}{userId, filter.From, filter.To}
+
+	var id, name, join string
+	switch filter.GroupBy {
+	case structs.TimeReportByLabel:
+		// Labels are per user, so only the reporting user's labels count;
+		// time on unlabeled items ends up in a row without an id.
+		id, name = "l.id", "COALESCE(l.name, '')"
+		join = fmt.Sprintf(`LEFT JOIN (SELECT il.item_id, lb.id, lb.name FROM %s il
+								INNER JOIN %s lb on lb.id=il.label_id
+								WHERE lb.user_id=$1) l on l.item_id=te.item_id`, itemsLabelsTable, labelsTable)
+	case structs.TimeReportByDay:
+		args = append(args, filter.From.Location().String())
+		id, name = "NULL::int", "to_char(te.started_at AT TIME ZONE $4, 'YYYY-MM-DD')"
+	default:
+		id, name = "tl.id", "tl.title"
+		join = fmt.Sprintf(`INNER JOIN %s li on li.item_id=te.item_id
+							INNER JOIN %s tl on tl.id=li.list_id`, listsItemsTable, todoListsTable)
+	}
+
+	// Time on trashed items is left out until they are restored.
+	query := fmt.Sprintf(`SELECT %s AS id, %s AS name, SUM(%s)::bigint AS seconds FROM %s te %s
+							WHERE te.user_id=$1 AND te.started_at >= $2 AND te.started_at < $3
+							AND EXISTS (SELECT 1 FROM %s tti INNER JOIN %s tli on tli.item_id=tti.id
+								WHERE tti.id=te.item_id AND %s)
+							GROUP BY 1, 2 ORDER BY 2`, id, name, trackedSeconds, timeEntriesTable, join,
+		todoItemsTable, listsItemsTable, liveItemCondition("tti", "tli"))
+	if err := r.db.Select(&rows, query, args...); err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,234 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/fr13n8/todo-app/structs"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeEntryPostgres_Start(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "smock")
+
+	r := NewTimeEntryPostgres(db)
+
+	type input struct {
+		userId int
+		itemId int
+	}
+
+	type mockBehavior func(input input)
+
+	testTable := []struct {
+		name         string
+		input        input
+		mockBehavior mockBehavior
+		wantId       int
+		wantErr      bool
+	}{
+		{
+			name:  "Ok",
+			input: input{userId: 1, itemId: 2},
+			mockBehavior: func(input input) {
+				mock.ExpectBegin()
+				expectJournal(mock, input.userId)
+
+				mock.ExpectExec("SELECT id FROM users WHERE (.+) FOR UPDATE").
+					WithArgs(input.userId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
+				mock.ExpectExec("UPDATE time_entries SET stopped_at=now\\(\\) WHERE (.+)").
+					WithArgs(input.userId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
+				mock.ExpectQuery("INSERT INTO time_entries").
+					WithArgs(input.itemId, input.userId).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+
+				mock.ExpectCommit()
+			},
+			wantId: 3,
+		},
+		{
+			name:  "Failed Insert",
+			input: input{userId: 1, itemId: 2},
+			mockBehavior: func(input input) {
+				mock.ExpectBegin()
+				expectJournal(mock, input.userId)
+
+				mock.ExpectExec("SELECT id FROM users WHERE (.+) FOR UPDATE").
+					WithArgs(input.userId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
+				mock.ExpectExec("UPDATE time_entries SET stopped_at=now\\(\\) WHERE (.+)").
+					WithArgs(input.userId).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+
+				mock.ExpectQuery("INSERT INTO time_entries").
+					WithArgs(input.itemId, input.userId).
+					WillReturnError(errors.New("insert error"))
+
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior(testCase.input)
+
+			got, token, err := r.Start(testCase.input.userId, testCase.input.itemId)
+			if testCase.wantErr {
+				assert.Error(t, err)
+				assert.Empty(t, token)
+			} else {
+				assert.NoError(t, err)
+				assert.NotEmpty(t, token)
+				assert.Equal(t, testCase.wantId, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTimeEntryPostgres_Stop(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "smock")
+
+	r := NewTimeEntryPostgres(db)
+
+	testTable := []struct {
+		name     string
+		affected int64
+		wantErr  error
+	}{
+		{
+			name:     "Ok",
+			affected: 1,
+		},
+		{
+			name:    "No running timer",
+			wantErr: ErrNoRunningTimer,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			mock.ExpectBegin()
+			expectJournal(mock, 1)
+			mock.ExpectExec("UPDATE time_entries SET stopped_at=now\\(\\) WHERE (.+)").
+				WithArgs(1, 2).
+				WillReturnResult(sqlmock.NewResult(0, testCase.affected))
+			if testCase.wantErr != nil {
+				mock.ExpectRollback()
+			} else {
+				mock.ExpectCommit()
+			}
+
+			token, err := r.Stop(1, 2)
+			assert.Equal(t, testCase.wantErr, err)
+			if testCase.wantErr != nil {
+				assert.Empty(t, token)
+			} else {
+				assert.NotEmpty(t, token)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTimeEntryPostgres_GetReport(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "smock")
+
+	r := NewTimeEntryPostgres(db)
+
+	location, _ := time.LoadLocation("Europe/Berlin")
+	from := time.Date(2021, 6, 1, 0, 0, 0, 0, location)
+	to := time.Date(2021, 6, 8, 0, 0, 0, 0, location)
+
+	type mockBehavior func(filter structs.TimeReportFilter)
+
+	testTable := []struct {
+		name         string
+		filter       structs.TimeReportFilter
+		mockBehavior mockBehavior
+		want         []structs.TimeReportRow
+	}{
+		{
+			name:   "By list",
+			filter: structs.TimeReportFilter{From: from, To: to},
+			mockBehavior: func(filter structs.TimeReportFilter) {
+				rows := sqlmock.NewRows([]string{"id", "name", "seconds"}).AddRow(1, "list", 3600)
+
+				mock.ExpectQuery(`SELECT tl.id AS id, tl.title AS name, SUM\((.+)\)::bigint AS seconds FROM time_entries te
+									INNER JOIN lists_items li on (.+)
+									INNER JOIN todo_lists tl on (.+)
+									WHERE (.+) GROUP BY 1, 2 ORDER BY 2`).
+					WithArgs(1, filter.From, filter.To).
+					WillReturnRows(rows)
+			},
+			want: []structs.TimeReportRow{{Id: intPointer(1), Name: "list", Seconds: 3600}},
+		},
+		{
+			name:   "By label",
+			filter: structs.TimeReportFilter{From: from, To: to, GroupBy: structs.TimeReportByLabel},
+			mockBehavior: func(filter structs.TimeReportFilter) {
+				rows := sqlmock.NewRows([]string{"id", "name", "seconds"}).
+					AddRow(nil, "", 60).
+					AddRow(2, "work", 3600)
+
+				mock.ExpectQuery(`SELECT l.id AS id, (.+) AS name, (.+) FROM time_entries te
+									LEFT JOIN \(SELECT (.+) FROM items_labels il (.+)\) l on (.+)
+									WHERE (.+)`).
+					WithArgs(1, filter.From, filter.To).
+					WillReturnRows(rows)
+			},
+			want: []structs.TimeReportRow{
+				{Name: "", Seconds: 60},
+				{Id: intPointer(2), Name: "work", Seconds: 3600},
+			},
+		},
+		{
+			name:   "By day",
+			filter: structs.TimeReportFilter{From: from, To: to, GroupBy: structs.TimeReportByDay},
+			mockBehavior: func(filter structs.TimeReportFilter) {
+				rows := sqlmock.NewRows([]string{"id", "name", "seconds"}).AddRow(nil, "2021-06-01", 7200)
+
+				mock.ExpectQuery(`SELECT NULL::int AS id, to_char\(te.started_at AT TIME ZONE \$4, 'YYYY-MM-DD'\) AS name`).
+					WithArgs(1, filter.From, filter.To, "Europe/Berlin").
+					WillReturnRows(rows)
+			},
+			want: []structs.TimeReportRow{{Name: "2021-06-01", Seconds: 7200}},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior(testCase.filter)
+
+			got, err := r.GetReport(1, testCase.filter)
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.want, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -1,13 +1,44 @@
 package repository
 
 import (
+	"database/sql"
+	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/fr13n8/todo-app/structs"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
+var itemColumns = fmt.Sprintf(`ti.id, ti.title, ti.description, ti.done, ti.status_id, ti.position, ti.due_date, ti.recurrence, ti.recurrence_start,
+							ti.created_at, ti.updated_at, ti.completed_at, ti.created_by, ti.updated_by,
+							ti.archived_at, ti.priority, ti.assignee_id, li.list_id, ti.version, CASE WHEN ti.done THEN '%s'
+								WHEN EXISTS (SELECT 1 FROM %s d INNER JOIN %s b on b.id=d.blocker_id
+									INNER JOIN %s bli on bli.item_id=b.id
+									WHERE d.item_id=ti.id AND NOT b.done AND %s) THEN '%s'
+								ELSE '%s' END AS state,
+							(SELECT COALESCE(SUM(EXTRACT(EPOCH FROM COALESCE(te.stopped_at, now()) - te.started_at)), 0)::bigint
+								FROM %s te WHERE te.item_id=ti.id) AS tracked_seconds`,
+	structs.ItemStateDone, itemsDependenciesTable, todoItemsTable, listsItemsTable, liveItemCondition("b", "bli"),
+	structs.ItemStateBlocked, structs.ItemStateReady, timeEntriesTable)
+
+// statusInListQuery picks the status an item takes over when it lands in
+// another list: one of the same done category, preferably with the same name
+// as its current status. The list id is passed as a query parameter.
+const statusInListQuery = `(SELECT s.id FROM %s s
+							WHERE s.list_id=$%d AND s.is_done=ti.done
+							ORDER BY (s.name=(SELECT cs.name FROM %s cs WHERE cs.id=ti.status_id)) IS TRUE DESC, s.position, s.id
+							LIMIT 1)`
+
+// assigneeInListQuery keeps the assignee of an item landing in another list
+// if they can access that list too. The list id is passed as a query
+// parameter.
+const assigneeInListQuery = `(SELECT aul.user_id FROM %s aul WHERE aul.list_id=$%d AND aul.user_id=ti.assignee_id)`
+
+var itemAuditColumns = newAuditColumns("ti", "ti.completed_at")
+
 type TodoItemPostgres struct {
 	db *sqlx.DB
 }
@@ -16,75 +47,195 @@ func NewTodoItemPostgres(db *sqlx.DB) *TodoItemPostgres {
 	return &TodoItemPostgres{db: db}
 }
 
-func (r *TodoItemPostgres) Create(listId int, input structs.Item) (int, error) {
-	tx, err := r.db.Begin()
+func (r *TodoItemPostgres) Create(listId int, userId int, input structs.Item) (int, string, error) {
+	var itemId int
+	token, err := journal(r.db, userId, func(tx *sql.Tx) error {
+		var err error
+		itemId, err = r.create(tx, listId, userId, input)
+		return err
+	})
 	if err != nil {
-		return 0, err
+		return 0, "", err
 	}
+	return itemId, token, nil
+}
 
+func (r *TodoItemPostgres) create(tx *sql.Tx, listId int, userId int, input structs.Item) (int, error) {
 	var itemId int
-	createItemQuery := fmt.Sprintf("INSERT INTO %s (title, description) VALUES ($1, $2) RETURNING id", todoItemsTable)
-	row := tx.QueryRow(createItemQuery, input.Title, input.Description)
+	createItemQuery := fmt.Sprintf(`INSERT INTO %s (title, description, done, status_id, due_date, recurrence, recurrence_start,
+							priority, assignee_id, created_by, updated_by)
+							VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $10) RETURNING id`, todoItemsTable)
+	row := tx.QueryRow(createItemQuery, input.Title, input.Description, input.Done, input.StatusId,
+		input.DueDate, input.Recurrence, input.RecurrenceStart, input.Priority, input.AssigneeId, userId)
 	if err := row.Scan(&itemId); err != nil {
-		rolError := tx.Rollback()
-		if rolError != nil {
-			return 0, rolError
-		}
 		return 0, err
 	}
 
 	createListItemsQuery := fmt.Sprintf("INSERT INTO %s (list_id, item_id) VALUES($1, $2) RETURNING id", listsItemsTable)
-	_, err = tx.Exec(createListItemsQuery, listId, itemId)
+	_, err := tx.Exec(createListItemsQuery, listId, itemId)
+	return itemId, err
+}
+
+func (r *TodoItemPostgres) GetAll(listId int, userId int, filter structs.ItemFilter) ([]structs.Item, structs.PageInfo, error) {
+	var items []structs.Item
+	var page structs.PageInfo
+	keys, err := newKeyset(filter.Sort, filter.Order, itemSortColumns)
 	if err != nil {
-		rolError := tx.Rollback()
-		if rolError != nil {
-			return 0, rolError
+		return nil, page, err
+	}
+
+	archived := "ti.archived_at IS NULL"
+	if filter.Archived {
+		archived = "ti.archived_at IS NOT NULL"
+	}
+	conditions := []string{"li.list_id=$1", "ul.user_id=$2", liveItemCondition("ti", "li"), archived}
+	args := []interface{}{listId, userId}
+
+	if len(filter.LabelIds) > 0 {
+		conditions = append(conditions, fmt.Sprintf(`ti.id IN (SELECT il.item_id FROM %s il
+							INNER JOIN %s l on l.id=il.label_id
+							WHERE l.user_id=$2 AND il.label_id = ANY($3)
+							GROUP BY il.item_id HAVING count(*)=$4)`, itemsLabelsTable, labelsTable))
+		args = append(args, pq.Array(filter.LabelIds), len(filter.LabelIds))
+	}
+	if filter.Expr != nil {
+		var condition string
+		condition, args = compileItemQuery(filter.Expr, 2, args)
+		conditions = append(conditions, condition)
+	}
+	if filter.Done != nil {
+		args = append(args, *filter.Done)
+		conditions = append(conditions, fmt.Sprintf("ti.done=$%d", len(args)))
+	}
+	if filter.TitleContains != "" {
+		args = append(args, "%"+likeEscaper.Replace(filter.TitleContains)+"%")
+		conditions = append(conditions, fmt.Sprintf("ti.title ILIKE $%d", len(args)))
+	}
+	conditions, args = itemAuditColumns.where(filter.AuditFilter, conditions, args)
+
+	from := func(conditions []string) string {
+		return fmt.Sprintf(`FROM %s ti 
+							INNER JOIN %s li on li.item_id=ti.id
+							INNER JOIN %s ul on ul.list_id=li.list_id
+							WHERE %s`, todoItemsTable, listsItemsTable, usersListsTable, strings.Join(conditions, " AND "))
+	}
+	pageConditions, pageArgs := conditions, args
+	if filter.Cursor != "" {
+		if pageConditions, pageArgs, err = keys.after(filter.Cursor, conditions, args); err != nil {
+			return nil, page, err
+		}
+	}
+	paged := filter.Cursor != "" || filter.Limit > 0
+	if paged {
+		if err := r.db.Get(&page.Total, "SELECT count(*) "+from(conditions), args...); err != nil {
+			return nil, page, err
 		}
-		return 0, err
 	}
 
-	return itemId, tx.Commit()
+	query := fmt.Sprintf("SELECT %s %s %s", itemColumns, from(pageConditions), keys.orderBy())
+	if filter.Limit > 0 {
+		pageArgs = append(pageArgs, filter.Limit+1)
+		query += fmt.Sprintf(" LIMIT $%d", len(pageArgs))
+	}
+	if err := r.db.Select(&items, query, pageArgs...); err != nil {
+		return nil, page, err
+	}
+
+	if filter.Limit > 0 && len(items) > filter.Limit {
+		items = items[:filter.Limit]
+		page.NextCursor = keys.encodeCursor(itemSortValues(keys, items[len(items)-1]))
+	}
+	if !paged {
+		page.Total = len(items)
+	}
+	return items, page, nil
 }
 
-func (r *TodoItemPostgres) GetAll(listId int, userId int) ([]structs.Item, error) {
-	var items []structs.Item
+// Stamp sums up the items of the list for conditional requests. Trashing
+// the list hides its items, so it counts as a change of them.
+func (r *TodoItemPostgres) Stamp(listId int, userId int) (structs.CollectionStamp, error) {
+	var stamp structs.CollectionStamp
 
-	query := fmt.Sprintf(`SELECT ti.id, ti.title, ti.description, ti.done FROM %s ti 
+	query := fmt.Sprintf(`SELECT count(*) AS count, COALESCE(sum(ti.id), 0) AS id_sum,
+							count(*) FILTER (WHERE ti.deleted_at IS NULL AND ti.archived_at IS NULL) AS live,
+							max(GREATEST(ti.updated_at, ti.deleted_at, ti.archived_at, tl.deleted_at)) AS last_modified
+							FROM %s ti
 							INNER JOIN %s li on li.item_id=ti.id
+							INNER JOIN %s tl on tl.id=li.list_id
 							INNER JOIN %s ul on ul.list_id=li.list_id
-							WHERE li.list_id=$1 AND ul.user_id=$2`, todoItemsTable, listsItemsTable, usersListsTable)
-	if err := r.db.Select(&items, query, listId, userId); err != nil {
-		return nil, err
-	}
+							WHERE li.list_id=$1 AND ul.user_id=$2`,
+		todoItemsTable, listsItemsTable, todoListsTable, usersListsTable)
+	err := r.db.Get(&stamp, query, listId, userId)
 
-	return items, nil
+	return stamp, err
 }
 
 func (r *TodoItemPostgres) GetById(userId int, itemId int) (structs.Item, error) {
 	var item structs.Item
 
-	query := fmt.Sprintf(`SELECT ti.id, ti.title, ti.description, ti.done FROM %s ti 
+	query := fmt.Sprintf(`SELECT %s FROM %s ti 
 							INNER JOIN %s li on li.item_id=ti.id
 							INNER JOIN %s ul on ul.list_id=li.list_id
-							WHERE ti.id=$1 AND ul.user_id=$2`, todoItemsTable, listsItemsTable, usersListsTable)
+							WHERE ti.id=$1 AND ul.user_id=$2 AND %s`,
+		itemColumns, todoItemsTable, listsItemsTable, usersListsTable, liveItemCondition("ti", "li"))
 	if err := r.db.Get(&item, query, itemId, userId); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return item, ErrRecordNotFound
+		}
 		return item, err
 	}
 
 	return item, nil
 }
 
-func (r *TodoItemPostgres) Delete(userId int, itemId int) error {
-	query := fmt.Sprintf(`DELETE FROM %s ti USING %s li, %s ul 
-							WHERE ti.id=li.item_id
-							AND li.list_id=ul.list_id
-							AND ul.user_id=$1
-							AND ti.id = $2`, todoItemsTable, listsItemsTable, usersListsTable)
-	_, err := r.db.Exec(query, userId, itemId)
-	return err
+// Delete moves the item to the trash. A timer running on it is stopped, as
+// it can't be reached anymore to stop it. It returns the undo token.
+func (r *TodoItemPostgres) Delete(userId int, itemId int, version *int) (string, error) {
+	return journal(r.db, userId, func(tx *sql.Tx) error {
+		return r.trash(tx, userId, itemId, version)
+	})
+}
+
+// trash moves the item to the trash. With a version set, only that version
+// of the item is trashed, so a stale If-Match can't slip in between the
+// check and the change.
+func (r *TodoItemPostgres) trash(e execer, userId int, itemId int, version *int) error {
+	args := []interface{}{userId, itemId}
+	versionCondition := ""
+	if version != nil {
+		versionCondition = " AND ti.version=$3"
+		args = append(args, *version)
+	}
+	query := fmt.Sprintf(`WITH trashed AS (
+								UPDATE %s ti SET deleted_at=now(), deleted_by=$1 FROM %s li, %s ul
+								WHERE ti.id=li.item_id
+								AND li.list_id=ul.list_id
+								AND ul.user_id=$1
+								AND ti.id = $2
+								AND %s%s
+								RETURNING ti.id
+							), stopped AS (
+								UPDATE %s te SET stopped_at=now() FROM trashed
+								WHERE te.item_id=trashed.id AND te.stopped_at IS NULL
+							)
+							SELECT count(*) FROM trashed`,
+		todoItemsTable, listsItemsTable, usersListsTable, liveItemCondition("ti", "li"), versionCondition, timeEntriesTable)
+	var trashed int
+	if err := e.QueryRow(query, args...).Scan(&trashed); err != nil {
+		return err
+	}
+	return checkTrashed(trashed, version)
 }
 
-func (r *TodoItemPostgres) Update(userId int, itemId int, input structs.UpdateItemInput) error {
+// Update changes the fields of the item that are set and returns the undo
+// token.
+func (r *TodoItemPostgres) Update(userId int, itemId int, input structs.UpdateItemInput) (string, error) {
+	return journal(r.db, userId, func(tx *sql.Tx) error {
+		return r.update(tx, userId, itemId, input)
+	})
+}
+
+func (r *TodoItemPostgres) update(e execer, userId int, itemId int, input structs.UpdateItemInput) error {
 	setValues := make([]string, 0)
 	args := make([]interface{}, 0)
 	argId := 1
@@ -107,14 +258,393 @@ func (r *TodoItemPostgres) Update(userId int, itemId int, input structs.UpdateIt
 		argId++
 	}
 
+	if input.StatusId != nil {
+		setValues = append(setValues, fmt.Sprintf("status_id=$%d", argId))
+		args = append(args, *input.StatusId)
+		argId++
+	}
+
+	if input.DueDate != nil {
+		setValues = append(setValues, fmt.Sprintf("due_date=$%d", argId))
+		args = append(args, *input.DueDate)
+		argId++
+	} else if input.ClearDueDate {
+		setValues = append(setValues, "due_date=NULL")
+	}
+
+	if input.Recurrence != nil {
+		setValues = append(setValues, fmt.Sprintf("recurrence=NULLIF($%d, '')", argId))
+		args = append(args, *input.Recurrence)
+		argId++
+	}
+
+	if input.RecurrenceStart != nil {
+		setValues = append(setValues, fmt.Sprintf("recurrence_start=$%d", argId))
+		args = append(args, *input.RecurrenceStart)
+		argId++
+	}
+
+	if input.Priority != nil {
+		setValues = append(setValues, fmt.Sprintf("priority=$%d", argId))
+		args = append(args, *input.Priority)
+		argId++
+	}
+
+	if input.AssigneeId != nil {
+		setValues = append(setValues, fmt.Sprintf("assignee_id=$%d", argId))
+		args = append(args, *input.AssigneeId)
+		argId++
+	} else if input.ClearAssignee {
+		setValues = append(setValues, "assignee_id=NULL")
+	}
+
+	setValues = append(setValues, fmt.Sprintf("updated_by=$%d", argId))
+
 	setQuery := strings.Join(setValues, ",")
 	query := fmt.Sprintf(`UPDATE %s ti SET %s FROM %s li, %s ul
 							WHERE ti.id=li.item_id
 							AND li.list_id=ul.list_id
 							AND ul.user_id=$%d
-							AND ti.id=$%d`, todoItemsTable, setQuery, listsItemsTable, usersListsTable, argId, argId+1)
+							AND ti.id=$%d
+							AND %s`, todoItemsTable, setQuery, listsItemsTable, usersListsTable, argId, argId+1,
+		liveItemCondition("ti", "li"))
 	args = append(args, userId, itemId)
+	if input.Version != nil {
+		query += fmt.Sprintf(" AND ti.version=$%d", argId+2)
+		args = append(args, *input.Version)
+	}
+
+	result, err := e.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+	if input.Version == nil {
+		return checkAffected(result)
+	}
+	return checkVersion(result)
+}
 
-	_, err := r.db.Exec(query, args...)
+// Complete records the completion of a recurring item and rolls it forward
+// to change.Next, or closes it when that is nil. The update coming with the
+// completion, if any, is applied in the same transaction. It returns the
+// undo token.
+func (r *TodoItemPostgres) Complete(userId int, change structs.ItemChange) (string, error) {
+	return journal(r.db, userId, func(tx *sql.Tx) error {
+		return r.completeChange(tx, userId, change)
+	})
+}
+
+// completeChange applies the update of a completing change, if any, and then
+// the completion. The update checks the version and bumps it, so the
+// completion only checks it when there is no update.
+func (r *TodoItemPostgres) completeChange(tx *sql.Tx, userId int, change structs.ItemChange) error {
+	version := change.Version
+	if change.Update != nil {
+		if err := r.update(tx, userId, change.ItemId, *change.Update); err != nil {
+			return err
+		}
+		version = nil
+	}
+	return r.complete(tx, userId, change.ItemId, version, change.Next)
+}
+
+func (r *TodoItemPostgres) complete(tx *sql.Tx, userId int, itemId int, version *int, next *time.Time) error {
+	args := []interface{}{userId, itemId}
+	versionCondition := ""
+	if version != nil {
+		versionCondition = " AND ti.version=$3"
+		args = append(args, *version)
+	}
+	createCompletionQuery := fmt.Sprintf(`INSERT INTO %s (item_id, due_date)
+							SELECT ti.id, ti.due_date FROM %s ti
+							INNER JOIN %s li on li.item_id=ti.id
+							INNER JOIN %s ul on ul.list_id=li.list_id
+							WHERE ul.user_id=$1 AND ti.id=$2 AND %s%s`,
+		itemsCompletionsTable, todoItemsTable, listsItemsTable, usersListsTable, liveItemCondition("ti", "li"), versionCondition)
+	result, err := tx.Exec(createCompletionQuery, args...)
+	if err != nil {
+		return err
+	}
+	if version != nil {
+		err = checkVersion(result)
+	} else {
+		err = checkAffected(result)
+	}
+	if err != nil {
+		return err
+	}
+
+	args = nil
+
+	var updateItemQuery string
+	if next != nil {
+		updateItemQuery = fmt.Sprintf("UPDATE %s SET done=false, due_date=$1, updated_by=$2 WHERE id=$3", todoItemsTable)
+		args = append(args, *next, userId, itemId)
+	} else {
+		updateItemQuery = fmt.Sprintf("UPDATE %s SET done=true, updated_by=$1 WHERE id=$2", todoItemsTable)
+		args = append(args, userId, itemId)
+	}
+	_, err = tx.Exec(updateItemQuery, args...)
 	return err
 }
+
+func (r *TodoItemPostgres) GetCompletions(userId int, itemId int) ([]structs.ItemCompletion, error) {
+	var completions []structs.ItemCompletion
+
+	query := fmt.Sprintf(`SELECT ic.id, ic.item_id, ic.due_date, ic.completed_at FROM %s ic
+							INNER JOIN %s li on li.item_id=ic.item_id
+							INNER JOIN %s ul on ul.list_id=li.list_id
+							WHERE ic.item_id=$1 AND ul.user_id=$2
+							ORDER BY ic.completed_at DESC`, itemsCompletionsTable, listsItemsTable, usersListsTable)
+	if err := r.db.Select(&completions, query, itemId, userId); err != nil {
+		return nil, err
+	}
+
+	return completions, nil
+}
+
+// Move moves the item to another list of the user and returns the undo
+// token.
+func (r *TodoItemPostgres) Move(userId int, itemId int, listId int) (string, error) {
+	return journal(r.db, userId, func(tx *sql.Tx) error {
+		return r.move(tx, userId, itemId, listId)
+	})
+}
+
+func (r *TodoItemPostgres) move(e execer, userId int, itemId int, listId int) error {
+	// Statuses belong to a list, so the item switches to the matching
+	// status of the target list along with the move.
+	statusQuery := fmt.Sprintf(statusInListQuery, statusesTable, 1, statusesTable)
+	query := fmt.Sprintf(`WITH moved AS (
+								UPDATE %s li SET list_id=$1 FROM %s ul, %s tul
+								WHERE li.list_id=ul.list_id
+								AND ul.user_id=$2
+								AND tul.list_id=$1
+								AND tul.user_id=$2
+								AND li.item_id=$3
+								RETURNING li.item_id
+							)
+							UPDATE %s ti SET status_id=%s, updated_by=$2 FROM moved WHERE ti.id=moved.item_id`,
+		listsItemsTable, usersListsTable, usersListsTable, todoItemsTable, statusQuery)
+	result, err := e.Exec(query, listId, userId, itemId)
+	if err != nil {
+		return err
+	}
+
+	return checkAffected(result)
+}
+
+func (r *TodoItemPostgres) Copy(userId int, itemId int, input structs.CopyItemInput) (int, string, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, "", err
+	}
+
+	token, err := beginJournal(tx, userId)
+	if err != nil {
+		rolError := tx.Rollback()
+		if rolError != nil {
+			return 0, "", rolError
+		}
+		return 0, "", err
+	}
+
+	var copyId int
+	statusQuery := fmt.Sprintf(statusInListQuery, statusesTable, 3, statusesTable)
+	assigneeQuery := fmt.Sprintf(assigneeInListQuery, usersListsTable, 3)
+	copyItemQuery := fmt.Sprintf(`INSERT INTO %s (title, description, done, status_id, due_date, recurrence, recurrence_start,
+							priority, assignee_id, created_by, updated_by)
+							SELECT ti.title, ti.description, ti.done, %s, ti.due_date, ti.recurrence, ti.recurrence_start,
+							ti.priority, %s, $2, $2 FROM %s ti
+							INNER JOIN %s li on li.item_id=ti.id
+							INNER JOIN %s ul on ul.list_id=li.list_id
+							WHERE ti.id=$1 AND ul.user_id=$2 AND %s
+							RETURNING id`, todoItemsTable, statusQuery, assigneeQuery, todoItemsTable, listsItemsTable,
+		usersListsTable, liveItemCondition("ti", "li"))
+	row := tx.QueryRow(copyItemQuery, itemId, userId, input.ListId)
+	if err := row.Scan(&copyId); err != nil {
+		rolError := tx.Rollback()
+		if rolError != nil {
+			return 0, "", rolError
+		}
+		return 0, "", err
+	}
+
+	var listItemId int
+	createListItemsQuery := fmt.Sprintf(`INSERT INTO %s (list_id, item_id)
+							SELECT ul.list_id, $1 FROM %s ul
+							WHERE ul.list_id=$2 AND ul.user_id=$3
+							RETURNING id`, listsItemsTable, usersListsTable)
+	row = tx.QueryRow(createListItemsQuery, copyId, input.ListId, userId)
+	if err := row.Scan(&listItemId); err != nil {
+		rolError := tx.Rollback()
+		if rolError != nil {
+			return 0, "", rolError
+		}
+		return 0, "", err
+	}
+
+	if input.Labels {
+		copyLabelsQuery := fmt.Sprintf(`INSERT INTO %s (item_id, label_id)
+							SELECT $1, il.label_id FROM %s il
+							WHERE il.item_id=$2`, itemsLabelsTable, itemsLabelsTable)
+		if _, err := tx.Exec(copyLabelsQuery, copyId, itemId); err != nil {
+			rolError := tx.Rollback()
+			if rolError != nil {
+				return 0, "", rolError
+			}
+			return 0, "", err
+		}
+	}
+
+	return copyId, token, tx.Commit()
+}
+
+// Batch applies the changes of an item batch in one transaction, see
+// runBatch. A completing update records the completion along with it.
+func (r *TodoItemPostgres) Batch(userId int, changes []structs.ItemChange, atomic bool) ([]structs.BatchResult, string, error) {
+	return runBatch(r.db, userId, len(changes), atomic, func(tx *sql.Tx, i int) (int, error) {
+		change := changes[i]
+		switch change.Op {
+		case structs.OpCreate:
+			return r.create(tx, change.ListId, userId, change.Item)
+		case structs.OpUpdate:
+			if change.Complete {
+				if err := r.completeChange(tx, userId, change); err != nil {
+					return 0, err
+				}
+			} else if change.Update != nil {
+				if err := r.update(tx, userId, change.ItemId, *change.Update); err != nil {
+					return 0, err
+				}
+			}
+		case structs.OpMove:
+			if err := r.move(tx, userId, change.ItemId, change.ListId); err != nil {
+				return 0, err
+			}
+		case structs.OpDelete:
+			if err := r.trash(tx, userId, change.ItemId, nil); err != nil {
+				return 0, err
+			}
+		default:
+			return 0, fmt.Errorf("%w: unknown op %q", structs.ErrInvalidOperation, change.Op)
+		}
+		return change.ItemId, nil
+	})
+}
+
+// ArchiveCompleted archives the items that have been done for longer than
+// their list's auto_archive_days and returns how many it archived.
+func (r *TodoItemPostgres) ArchiveCompleted() (int64, error) {
+	query := fmt.Sprintf(`UPDATE %s ti SET archived_at=now() FROM %s li, %s tl
+							WHERE li.item_id=ti.id
+							AND tl.id=li.list_id
+							AND tl.auto_archive_days IS NOT NULL
+							AND ti.done
+							AND ti.archived_at IS NULL
+							AND ti.completed_at < now() - tl.auto_archive_days * interval '1 day'
+							AND %s`, todoItemsTable, listsItemsTable, todoListsTable, liveItemCondition("ti", "li"))
+	res, err := r.db.Exec(query)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// copyList copies the items of one list into another inside the caller's
+// transaction. Ids for the copies are drawn from the sequence up front, so
+// every step is a single statement over the whole list no matter how many
+// items it has. Items not done in the copy take a status that isn't done
+// either; blockers are only kept when they were copied along.
+func (r *TodoItemPostgres) copyList(tx *sql.Tx, userId int, fromListId int, toListId int,
+	input structs.DuplicateListInput, storageKeys map[string]string, quota int64) error {
+	createCopiesQuery := "CREATE TEMP TABLE item_copies (old_id int, new_id int) ON COMMIT DROP"
+	if _, err := tx.Exec(createCopiesQuery); err != nil {
+		return err
+	}
+
+	mapCopiesQuery := fmt.Sprintf(`INSERT INTO item_copies (old_id, new_id)
+							SELECT ti.id, nextval(pg_get_serial_sequence('%s', 'id')) FROM %s ti
+							INNER JOIN %s li on li.item_id=ti.id
+							WHERE li.list_id=$1 AND %s
+							ORDER BY ti.id`, todoItemsTable, todoItemsTable, listsItemsTable, liveItemCondition("ti", "li"))
+	if _, err := tx.Exec(mapCopiesQuery, fromListId); err != nil {
+		return err
+	}
+
+	copyItemsQuery := fmt.Sprintf(`INSERT INTO %s (id, title, description, done, status_id, position, due_date,
+							recurrence, recurrence_start, archived_at, priority, assignee_id, created_by, updated_by)
+							SELECT ic.new_id, ti.title, ti.description, ti.done AND $2,
+							(SELECT s.id FROM %s s
+								WHERE s.list_id=$1 AND s.is_done=(ti.done AND $2)
+								ORDER BY (s.name=(SELECT cs.name FROM %s cs WHERE cs.id=ti.status_id)) IS TRUE DESC, s.position, s.id
+								LIMIT 1),
+							ti.position, ti.due_date, ti.recurrence, ti.recurrence_start,
+							CASE WHEN $2 THEN ti.archived_at END, ti.priority, %s, $3, $3
+							FROM item_copies ic INNER JOIN %s ti on ti.id=ic.old_id`,
+		todoItemsTable, statusesTable, statusesTable, fmt.Sprintf(assigneeInListQuery, usersListsTable, 1), todoItemsTable)
+	if _, err := tx.Exec(copyItemsQuery, toListId, input.Done, userId); err != nil {
+		return err
+	}
+
+	createListItemsQuery := fmt.Sprintf(`INSERT INTO %s (list_id, item_id)
+							SELECT $1, ic.new_id FROM item_copies ic`, listsItemsTable)
+	if _, err := tx.Exec(createListItemsQuery, toListId); err != nil {
+		return err
+	}
+
+	copyDependenciesQuery := fmt.Sprintf(`INSERT INTO %s (item_id, blocker_id)
+							SELECT ic.new_id, bc.new_id FROM %s d
+							INNER JOIN item_copies ic on ic.old_id=d.item_id
+							INNER JOIN item_copies bc on bc.old_id=d.blocker_id`, itemsDependenciesTable, itemsDependenciesTable)
+	if _, err := tx.Exec(copyDependenciesQuery); err != nil {
+		return err
+	}
+
+	if input.Labels {
+		copyLabelsQuery := fmt.Sprintf(`INSERT INTO %s (item_id, label_id, position)
+							SELECT ic.new_id, il.label_id, il.position FROM %s il
+							INNER JOIN item_copies ic on ic.old_id=il.item_id`, itemsLabelsTable, itemsLabelsTable)
+		if _, err := tx.Exec(copyLabelsQuery); err != nil {
+			return err
+		}
+	}
+
+	if len(storageKeys) == 0 {
+		return nil
+	}
+
+	// Serialized with uploads of the same user, like in AttachmentPostgres.
+	if _, err := tx.Exec("SELECT pg_advisory_xact_lock($1)", userId); err != nil {
+		return err
+	}
+
+	oldKeys := make([]string, 0, len(storageKeys))
+	newKeys := make([]string, 0, len(storageKeys))
+	for oldKey, newKey := range storageKeys {
+		oldKeys = append(oldKeys, oldKey)
+		newKeys = append(newKeys, newKey)
+	}
+	copyAttachmentsQuery := fmt.Sprintf(`INSERT INTO %s (item_id, user_id, filename, content_type, size, storage_key)
+							SELECT ic.new_id, $1, a.filename, a.content_type, a.size, k.new_key FROM %s a
+							INNER JOIN item_copies ic on ic.old_id=a.item_id
+							INNER JOIN unnest($2::text[], $3::text[]) AS k(old_key, new_key) on k.old_key=a.storage_key
+							ORDER BY a.id`, attachmentsTable, attachmentsTable)
+	if _, err := tx.Exec(copyAttachmentsQuery, userId, pq.Array(oldKeys), pq.Array(newKeys)); err != nil {
+		return err
+	}
+
+	if quota > 0 {
+		var usage int64
+		usageQuery := fmt.Sprintf("SELECT COALESCE(SUM(a.size), 0) FROM %s a WHERE a.user_id=$1", attachmentsTable)
+		row := tx.QueryRow(usageQuery, userId)
+		if err := row.Scan(&usage); err != nil {
+			return err
+		}
+		if usage > quota {
+			return ErrQuotaExceeded
+		}
+	}
+
+	return nil
+}
@@ -1,13 +1,14 @@
 package repository
 
 import (
-	"database/sql"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/fr13n8/todo-app/structs"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -23,6 +24,7 @@ func TestTodoItemPostgres_Create(t *testing.T) {
 
 	type input struct {
 		listId int
+		userId int
 		item   structs.Item
 	}
 	type mockBehavior func(input input, id int)
@@ -38,6 +40,7 @@ func TestTodoItemPostgres_Create(t *testing.T) {
 			name: "OK",
 			input: input{
 				listId: 1,
+				userId: 2,
 				item: structs.Item{
 					Id:          1,
 					Title:       "Test title",
@@ -47,10 +50,11 @@ func TestTodoItemPostgres_Create(t *testing.T) {
 			wantId: 1,
 			mockBehavior: func(input input, id int) {
 				mock.ExpectBegin()
+				expectJournal(mock, input.userId)
 
 				rows := sqlmock.NewRows([]string{"wantId"}).AddRow(id)
 				mock.ExpectQuery("INSERT INTO todo_items").
-					WithArgs(input.item.Title, input.item.Description).
+					WithArgs(input.item.Title, input.item.Description, input.item.Done, input.item.StatusId, input.item.DueDate, input.item.Recurrence, input.item.RecurrenceStart, input.item.Priority, input.item.AssigneeId, input.userId).
 					WillReturnRows(rows)
 
 				mock.ExpectExec("INSERT INTO lists_items").
@@ -64,6 +68,7 @@ func TestTodoItemPostgres_Create(t *testing.T) {
 			name: "Empty Fields",
 			input: input{
 				listId: 1,
+				userId: 2,
 				item: structs.Item{
 					Title:       "",
 					Description: "description",
@@ -71,10 +76,11 @@ func TestTodoItemPostgres_Create(t *testing.T) {
 			},
 			mockBehavior: func(input input, id int) {
 				mock.ExpectBegin()
+				expectJournal(mock, input.userId)
 
 				rows := sqlmock.NewRows([]string{"id"}).AddRow(id).RowError(1, errors.New("insert error"))
 				mock.ExpectQuery("INSERT INTO todo_items").
-					WithArgs(input.item.Title, input.item.Description).
+					WithArgs(input.item.Title, input.item.Description, input.item.Done, input.item.StatusId, input.item.DueDate, input.item.Recurrence, input.item.RecurrenceStart, input.item.Priority, input.item.AssigneeId, input.userId).
 					WillReturnRows(rows)
 
 				mock.ExpectRollback()
@@ -85,6 +91,7 @@ func TestTodoItemPostgres_Create(t *testing.T) {
 			name: "Failed 2nd Insert",
 			input: input{
 				listId: 1,
+				userId: 2,
 				item: structs.Item{
 					Id:          1,
 					Title:       "title",
@@ -93,10 +100,11 @@ func TestTodoItemPostgres_Create(t *testing.T) {
 			},
 			mockBehavior: func(input input, id int) {
 				mock.ExpectBegin()
+				expectJournal(mock, input.userId)
 
 				rows := sqlmock.NewRows([]string{"id"}).AddRow(id)
 				mock.ExpectQuery("INSERT INTO todo_items").
-					WithArgs(input.item.Title, input.item.Description).
+					WithArgs(input.item.Title, input.item.Description, input.item.Done, input.item.StatusId, input.item.DueDate, input.item.Recurrence, input.item.RecurrenceStart, input.item.Priority, input.item.AssigneeId, input.userId).
 					WillReturnRows(rows)
 
 				mock.ExpectExec("INSERT INTO lists_items").
@@ -113,11 +121,13 @@ func TestTodoItemPostgres_Create(t *testing.T) {
 		t.Run(testCase.name, func(t *testing.T) {
 			testCase.mockBehavior(testCase.input, testCase.wantId)
 
-			got, err := r.Create(testCase.input.listId, testCase.input.item)
+			got, token, err := r.Create(testCase.input.listId, testCase.input.userId, testCase.input.item)
 			if testCase.wantErr {
 				assert.Error(t, err)
+				assert.Empty(t, token)
 			} else {
 				assert.NoError(t, err)
+				assert.NotEmpty(t, token)
 				assert.Equal(t, testCase.wantId, got)
 			}
 			assert.NoError(t, mock.ExpectationsWereMet())
@@ -223,16 +233,24 @@ func TestTodoItemPostgres_GetAll(t *testing.T) {
 	type input struct {
 		listId int
 		userId int
+		filter structs.ItemFilter
 	}
 
 	type mockBehavior func(input input)
 
+	completedBefore := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	completedAt := time.Date(2021, 5, 30, 10, 0, 0, 0, time.UTC)
+	dueDate := time.Date(2021, 6, 10, 0, 0, 0, 0, time.UTC)
+	keys, _ := newKeyset("-due_date,title", "", itemSortColumns)
+	secondPage := keys.encodeCursor([]interface{}{nil, "title2", 2})
+
 	testTable := []struct {
 		name         string
 		input        input
 		wantErr      bool
 		mockBehavior mockBehavior
 		want         []structs.Item
+		wantPage     structs.PageInfo
 	}{
 		{
 			name: "OK",
@@ -273,6 +291,191 @@ func TestTodoItemPostgres_GetAll(t *testing.T) {
 					Done:        true,
 				},
 			},
+			wantPage: structs.PageInfo{Total: 3},
+		},
+		{
+			name: "OK_WithLabels",
+			input: input{
+				listId: 1,
+				userId: 1,
+				filter: structs.ItemFilter{LabelIds: []int{1, 2}},
+			},
+			mockBehavior: func(input input) {
+				rows := sqlmock.NewRows([]string{"id", "title", "description", "done"}).
+					AddRow("1", "title", "description", false)
+
+				mock.ExpectQuery(`SELECT (.+) FROM todo_items ti 
+									INNER JOIN lists_items li on (.+)
+									INNER JOIN users_lists ul on (.+)
+									WHERE (.+) AND ti.id IN \(SELECT il.item_id FROM items_labels il (.+)\)`).
+					WithArgs(input.listId, input.userId, pq.Array(input.filter.LabelIds), len(input.filter.LabelIds)).
+					WillReturnRows(rows)
+			},
+			want: []structs.Item{
+				{
+					Id:          1,
+					Title:       "title",
+					Description: "description",
+					Done:        false,
+				},
+			},
+			wantPage: structs.PageInfo{Total: 1},
+		},
+		{
+			name: "OK_WithAuditFilter",
+			input: input{
+				listId: 1,
+				userId: 1,
+				filter: structs.ItemFilter{
+					AuditFilter: structs.AuditFilter{
+						Sort:            "created_at",
+						CompletedBefore: &completedBefore,
+						UpdatedBy:       intPointer(2),
+					},
+					LabelIds: []int{3},
+				},
+			},
+			mockBehavior: func(input input) {
+				rows := sqlmock.NewRows([]string{"id", "title", "description", "done", "completed_at", "updated_by"}).
+					AddRow("1", "title", "description", true, completedAt, 2)
+
+				mock.ExpectQuery(`SELECT (.+) FROM todo_items ti
+									INNER JOIN lists_items li on (.+)
+									INNER JOIN users_lists ul on (.+)
+									WHERE (.+) AND ti.completed_at<\$5 AND ti.updated_by=\$6
+									ORDER BY ti.created_at ASC NULLS LAST, ti.id ASC`).
+					WithArgs(input.listId, input.userId, pq.Array(input.filter.LabelIds), 1, completedBefore, 2).
+					WillReturnRows(rows)
+			},
+			want: []structs.Item{
+				{
+					Id:          1,
+					Title:       "title",
+					Description: "description",
+					Done:        true,
+					CompletedAt: &completedAt,
+					UpdatedBy:   intPointer(2),
+				},
+			},
+			wantPage: structs.PageInfo{Total: 1},
+		},
+		{
+			name: "OK_Archived",
+			input: input{
+				listId: 1,
+				userId: 1,
+				filter: structs.ItemFilter{Archived: true},
+			},
+			mockBehavior: func(input input) {
+				rows := sqlmock.NewRows([]string{"id", "title", "description", "done", "archived_at"}).
+					AddRow("1", "title", "description", true, completedAt)
+
+				mock.ExpectQuery(`SELECT (.+) FROM todo_items ti
+									INNER JOIN lists_items li on (.+)
+									INNER JOIN users_lists ul on (.+)
+									WHERE (.+) AND ti.archived_at IS NOT NULL
+									ORDER BY ti.id ASC`).
+					WithArgs(input.listId, input.userId).
+					WillReturnRows(rows)
+			},
+			want: []structs.Item{
+				{
+					Id:          1,
+					Title:       "title",
+					Description: "description",
+					Done:        true,
+					ArchivedAt:  &completedAt,
+				},
+			},
+			wantPage: structs.PageInfo{Total: 1},
+		},
+		{
+			name: "OK_FirstPage",
+			input: input{
+				listId: 1,
+				userId: 1,
+				filter: structs.ItemFilter{
+					AuditFilter:   structs.AuditFilter{Sort: "-due_date,title"},
+					Done:          boolPointer(false),
+					TitleContains: "50%",
+					PageFilter:    structs.PageFilter{Limit: 2},
+				},
+			},
+			mockBehavior: func(input input) {
+				mock.ExpectQuery(`SELECT count\(\*\) FROM todo_items ti
+									INNER JOIN lists_items li on (.+)
+									INNER JOIN users_lists ul on (.+)
+									WHERE (.+) AND ti.done=\$3 AND ti.title ILIKE \$4$`).
+					WithArgs(input.listId, input.userId, false, `%50\%%`).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(5))
+
+				rows := sqlmock.NewRows([]string{"id", "title", "due_date"}).
+					AddRow("1", "title", dueDate).
+					AddRow("2", "title2", nil).
+					AddRow("3", "title3", nil)
+				mock.ExpectQuery(`SELECT (.+) FROM todo_items ti
+									INNER JOIN lists_items li on (.+)
+									INNER JOIN users_lists ul on (.+)
+									WHERE (.+) AND ti.done=\$3 AND ti.title ILIKE \$4
+									ORDER BY ti.due_date DESC NULLS LAST, ti.title ASC NULLS LAST, ti.id ASC LIMIT \$5`).
+					WithArgs(input.listId, input.userId, false, `%50\%%`, 3).
+					WillReturnRows(rows)
+			},
+			want: []structs.Item{
+				{Id: 1, Title: "title", DueDate: &dueDate},
+				{Id: 2, Title: "title2"},
+			},
+			wantPage: structs.PageInfo{Total: 5, NextCursor: secondPage},
+		},
+		{
+			name: "OK_NextPage",
+			input: input{
+				listId: 1,
+				userId: 1,
+				filter: structs.ItemFilter{
+					AuditFilter: structs.AuditFilter{Sort: "-due_date,title"},
+					PageFilter:  structs.PageFilter{Cursor: secondPage, Limit: 2},
+				},
+			},
+			mockBehavior: func(input input) {
+				mock.ExpectQuery(`SELECT count\(\*\) FROM (.+) WHERE (.+) AND ti.archived_at IS NULL$`).
+					WithArgs(input.listId, input.userId).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(5))
+
+				rows := sqlmock.NewRows([]string{"id", "title"}).
+					AddRow("3", "title3")
+				mock.ExpectQuery(`SELECT (.+) FROM (.+) WHERE (.+) AND ti.archived_at IS NULL AND
+									\(\(\(ti.due_date IS NULL\)>\$3\) OR
+									\(\(ti.due_date IS NULL\) IS NOT DISTINCT FROM \$4 AND ti.due_date<\$5\) OR
+									\(\(ti.due_date IS NULL\) IS NOT DISTINCT FROM \$6 AND ti.due_date IS NOT DISTINCT FROM \$7 AND ti.title>\$8\) OR
+									\(\(ti.due_date IS NULL\) IS NOT DISTINCT FROM \$9 AND ti.due_date IS NOT DISTINCT FROM \$10 AND ti.title IS NOT DISTINCT FROM \$11 AND ti.id>\$12\)\)
+									ORDER BY (.+) LIMIT \$13`).
+					WithArgs(input.listId, input.userId, true, true, nil, true, nil, "title2",
+						true, nil, "title2", float64(2), 3).
+					WillReturnRows(rows)
+			},
+			want:     []structs.Item{{Id: 3, Title: "title3"}},
+			wantPage: structs.PageInfo{Total: 5},
+		},
+		{
+			name: "Invalid sort",
+			input: input{
+				listId: 1,
+				userId: 1,
+				filter: structs.ItemFilter{AuditFilter: structs.AuditFilter{Sort: "title,color"}},
+			},
+			mockBehavior: func(input input) {},
+			wantErr:      true,
+		},
+		{
+			name: "Cursor of another sort",
+			input: input{
+				listId: 1,
+				userId: 1,
+				filter: structs.ItemFilter{PageFilter: structs.PageFilter{Cursor: secondPage}},
+			},
+			mockBehavior: func(input input) {},
+			wantErr:      true,
 		},
 		{
 			name: "no records",
@@ -297,18 +500,45 @@ func TestTodoItemPostgres_GetAll(t *testing.T) {
 		t.Run(testCase.name, func(t *testing.T) {
 			testCase.mockBehavior(testCase.input)
 
-			got, err := r.GetAll(testCase.input.listId, testCase.input.userId)
+			got, page, err := r.GetAll(testCase.input.listId, testCase.input.userId, testCase.input.filter)
 			if testCase.wantErr {
 				assert.Error(t, err)
 			} else {
 				assert.NoError(t, err)
 				assert.Equal(t, testCase.want, got)
+				assert.Equal(t, testCase.wantPage, page)
 			}
 			assert.NoError(t, mock.ExpectationsWereMet())
 		})
 	}
 }
 
+func TestTodoItemPostgres_Stamp(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "smock")
+
+	r := NewTodoItemPostgres(db)
+
+	modified := time.Date(2021, 6, 2, 10, 0, 0, 0, time.UTC)
+	rows := sqlmock.NewRows([]string{"count", "id_sum", "live", "last_modified"}).AddRow(3, 6, 2, modified)
+	mock.ExpectQuery(`SELECT count\(\*\) AS count, COALESCE\(sum\(ti.id\), 0\) AS id_sum,
+						count\(\*\) FILTER \(WHERE ti.deleted_at IS NULL AND ti.archived_at IS NULL\) AS live,
+						max\(GREATEST\(ti.updated_at, ti.deleted_at, ti.archived_at, tl.deleted_at\)\) AS last_modified
+						FROM todo_items ti (.+)
+						WHERE li.list_id=\$1 AND ul.user_id=\$2`).
+		WithArgs(1, 1).
+		WillReturnRows(rows)
+
+	got, err := r.Stamp(1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, structs.CollectionStamp{Count: 3, IdSum: 6, Live: 2, LastModified: &modified}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestTodoItemPostgres_Delete(t *testing.T) {
 	mockDB, mock, err := sqlmock.New()
 	if err != nil {
@@ -320,8 +550,9 @@ func TestTodoItemPostgres_Delete(t *testing.T) {
 	r := NewTodoItemPostgres(db)
 
 	type input struct {
-		userId int
-		itemId int
+		userId  int
+		itemId  int
+		version *int
 	}
 
 	type mockBehavior func(input input)
@@ -332,14 +563,38 @@ func TestTodoItemPostgres_Delete(t *testing.T) {
 		mockBehavior mockBehavior
 		input        input
 	}{
+		{
+			name: "Stale version",
+			mockBehavior: func(input input) {
+				mock.ExpectBegin()
+				expectJournal(mock, input.userId)
+
+				mock.ExpectQuery(`WITH trashed AS (.+) AND ti.version=\$3 RETURNING ti.id`).
+					WithArgs(input.userId, input.itemId, *input.version).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+				mock.ExpectRollback()
+			},
+			input: input{
+				userId:  1,
+				itemId:  1,
+				version: intPointer(3),
+			},
+			wantErr: true,
+		},
 		{
 			name: "Ok",
 			mockBehavior: func(input input) {
-				mock.ExpectExec(`DELETE FROM todo_items ti 
-									USING lists_items li, users_lists ul 
-									WHERE (.+)`).
+				mock.ExpectBegin()
+				expectJournal(mock, input.userId)
+
+				mock.ExpectQuery(`WITH trashed AS \( UPDATE todo_items ti SET deleted_at=now\(\), deleted_by=\$1 FROM lists_items li, users_lists ul
+									WHERE (.+) RETURNING ti.id \), stopped AS \(
+									UPDATE time_entries te SET stopped_at=now\(\) FROM trashed WHERE (.+) \) SELECT count\(\*\) FROM trashed`).
 					WithArgs(input.userId, input.itemId).
-					WillReturnResult(sqlmock.NewResult(1, 1))
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+				mock.ExpectCommit()
 			},
 			input: input{
 				userId: 1,
@@ -349,11 +604,16 @@ func TestTodoItemPostgres_Delete(t *testing.T) {
 		{
 			name: "No record found",
 			mockBehavior: func(input input) {
-				mock.ExpectExec(`DELETE FROM todo_items ti 
-									USING lists_items li, users_lists ul 
-									WHERE (.+)`).
+				mock.ExpectBegin()
+				expectJournal(mock, input.userId)
+
+				mock.ExpectQuery(`WITH trashed AS \( UPDATE todo_items ti SET deleted_at=now\(\), deleted_by=\$1 FROM lists_items li, users_lists ul
+									WHERE (.+) RETURNING ti.id \), stopped AS \(
+									UPDATE time_entries te SET stopped_at=now\(\) FROM trashed WHERE (.+) \) SELECT count\(\*\) FROM trashed`).
 					WithArgs(input.userId, input.itemId).
-					WillReturnError(sql.ErrNoRows)
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+				mock.ExpectRollback()
 			},
 			input: input{
 				userId: 1,
@@ -367,11 +627,13 @@ func TestTodoItemPostgres_Delete(t *testing.T) {
 		t.Run(testCase.name, func(t *testing.T) {
 			testCase.mockBehavior(testCase.input)
 
-			err := r.Delete(testCase.input.userId, testCase.input.itemId)
+			token, err := r.Delete(testCase.input.userId, testCase.input.itemId, testCase.input.version)
 			if testCase.wantErr {
 				assert.Error(t, err)
+				assert.Empty(t, token)
 			} else {
 				assert.NoError(t, err)
+				assert.NotEmpty(t, token)
 			}
 			assert.NoError(t, mock.ExpectationsWereMet())
 		})
@@ -450,10 +712,59 @@ func TestTodoItemPostgres_Update(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Ok_ClearDueDate",
+			mockBehavior: func(input input) {
+				mock.ExpectExec(`UPDATE todo_items ti SET title=\$1,due_date=NULL,updated_by=\$2 FROM lists_items li, users_lists ul WHERE (.+)`).
+					WithArgs(input.item.Title, input.itemId, input.userId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			input: input{
+				itemId: 1,
+				userId: 1,
+				item: structs.UpdateItemInput{
+					Title:        stringPointer("new title"),
+					ClearDueDate: true,
+				},
+			},
+		},
+		{
+			name: "Ok_Version",
+			mockBehavior: func(input input) {
+				mock.ExpectExec(`UPDATE todo_items ti SET title=\$1,updated_by=\$2 FROM lists_items li, users_lists ul WHERE (.+) AND ti.version=\$4`).
+					WithArgs(input.item.Title, input.userId, input.itemId, 3).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			input: input{
+				itemId: 1,
+				userId: 1,
+				item: structs.UpdateItemInput{
+					Title:   stringPointer("new title"),
+					Version: intPointer(3),
+				},
+			},
+		},
+		{
+			name: "Version changed",
+			mockBehavior: func(input input) {
+				mock.ExpectExec(`UPDATE todo_items ti SET title=\$1,updated_by=\$2 FROM lists_items li, users_lists ul WHERE (.+) AND ti.version=\$4`).
+					WithArgs(input.item.Title, input.userId, input.itemId, 3).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			input: input{
+				itemId: 1,
+				userId: 1,
+				item: structs.UpdateItemInput{
+					Title:   stringPointer("new title"),
+					Version: intPointer(3),
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "OK_NoInputFields",
 			mockBehavior: func(input input) {
-				mock.ExpectExec("UPDATE todo_items ti SET FROM lists_items li, users_lists ul WHERE (.+)").
+				mock.ExpectExec(`UPDATE todo_items ti SET updated_by=\$1 FROM lists_items li, users_lists ul WHERE (.+)`).
 					WithArgs(input.itemId, input.userId).
 					WillReturnResult(sqlmock.NewResult(0, 1))
 			},
@@ -466,23 +777,713 @@ func TestTodoItemPostgres_Update(t *testing.T) {
 
 	for _, testCase := range testTable {
 		t.Run(testCase.name, func(t *testing.T) {
+			mock.ExpectBegin()
+			expectJournal(mock, testCase.input.userId)
 			testCase.mockBehavior(testCase.input)
+			if testCase.wantErr {
+				mock.ExpectRollback()
+			} else {
+				mock.ExpectCommit()
+			}
 
-			err := r.Update(testCase.input.userId, testCase.input.itemId, testCase.input.item)
+			token, err := r.Update(testCase.input.userId, testCase.input.itemId, testCase.input.item)
 			if testCase.wantErr {
 				assert.Error(t, err)
+				assert.Empty(t, token)
 			} else {
 				assert.NoError(t, err)
+				assert.NotEmpty(t, token)
 			}
 			assert.NoError(t, mock.ExpectationsWereMet())
 		})
 	}
 }
 
-func stringPointer(s string) *string {
-	return &s
-}
+func TestTodoItemPostgres_Complete(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "smock")
 
-func boolPointer(b bool) *bool {
-	return &b
+	r := NewTodoItemPostgres(db)
+
+	next := time.Date(2026, 10, 27, 9, 0, 0, 0, time.UTC)
+
+	type input struct {
+		userId  int
+		itemId  int
+		update  *structs.UpdateItemInput
+		next    *time.Time
+		version *int
+	}
+
+	type mockBehavior func(input input)
+
+	testTable := []struct {
+		name         string
+		input        input
+		mockBehavior mockBehavior
+		wantErr      bool
+	}{
+		{
+			name: "Ok_RollForward",
+			input: input{
+				userId: 1,
+				itemId: 1,
+				next:   &next,
+			},
+			mockBehavior: func(input input) {
+				mock.ExpectBegin()
+				expectJournal(mock, input.userId)
+
+				mock.ExpectExec(`INSERT INTO items_completions \(item_id, due_date\)
+									SELECT (.+) FROM todo_items ti
+									INNER JOIN lists_items li on (.+)
+									INNER JOIN users_lists ul on (.+)
+									WHERE (.+)`).
+					WithArgs(input.userId, input.itemId).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				mock.ExpectExec(`UPDATE todo_items SET done=false, due_date=\$1, updated_by=\$2 WHERE id=\$3`).
+					WithArgs(*input.next, input.userId, input.itemId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Ok_SeriesOver",
+			input: input{
+				userId: 1,
+				itemId: 1,
+			},
+			mockBehavior: func(input input) {
+				mock.ExpectBegin()
+				expectJournal(mock, input.userId)
+
+				mock.ExpectExec("INSERT INTO items_completions").
+					WithArgs(input.userId, input.itemId).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				mock.ExpectExec(`UPDATE todo_items SET done=true, updated_by=\$1 WHERE id=\$2`).
+					WithArgs(input.userId, input.itemId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Ok_WithUpdate",
+			input: input{
+				userId: 1,
+				itemId: 1,
+				update: &structs.UpdateItemInput{StatusId: intPointer(4)},
+				next:   &next,
+			},
+			mockBehavior: func(input input) {
+				mock.ExpectBegin()
+				expectJournal(mock, input.userId)
+
+				mock.ExpectExec(`UPDATE todo_items ti SET status_id=\$1,updated_by=\$2 FROM lists_items li, users_lists ul WHERE (.+)`).
+					WithArgs(*input.update.StatusId, input.userId, input.itemId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
+				mock.ExpectExec("INSERT INTO items_completions").
+					WithArgs(input.userId, input.itemId).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				mock.ExpectExec(`UPDATE todo_items SET done=false, due_date=\$1, updated_by=\$2 WHERE id=\$3`).
+					WithArgs(*input.next, input.userId, input.itemId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Stale version",
+			input: input{
+				userId:  1,
+				itemId:  1,
+				next:    &next,
+				version: intPointer(3),
+			},
+			mockBehavior: func(input input) {
+				mock.ExpectBegin()
+				expectJournal(mock, input.userId)
+
+				mock.ExpectExec(`INSERT INTO items_completions (.+) AND ti.version=\$3`).
+					WithArgs(input.userId, input.itemId, *input.version).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "Failed Update",
+			input: input{
+				userId: 1,
+				itemId: 1,
+				next:   &next,
+			},
+			mockBehavior: func(input input) {
+				mock.ExpectBegin()
+				expectJournal(mock, input.userId)
+
+				mock.ExpectExec("INSERT INTO items_completions").
+					WithArgs(input.userId, input.itemId).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				mock.ExpectExec("UPDATE todo_items").
+					WithArgs(*input.next, input.userId, input.itemId).
+					WillReturnError(errors.New("update error"))
+
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior(testCase.input)
+
+			token, err := r.Complete(testCase.input.userId, structs.ItemChange{
+				Op:       structs.OpUpdate,
+				ItemId:   testCase.input.itemId,
+				Update:   testCase.input.update,
+				Complete: true,
+				Next:     testCase.input.next,
+				Version:  testCase.input.version,
+			})
+			if testCase.wantErr {
+				assert.Error(t, err)
+				assert.Empty(t, token)
+			} else {
+				assert.NoError(t, err)
+				assert.NotEmpty(t, token)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTodoItemPostgres_GetCompletions(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "smock")
+
+	r := NewTodoItemPostgres(db)
+
+	dueDate := time.Date(2026, 10, 20, 9, 0, 0, 0, time.UTC)
+	completedAt := time.Date(2026, 10, 20, 11, 30, 0, 0, time.UTC)
+
+	type input struct {
+		userId int
+		itemId int
+	}
+
+	type mockBehavior func(input input)
+
+	testTable := []struct {
+		name         string
+		input        input
+		mockBehavior mockBehavior
+		want         []structs.ItemCompletion
+		wantErr      bool
+	}{
+		{
+			name: "Ok",
+			input: input{
+				userId: 1,
+				itemId: 1,
+			},
+			mockBehavior: func(input input) {
+				rows := sqlmock.NewRows([]string{"id", "item_id", "due_date", "completed_at"}).
+					AddRow(1, 1, dueDate, completedAt)
+
+				mock.ExpectQuery(`SELECT (.+) FROM items_completions ic
+									INNER JOIN lists_items li on (.+)
+									INNER JOIN users_lists ul on (.+)
+									WHERE (.+)`).
+					WithArgs(input.itemId, input.userId).
+					WillReturnRows(rows)
+			},
+			want: []structs.ItemCompletion{
+				{
+					Id:          1,
+					ItemId:      1,
+					DueDate:     &dueDate,
+					CompletedAt: completedAt,
+				},
+			},
+		},
+		{
+			name: "Query error",
+			input: input{
+				userId: 1,
+				itemId: 1,
+			},
+			mockBehavior: func(input input) {
+				mock.ExpectQuery("SELECT (.+) FROM items_completions ic").
+					WithArgs(input.itemId, input.userId).
+					WillReturnError(errors.New("query error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior(testCase.input)
+
+			got, err := r.GetCompletions(testCase.input.userId, testCase.input.itemId)
+			if testCase.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testCase.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTodoItemPostgres_Move(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "smock")
+
+	r := NewTodoItemPostgres(db)
+
+	type input struct {
+		userId int
+		itemId int
+		listId int
+	}
+
+	type mockBehavior func(input input)
+
+	testTable := []struct {
+		name         string
+		input        input
+		mockBehavior mockBehavior
+		wantErr      bool
+	}{
+		{
+			name: "Ok",
+			input: input{
+				userId: 1,
+				itemId: 1,
+				listId: 2,
+			},
+			mockBehavior: func(input input) {
+				mock.ExpectBegin()
+				expectJournal(mock, input.userId)
+
+				mock.ExpectExec(`WITH moved AS \( UPDATE lists_items li SET list_id=\$1 FROM users_lists ul, users_lists tul WHERE (.+) RETURNING li.item_id \) UPDATE todo_items ti SET status_id=(.+) FROM moved WHERE (.+)`).
+					WithArgs(input.listId, input.userId, input.itemId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "No access",
+			input: input{
+				userId: 1,
+				itemId: 1,
+				listId: 2,
+			},
+			mockBehavior: func(input input) {
+				mock.ExpectBegin()
+				expectJournal(mock, input.userId)
+
+				mock.ExpectExec("UPDATE lists_items li SET list_id").
+					WithArgs(input.listId, input.userId, input.itemId).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior(testCase.input)
+
+			token, err := r.Move(testCase.input.userId, testCase.input.itemId, testCase.input.listId)
+			if testCase.wantErr {
+				assert.Error(t, err)
+				assert.Empty(t, token)
+			} else {
+				assert.NoError(t, err)
+				assert.NotEmpty(t, token)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTodoItemPostgres_Copy(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "smock")
+
+	r := NewTodoItemPostgres(db)
+
+	type input struct {
+		userId int
+		itemId int
+		copy   structs.CopyItemInput
+	}
+
+	type mockBehavior func(input input, id int)
+
+	testTable := []struct {
+		name         string
+		input        input
+		mockBehavior mockBehavior
+		wantId       int
+		wantErr      bool
+	}{
+		{
+			name: "Ok",
+			input: input{
+				userId: 1,
+				itemId: 1,
+				copy:   structs.CopyItemInput{ListId: 2},
+			},
+			wantId: 3,
+			mockBehavior: func(input input, id int) {
+				mock.ExpectBegin()
+				expectJournal(mock, input.userId)
+
+				mock.ExpectQuery(`INSERT INTO todo_items (.+) SELECT (.+) FROM todo_items ti`).
+					WithArgs(input.itemId, input.userId, input.copy.ListId).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(id))
+
+				mock.ExpectQuery(`INSERT INTO lists_items \(list_id, item_id\)
+									SELECT (.+) FROM users_lists ul`).
+					WithArgs(id, input.copy.ListId, input.userId).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Ok_WithLabels",
+			input: input{
+				userId: 1,
+				itemId: 1,
+				copy:   structs.CopyItemInput{ListId: 2, Labels: true},
+			},
+			wantId: 3,
+			mockBehavior: func(input input, id int) {
+				mock.ExpectBegin()
+				expectJournal(mock, input.userId)
+
+				mock.ExpectQuery("INSERT INTO todo_items").
+					WithArgs(input.itemId, input.userId, input.copy.ListId).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(id))
+
+				mock.ExpectQuery("INSERT INTO lists_items").
+					WithArgs(id, input.copy.ListId, input.userId).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+				mock.ExpectExec(`INSERT INTO items_labels \(item_id, label_id\)
+									SELECT (.+) FROM items_labels il`).
+					WithArgs(id, input.itemId).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "No access to target list",
+			input: input{
+				userId: 1,
+				itemId: 1,
+				copy:   structs.CopyItemInput{ListId: 2},
+			},
+			wantId: 3,
+			mockBehavior: func(input input, id int) {
+				mock.ExpectBegin()
+				expectJournal(mock, input.userId)
+
+				mock.ExpectQuery("INSERT INTO todo_items").
+					WithArgs(input.itemId, input.userId, input.copy.ListId).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(id))
+
+				mock.ExpectQuery("INSERT INTO lists_items").
+					WithArgs(id, input.copy.ListId, input.userId).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior(testCase.input, testCase.wantId)
+
+			got, token, err := r.Copy(testCase.input.userId, testCase.input.itemId, testCase.input.copy)
+			if testCase.wantErr {
+				assert.Error(t, err)
+				assert.Empty(t, token)
+			} else {
+				assert.NoError(t, err)
+				assert.NotEmpty(t, token)
+				assert.Equal(t, testCase.wantId, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTodoItemPostgres_ArchiveCompleted(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "smock")
+
+	r := NewTodoItemPostgres(db)
+
+	testTable := []struct {
+		name         string
+		mockBehavior func()
+		want         int64
+		wantErr      bool
+	}{
+		{
+			name: "Ok",
+			mockBehavior: func() {
+				mock.ExpectExec(`UPDATE todo_items ti SET archived_at=now\(\) FROM lists_items li, todo_lists tl
+									WHERE (.+) AND tl.auto_archive_days IS NOT NULL AND ti.done AND ti.archived_at IS NULL
+									AND ti.completed_at < now\(\) - tl.auto_archive_days \* interval '1 day' AND (.+)`).
+					WillReturnResult(sqlmock.NewResult(0, 3))
+			},
+			want: 3,
+		},
+		{
+			name: "Failed",
+			mockBehavior: func() {
+				mock.ExpectExec(`UPDATE todo_items ti SET archived_at=now\(\)`).
+					WillReturnError(errors.New("some error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior()
+
+			got, err := r.ArchiveCompleted()
+			if testCase.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testCase.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func stringPointer(s string) *string {
+	return &s
+}
+
+func TestTodoItemPostgres_Batch(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "smock")
+
+	r := NewTodoItemPostgres(db)
+
+	type input struct {
+		changes []structs.ItemChange
+		atomic  bool
+	}
+
+	next := time.Date(2021, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	testTable := []struct {
+		name         string
+		input        input
+		mockBehavior func(input input)
+		want         []structs.BatchResult
+		wantToken    bool
+		wantErr      bool
+	}{
+		{
+			name: "Atomic",
+			input: input{
+				changes: []structs.ItemChange{
+					{Op: structs.OpCreate, ListId: 1, Item: structs.Item{Title: "title"}},
+					{Op: structs.OpUpdate, ItemId: 2, Update: &structs.UpdateItemInput{Title: stringPointer("new")}},
+					{Op: structs.OpUpdate, ItemId: 3, Complete: true, Next: &next},
+					{Op: structs.OpDelete, ItemId: 4},
+				},
+				atomic: true,
+			},
+			mockBehavior: func(input input) {
+				mock.ExpectBegin()
+				expectJournal(mock, 1)
+				mock.ExpectQuery("INSERT INTO todo_items").
+					WithArgs("title", "", false, nil, nil, nil, nil, 0, nil, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+				mock.ExpectExec("INSERT INTO lists_items").
+					WithArgs(1, 5).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("UPDATE todo_items ti SET title=\\$1,updated_by=\\$2").
+					WithArgs("new", 1, 2).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO items_completions").
+					WithArgs(1, 3).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("UPDATE todo_items SET done=false, due_date=\\$1").
+					WithArgs(next, 1, 3).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("WITH trashed AS (.+) UPDATE time_entries").
+					WithArgs(1, 4).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectCommit()
+			},
+			want:      []structs.BatchResult{{Id: 5}, {Id: 2}, {Id: 3}, {Id: 4}},
+			wantToken: true,
+		},
+		{
+			name: "Atomic failure rolls back",
+			input: input{
+				changes: []structs.ItemChange{
+					{Op: structs.OpDelete, ItemId: 4},
+					{Op: structs.OpMove, ItemId: 3, ListId: 2},
+					{Op: structs.OpDelete, ItemId: 5},
+				},
+				atomic: true,
+			},
+			mockBehavior: func(input input) {
+				mock.ExpectBegin()
+				expectJournal(mock, 1)
+				mock.ExpectQuery("WITH trashed AS").
+					WithArgs(1, 4).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectExec("WITH moved AS").
+					WithArgs(2, 1, 3).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			want: []structs.BatchResult{{Id: 4}, {Err: errors.New("record not found")}, {}},
+		},
+		{
+			name: "Update of an item deleted earlier in the batch",
+			input: input{
+				changes: []structs.ItemChange{
+					{Op: structs.OpDelete, ItemId: 4},
+					{Op: structs.OpUpdate, ItemId: 4, Update: &structs.UpdateItemInput{Title: stringPointer("new")}},
+				},
+				atomic: true,
+			},
+			mockBehavior: func(input input) {
+				mock.ExpectBegin()
+				expectJournal(mock, 1)
+				mock.ExpectQuery("WITH trashed AS").
+					WithArgs(1, 4).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectExec("UPDATE todo_items ti SET title=\\$1,updated_by=\\$2").
+					WithArgs("new", 1, 4).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			want: []structs.BatchResult{{Id: 4}, {Err: ErrRecordNotFound}},
+		},
+		{
+			name: "Best effort",
+			input: input{
+				changes: []structs.ItemChange{
+					{Op: structs.OpMove, ItemId: 3, ListId: 2},
+					{Op: structs.OpDelete, ItemId: 4},
+				},
+			},
+			mockBehavior: func(input input) {
+				mock.ExpectBegin()
+				expectJournal(mock, 1)
+				mock.ExpectExec("SAVEPOINT batch_change").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("WITH moved AS").
+					WithArgs(2, 1, 3).
+					WillReturnError(errors.New("some error"))
+				mock.ExpectExec("ROLLBACK TO SAVEPOINT batch_change").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("SAVEPOINT batch_change").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery("WITH trashed AS").
+					WithArgs(1, 4).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectExec("RELEASE SAVEPOINT batch_change").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+			want:      []structs.BatchResult{{Err: errors.New("some error")}, {Id: 4}},
+			wantToken: true,
+		},
+		{
+			name: "Commit failure",
+			input: input{
+				changes: []structs.ItemChange{{Op: structs.OpDelete, ItemId: 4}},
+				atomic:  true,
+			},
+			mockBehavior: func(input input) {
+				mock.ExpectBegin()
+				expectJournal(mock, 1)
+				mock.ExpectQuery("WITH trashed AS").
+					WithArgs(1, 4).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectCommit().WillReturnError(errors.New("some error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior(testCase.input)
+
+			got, token, err := r.Batch(1, testCase.input.changes, testCase.input.atomic)
+			if testCase.wantErr {
+				assert.Error(t, err)
+				assert.Empty(t, token)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testCase.want, got)
+				assert.Equal(t, testCase.wantToken, token != "")
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func boolPointer(b bool) *bool {
+	return &b
+}
+
+func intPointer(i int) *int {
+	return &i
 }
@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"database/sql"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -8,78 +10,221 @@ import (
 	"github.com/jmoiron/sqlx"
 )
 
+// listCompletedAtQuery derives when a list was completed: the time its last
+// item was done, as long as all of its items are.
+var listCompletedAtQuery = fmt.Sprintf(`(SELECT CASE WHEN bool_and(cti.done) THEN MAX(cti.completed_at) END
+							FROM %s cli INNER JOIN %s cti on cti.id=cli.item_id
+							WHERE cli.list_id=tl.id AND cti.deleted_at IS NULL)`, listsItemsTable, todoItemsTable)
+
+var listColumns = fmt.Sprintf(`tl.id, tl.title, tl.description, tl.created_at, tl.updated_at, tl.created_by, tl.updated_by,
+							tl.archived_at, tl.auto_archive_days, tl.version, %s AS completed_at`, listCompletedAtQuery)
+
+// ErrVersionMismatch is returned by updates asking for a version of a list or
+// an item that has changed since.
+var ErrVersionMismatch = errors.New("the version doesn't match, it was changed in the meantime")
+
+var listAuditColumns = newAuditColumns("tl", listCompletedAtQuery)
+
 type TodoListPostgres struct {
-	db *sqlx.DB
+	db    *sqlx.DB
+	items *TodoItemPostgres
 }
 
 func NewTodoListPostgres(db *sqlx.DB) *TodoListPostgres {
-	return &TodoListPostgres{db: db}
+	return &TodoListPostgres{db: db, items: NewTodoItemPostgres(db)}
 }
 
-func (r *TodoListPostgres) Create(userId int, list structs.List) (int, error) {
-	tx, err := r.db.Begin()
+func (r *TodoListPostgres) Create(userId int, list structs.List) (int, string, error) {
+	var id int
+	token, err := journal(r.db, userId, func(tx *sql.Tx) error {
+		var err error
+		id, err = r.create(tx, userId, list)
+		return err
+	})
 	if err != nil {
-		return 0, err
+		return 0, "", err
 	}
+	return id, token, nil
+}
 
+func (r *TodoListPostgres) create(tx *sql.Tx, userId int, list structs.List) (int, error) {
 	var id int
-	createListQuery := fmt.Sprintf("INSERT INTO %s (title, description) VALUES($1, $2) RETURNING id", todoListsTable)
-	row := tx.QueryRow(createListQuery, list.Title, list.Description)
+	createListQuery := fmt.Sprintf(`INSERT INTO %s (title, description, auto_archive_days, created_by, updated_by)
+							VALUES($1, $2, $3, $4, $4) RETURNING id`, todoListsTable)
+	row := tx.QueryRow(createListQuery, list.Title, list.Description, list.AutoArchiveDays, userId)
 	if err := row.Scan(&id); err != nil {
-		rollErr := tx.Rollback()
-		if rollErr != nil {
-			return 0, rollErr
-		}
 		return 0, err
 	}
 
 	createUsersListQuery := fmt.Sprintf("INSERT INTO %s (user_id, list_id) VALUES($1, $2)", usersListsTable)
-	_, err = tx.Exec(createUsersListQuery, userId, id)
+	_, err := tx.Exec(createUsersListQuery, userId, id)
+	return id, err
+}
+
+func (r *TodoListPostgres) GetAll(userId int, filter structs.ListFilter) ([]structs.List, structs.PageInfo, error) {
+	var lists []structs.List
+	var page structs.PageInfo
+	keys, err := newKeyset(filter.Sort, filter.Order, listSortColumns)
 	if err != nil {
-		rollErr := tx.Rollback()
-		if rollErr != nil {
-			return 0, rollErr
+		return nil, page, err
+	}
+
+	archived := "tl.archived_at IS NULL"
+	if filter.Archived {
+		archived = "tl.archived_at IS NOT NULL"
+	}
+	conditions := []string{"ul.user_id = $1", "tl.deleted_at IS NULL", archived}
+	args := []interface{}{userId}
+
+	if filter.Done != nil {
+		done := "IS NULL"
+		if *filter.Done {
+			done = "IS NOT NULL"
+		}
+		conditions = append(conditions, listCompletedAtQuery+" "+done)
+	}
+	if filter.TitleContains != "" {
+		args = append(args, "%"+likeEscaper.Replace(filter.TitleContains)+"%")
+		conditions = append(conditions, fmt.Sprintf("tl.title ILIKE $%d", len(args)))
+	}
+	conditions, args = listAuditColumns.where(filter.AuditFilter, conditions, args)
+
+	from := func(conditions []string) string {
+		return fmt.Sprintf(`FROM %s tl
+							INNER JOIN %s ul ON tl.id = ul.list_id
+							WHERE %s`, todoListsTable, usersListsTable, strings.Join(conditions, " AND "))
+	}
+	pageConditions, pageArgs := conditions, args
+	if filter.Cursor != "" {
+		if pageConditions, pageArgs, err = keys.after(filter.Cursor, conditions, args); err != nil {
+			return nil, page, err
+		}
+	}
+	paged := filter.Cursor != "" || filter.Limit > 0
+	if paged {
+		if err := r.db.Get(&page.Total, "SELECT count(*) "+from(conditions), args...); err != nil {
+			return nil, page, err
 		}
-		return 0, err
 	}
 
-	return id, tx.Commit()
+	query := fmt.Sprintf("SELECT %s %s %s", listColumns, from(pageConditions), keys.orderBy())
+	if filter.Limit > 0 {
+		pageArgs = append(pageArgs, filter.Limit+1)
+		query += fmt.Sprintf(" LIMIT $%d", len(pageArgs))
+	}
+	if err := r.db.Select(&lists, query, pageArgs...); err != nil {
+		return nil, page, err
+	}
+
+	if filter.Limit > 0 && len(lists) > filter.Limit {
+		lists = lists[:filter.Limit]
+		page.NextCursor = keys.encodeCursor(listSortValues(keys, lists[len(lists)-1]))
+	}
+	if !paged {
+		page.Total = len(lists)
+	}
+	return lists, page, nil
 }
 
-func (r *TodoListPostgres) GetAll(userId int) ([]structs.List, error) {
-	var lists []structs.List
+// Stamp sums up the lists of the user for conditional requests, along with
+// their saved views. The items are in it too, through the times the lists
+// were last changed, as they make the lists complete.
+func (r *TodoListPostgres) Stamp(userId int) (structs.CollectionStamp, error) {
+	var stamp structs.CollectionStamp
 
-	query := fmt.Sprintf(`SELECT tl.id, tl.title, tl.description FROM %s tl 
-							INNER JOIN %s ul ON tl.id = ul.list_id
-							WHERE ul.user_id = $1`, todoListsTable, usersListsTable)
-	err := r.db.Select(&lists, query, userId)
+	query := fmt.Sprintf(`SELECT count(*) AS count, COALESCE(sum(s.id), 0) AS id_sum,
+							count(*) FILTER (WHERE s.live) AS live, max(s.modified_at) AS last_modified
+							FROM (
+								SELECT tl.id, tl.deleted_at IS NULL AND tl.archived_at IS NULL AS live,
+									GREATEST(tl.updated_at, tl.deleted_at, tl.archived_at,
+										(SELECT max(GREATEST(ti.updated_at, ti.deleted_at)) FROM %s li
+											INNER JOIN %s ti on ti.id=li.item_id WHERE li.list_id=tl.id)) AS modified_at
+								FROM %s tl
+								INNER JOIN %s ul ON tl.id = ul.list_id
+								WHERE ul.user_id = $1
+								UNION ALL
+								SELECT -v.id, true, v.updated_at FROM %s v WHERE v.user_id = $1
+							) s`, listsItemsTable, todoItemsTable, todoListsTable, usersListsTable, viewsTable)
+	err := r.db.Get(&stamp, query, userId)
 
-	return lists, err
+	return stamp, err
 }
 
 func (r *TodoListPostgres) GetById(listId int, userId int) (structs.List, error) {
 	var list structs.List
 
-	query := fmt.Sprintf(`SELECT tl.id, tl.title, tl.description FROM %s tl
+	query := fmt.Sprintf(`SELECT %s FROM %s tl
 							INNER JOIN %s ul ON tl.id = ul.list_id
 							WHERE ul.user_id = $1
-							AND ul.list_id = $2`, todoListsTable, usersListsTable)
+							AND ul.list_id = $2
+							AND tl.deleted_at IS NULL`, listColumns, todoListsTable, usersListsTable)
 	err := r.db.Get(&list, query, userId, listId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return list, ErrRecordNotFound
+	}
 
 	return list, err
 }
 
-func (r *TodoListPostgres) Delete(listId int, userId int) error {
-	query := fmt.Sprintf(`DELETE FROM %s tl USING %s ul
-							WHERE tl.id=ul.list_id
-							AND ul.user_id=$1
-							AND ul.list_id=$2`, todoListsTable, usersListsTable)
-	_, err := r.db.Exec(query, userId, listId)
+// Delete moves the list to the trash; its items go with it and stay hidden
+// until the list is restored. Timers running on them are stopped. It
+// returns the undo token.
+func (r *TodoListPostgres) Delete(listId int, userId int, version *int) (string, error) {
+	return journal(r.db, userId, func(tx *sql.Tx) error {
+		return r.trash(tx, listId, userId, version)
+	})
+}
 
-	return err
+// trash moves the list to the trash; see TodoItemPostgres.trash for the
+// version.
+func (r *TodoListPostgres) trash(e execer, listId int, userId int, version *int) error {
+	args := []interface{}{userId, listId}
+	versionCondition := ""
+	if version != nil {
+		versionCondition = " AND tl.version=$3"
+		args = append(args, *version)
+	}
+	query := fmt.Sprintf(`WITH trashed AS (
+								UPDATE %s tl SET deleted_at=now(), deleted_by=$1 FROM %s ul
+								WHERE tl.id=ul.list_id
+								AND ul.user_id=$1
+								AND ul.list_id=$2
+								AND tl.deleted_at IS NULL%s
+								RETURNING tl.id
+							), stopped AS (
+								UPDATE %s te SET stopped_at=now() FROM %s li, trashed
+								WHERE li.list_id=trashed.id AND te.item_id=li.item_id AND te.stopped_at IS NULL
+							)
+							SELECT count(*) FROM trashed`,
+		todoListsTable, usersListsTable, versionCondition, timeEntriesTable, listsItemsTable)
+	var trashed int
+	if err := e.QueryRow(query, args...).Scan(&trashed); err != nil {
+		return err
+	}
+	return checkTrashed(trashed, version)
+}
+
+// checkTrashed tells a trashing that found nothing, or nothing of the version
+// it asked for.
+func checkTrashed(trashed int, version *int) error {
+	switch {
+	case trashed > 0:
+		return nil
+	case version != nil:
+		return ErrVersionMismatch
+	}
+	return ErrRecordNotFound
+}
+
+// Update changes the fields of the list that are set and returns the undo
+// token.
+func (r *TodoListPostgres) Update(listId int, userId int, input structs.UpdateListInput) (string, error) {
+	return journal(r.db, userId, func(tx *sql.Tx) error {
+		return r.update(tx, listId, userId, input)
+	})
 }
 
-func (r *TodoListPostgres) Update(listId int, userId int, input structs.UpdateListInput) error {
+func (r *TodoListPostgres) update(e execer, listId int, userId int, input structs.UpdateListInput) error {
 	setValues := make([]string, 0)
 	args := make([]interface{}, 0)
 	argId := 1
@@ -96,13 +241,171 @@ func (r *TodoListPostgres) Update(listId int, userId int, input structs.UpdateLi
 		argId++
 	}
 
+	if input.AutoArchiveDays != nil {
+		setValues = append(setValues, fmt.Sprintf("auto_archive_days=NULLIF($%d, 0)", argId))
+		args = append(args, *input.AutoArchiveDays)
+		argId++
+	}
+
+	setValues = append(setValues, fmt.Sprintf("updated_by=$%d", argId+1))
+
 	setQuery := strings.Join(setValues, ",")
 	query := fmt.Sprintf(`UPDATE %s tl SET %s FROM %s ul
 							WHERE tl.id=ul.list_id
 							AND ul.list_id=$%d
-							AND ul.user_id=$%d`, todoListsTable, setQuery, usersListsTable, argId, argId+1)
+							AND ul.user_id=$%d
+							AND tl.deleted_at IS NULL`, todoListsTable, setQuery, usersListsTable, argId, argId+1)
 	args = append(args, listId, userId)
+	if input.Version != nil {
+		query += fmt.Sprintf(" AND tl.version=$%d", argId+2)
+		args = append(args, *input.Version)
+	}
+
+	result, err := e.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+	if input.Version == nil {
+		return checkAffected(result)
+	}
+	return checkVersion(result)
+}
+
+// checkVersion tells an update that found no row of the version it asked for.
+func checkVersion(result sql.Result) error {
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrVersionMismatch
+	}
+	return nil
+}
+
+// SetArchived archives or unarchives the list and returns the undo token.
+// Archived lists drop out of GetAll but can still be opened and edited.
+func (r *TodoListPostgres) SetArchived(listId int, userId int, archived bool) (string, error) {
+	return journal(r.db, userId, func(tx *sql.Tx) error {
+		query := fmt.Sprintf(`UPDATE %s tl SET archived_at=CASE WHEN $3 THEN COALESCE(tl.archived_at, now()) END
+								FROM %s ul
+								WHERE tl.id=ul.list_id
+								AND ul.user_id=$1
+								AND ul.list_id=$2
+								AND tl.deleted_at IS NULL`, todoListsTable, usersListsTable)
+		_, err := tx.Exec(query, userId, listId, archived)
+		return err
+	})
+}
+
+// Batch applies the changes of a list batch in one transaction, see
+// runBatch.
+func (r *TodoListPostgres) Batch(userId int, changes []structs.ListChange, atomic bool) ([]structs.BatchResult, string, error) {
+	return runBatch(r.db, userId, len(changes), atomic, func(tx *sql.Tx, i int) (int, error) {
+		change := changes[i]
+		switch change.Op {
+		case structs.OpCreate:
+			return r.create(tx, userId, change.List)
+		case structs.OpUpdate:
+			if err := r.update(tx, change.ListId, userId, change.Update); err != nil {
+				return 0, err
+			}
+		case structs.OpDelete:
+			if err := r.trash(tx, change.ListId, userId, nil); err != nil {
+				return 0, err
+			}
+		default:
+			return 0, fmt.Errorf("%w: unknown op %q", structs.ErrInvalidOperation, change.Op)
+		}
+		return change.ListId, nil
+	})
+}
+
+// Duplicate copies the list together with its statuses and transitions and,
+// depending on the input, its items in the same transaction. storageKeys maps
+// the storage keys of the attachments to copy to the keys their blobs were
+// copied to; the quota is checked against the copies.
+func (r *TodoListPostgres) Duplicate(userId int, listId int, input structs.DuplicateListInput,
+	storageKeys map[string]string, quota int64) (int, string, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, "", err
+	}
+
+	token, err := beginJournal(tx, userId)
+	if err != nil {
+		rollErr := tx.Rollback()
+		if rollErr != nil {
+			return 0, "", rollErr
+		}
+		return 0, "", err
+	}
+
+	var id int
+	duplicateListQuery := fmt.Sprintf(`INSERT INTO %s (title, description, auto_archive_days, created_by, updated_by)
+							SELECT COALESCE(NULLIF($3, ''), tl.title), tl.description, tl.auto_archive_days, $1, $1
+							FROM %s tl
+							INNER JOIN %s ul ON tl.id = ul.list_id
+							WHERE ul.user_id = $1
+							AND ul.list_id = $2
+							AND tl.deleted_at IS NULL
+							RETURNING id`, todoListsTable, todoListsTable, usersListsTable)
+	row := tx.QueryRow(duplicateListQuery, userId, listId, input.Title)
+	if err := row.Scan(&id); err != nil {
+		rollErr := tx.Rollback()
+		if rollErr != nil {
+			return 0, "", rollErr
+		}
+		return 0, "", err
+	}
+
+	createUsersListQuery := fmt.Sprintf("INSERT INTO %s (user_id, list_id) VALUES($1, $2)", usersListsTable)
+	if _, err := tx.Exec(createUsersListQuery, userId, id); err != nil {
+		rollErr := tx.Rollback()
+		if rollErr != nil {
+			return 0, "", rollErr
+		}
+		return 0, "", err
+	}
+
+	copyStatusesQuery := fmt.Sprintf(`INSERT INTO %s (list_id, name, position, is_done, wip_limit)
+							SELECT $1, s.name, s.position, s.is_done, s.wip_limit FROM %s s
+							WHERE s.list_id=$2`, statusesTable, statusesTable)
+	if _, err := tx.Exec(copyStatusesQuery, id, listId); err != nil {
+		rollErr := tx.Rollback()
+		if rollErr != nil {
+			return 0, "", rollErr
+		}
+		return 0, "", err
+	}
+
+	// Status names are unique within a list, so they map the transitions
+	// onto the copied statuses.
+	copyTransitionsQuery := fmt.Sprintf(`INSERT INTO %s (from_status_id, to_status_id)
+							SELECT nf.id, nt.id FROM %s st
+							INNER JOIN %s f on f.id=st.from_status_id
+							INNER JOIN %s t on t.id=st.to_status_id
+							INNER JOIN %s nf on nf.list_id=$1 AND nf.name=f.name
+							INNER JOIN %s nt on nt.list_id=$1 AND nt.name=t.name
+							WHERE f.list_id=$2`, statusesTransitionsTable, statusesTransitionsTable,
+		statusesTable, statusesTable, statusesTable, statusesTable)
+	if _, err := tx.Exec(copyTransitionsQuery, id, listId); err != nil {
+		rollErr := tx.Rollback()
+		if rollErr != nil {
+			return 0, "", rollErr
+		}
+		return 0, "", err
+	}
+
+	if input.Items {
+		if err := r.items.copyList(tx, userId, listId, id, input, storageKeys, quota); err != nil {
+			rollErr := tx.Rollback()
+			if rollErr != nil {
+				return 0, "", rollErr
+			}
+			return 0, "", err
+		}
+	}
 
-	_, err := r.db.Exec(query, args...)
-	return err
+	return id, token, tx.Commit()
 }
@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/fr13n8/todo-app/structs"
@@ -40,18 +41,20 @@ func TestTodoListPostgres_Create(t *testing.T) {
 			input: input{
 				userId: 1,
 				list: structs.List{
-					Id:          1,
-					Title:       "Test title",
-					Description: "Test description",
+					Id:              1,
+					Title:           "Test title",
+					Description:     "Test description",
+					AutoArchiveDays: intPointer(7),
 				},
 			},
 			wantId: 1,
 			mockBehavior: func(input input, id int) {
 				mock.ExpectBegin()
+				expectJournal(mock, input.userId)
 
 				rows := sqlmock.NewRows([]string{"id"}).AddRow(id)
 				mock.ExpectQuery("INSERT INTO todo_lists").
-					WithArgs(input.list.Title, input.list.Description).
+					WithArgs(input.list.Title, input.list.Description, input.list.AutoArchiveDays, input.userId).
 					WillReturnRows(rows)
 
 				mock.ExpectExec("INSERT INTO users_lists").
@@ -72,10 +75,11 @@ func TestTodoListPostgres_Create(t *testing.T) {
 			},
 			mockBehavior: func(input input, id int) {
 				mock.ExpectBegin()
+				expectJournal(mock, input.userId)
 
 				rows := sqlmock.NewRows([]string{"id"})
 				mock.ExpectQuery("INSERT INTO todo_lists").
-					WithArgs(input.list.Title, input.list.Description).
+					WithArgs(input.list.Title, input.list.Description, input.list.AutoArchiveDays, input.userId).
 					WillReturnRows(rows)
 
 				mock.ExpectRollback()
@@ -87,10 +91,11 @@ func TestTodoListPostgres_Create(t *testing.T) {
 			wantErr: true,
 			mockBehavior: func(input input, id int) {
 				mock.ExpectBegin()
+				expectJournal(mock, input.userId)
 
 				rows := sqlmock.NewRows([]string{"id"}).AddRow(id)
 				mock.ExpectQuery("INSERT INTO todo_lists").
-					WithArgs(input.list.Title, input.list.Description).
+					WithArgs(input.list.Title, input.list.Description, input.list.AutoArchiveDays, input.userId).
 					WillReturnRows(rows)
 
 				mock.ExpectExec("INSERT INTO users_lists").
@@ -113,11 +118,13 @@ func TestTodoListPostgres_Create(t *testing.T) {
 		t.Run(testCase.name, func(t *testing.T) {
 			testCase.mockBehavior(testCase.input, testCase.wantId)
 
-			got, err := r.Create(testCase.input.userId, testCase.input.list)
+			got, token, err := r.Create(testCase.input.userId, testCase.input.list)
 			if testCase.wantErr {
 				assert.Error(t, err)
+				assert.Empty(t, token)
 			} else {
 				assert.NoError(t, err)
+				assert.NotEmpty(t, token)
 				assert.Equal(t, testCase.wantId, got)
 			}
 			assert.NoError(t, mock.ExpectationsWereMet())
@@ -217,16 +224,24 @@ func TestTodoListPostgres_GetAll(t *testing.T) {
 
 	type input struct {
 		userId int
+		filter structs.ListFilter
 	}
 
 	type mockBehavior func(input)
 
+	updatedAfter := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	updatedAt := time.Date(2021, 6, 2, 10, 0, 0, 0, time.UTC)
+	keys, _ := newKeyset("-updated_at", "", listSortColumns)
+	nextPage := keys.encodeCursor([]interface{}{"2021-06-03T00:00:00Z", 7})
+	lastPage := keys.encodeCursor([]interface{}{"2021-06-02T10:00:00Z", 1})
+
 	testTable := []struct {
 		name         string
 		input        input
 		mockBehavior mockBehavior
 		wantErr      bool
 		want         []structs.List
+		wantPage     structs.PageInfo
 	}{
 		{
 			name: "Ok",
@@ -262,6 +277,114 @@ func TestTodoListPostgres_GetAll(t *testing.T) {
 					WithArgs(input.userId).
 					WillReturnRows(rows)
 			},
+			wantPage: structs.PageInfo{Total: 3},
+		},
+		{
+			name: "Filtered and sorted",
+			input: input{
+				userId: 1,
+				filter: structs.ListFilter{AuditFilter: structs.AuditFilter{
+					Sort:           "completed_at",
+					Order:          "desc",
+					UpdatedAfter:   &updatedAfter,
+					CompletedAfter: &updatedAfter,
+					CreatedBy:      intPointer(1),
+				}},
+			},
+			want: []structs.List{
+				{
+					Id:          1,
+					Title:       "title",
+					Description: "description",
+					UpdatedAt:   &updatedAt,
+					CompletedAt: &updatedAt,
+				},
+			},
+			mockBehavior: func(input input) {
+				rows := sqlmock.NewRows([]string{"id", "title", "description", "updated_at", "completed_at"}).
+					AddRow("1", "title", "description", updatedAt, updatedAt)
+
+				mock.ExpectQuery(`SELECT (.+) FROM todo_lists tl
+										INNER JOIN users_lists ul ON (.+)
+										WHERE ul.user_id = \$1 AND tl.deleted_at IS NULL AND tl.archived_at IS NULL AND tl.updated_at>=\$2 AND \(SELECT CASE (.+)\)>=\$3 AND tl.created_by=\$4
+										ORDER BY \(SELECT CASE (.+)\) DESC NULLS LAST, tl.id DESC`).
+					WithArgs(input.userId, updatedAfter, updatedAfter, 1).
+					WillReturnRows(rows)
+			},
+			wantPage: structs.PageInfo{Total: 1},
+		},
+		{
+			name: "Archived",
+			input: input{
+				userId: 1,
+				filter: structs.ListFilter{Archived: true},
+			},
+			want: []structs.List{
+				{
+					Id:          1,
+					Title:       "title",
+					Description: "description",
+					ArchivedAt:  &updatedAt,
+				},
+			},
+			mockBehavior: func(input input) {
+				rows := sqlmock.NewRows([]string{"id", "title", "description", "archived_at"}).
+					AddRow("1", "title", "description", updatedAt)
+
+				mock.ExpectQuery(`SELECT (.+) FROM todo_lists tl
+										INNER JOIN users_lists ul ON (.+)
+										WHERE ul.user_id = \$1 AND tl.deleted_at IS NULL AND tl.archived_at IS NOT NULL
+										ORDER BY tl.id ASC`).
+					WithArgs(input.userId).
+					WillReturnRows(rows)
+			},
+			wantPage: structs.PageInfo{Total: 1},
+		},
+		{
+			name: "Paged",
+			input: input{
+				userId: 1,
+				filter: structs.ListFilter{
+					AuditFilter:   structs.AuditFilter{Sort: "-updated_at"},
+					Done:          boolPointer(true),
+					TitleContains: "work",
+					PageFilter:    structs.PageFilter{Cursor: nextPage, Limit: 1},
+				},
+			},
+			want: []structs.List{
+				{
+					Id:        1,
+					Title:     "work",
+					UpdatedAt: &updatedAt,
+				},
+			},
+			wantPage: structs.PageInfo{Total: 4, NextCursor: lastPage},
+			mockBehavior: func(input input) {
+				mock.ExpectQuery(`SELECT count\(\*\) FROM todo_lists tl
+										INNER JOIN users_lists ul ON (.+)
+										WHERE ul.user_id = \$1 AND tl.deleted_at IS NULL AND tl.archived_at IS NULL AND \(SELECT CASE (.+)\) IS NOT NULL AND tl.title ILIKE \$2$`).
+					WithArgs(input.userId, "%work%").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(4))
+
+				rows := sqlmock.NewRows([]string{"id", "title", "updated_at"}).
+					AddRow("1", "work", updatedAt).
+					AddRow("4", "more work", updatedAfter)
+				mock.ExpectQuery(`SELECT (.+) FROM todo_lists tl
+										INNER JOIN users_lists ul ON (.+)
+										WHERE (.+) AND tl.title ILIKE \$2 AND \(\(tl.updated_at<\$3\) OR \(tl.updated_at IS NOT DISTINCT FROM \$4 AND tl.id>\$5\)\)
+										ORDER BY tl.updated_at DESC NULLS LAST, tl.id ASC LIMIT \$6`).
+					WithArgs(input.userId, "%work%", "2021-06-03T00:00:00Z", "2021-06-03T00:00:00Z", float64(7), 2).
+					WillReturnRows(rows)
+			},
+		},
+		{
+			name: "Invalid cursor",
+			input: input{
+				userId: 1,
+				filter: structs.ListFilter{PageFilter: structs.PageFilter{Cursor: "not a cursor"}},
+			},
+			mockBehavior: func(input input) {},
+			wantErr:      true,
 		},
 		{
 			name: "No record found",
@@ -283,18 +406,47 @@ func TestTodoListPostgres_GetAll(t *testing.T) {
 		t.Run(testCase.name, func(t *testing.T) {
 			testCase.mockBehavior(testCase.input)
 
-			got, err := r.GetAll(testCase.input.userId)
+			got, page, err := r.GetAll(testCase.input.userId, testCase.input.filter)
 			if testCase.wantErr {
 				assert.Error(t, err)
 			} else {
 				assert.NoError(t, err)
 				assert.Equal(t, testCase.want, got)
+				assert.Equal(t, testCase.wantPage, page)
 			}
 			assert.NoError(t, mock.ExpectationsWereMet())
 		})
 	}
 }
 
+func TestTodoListPostgres_Stamp(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "smock")
+
+	r := NewTodoListPostgres(db)
+
+	rows := sqlmock.NewRows([]string{"count", "id_sum", "live", "last_modified"}).AddRow(0, 0, 0, nil)
+	mock.ExpectQuery(`SELECT count\(\*\) AS count, COALESCE\(sum\(s.id\), 0\) AS id_sum,
+						count\(\*\) FILTER \(WHERE s.live\) AS live, max\(s.modified_at\) AS last_modified
+						FROM \((.+)FROM todo_lists tl
+						INNER JOIN users_lists ul ON tl.id = ul.list_id
+						WHERE ul.user_id = \$1
+						UNION ALL
+						SELECT -v.id, true, v.updated_at FROM views v WHERE v.user_id = \$1
+						\) s`).
+		WithArgs(1).
+		WillReturnRows(rows)
+
+	got, err := r.Stamp(1)
+	assert.NoError(t, err)
+	assert.Equal(t, structs.CollectionStamp{}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestTodoListPostgres_Delete(t *testing.T) {
 	mockDB, mock, err := sqlmock.New()
 	if err != nil {
@@ -306,8 +458,9 @@ func TestTodoListPostgres_Delete(t *testing.T) {
 	r := NewTodoListPostgres(db)
 
 	type input struct {
-		userId int
-		listId int
+		userId  int
+		listId  int
+		version *int
 	}
 
 	type mockBehavior func(input input)
@@ -318,6 +471,25 @@ func TestTodoListPostgres_Delete(t *testing.T) {
 		input        input
 		mockBehavior mockBehavior
 	}{
+		{
+			name: "Stale version",
+			input: input{
+				listId:  1,
+				userId:  1,
+				version: intPointer(2),
+			},
+			mockBehavior: func(input input) {
+				mock.ExpectBegin()
+				expectJournal(mock, input.userId)
+
+				mock.ExpectQuery(`WITH trashed AS (.+) AND tl.deleted_at IS NULL AND tl.version=\$3 RETURNING tl.id`).
+					WithArgs(input.userId, input.listId, *input.version).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
 		{
 			name: "Ok",
 			input: input{
@@ -325,11 +497,16 @@ func TestTodoListPostgres_Delete(t *testing.T) {
 				userId: 1,
 			},
 			mockBehavior: func(input input) {
-				mock.ExpectExec(`DELETE FROM todo_lists tl 
-									USING users_lists ul 
-									WHERE (.+)`).
+				mock.ExpectBegin()
+				expectJournal(mock, input.userId)
+
+				mock.ExpectQuery(`WITH trashed AS \( UPDATE todo_lists tl SET deleted_at=now\(\), deleted_by=\$1 FROM users_lists ul
+									WHERE (.+) RETURNING tl.id \), stopped AS \(
+									UPDATE time_entries te SET stopped_at=now\(\) FROM lists_items li, trashed WHERE (.+) \) SELECT count\(\*\) FROM trashed`).
 					WithArgs(input.userId, input.listId).
-					WillReturnResult(sqlmock.NewResult(1, 1))
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+				mock.ExpectCommit()
 			},
 		},
 		{
@@ -339,11 +516,16 @@ func TestTodoListPostgres_Delete(t *testing.T) {
 				listId: 1,
 			},
 			mockBehavior: func(input input) {
-				mock.ExpectExec(`DELETE FROM todo_lists tl 
-									USING users_lists ul 
-									WHERE (.+)`).
+				mock.ExpectBegin()
+				expectJournal(mock, input.userId)
+
+				mock.ExpectQuery(`WITH trashed AS \( UPDATE todo_lists tl SET deleted_at=now\(\), deleted_by=\$1 FROM users_lists ul
+									WHERE (.+) RETURNING tl.id \), stopped AS \(
+									UPDATE time_entries te SET stopped_at=now\(\) FROM lists_items li, trashed WHERE (.+) \) SELECT count\(\*\) FROM trashed`).
 					WithArgs(input.userId, input.listId).
-					WillReturnError(sql.ErrNoRows)
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+				mock.ExpectRollback()
 			},
 			wantErr: true,
 		},
@@ -353,11 +535,13 @@ func TestTodoListPostgres_Delete(t *testing.T) {
 		t.Run(testCase.name, func(t *testing.T) {
 			testCase.mockBehavior(testCase.input)
 
-			err := r.Delete(testCase.input.listId, testCase.input.userId)
+			token, err := r.Delete(testCase.input.listId, testCase.input.userId, testCase.input.version)
 			if testCase.wantErr {
 				assert.Error(t, err)
+				assert.Empty(t, token)
 			} else {
 				assert.NoError(t, err)
+				assert.NotEmpty(t, token)
 			}
 			assert.NoError(t, mock.ExpectationsWereMet())
 		})
@@ -404,6 +588,39 @@ func TestTodoListPostgres_Update(t *testing.T) {
 					WillReturnResult(sqlmock.NewResult(1, 1))
 			},
 		},
+		{
+			name: "Ok_Version",
+			input: input{
+				userId: 1,
+				listId: 2,
+				list: structs.UpdateListInput{
+					Title:   stringPointer("title"),
+					Version: intPointer(5),
+				},
+			},
+			mockBehavior: func(input input) {
+				mock.ExpectExec(`UPDATE todo_lists tl SET (.+) FROM users_lists ul WHERE (.+) AND tl.version=\$4`).
+					WithArgs(input.list.Title, input.listId, input.userId, 5).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name:    "Version changed",
+			wantErr: true,
+			input: input{
+				userId: 1,
+				listId: 2,
+				list: structs.UpdateListInput{
+					Title:   stringPointer("title"),
+					Version: intPointer(5),
+				},
+			},
+			mockBehavior: func(input input) {
+				mock.ExpectExec(`UPDATE todo_lists tl SET (.+) FROM users_lists ul WHERE (.+) AND tl.version=\$4`).
+					WithArgs(input.list.Title, input.listId, input.userId, 5).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+		},
 		{
 			name: "OK_WithoutDescription",
 			input: input{
@@ -434,6 +651,21 @@ func TestTodoListPostgres_Update(t *testing.T) {
 					WillReturnResult(sqlmock.NewResult(1, 1))
 			},
 		},
+		{
+			name: "OK_AutoArchiveOff",
+			input: input{
+				list: structs.UpdateListInput{
+					AutoArchiveDays: intPointer(0),
+				},
+				listId: 1,
+				userId: 1,
+			},
+			mockBehavior: func(input input) {
+				mock.ExpectExec(`UPDATE todo_lists tl SET auto_archive_days=NULLIF\(\$1, 0\),updated_by=\$3 FROM users_lists ul WHERE (.+)`).
+					WithArgs(0, input.listId, input.userId).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
 		{
 			name: "OK_NoInputFields",
 			input: input{
@@ -441,21 +673,431 @@ func TestTodoListPostgres_Update(t *testing.T) {
 				userId: 1,
 			},
 			mockBehavior: func(input input) {
-				mock.ExpectExec("UPDATE todo_lists tl SET FROM users_lists ul WHERE (.+)").
+				mock.ExpectExec(`UPDATE todo_lists tl SET updated_by=\$2 FROM users_lists ul WHERE (.+)`).
 					WithArgs(input.listId, input.userId).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 			},
 		},
 	}
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			mock.ExpectBegin()
+			expectJournal(mock, testCase.input.userId)
+			testCase.mockBehavior(testCase.input)
+			if testCase.wantErr {
+				mock.ExpectRollback()
+			} else {
+				mock.ExpectCommit()
+			}
+
+			token, err := r.Update(testCase.input.listId, testCase.input.userId, testCase.input.list)
+			if testCase.wantErr {
+				assert.Error(t, err)
+				assert.Empty(t, token)
+			} else {
+				assert.NoError(t, err)
+				assert.NotEmpty(t, token)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTodoListPostgres_SetArchived(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "smock")
+
+	r := NewTodoListPostgres(db)
+
+	type input struct {
+		listId   int
+		userId   int
+		archived bool
+	}
+
+	testTable := []struct {
+		name         string
+		input        input
+		mockBehavior func(input)
+		wantErr      bool
+	}{
+		{
+			name:  "Archive",
+			input: input{listId: 1, userId: 1, archived: true},
+			mockBehavior: func(input input) {
+				mock.ExpectExec(`UPDATE todo_lists tl SET archived_at=CASE WHEN \$3 THEN COALESCE\(tl.archived_at, now\(\)\) END
+										FROM users_lists ul WHERE (.+)`).
+					WithArgs(input.userId, input.listId, input.archived).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name:  "Unarchive",
+			input: input{listId: 1, userId: 1},
+			mockBehavior: func(input input) {
+				mock.ExpectExec(`UPDATE todo_lists tl SET archived_at=(.+) FROM users_lists ul WHERE (.+)`).
+					WithArgs(input.userId, input.listId, input.archived).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name:  "Failed",
+			input: input{listId: 1, userId: 1, archived: true},
+			mockBehavior: func(input input) {
+				mock.ExpectExec(`UPDATE todo_lists tl SET archived_at=(.+) FROM users_lists ul WHERE (.+)`).
+					WithArgs(input.userId, input.listId, input.archived).
+					WillReturnError(errors.New("some error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			mock.ExpectBegin()
+			expectJournal(mock, testCase.input.userId)
+			testCase.mockBehavior(testCase.input)
+			if testCase.wantErr {
+				mock.ExpectRollback()
+			} else {
+				mock.ExpectCommit()
+			}
+
+			token, err := r.SetArchived(testCase.input.listId, testCase.input.userId, testCase.input.archived)
+			if testCase.wantErr {
+				assert.Error(t, err)
+				assert.Empty(t, token)
+			} else {
+				assert.NoError(t, err)
+				assert.NotEmpty(t, token)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTodoListPostgres_Duplicate(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "smock")
+
+	r := NewTodoListPostgres(db)
+
+	type input struct {
+		userId      int
+		listId      int
+		input       structs.DuplicateListInput
+		storageKeys map[string]string
+		quota       int64
+	}
+
+	expectList := func(input input, id int) {
+		mock.ExpectBegin()
+		expectJournal(mock, input.userId)
+
+		rows := sqlmock.NewRows([]string{"id"}).AddRow(id)
+		mock.ExpectQuery(`INSERT INTO todo_lists \(title, description, auto_archive_days, created_by, updated_by\)
+								SELECT COALESCE\(NULLIF\(\$3, ''\), tl.title\), (.+) FROM todo_lists tl (.+)`).
+			WithArgs(input.userId, input.listId, input.input.Title).
+			WillReturnRows(rows)
+
+		mock.ExpectExec("INSERT INTO users_lists").
+			WithArgs(input.userId, id).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		mock.ExpectExec(`INSERT INTO statuses \(list_id, name, position, is_done, wip_limit\)`).
+			WithArgs(id, input.listId).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+
+		mock.ExpectExec(`INSERT INTO statuses_transitions \(from_status_id, to_status_id\)`).
+			WithArgs(id, input.listId).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+
+	expectItems := func(input input, id int) {
+		mock.ExpectExec("CREATE TEMP TABLE item_copies").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		mock.ExpectExec(`INSERT INTO item_copies \(old_id, new_id\)
+								SELECT ti.id, nextval\(pg_get_serial_sequence\('todo_items', 'id'\)\) FROM todo_items ti (.+)`).
+			WithArgs(input.listId).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+
+		mock.ExpectExec(`INSERT INTO todo_items \(id, (.+)\) SELECT ic.new_id, ti.title, ti.description, ti.done AND \$2, (.+)
+								FROM item_copies ic INNER JOIN todo_items ti on ti.id=ic.old_id`).
+			WithArgs(id, input.input.Done, input.userId).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+
+		mock.ExpectExec(`INSERT INTO lists_items \(list_id, item_id\) SELECT \$1, ic.new_id FROM item_copies ic`).
+			WithArgs(id).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+
+		mock.ExpectExec(`INSERT INTO items_dependencies \(item_id, blocker_id\)`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+
+	testTable := []struct {
+		name         string
+		input        input
+		mockBehavior func(input input, id int)
+		wantId       int
+		wantErr      error
+	}{
+		{
+			name:   "List only",
+			input:  input{userId: 1, listId: 2},
+			wantId: 3,
+			mockBehavior: func(input input, id int) {
+				expectList(input, id)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Everything",
+			input: input{
+				userId:      1,
+				listId:      2,
+				input:       structs.DuplicateListInput{Title: "copy", Items: true, Done: true, Labels: true, Attachments: true},
+				storageKeys: map[string]string{"1/old": "1/new"},
+				quota:       100,
+			},
+			wantId: 3,
+			mockBehavior: func(input input, id int) {
+				expectList(input, id)
+				expectItems(input, id)
+
+				mock.ExpectExec(`INSERT INTO items_labels \(item_id, label_id, position\)`).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+
+				mock.ExpectExec(`SELECT pg_advisory_xact_lock\(\$1\)`).
+					WithArgs(input.userId).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+
+				mock.ExpectExec(`INSERT INTO attachments \(item_id, user_id, filename, content_type, size, storage_key\)
+								SELECT ic.new_id, \$1, (.+) unnest\(\$2::text\[\], \$3::text\[\]\) (.+)`).
+					WithArgs(input.userId, sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
+				mock.ExpectQuery(`SELECT COALESCE\(SUM\(a.size\), 0\) FROM attachments a WHERE a.user_id=\$1`).
+					WithArgs(input.userId).
+					WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(60))
+
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Quota exceeded",
+			input: input{
+				userId:      1,
+				listId:      2,
+				input:       structs.DuplicateListInput{Items: true, Attachments: true},
+				storageKeys: map[string]string{"1/old": "1/new"},
+				quota:       100,
+			},
+			wantId: 3,
+			mockBehavior: func(input input, id int) {
+				expectList(input, id)
+				expectItems(input, id)
+
+				mock.ExpectExec(`SELECT pg_advisory_xact_lock\(\$1\)`).
+					WithArgs(input.userId).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+
+				mock.ExpectExec("INSERT INTO attachments").
+					WithArgs(input.userId, sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
+				mock.ExpectQuery(`SELECT COALESCE\(SUM\(a.size\), 0\) FROM attachments a WHERE a.user_id=\$1`).
+					WithArgs(input.userId).
+					WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(160))
+
+				mock.ExpectRollback()
+			},
+			wantErr: ErrQuotaExceeded,
+		},
+		{
+			name:  "Not found",
+			input: input{userId: 1, listId: 2},
+			mockBehavior: func(input input, id int) {
+				mock.ExpectBegin()
+				expectJournal(mock, input.userId)
+
+				mock.ExpectQuery("INSERT INTO todo_lists").
+					WithArgs(input.userId, input.listId, input.input.Title).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+				mock.ExpectRollback()
+			},
+			wantErr: sql.ErrNoRows,
+		},
+		{
+			name:   "Failed items",
+			input:  input{userId: 1, listId: 2, input: structs.DuplicateListInput{Items: true}},
+			wantId: 3,
+			mockBehavior: func(input input, id int) {
+				expectList(input, id)
+
+				mock.ExpectExec("CREATE TEMP TABLE item_copies").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+
+				mock.ExpectExec("INSERT INTO item_copies").
+					WithArgs(input.listId).
+					WillReturnError(errors.New("some error"))
+
+				mock.ExpectRollback()
+			},
+			wantErr: errors.New("some error"),
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior(testCase.input, testCase.wantId)
+
+			got, token, err := r.Duplicate(testCase.input.userId, testCase.input.listId, testCase.input.input,
+				testCase.input.storageKeys, testCase.input.quota)
+			if testCase.wantErr != nil {
+				assert.Equal(t, testCase.wantErr, err)
+				assert.Empty(t, token)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testCase.wantId, got)
+				assert.NotEmpty(t, token)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTodoListPostgres_Batch(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "smock")
+
+	r := NewTodoListPostgres(db)
+
+	type input struct {
+		changes []structs.ListChange
+		atomic  bool
+	}
+
+	testTable := []struct {
+		name         string
+		input        input
+		mockBehavior func(input input)
+		want         []structs.BatchResult
+		wantToken    bool
+		wantErr      bool
+	}{
+		{
+			name: "Atomic",
+			input: input{
+				changes: []structs.ListChange{
+					{Op: structs.OpCreate, List: structs.List{Title: "sprint 2"}},
+					{Op: structs.OpUpdate, ListId: 1, Update: structs.UpdateListInput{Title: stringPointer("sprint 1")}},
+					{Op: structs.OpDelete, ListId: 3},
+				},
+				atomic: true,
+			},
+			mockBehavior: func(input input) {
+				mock.ExpectBegin()
+				expectJournal(mock, 1)
+				mock.ExpectQuery("INSERT INTO todo_lists").
+					WithArgs("sprint 2", "", nil, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+				mock.ExpectExec("INSERT INTO users_lists").
+					WithArgs(1, 2).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(`UPDATE todo_lists tl SET title=\$1,updated_by=\$3`).
+					WithArgs("sprint 1", 1, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("WITH trashed AS").
+					WithArgs(1, 3).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectCommit()
+			},
+			want:      []structs.BatchResult{{Id: 2}, {Id: 1}, {Id: 3}},
+			wantToken: true,
+		},
+		{
+			name: "Atomic failure rolls back",
+			input: input{
+				changes: []structs.ListChange{
+					{Op: structs.OpCreate, List: structs.List{Title: "sprint 2"}},
+					{Op: structs.OpDelete, ListId: 3},
+				},
+				atomic: true,
+			},
+			mockBehavior: func(input input) {
+				mock.ExpectBegin()
+				expectJournal(mock, 1)
+				mock.ExpectQuery("INSERT INTO todo_lists").
+					WithArgs("sprint 2", "", nil, 1).
+					WillReturnError(errors.New("some error"))
+				mock.ExpectRollback()
+			},
+			want: []structs.BatchResult{{Err: errors.New("some error")}, {}},
+		},
+		{
+			name: "Best effort",
+			input: input{
+				changes: []structs.ListChange{
+					{Op: structs.OpDelete, ListId: 3},
+					{Op: structs.OpDelete, ListId: 4},
+				},
+			},
+			mockBehavior: func(input input) {
+				mock.ExpectBegin()
+				expectJournal(mock, 1)
+				mock.ExpectExec("SAVEPOINT batch_change").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery("WITH trashed AS").
+					WithArgs(1, 3).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectExec("RELEASE SAVEPOINT batch_change").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("SAVEPOINT batch_change").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery("WITH trashed AS").
+					WithArgs(1, 4).
+					WillReturnError(errors.New("some error"))
+				mock.ExpectExec("ROLLBACK TO SAVEPOINT batch_change").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+			want:      []structs.BatchResult{{Id: 3}, {Err: errors.New("some error")}},
+			wantToken: true,
+		},
+		{
+			name: "Begin failure",
+			input: input{
+				changes: []structs.ListChange{{Op: structs.OpDelete, ListId: 3}},
+			},
+			mockBehavior: func(input input) {
+				mock.ExpectBegin().WillReturnError(errors.New("some error"))
+			},
+			wantErr: true,
+		},
+	}
+
 	for _, testCase := range testTable {
 		t.Run(testCase.name, func(t *testing.T) {
 			testCase.mockBehavior(testCase.input)
 
-			err := r.Update(testCase.input.listId, testCase.input.userId, testCase.input.list)
+			got, token, err := r.Batch(1, testCase.input.changes, testCase.input.atomic)
 			if testCase.wantErr {
 				assert.Error(t, err)
+				assert.Empty(t, token)
 			} else {
 				assert.NoError(t, err)
+				assert.Equal(t, testCase.want, got)
+				assert.Equal(t, testCase.wantToken, token != "")
 			}
 			assert.NoError(t, mock.ExpectationsWereMet())
 		})
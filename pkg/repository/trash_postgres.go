@@ -0,0 +1,198 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fr13n8/todo-app/structs"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+var ErrListInTrash = errors.New("the item's list is in the trash, restore the list first")
+
+// liveItemCondition keeps out items that are in the trash, on their own or
+// along with their list. item and link are the aliases of the item and of
+// its lists_items row in the query.
+func liveItemCondition(item string, link string) string {
+	return fmt.Sprintf(`%s.deleted_at IS NULL AND NOT EXISTS (SELECT 1 FROM %s dtl
+							WHERE dtl.id=%s.list_id AND dtl.deleted_at IS NOT NULL)`, item, todoListsTable, link)
+}
+
+type TrashPostgres struct {
+	db *sqlx.DB
+}
+
+func NewTrashPostgres(db *sqlx.DB) *TrashPostgres {
+	return &TrashPostgres{db: db}
+}
+
+func (r *TrashPostgres) GetAll(userId int) ([]structs.TrashEntry, error) {
+	var entries []structs.TrashEntry
+
+	query := fmt.Sprintf(`SELECT '%s' AS type, tl.id, tl.title, NULL::int AS list_id, tl.deleted_at, tl.deleted_by FROM %s tl
+							INNER JOIN %s ul on ul.list_id=tl.id
+							WHERE ul.user_id=$1 AND tl.deleted_at IS NOT NULL
+							UNION ALL
+							SELECT '%s', ti.id, ti.title, li.list_id, ti.deleted_at, ti.deleted_by FROM %s ti
+							INNER JOIN %s li on li.item_id=ti.id
+							INNER JOIN %s ul on ul.list_id=li.list_id
+							INNER JOIN %s tl on tl.id=li.list_id
+							WHERE ul.user_id=$1 AND ti.deleted_at IS NOT NULL AND tl.deleted_at IS NULL
+							ORDER BY deleted_at DESC, type, id`,
+		structs.TrashList, todoListsTable, usersListsTable,
+		structs.TrashItem, todoItemsTable, listsItemsTable, usersListsTable, todoListsTable)
+	if err := r.db.Select(&entries, query, userId); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// RestoreList takes the list out of the trash and returns the undo token.
+func (r *TrashPostgres) RestoreList(userId int, listId int) (string, error) {
+	return journal(r.db, userId, func(tx *sql.Tx) error {
+		query := fmt.Sprintf(`UPDATE %s tl SET deleted_at=NULL, deleted_by=NULL FROM %s ul
+								WHERE tl.id=ul.list_id AND ul.user_id=$1 AND tl.id=$2
+								AND tl.deleted_at IS NOT NULL`, todoListsTable, usersListsTable)
+		result, err := tx.Exec(query, userId, listId)
+		if err != nil {
+			return err
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return errors.New("record not found")
+		}
+		return nil
+	})
+}
+
+// RestoreItem takes the item out of the trash and returns the undo token.
+// An item can't come back to a list in the trash.
+func (r *TrashPostgres) RestoreItem(userId int, itemId int) (string, error) {
+	return journal(r.db, userId, func(tx *sql.Tx) error {
+		return r.restoreItem(tx, userId, itemId)
+	})
+}
+
+func (r *TrashPostgres) restoreItem(tx *sql.Tx, userId int, itemId int) error {
+	query := fmt.Sprintf(`UPDATE %s ti SET deleted_at=NULL, deleted_by=NULL FROM %s li, %s ul, %s tl
+							WHERE ti.id=li.item_id AND li.list_id=ul.list_id AND tl.id=li.list_id
+							AND ul.user_id=$1 AND ti.id=$2
+							AND ti.deleted_at IS NOT NULL AND tl.deleted_at IS NULL`,
+		todoItemsTable, listsItemsTable, usersListsTable, todoListsTable)
+	result, err := tx.Exec(query, userId, itemId)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected > 0 {
+		return nil
+	}
+
+	// Nothing was restored; tell a trashed list apart from a missing item.
+	var listTrashed bool
+	checkQuery := fmt.Sprintf(`SELECT tl.deleted_at IS NOT NULL FROM %s ti
+							INNER JOIN %s li on li.item_id=ti.id
+							INNER JOIN %s ul on ul.list_id=li.list_id
+							INNER JOIN %s tl on tl.id=li.list_id
+							WHERE ul.user_id=$1 AND ti.id=$2 AND ti.deleted_at IS NOT NULL`,
+		todoItemsTable, listsItemsTable, usersListsTable, todoListsTable)
+	if err := tx.QueryRow(checkQuery, userId, itemId).Scan(&listTrashed); err != nil || !listTrashed {
+		return errors.New("record not found")
+	}
+	return ErrListInTrash
+}
+
+// PurgeList deletes the list in the trash for good, with all of its items,
+// and returns the undo token.
+func (r *TrashPostgres) PurgeList(userId int, listId int) (string, error) {
+	listCondition := fmt.Sprintf("tl.id=$2 AND tl.id IN (SELECT ul.list_id FROM %s ul WHERE ul.user_id=$1)", usersListsTable)
+	return journal(r.db, userId, func(tx *sql.Tx) error {
+		purge, err := r.purge(tx, listCondition, "false", userId, listId)
+		if err != nil {
+			return err
+		}
+		if purge.Lists == 0 {
+			return errors.New("record not found")
+		}
+		return nil
+	})
+}
+
+// PurgeItem deletes the item in the trash for good and returns the undo
+// token.
+func (r *TrashPostgres) PurgeItem(userId int, itemId int) (string, error) {
+	itemCondition := fmt.Sprintf("ti.id=$2 AND li.list_id IN (SELECT ul.list_id FROM %s ul WHERE ul.user_id=$1)", usersListsTable)
+	return journal(r.db, userId, func(tx *sql.Tx) error {
+		purge, err := r.purge(tx, "false", itemCondition, userId, itemId)
+		if err != nil {
+			return err
+		}
+		if purge.Items == 0 {
+			return errors.New("record not found")
+		}
+		return nil
+	})
+}
+
+// Empty deletes everything in the user's trash for good and returns the
+// undo token along with what went away.
+func (r *TrashPostgres) Empty(userId int) (structs.TrashPurge, string, error) {
+	var purge structs.TrashPurge
+	listCondition := fmt.Sprintf("tl.id IN (SELECT ul.list_id FROM %s ul WHERE ul.user_id=$1)", usersListsTable)
+	itemCondition := fmt.Sprintf("li.list_id IN (SELECT ul.list_id FROM %s ul WHERE ul.user_id=$1)", usersListsTable)
+	token, err := journal(r.db, userId, func(tx *sql.Tx) error {
+		var err error
+		purge, err = r.purge(tx, listCondition, itemCondition, userId)
+		return err
+	})
+	if err != nil {
+		return structs.TrashPurge{}, "", err
+	}
+	return purge, token, nil
+}
+
+// PurgeOlderThan isn't journaled, what the retention period removes can't
+// be undone and the blobs of its attachments are left to the caller.
+func (r *TrashPostgres) PurgeOlderThan(before time.Time) (structs.TrashPurge, error) {
+	return r.purge(r.db, "tl.deleted_at < $1", "ti.deleted_at < $1", before)
+}
+
+// purge removes the trashed lists and items matching the conditions for
+// good, a list together with all of its items. It runs as one statement,
+// and since every part of it reads the rows as they were before, the keys
+// of the attachments going away with the items can still be collected.
+func (r *TrashPostgres) purge(db execer, listCondition string, itemCondition string, args ...interface{}) (structs.TrashPurge, error) {
+	var purge structs.TrashPurge
+	var keys pq.StringArray
+
+	query := fmt.Sprintf(`WITH purged_lists AS (
+								DELETE FROM %s tl WHERE tl.deleted_at IS NOT NULL AND %s
+								RETURNING tl.id
+							), purged_items AS (
+								DELETE FROM %s ti USING %s li
+								WHERE li.item_id=ti.id
+								AND (li.list_id IN (SELECT id FROM purged_lists) OR ti.deleted_at IS NOT NULL AND %s)
+								RETURNING ti.id
+							)
+							SELECT (SELECT COUNT(*) FROM purged_lists), (SELECT COUNT(*) FROM purged_items),
+							ARRAY(SELECT a.storage_key FROM %s a WHERE a.item_id IN (SELECT id FROM purged_items))`,
+		todoListsTable, listCondition, todoItemsTable, listsItemsTable, itemCondition, attachmentsTable)
+	if err := db.QueryRow(query, args...).Scan(&purge.Lists, &purge.Items, &keys); err != nil {
+		return purge, err
+	}
+
+	purge.StorageKeys = keys
+	return purge, nil
+}
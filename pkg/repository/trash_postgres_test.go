@@ -0,0 +1,200 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/fr13n8/todo-app/structs"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrashPostgres_GetAll(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "smock")
+
+	r := NewTrashPostgres(db)
+
+	deletedAt := time.Date(2021, 6, 2, 10, 0, 0, 0, time.UTC)
+	rows := sqlmock.NewRows([]string{"type", "id", "title", "list_id", "deleted_at", "deleted_by"}).
+		AddRow("item", 4, "task", 2, deletedAt, 1).
+		AddRow("list", 3, "groceries", nil, deletedAt.Add(-time.Hour), 1)
+	mock.ExpectQuery(`SELECT 'list' AS type, (.+) FROM todo_lists tl
+						INNER JOIN users_lists ul on (.+)
+						WHERE ul.user_id=\$1 AND tl.deleted_at IS NOT NULL
+						UNION ALL
+						SELECT 'item', (.+) FROM todo_items ti (.+)
+						WHERE ul.user_id=\$1 AND ti.deleted_at IS NOT NULL AND tl.deleted_at IS NULL
+						ORDER BY deleted_at DESC, type, id`).
+		WithArgs(1).
+		WillReturnRows(rows)
+
+	got, err := r.GetAll(1)
+	assert.NoError(t, err)
+	assert.Equal(t, []structs.TrashEntry{
+		{Type: "item", Id: 4, Title: "task", ListId: intPointer(2), DeletedAt: deletedAt, DeletedBy: intPointer(1)},
+		{Type: "list", Id: 3, Title: "groceries", DeletedAt: deletedAt.Add(-time.Hour), DeletedBy: intPointer(1)},
+	}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTrashPostgres_RestoreItem(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "smock")
+
+	r := NewTrashPostgres(db)
+
+	type mockBehavior func()
+
+	testTable := []struct {
+		name         string
+		mockBehavior mockBehavior
+		wantErr      error
+	}{
+		{
+			name: "Ok",
+			mockBehavior: func() {
+				mock.ExpectExec(`UPDATE todo_items ti SET deleted_at=NULL, deleted_by=NULL FROM (.+)
+									WHERE (.+) AND ti.deleted_at IS NOT NULL AND tl.deleted_at IS NULL`).
+					WithArgs(1, 4).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "List in trash",
+			mockBehavior: func() {
+				mock.ExpectExec("UPDATE todo_items ti SET deleted_at=NULL").
+					WithArgs(1, 4).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+
+				mock.ExpectQuery(`SELECT tl.deleted_at IS NOT NULL FROM todo_items ti (.+) WHERE (.+)`).
+					WithArgs(1, 4).
+					WillReturnRows(sqlmock.NewRows([]string{"trashed"}).AddRow(true))
+			},
+			wantErr: ErrListInTrash,
+		},
+		{
+			name: "Not in trash",
+			mockBehavior: func() {
+				mock.ExpectExec("UPDATE todo_items ti SET deleted_at=NULL").
+					WithArgs(1, 4).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+
+				mock.ExpectQuery("SELECT tl.deleted_at IS NOT NULL").
+					WithArgs(1, 4).
+					WillReturnRows(sqlmock.NewRows([]string{"trashed"}))
+			},
+			wantErr: errors.New("record not found"),
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			mock.ExpectBegin()
+			expectJournal(mock, 1)
+			testCase.mockBehavior()
+			if testCase.wantErr != nil {
+				mock.ExpectRollback()
+			} else {
+				mock.ExpectCommit()
+			}
+
+			token, err := r.RestoreItem(1, 4)
+			if testCase.wantErr != nil {
+				assert.Equal(t, testCase.wantErr, err)
+				assert.Empty(t, token)
+			} else {
+				assert.NoError(t, err)
+				assert.NotEmpty(t, token)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTrashPostgres_PurgeList(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "smock")
+
+	r := NewTrashPostgres(db)
+
+	testTable := []struct {
+		name    string
+		lists   int
+		wantErr error
+	}{
+		{
+			name:  "Ok",
+			lists: 1,
+		},
+		{
+			name:    "Not found",
+			wantErr: errors.New("record not found"),
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			mock.ExpectBegin()
+			expectJournal(mock, 1)
+			mock.ExpectQuery(`WITH purged_lists AS \( DELETE FROM todo_lists tl WHERE tl.deleted_at IS NOT NULL AND tl.id=\$2 (.+) RETURNING tl.id \),
+						purged_items AS \( DELETE FROM todo_items ti USING lists_items li
+						WHERE li.item_id=ti.id AND \(li.list_id IN \(SELECT id FROM purged_lists\) OR ti.deleted_at IS NOT NULL AND false\)
+						RETURNING ti.id \)
+						SELECT (.+) ARRAY\(SELECT a.storage_key FROM attachments a (.+)\)`).
+				WithArgs(1, 3).
+				WillReturnRows(sqlmock.NewRows([]string{"lists", "items", "keys"}).AddRow(testCase.lists, 2, "{1/a,1/b}"))
+			if testCase.wantErr != nil {
+				mock.ExpectRollback()
+			} else {
+				mock.ExpectCommit()
+			}
+
+			token, err := r.PurgeList(1, 3)
+			if testCase.wantErr != nil {
+				assert.Equal(t, testCase.wantErr, err)
+				assert.Empty(t, token)
+			} else {
+				assert.NoError(t, err)
+				assert.NotEmpty(t, token)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTrashPostgres_PurgeOlderThan(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "smock")
+
+	r := NewTrashPostgres(db)
+
+	before := time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(`WITH purged_lists AS \( DELETE FROM todo_lists tl WHERE tl.deleted_at IS NOT NULL AND tl.deleted_at < \$1 (.+)`).
+		WithArgs(before).
+		WillReturnRows(sqlmock.NewRows([]string{"lists", "items", "keys"}).AddRow(1, 2, "{1/a,1/b}"))
+
+	got, err := r.PurgeOlderThan(before)
+	assert.NoError(t, err)
+	assert.Equal(t, structs.TrashPurge{Lists: 1, Items: 2, StorageKeys: pq.StringArray{"1/a", "1/b"}}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
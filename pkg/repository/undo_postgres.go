@@ -0,0 +1,346 @@
+package repository
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+var (
+	ErrUndoExpired  = errors.New("the undo token is unknown or has expired")
+	ErrUndoConflict = errors.New("the changes can't be undone, the rows were changed since")
+)
+
+// undoTable tells how to put back the rows of a table the undo journal
+// records, see the record_undo_change trigger.
+type undoTable struct {
+	// restored are the columns set back on a changed row; the others
+	// follow by trigger, such as version and updated_at, or never change.
+	restored []string
+	// trash makes undoing the creation of a row move it to the trash
+	// rather than delete it, along with whatever was attached to it since.
+	trash bool
+	// link marks rows created along with a list or an item, which stay
+	// with it in the trash.
+	link bool
+	// check vets a deleted row before it is put back, for what the
+	// constraints of the table can't tell.
+	check func(tx *sql.Tx, oldRow json.RawMessage) error
+}
+
+var undoTables = map[string]undoTable{
+	todoListsTable: {
+		restored: []string{"title", "description", "archived_at", "auto_archive_days", "deleted_at", "deleted_by",
+			"updated_by"},
+		trash: true,
+	},
+	usersListsTable: {restored: []string{"user_id", "list_id"}, link: true},
+	todoItemsTable: {
+		restored: []string{"title", "description", "done", "status_id", "position", "due_date", "recurrence",
+			"recurrence_start", "archived_at", "priority", "assignee_id", "deleted_at", "deleted_by", "updated_by"},
+		trash: true,
+	},
+	listsItemsTable:          {restored: []string{"list_id"}, link: true},
+	itemsLabelsTable:         {restored: []string{"label_id", "position"}},
+	itemsCompletionsTable:    {restored: []string{"due_date", "completed_at"}},
+	labelsTable:              {restored: []string{"name", "color", "wip_limit"}},
+	itemsDependenciesTable:   {check: checkDependency},
+	attachmentsTable:         {},
+	timeEntriesTable:         {restored: []string{"started_at", "stopped_at", "note"}},
+	statusesTable:            {restored: []string{"name", "position", "is_done", "wip_limit"}},
+	statusesTransitionsTable: {},
+	itemsRevisionsTable:      {},
+	templatesTable:           {},
+	templateItemsTable:       {},
+	templateItemsLabelsTable: {},
+	viewsTable:               {restored: []string{"name", "query", "updated_at"}},
+}
+
+// generatedColumns can't be written, so they are left out when a deleted
+// row is put back.
+var generatedColumns = map[string]bool{"search_vector": true}
+
+// beginJournal makes the transaction record its changes under a new undo
+// token, which it returns.
+func beginJournal(tx *sql.Tx, userId int) (string, error) {
+	token := uuid.NewString()
+	if _, err := tx.Exec("SELECT set_config('todo.undo_token', $1, true)", token); err != nil {
+		return "", err
+	}
+	query := fmt.Sprintf("INSERT INTO %s (token, user_id) VALUES ($1, $2)", undoActionsTable)
+	if _, err := tx.Exec(query, token, userId); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// journal applies the change in a transaction recording it, see
+// beginJournal, and returns the undo token.
+func journal(db *sqlx.DB, userId int, change func(tx *sql.Tx) error) (string, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return "", err
+	}
+
+	token, err := beginJournal(tx, userId)
+	if err == nil {
+		err = change(tx)
+	}
+	if err != nil {
+		rollErr := tx.Rollback()
+		if rollErr != nil {
+			return "", rollErr
+		}
+		return "", err
+	}
+	if err := tx.Commit(); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+type undoChange struct {
+	Table  string
+	RowId  int
+	OldRow []byte
+	NewRow []byte
+}
+
+type UndoPostgres struct {
+	db *sqlx.DB
+}
+
+func NewUndoPostgres(db *sqlx.DB) *UndoPostgres {
+	return &UndoPostgres{db: db}
+}
+
+// Undo puts back the rows the action of the token changed, as long as it
+// was taken after since and none of them changed again; other rows don't
+// matter. Undoing is journaled in turn, so the token returned redoes the
+// action.
+func (r *UndoPostgres) Undo(userId int, token string, since time.Time) (string, error) {
+	return journal(r.db, userId, func(tx *sql.Tx) error {
+		var found string
+		query := fmt.Sprintf(`SELECT ua.token FROM %s ua WHERE ua.token=$1 AND ua.user_id=$2 AND ua.created_at > $3
+								FOR UPDATE`, undoActionsTable)
+		if err := tx.QueryRow(query, token, userId, since).Scan(&found); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrUndoExpired
+			}
+			return err
+		}
+		// Rows put back come with their history, see todo_items_revise.
+		if _, err := tx.Exec("SELECT set_config('todo.undoing', 'on', true)"); err != nil {
+			return err
+		}
+
+		changes, err := r.changes(tx, token)
+		if err != nil {
+			return err
+		}
+		for i := len(changes) - 1; i >= 0; i-- {
+			if err := revertChange(tx, userId, changes[i]); err != nil {
+				return err
+			}
+		}
+
+		query = fmt.Sprintf("DELETE FROM %s WHERE token=$1", undoActionsTable)
+		_, err = tx.Exec(query, token)
+		return err
+	})
+}
+
+// changes reads the changes of the action in order, merging the ones of
+// the same row: it goes back to how the first one found it and must still
+// be as the last one left it.
+func (r *UndoPostgres) changes(tx *sql.Tx, token string) ([]undoChange, error) {
+	query := fmt.Sprintf(`SELECT uc.table_name, uc.row_id, uc.old_row, uc.new_row FROM %s uc
+							WHERE uc.token=$1 ORDER BY uc.id`, undoChangesTable)
+	rows, err := tx.Query(query, token)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var changes []undoChange
+	merged := make(map[string]int)
+	for rows.Next() {
+		var change undoChange
+		if err := rows.Scan(&change.Table, &change.RowId, &change.OldRow, &change.NewRow); err != nil {
+			return nil, err
+		}
+		key := fmt.Sprintf("%s %d", change.Table, change.RowId)
+		if i, ok := merged[key]; ok {
+			changes[i].NewRow = change.NewRow
+			continue
+		}
+		merged[key] = len(changes)
+		changes = append(changes, change)
+	}
+	return changes, rows.Err()
+}
+
+// revertChange puts a row back as it was before the change, failing with
+// ErrUndoConflict when it isn't as the change left it anymore.
+func revertChange(tx *sql.Tx, userId int, change undoChange) error {
+	table, ok := undoTables[change.Table]
+	if !ok {
+		return fmt.Errorf("no undo for changes of %s", change.Table)
+	}
+
+	switch {
+	case change.OldRow == nil && change.NewRow == nil:
+		// Created and deleted again.
+		return nil
+	case change.NewRow == nil:
+		if table.check != nil {
+			if err := table.check(tx, change.OldRow); err != nil {
+				return err
+			}
+		}
+		return reinsertRow(tx, change)
+	}
+
+	var unchanged bool
+	query := fmt.Sprintf("SELECT to_jsonb(t)=$2::jsonb FROM %s t WHERE t.id=$1 FOR UPDATE", change.Table)
+	if err := tx.QueryRow(query, change.RowId, change.NewRow).Scan(&unchanged); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrUndoConflict
+		}
+		return err
+	}
+	if !unchanged {
+		return ErrUndoConflict
+	}
+
+	if change.OldRow == nil {
+		switch {
+		case table.link:
+			return nil
+		case table.trash:
+			query = fmt.Sprintf("UPDATE %s SET deleted_at=now(), deleted_by=$2 WHERE id=$1", change.Table)
+			_, err := tx.Exec(query, change.RowId, userId)
+			return err
+		}
+		query = fmt.Sprintf("DELETE FROM %s WHERE id=$1", change.Table)
+		_, err := tx.Exec(query, change.RowId)
+		return err
+	}
+
+	columns, err := changedColumns(change, table.restored)
+	if err != nil || len(columns) == 0 {
+		return err
+	}
+	setValues := make([]string, len(columns))
+	for i, column := range columns {
+		setValues[i] = fmt.Sprintf("%s=r.%s", column, column)
+	}
+	query = fmt.Sprintf("UPDATE %s t SET %s FROM jsonb_populate_record(NULL::%s, $2) r WHERE t.id=$1",
+		change.Table, strings.Join(setValues, ", "), change.Table)
+	_, err = tx.Exec(query, change.RowId, change.OldRow)
+	return conflictOnViolation(err)
+}
+
+// changedColumns picks the columns to restore that the change set to
+// another value, so that the triggers of the others don't fire.
+func changedColumns(change undoChange, restored []string) ([]string, error) {
+	var oldRow, newRow map[string]json.RawMessage
+	if err := json.Unmarshal(change.OldRow, &oldRow); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(change.NewRow, &newRow); err != nil {
+		return nil, err
+	}
+
+	var columns []string
+	for _, column := range restored {
+		if !bytes.Equal(oldRow[column], newRow[column]) {
+			columns = append(columns, column)
+		}
+	}
+	return columns, nil
+}
+
+// reinsertRow puts back a deleted row, which conflicts when a row took its
+// id or what it referred to is gone.
+func reinsertRow(tx *sql.Tx, change undoChange) error {
+	var oldRow map[string]json.RawMessage
+	if err := json.Unmarshal(change.OldRow, &oldRow); err != nil {
+		return err
+	}
+	var columns []string
+	for column := range oldRow {
+		if !generatedColumns[column] {
+			columns = append(columns, column)
+		}
+	}
+	sort.Strings(columns)
+
+	query := fmt.Sprintf("INSERT INTO %s (%s) SELECT %s FROM jsonb_populate_record(NULL::%s, $1)",
+		change.Table, strings.Join(columns, ", "), strings.Join(columns, ", "), change.Table)
+	_, err := tx.Exec(query, change.OldRow)
+	return conflictOnViolation(err)
+}
+
+// checkDependency refuses to put back a dependency that would close a cycle
+// with the ones added since it was deleted.
+func checkDependency(tx *sql.Tx, oldRow json.RawMessage) error {
+	var dependency struct {
+		ItemId    int `json:"item_id"`
+		BlockerId int `json:"blocker_id"`
+	}
+	if err := json.Unmarshal(oldRow, &dependency); err != nil {
+		return err
+	}
+
+	cycle, err := dependencyCycle(tx, dependency.ItemId, dependency.BlockerId)
+	if err != nil {
+		return err
+	}
+	if cycle {
+		return ErrUndoConflict
+	}
+	return nil
+}
+
+// conflictOnViolation turns the violation of a constraint, by a row that
+// took the place of the one put back, into ErrUndoConflict.
+func conflictOnViolation(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code.Class() == "23" {
+		return ErrUndoConflict
+	}
+	return err
+}
+
+// DeleteOlderThan forgets the actions taken before the time. It returns how
+// many there were, along with the storage keys of the attachments they
+// deleted for good: their blobs are kept until the deletion can't be undone
+// anymore and are left for the caller to delete.
+func (r *UndoPostgres) DeleteOlderThan(before time.Time) (int64, []string, error) {
+	var deleted int64
+	var keys pq.StringArray
+
+	query := fmt.Sprintf(`WITH expired AS (
+								DELETE FROM %s WHERE created_at <= $1 RETURNING token
+							)
+							SELECT (SELECT COUNT(*) FROM expired),
+							ARRAY(SELECT uc.old_row->>'storage_key' FROM %s uc
+								WHERE uc.token IN (SELECT token FROM expired)
+								AND uc.table_name='%s' AND uc.new_row IS NULL
+								AND NOT EXISTS (SELECT 1 FROM %s a WHERE a.storage_key=uc.old_row->>'storage_key'))`,
+		undoActionsTable, undoChangesTable, attachmentsTable, attachmentsTable)
+	if err := r.db.QueryRow(query, before).Scan(&deleted, &keys); err != nil {
+		return 0, nil, err
+	}
+	return deleted, keys, nil
+}
@@ -0,0 +1,264 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+// expectJournal expects the statements of beginJournal.
+func expectJournal(mock sqlmock.Sqlmock, userId int) {
+	mock.ExpectExec(`SELECT set_config\('todo.undo_token', \$1, true\)`).
+		WithArgs(sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	mock.ExpectExec(`INSERT INTO undo_actions \(token, user_id\) VALUES \(\$1, \$2\)`).
+		WithArgs(sqlmock.AnyArg(), userId).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+}
+
+func TestUndoPostgres_Undo(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "smock")
+
+	r := NewUndoPostgres(db)
+
+	since := time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)
+	token := "0f8fad5b-d9cb-469f-a165-70867728950e"
+	changeColumns := []string{"table_name", "row_id", "old_row", "new_row"}
+
+	type mockBehavior func()
+
+	testTable := []struct {
+		name         string
+		mockBehavior mockBehavior
+		wantErr      error
+	}{
+		{
+			name: "Ok",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				expectJournal(mock, 1)
+
+				mock.ExpectQuery(`SELECT ua.token FROM undo_actions ua WHERE ua.token=\$1 AND ua.user_id=\$2 AND ua.created_at > \$3\s+FOR UPDATE`).
+					WithArgs(token, 1, since).
+					WillReturnRows(sqlmock.NewRows([]string{"token"}).AddRow(token))
+
+				mock.ExpectExec(`SELECT set_config\('todo.undoing', 'on', true\)`).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
+				mock.ExpectQuery(`SELECT uc.table_name, uc.row_id, uc.old_row, uc.new_row FROM undo_changes uc\s+WHERE uc.token=\$1 ORDER BY uc.id`).
+					WithArgs(token).
+					WillReturnRows(sqlmock.NewRows(changeColumns).
+						AddRow("todo_items", 2, []byte(`{"id":2,"title":"a","done":false,"version":1}`), []byte(`{"id":2,"title":"a","done":true,"version":2}`)).
+						AddRow("lists_items", 7, []byte(`{"id":7,"list_id":1,"item_id":2}`), nil).
+						AddRow("todo_items", 2, []byte(`{"id":2,"title":"a","done":true,"version":2}`), []byte(`{"id":2,"title":"b","done":true,"version":3}`)))
+
+				mock.ExpectExec(`INSERT INTO lists_items \(id, item_id, list_id\) SELECT id, item_id, list_id FROM jsonb_populate_record\(NULL::lists_items, \$1\)`).
+					WillReturnResult(sqlmock.NewResult(7, 1))
+
+				mock.ExpectQuery(`SELECT to_jsonb\(t\)=\$2::jsonb FROM todo_items t WHERE t.id=\$1 FOR UPDATE`).
+					WithArgs(2, []byte(`{"id":2,"title":"b","done":true,"version":3}`)).
+					WillReturnRows(sqlmock.NewRows([]string{"unchanged"}).AddRow(true))
+
+				mock.ExpectExec(`UPDATE todo_items t SET title=r.title, done=r.done FROM jsonb_populate_record\(NULL::todo_items, \$2\) r WHERE t.id=\$1`).
+					WithArgs(2, []byte(`{"id":2,"title":"a","done":false,"version":1}`)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
+				mock.ExpectExec(`DELETE FROM undo_actions WHERE token=\$1`).
+					WithArgs(token).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Created row",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				expectJournal(mock, 1)
+
+				mock.ExpectQuery("SELECT ua.token FROM undo_actions").
+					WithArgs(token, 1, since).
+					WillReturnRows(sqlmock.NewRows([]string{"token"}).AddRow(token))
+
+				mock.ExpectExec(`SELECT set_config\('todo.undoing', 'on', true\)`).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
+				mock.ExpectQuery("SELECT uc.table_name").
+					WithArgs(token).
+					WillReturnRows(sqlmock.NewRows(changeColumns).
+						AddRow("todo_lists", 4, nil, []byte(`{"id":4,"title":"a"}`)).
+						AddRow("users_lists", 9, nil, []byte(`{"id":9,"user_id":1,"list_id":4}`)))
+
+				mock.ExpectQuery(`SELECT to_jsonb\(t\)=\$2::jsonb FROM users_lists t`).
+					WithArgs(9, []byte(`{"id":9,"user_id":1,"list_id":4}`)).
+					WillReturnRows(sqlmock.NewRows([]string{"unchanged"}).AddRow(true))
+
+				mock.ExpectQuery(`SELECT to_jsonb\(t\)=\$2::jsonb FROM todo_lists t`).
+					WithArgs(4, []byte(`{"id":4,"title":"a"}`)).
+					WillReturnRows(sqlmock.NewRows([]string{"unchanged"}).AddRow(true))
+
+				mock.ExpectExec(`UPDATE todo_lists SET deleted_at=now\(\), deleted_by=\$2 WHERE id=\$1`).
+					WithArgs(4, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
+				mock.ExpectExec("DELETE FROM undo_actions").
+					WithArgs(token).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Changed since",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				expectJournal(mock, 1)
+
+				mock.ExpectQuery("SELECT ua.token FROM undo_actions").
+					WithArgs(token, 1, since).
+					WillReturnRows(sqlmock.NewRows([]string{"token"}).AddRow(token))
+
+				mock.ExpectExec(`SELECT set_config\('todo.undoing', 'on', true\)`).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
+				mock.ExpectQuery("SELECT uc.table_name").
+					WithArgs(token).
+					WillReturnRows(sqlmock.NewRows(changeColumns).
+						AddRow("todo_items", 2, []byte(`{"id":2,"done":false}`), []byte(`{"id":2,"done":true}`)))
+
+				mock.ExpectQuery(`SELECT to_jsonb\(t\)=\$2::jsonb FROM todo_items t`).
+					WithArgs(2, []byte(`{"id":2,"done":true}`)).
+					WillReturnRows(sqlmock.NewRows([]string{"unchanged"}).AddRow(false))
+
+				mock.ExpectRollback()
+			},
+			wantErr: ErrUndoConflict,
+		},
+		{
+			name: "Dependency",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				expectJournal(mock, 1)
+
+				mock.ExpectQuery("SELECT ua.token FROM undo_actions").
+					WithArgs(token, 1, since).
+					WillReturnRows(sqlmock.NewRows([]string{"token"}).AddRow(token))
+
+				mock.ExpectExec(`SELECT set_config\('todo.undoing', 'on', true\)`).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
+				mock.ExpectQuery("SELECT uc.table_name").
+					WithArgs(token).
+					WillReturnRows(sqlmock.NewRows(changeColumns).
+						AddRow("items_dependencies", 5, []byte(`{"id":5,"item_id":1,"blocker_id":2}`), nil))
+
+				mock.ExpectExec(`LOCK TABLE items_dependencies IN SHARE ROW EXCLUSIVE MODE`).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+
+				mock.ExpectQuery(`WITH RECURSIVE blockers\(id\) AS`).
+					WithArgs(2, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"cycle"}).AddRow(false))
+
+				mock.ExpectExec(`INSERT INTO items_dependencies \(blocker_id, id, item_id\) SELECT blocker_id, id, item_id FROM jsonb_populate_record`).
+					WillReturnResult(sqlmock.NewResult(5, 1))
+
+				mock.ExpectExec("DELETE FROM undo_actions").
+					WithArgs(token).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Dependency closing a cycle",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				expectJournal(mock, 1)
+
+				mock.ExpectQuery("SELECT ua.token FROM undo_actions").
+					WithArgs(token, 1, since).
+					WillReturnRows(sqlmock.NewRows([]string{"token"}).AddRow(token))
+
+				mock.ExpectExec(`SELECT set_config\('todo.undoing', 'on', true\)`).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
+				mock.ExpectQuery("SELECT uc.table_name").
+					WithArgs(token).
+					WillReturnRows(sqlmock.NewRows(changeColumns).
+						AddRow("items_dependencies", 5, []byte(`{"id":5,"item_id":1,"blocker_id":2}`), nil))
+
+				mock.ExpectExec(`LOCK TABLE items_dependencies IN SHARE ROW EXCLUSIVE MODE`).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+
+				mock.ExpectQuery(`WITH RECURSIVE blockers\(id\) AS (.+) SELECT \$1=\$2 OR EXISTS \(SELECT 1 FROM blockers WHERE id=\$2\)`).
+					WithArgs(2, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"cycle"}).AddRow(true))
+
+				mock.ExpectRollback()
+			},
+			wantErr: ErrUndoConflict,
+		},
+		{
+			name: "Expired",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				expectJournal(mock, 1)
+
+				mock.ExpectQuery("SELECT ua.token FROM undo_actions").
+					WithArgs(token, 1, since).
+					WillReturnRows(sqlmock.NewRows([]string{"token"}))
+
+				mock.ExpectRollback()
+			},
+			wantErr: ErrUndoExpired,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior()
+
+			got, err := r.Undo(1, token, since)
+			if testCase.wantErr != nil {
+				assert.Equal(t, testCase.wantErr, err)
+				assert.Empty(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.NotEmpty(t, got)
+				assert.NotEqual(t, token, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUndoPostgres_DeleteOlderThan(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "smock")
+
+	r := NewUndoPostgres(db)
+
+	before := time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(`WITH expired AS \(\s*DELETE FROM undo_actions WHERE created_at <= \$1 RETURNING token\s*\)(.+)`).
+		WithArgs(before).
+		WillReturnRows(sqlmock.NewRows([]string{"count", "keys"}).AddRow(3, "{1/key}"))
+
+	got, keys, err := r.DeleteOlderThan(before)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), got)
+	assert.Equal(t, []string{"1/key"}, keys)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
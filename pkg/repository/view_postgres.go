@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/fr13n8/todo-app/pkg/querylang"
+	"github.com/fr13n8/todo-app/structs"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+const viewColumns = "v.id, v.name, v.query, v.created_at, v.updated_at"
+
+type ViewPostgres struct {
+	db *sqlx.DB
+}
+
+func NewViewPostgres(db *sqlx.DB) *ViewPostgres {
+	return &ViewPostgres{db: db}
+}
+
+// checkSort refuses a view whose sort its items can't be ordered by.
+func checkSort(query structs.ViewQuery) error {
+	_, err := newKeyset(query.Sort, query.Order, itemSortColumns)
+	return err
+}
+
+func (r *ViewPostgres) Create(userId int, input structs.ViewInput) (int, string, error) {
+	if err := checkSort(input.Query); err != nil {
+		return 0, "", err
+	}
+
+	var id int
+	token, err := journal(r.db, userId, func(tx *sql.Tx) error {
+		query := fmt.Sprintf("INSERT INTO %s (user_id, name, query) VALUES ($1, $2, $3) RETURNING id", viewsTable)
+		row := tx.QueryRow(query, userId, input.Name, input.Query)
+		return row.Scan(&id)
+	})
+	if err != nil {
+		return 0, "", err
+	}
+	return id, token, nil
+}
+
+func (r *ViewPostgres) GetAll(userId int) ([]structs.View, error) {
+	var views []structs.View
+
+	query := fmt.Sprintf("SELECT %s FROM %s v WHERE v.user_id=$1 ORDER BY v.name, v.id", viewColumns, viewsTable)
+	err := r.db.Select(&views, query, userId)
+
+	return views, err
+}
+
+func (r *ViewPostgres) GetById(userId int, viewId int) (structs.View, error) {
+	var view structs.View
+
+	query := fmt.Sprintf("SELECT %s FROM %s v WHERE v.user_id=$1 AND v.id=$2", viewColumns, viewsTable)
+	err := r.db.Get(&view, query, userId, viewId)
+
+	return view, err
+}
+
+func (r *ViewPostgres) Update(userId int, viewId int, input structs.UpdateViewInput) (string, error) {
+	setValues := make([]string, 0)
+	args := make([]interface{}, 0)
+	argId := 1
+
+	if input.Name != nil {
+		setValues = append(setValues, fmt.Sprintf("name=$%d", argId))
+		args = append(args, *input.Name)
+		argId++
+	}
+
+	if input.Query != nil {
+		if err := checkSort(*input.Query); err != nil {
+			return "", err
+		}
+		setValues = append(setValues, fmt.Sprintf("query=$%d", argId))
+		args = append(args, *input.Query)
+		argId++
+	}
+
+	setValues = append(setValues, "updated_at=now()")
+
+	setQuery := strings.Join(setValues, ",")
+	query := fmt.Sprintf(`UPDATE %s v SET %s
+							WHERE v.user_id=$%d
+							AND v.id=$%d`, viewsTable, setQuery, argId, argId+1)
+	args = append(args, userId, viewId)
+
+	return journal(r.db, userId, func(tx *sql.Tx) error {
+		_, err := tx.Exec(query, args...)
+		return err
+	})
+}
+
+func (r *ViewPostgres) Delete(userId int, viewId int) (string, error) {
+	return journal(r.db, userId, func(tx *sql.Tx) error {
+		query := fmt.Sprintf("DELETE FROM %s v WHERE v.user_id=$1 AND v.id=$2", viewsTable)
+		_, err := tx.Exec(query, userId, viewId)
+		return err
+	})
+}
+
+// GetItems runs the query of a view over the active items of every list the
+// user can access; expr narrows them down further when it isn't nil.
+func (r *ViewPostgres) GetItems(userId int, query structs.ViewQuery, expr querylang.Node) ([]structs.Item, error) {
+	var items []structs.Item
+	conditions := []string{"ul.user_id=$1", liveItemCondition("ti", "li"), "ti.archived_at IS NULL"}
+	args := []interface{}{userId}
+	add := func(format string, values ...interface{}) {
+		numbers := make([]interface{}, len(values))
+		for i, value := range values {
+			args = append(args, value)
+			numbers[i] = len(args)
+		}
+		conditions = append(conditions, fmt.Sprintf(format, numbers...))
+	}
+
+	if query.Done != nil {
+		add("ti.done=$%d", *query.Done)
+	}
+	if len(query.LabelIds) > 0 {
+		add(fmt.Sprintf(`ti.id IN (SELECT il.item_id FROM %s il
+							INNER JOIN %s l on l.id=il.label_id
+							WHERE l.user_id=$1 AND il.label_id = ANY($%%d)
+							GROUP BY il.item_id HAVING count(*)=$%%d)`, itemsLabelsTable, labelsTable),
+			pq.Array(query.LabelIds), len(query.LabelIds))
+	}
+	if query.DueWithinDays != nil {
+		add("ti.due_date < now() + $%d * interval '1 day'", *query.DueWithinDays)
+	}
+	if query.MinPriority != nil {
+		add("ti.priority>=$%d", *query.MinPriority)
+	}
+	if query.Mine {
+		conditions = append(conditions, "ti.assignee_id=$1")
+	}
+	if expr != nil {
+		var condition string
+		condition, args = compileItemQuery(expr, 1, args)
+		conditions = append(conditions, condition)
+	}
+
+	keys, err := newKeyset(query.Sort, query.Order, itemSortColumns)
+	if err != nil {
+		return nil, err
+	}
+
+	itemsQuery := fmt.Sprintf(`SELECT %s FROM %s ti
+							INNER JOIN %s li on li.item_id=ti.id
+							INNER JOIN %s ul on ul.list_id=li.list_id
+							WHERE %s %s`, itemColumns, todoItemsTable, listsItemsTable, usersListsTable,
+		strings.Join(conditions, " AND "), keys.orderBy())
+	if err := r.db.Select(&items, itemsQuery, args...); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
@@ -0,0 +1,332 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/fr13n8/todo-app/pkg/querylang"
+	"github.com/fr13n8/todo-app/structs"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestViewPostgres_Create(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "smock")
+
+	r := NewViewPostgres(db)
+
+	done := false
+
+	testTable := []struct {
+		name         string
+		input        structs.ViewInput
+		mockBehavior func(input structs.ViewInput)
+		wantId       int
+		wantErr      bool
+	}{
+		{
+			name:  "Ok",
+			input: structs.ViewInput{Name: "open work", Query: structs.ViewQuery{Done: &done, LabelIds: []int{3}}},
+			mockBehavior: func(input structs.ViewInput) {
+				mock.ExpectBegin()
+				expectJournal(mock, 1)
+				mock.ExpectQuery(`INSERT INTO views \(user_id, name, query\) VALUES \(\$1, \$2, \$3\) RETURNING id`).
+					WithArgs(1, input.Name, []byte(`{"done":false,"label_ids":[3]}`)).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+				mock.ExpectCommit()
+			},
+			wantId: 2,
+		},
+		{
+			name:  "Failed",
+			input: structs.ViewInput{Name: "everything"},
+			mockBehavior: func(input structs.ViewInput) {
+				mock.ExpectBegin()
+				expectJournal(mock, 1)
+				mock.ExpectQuery("INSERT INTO views").
+					WithArgs(1, input.Name, []byte(`{}`)).
+					WillReturnError(errors.New("insert error"))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name:         "Unknown sort",
+			input:        structs.ViewInput{Name: "ranked", Query: structs.ViewQuery{Sort: "rank"}},
+			mockBehavior: func(input structs.ViewInput) {},
+			wantErr:      true,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior(testCase.input)
+
+			got, token, err := r.Create(1, testCase.input)
+			if testCase.wantErr {
+				assert.Error(t, err)
+				assert.Empty(t, token)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testCase.wantId, got)
+				assert.NotEmpty(t, token)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestViewPostgres_GetById(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "smock")
+
+	r := NewViewPostgres(db)
+
+	createdAt := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	days := 7
+
+	testTable := []struct {
+		name         string
+		mockBehavior func()
+		want         structs.View
+		wantErr      bool
+	}{
+		{
+			name: "Ok",
+			mockBehavior: func() {
+				rows := sqlmock.NewRows([]string{"id", "name", "query", "created_at", "updated_at"}).
+					AddRow(2, "this week", []byte(`{"due_within_days":7,"mine":true,"sort":"due_date"}`), createdAt, createdAt)
+				mock.ExpectQuery(`SELECT v.id, v.name, v.query, v.created_at, v.updated_at FROM views v WHERE v.user_id=\$1 AND v.id=\$2`).
+					WithArgs(1, 2).
+					WillReturnRows(rows)
+			},
+			want: structs.View{
+				Id:        2,
+				Name:      "this week",
+				Query:     structs.ViewQuery{DueWithinDays: &days, Mine: true, Sort: "due_date"},
+				CreatedAt: createdAt,
+				UpdatedAt: createdAt,
+			},
+		},
+		{
+			name: "Not found",
+			mockBehavior: func() {
+				mock.ExpectQuery("SELECT (.+) FROM views v WHERE (.+)").
+					WithArgs(1, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "query", "created_at", "updated_at"}))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior()
+
+			got, err := r.GetById(1, 2)
+			if testCase.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testCase.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestViewPostgres_Update(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "smock")
+
+	r := NewViewPostgres(db)
+
+	name := "renamed"
+
+	testTable := []struct {
+		name         string
+		input        structs.UpdateViewInput
+		mockBehavior func()
+		wantErr      bool
+	}{
+		{
+			name:  "Name and query",
+			input: structs.UpdateViewInput{Name: &name, Query: &structs.ViewQuery{Mine: true}},
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				expectJournal(mock, 1)
+				mock.ExpectExec(`UPDATE views v SET name=\$1,query=\$2,updated_at=now\(\) WHERE v.user_id=\$3 AND v.id=\$4`).
+					WithArgs(name, []byte(`{"mine":true}`), 1, 2).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:  "Name",
+			input: structs.UpdateViewInput{Name: &name},
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				expectJournal(mock, 1)
+				mock.ExpectExec(`UPDATE views v SET name=\$1,updated_at=now\(\) WHERE v.user_id=\$2 AND v.id=\$3`).
+					WithArgs(name, 1, 2).
+					WillReturnError(errors.New("update error"))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name:         "Unknown sort",
+			input:        structs.UpdateViewInput{Query: &structs.ViewQuery{Sort: "priority,rank"}},
+			mockBehavior: func() {},
+			wantErr:      true,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior()
+
+			token, err := r.Update(1, 2, testCase.input)
+			if testCase.wantErr {
+				assert.Error(t, err)
+				assert.Empty(t, token)
+			} else {
+				assert.NoError(t, err)
+				assert.NotEmpty(t, token)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestViewPostgres_GetItems(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "smock")
+
+	r := NewViewPostgres(db)
+
+	done := false
+	days := 7
+	high := structs.PriorityHigh
+	itemRows := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"id", "title", "description", "done", "state"}).
+			AddRow(4, "first", "", false, structs.ItemStateReady).
+			AddRow(9, "second", "", false, structs.ItemStateBlocked)
+	}
+
+	testTable := []struct {
+		name         string
+		query        structs.ViewQuery
+		expr         querylang.Node
+		mockBehavior func()
+		want         []structs.Item
+		wantErr      bool
+	}{
+		{
+			name: "Everything",
+			mockBehavior: func() {
+				mock.ExpectQuery(`SELECT (.+) FROM todo_items ti INNER JOIN lists_items li on li.item_id=ti.id
+										INNER JOIN users_lists ul on ul.list_id=li.list_id
+										WHERE ul.user_id=\$1 AND (.+) AND ti.archived_at IS NULL ORDER BY ti.id ASC`).
+					WithArgs(1).
+					WillReturnRows(itemRows())
+			},
+			want: []structs.Item{
+				{Id: 4, Title: "first", State: structs.ItemStateReady},
+				{Id: 9, Title: "second", State: structs.ItemStateBlocked},
+			},
+		},
+		{
+			name:  "All conditions",
+			query: structs.ViewQuery{Done: &done, LabelIds: []int{3, 5}, DueWithinDays: &days, MinPriority: &high, Mine: true, Sort: "due_date", Order: "desc"},
+			mockBehavior: func() {
+				mock.ExpectQuery(`SELECT (.+) WHERE ul.user_id=\$1 AND (.+) AND ti.archived_at IS NULL
+										AND ti.done=\$2
+										AND ti.id IN \(SELECT il.item_id FROM items_labels il (.+) il.label_id = ANY\(\$3\) GROUP BY il.item_id HAVING count\(\*\)=\$4\)
+										AND ti.due_date < now\(\) \+ \$5 \* interval '1 day'
+										AND ti.priority>=\$6
+										AND ti.assignee_id=\$1
+										ORDER BY ti.due_date DESC NULLS LAST, ti.id DESC`).
+					WithArgs(1, done, sqlmock.AnyArg(), 2, days, high).
+					WillReturnRows(itemRows())
+			},
+			want: []structs.Item{
+				{Id: 4, Title: "first", State: structs.ItemStateReady},
+				{Id: 9, Title: "second", State: structs.ItemStateBlocked},
+			},
+		},
+		{
+			name:  "Filter expression",
+			query: structs.ViewQuery{Done: &done},
+			expr:  querylang.Term{Field: "label", Op: querylang.OpMatch, Text: "work"},
+			mockBehavior: func() {
+				mock.ExpectQuery(`SELECT (.+) AND ti.done=\$2
+										AND EXISTS \(SELECT 1 FROM items_labels il INNER JOIN labels l on l.id=il.label_id
+										WHERE il.item_id=ti.id AND l.user_id=\$1 AND lower\(l.name\)=lower\(\$3\)\) ORDER BY ti.id ASC`).
+					WithArgs(1, done, "work").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			},
+		},
+		{
+			name:  "Audit sort",
+			query: structs.ViewQuery{Sort: "updated_at"},
+			mockBehavior: func() {
+				mock.ExpectQuery(`SELECT (.+) ORDER BY ti.updated_at ASC NULLS LAST, ti.id ASC`).
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			},
+		},
+		{
+			name:  "Priority then title",
+			query: structs.ViewQuery{Sort: "priority,title", Order: "desc"},
+			mockBehavior: func() {
+				mock.ExpectQuery(`SELECT (.+) ORDER BY ti.priority DESC NULLS LAST, ti.title DESC NULLS LAST, ti.id DESC`).
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			},
+		},
+		{
+			name: "Query error",
+			mockBehavior: func() {
+				mock.ExpectQuery("SELECT (.+) FROM todo_items ti (.+)").
+					WithArgs(1).
+					WillReturnError(errors.New("query error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.mockBehavior()
+
+			got, err := r.GetItems(1, testCase.query, testCase.expr)
+			if testCase.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testCase.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
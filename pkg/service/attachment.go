@@ -0,0 +1,197 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+
+	"github.com/fr13n8/todo-app/pkg/repository"
+	"github.com/fr13n8/todo-app/pkg/storage"
+	"github.com/fr13n8/todo-app/structs"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	ErrAttachmentTooLarge = errors.New("attachment is too large")
+	ErrQuotaExceeded      = repository.ErrQuotaExceeded
+)
+
+type AttachmentService struct {
+	repo     repository.Attachment
+	itemRepo repository.TodoItem
+	store    storage.BlobStore
+	cfg      Config
+}
+
+func NewAttachmentService(repo repository.Attachment, itemRepo repository.TodoItem, store storage.BlobStore, cfg Config) *AttachmentService {
+	return &AttachmentService{
+		repo:     repo,
+		itemRepo: itemRepo,
+		store:    store,
+		cfg:      cfg,
+	}
+}
+
+func (s *AttachmentService) Upload(userId int, itemId int, upload structs.AttachmentUpload) (int, string, error) {
+	if _, err := s.itemRepo.GetById(userId, itemId); err != nil {
+		return 0, "", errors.New("record not found")
+	}
+
+	limit, limitErr := s.cfg.MaxAttachmentSize, ErrAttachmentTooLarge
+	if s.cfg.AttachmentQuota > 0 {
+		usage, err := s.repo.GetUsage(userId)
+		if err != nil {
+			return 0, "", err
+		}
+		remaining := s.cfg.AttachmentQuota - usage
+		if remaining <= 0 {
+			return 0, "", ErrQuotaExceeded
+		}
+		if limit <= 0 || remaining < limit {
+			limit, limitErr = remaining, ErrQuotaExceeded
+		}
+	}
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(upload.Reader, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return 0, "", err
+	}
+	head = head[:n]
+
+	body := &sizeReader{r: io.MultiReader(bytes.NewReader(head), upload.Reader), limit: limit, err: limitErr}
+	attachment := structs.Attachment{
+		ItemId:      itemId,
+		Filename:    filepath.Base(upload.Filename),
+		ContentType: detectContentType(upload.Filename, upload.ContentType, head),
+		StorageKey:  fmt.Sprintf("%d/%s", userId, uuid.New().String()),
+	}
+
+	if err := s.store.Put(attachment.StorageKey, body, attachment.ContentType); err != nil {
+		s.deleteBlob(attachment.StorageKey)
+		if body.exceeded() {
+			return 0, "", limitErr
+		}
+		return 0, "", err
+	}
+	attachment.Size = body.n
+
+	id, token, err := s.repo.Create(userId, attachment, s.cfg.AttachmentQuota)
+	if err != nil {
+		s.deleteBlob(attachment.StorageKey)
+		return 0, "", err
+	}
+	return id, token, nil
+}
+
+func (s *AttachmentService) GetAll(userId int, itemId int) ([]structs.Attachment, error) {
+	if _, err := s.itemRepo.GetById(userId, itemId); err != nil {
+		return nil, errors.New("record not found")
+	}
+	return s.repo.GetAll(itemId)
+}
+
+func (s *AttachmentService) Download(userId int, itemId int, attachmentId int) (structs.Attachment, io.ReadCloser, error) {
+	if _, err := s.itemRepo.GetById(userId, itemId); err != nil {
+		return structs.Attachment{}, nil, errors.New("record not found")
+	}
+	attachment, err := s.repo.GetById(itemId, attachmentId)
+	if err != nil {
+		return attachment, nil, errors.New("record not found")
+	}
+
+	blob, err := s.store.Get(attachment.StorageKey)
+	if err != nil {
+		return attachment, nil, err
+	}
+	return attachment, blob, nil
+}
+
+// Delete removes the attachment and returns the undo token. Its blob goes
+// once the deletion can't be undone anymore, see UndoService.PurgeExpired.
+func (s *AttachmentService) Delete(userId int, itemId int, attachmentId int) (string, error) {
+	if _, err := s.itemRepo.GetById(userId, itemId); err != nil {
+		return "", errors.New("record not found")
+	}
+	if _, err := s.repo.GetById(itemId, attachmentId); err != nil {
+		return "", errors.New("record not found")
+	}
+
+	return s.repo.Delete(userId, itemId, attachmentId)
+}
+
+func (s *AttachmentService) MaxSize() int64 {
+	return s.cfg.MaxAttachmentSize
+}
+
+// deleteBlob is best effort: the row is the source of truth, so a blob that
+// can't be removed is only logged.
+func (s *AttachmentService) deleteBlob(key string) {
+	deleteBlobs(s.store, []string{key})
+}
+
+func deleteBlobs(store storage.BlobStore, keys []string) {
+	for _, key := range keys {
+		if err := store.Delete(key); err != nil {
+			logrus.Errorf("failed to delete blob %s: %s", key, err.Error())
+		}
+	}
+}
+
+func copyBlob(store storage.BlobStore, from string, to string, contentType string) error {
+	blob, err := store.Get(from)
+	if err != nil {
+		return err
+	}
+	defer blob.Close()
+
+	return store.Put(to, blob, contentType)
+}
+
+// detectContentType sniffs the content, falling back to the extension and
+// then to the type the client declared when sniffing is inconclusive.
+func detectContentType(filename string, declared string, head []byte) string {
+	contentType := http.DetectContentType(head)
+	if contentType != "application/octet-stream" {
+		return contentType
+	}
+	if byExt := mime.TypeByExtension(filepath.Ext(filename)); byExt != "" {
+		return byExt
+	}
+	if declared != "" {
+		if _, _, err := mime.ParseMediaType(declared); err == nil {
+			return declared
+		}
+	}
+	return contentType
+}
+
+// sizeReader counts the bytes read and fails once more than limit bytes came
+// through, so oversized uploads stop streaming instead of being stored.
+type sizeReader struct {
+	r     io.Reader
+	n     int64
+	limit int64
+	err   error
+}
+
+func (r *sizeReader) Read(p []byte) (int, error) {
+	if r.limit > 0 && int64(len(p)) > r.limit-r.n+1 {
+		p = p[:r.limit-r.n+1]
+	}
+	n, err := r.r.Read(p)
+	r.n += int64(n)
+	if r.exceeded() {
+		return n, r.err
+	}
+	return n, err
+}
+
+func (r *sizeReader) exceeded() bool {
+	return r.limit > 0 && r.n > r.limit
+}
@@ -18,6 +18,8 @@ var (
 	signingKey = viper.GetString("jwt.signingKey")
 )
 
+var ErrInvalidSearchLanguage = repository.ErrInvalidSearchLanguage
+
 type AuthService struct {
 	repo repository.Authorization
 }
@@ -0,0 +1,37 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/fr13n8/todo-app/pkg/repository"
+	"github.com/fr13n8/todo-app/structs"
+)
+
+var (
+	ErrInvalidOperation = structs.ErrInvalidOperation
+	ErrBatchAborted     = errors.New("not applied, another operation of the batch failed")
+	ErrRecordNotFound   = repository.ErrRecordNotFound
+)
+
+// abortBatch marks every operation but the failed one as not applied.
+func abortBatch(results []structs.BatchResult, failed int) []structs.BatchResult {
+	for i := range results {
+		if i != failed {
+			results[i] = structs.BatchResult{Err: ErrBatchAborted}
+		}
+	}
+	return results
+}
+
+// mergeBatchResults puts the results of the applied changes in the places
+// of their operations; indexes holds the place of each change.
+func mergeBatchResults(results []structs.BatchResult, indexes []int, applied []structs.BatchResult,
+	atomic bool) []structs.BatchResult {
+	for i, result := range applied {
+		results[indexes[i]] = result
+		if atomic && result.Err != nil {
+			return abortBatch(results, indexes[i])
+		}
+	}
+	return results
+}
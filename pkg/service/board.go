@@ -0,0 +1,243 @@
+package service
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/fr13n8/todo-app/pkg/repository"
+	"github.com/fr13n8/todo-app/structs"
+)
+
+var ErrWipLimitReached = repository.ErrWipLimitReached
+
+type BoardService struct {
+	repo       repository.Board
+	itemRepo   repository.TodoItem
+	listRepo   repository.TodoList
+	statusRepo repository.Status
+	labelRepo  repository.Label
+	items      *TodoItemService
+	cfg        Config
+}
+
+func NewBoardService(repo repository.Board, itemRepo repository.TodoItem, listRepo repository.TodoList,
+	statusRepo repository.Status, labelRepo repository.Label, items *TodoItemService, cfg Config) *BoardService {
+	return &BoardService{
+		repo:       repo,
+		itemRepo:   itemRepo,
+		listRepo:   listRepo,
+		statusRepo: statusRepo,
+		labelRepo:  labelRepo,
+		items:      items,
+		cfg:        cfg,
+	}
+}
+
+func (s *BoardService) Get(userId int, listId int, groupBy string) (structs.Board, error) {
+	if _, err := s.listRepo.GetById(listId, userId); err != nil {
+		return structs.Board{}, errors.New("record not found")
+	}
+
+	items, _, err := s.itemRepo.GetAll(listId, userId, structs.ItemFilter{})
+	if err != nil {
+		return structs.Board{}, err
+	}
+	if err := fillItemsLabels(s.labelRepo, userId, items); err != nil {
+		return structs.Board{}, err
+	}
+
+	if groupBy == structs.BoardByLabel {
+		return s.labelBoard(userId, listId, items)
+	}
+	return s.statusBoard(listId, items)
+}
+
+// Move puts an item into a column at a position in one step. Moving into a
+// status follows the list's workflow rules and the column's WIP limit.
+func (s *BoardService) Move(userId int, listId int, input structs.BoardMoveInput) (string, error) {
+	if _, err := s.listRepo.GetById(listId, userId); err != nil {
+		return "", errors.New("record not found")
+	}
+	item, err := s.itemRepo.GetById(userId, input.ItemId)
+	if err != nil {
+		return "", errors.New("record not found")
+	}
+
+	if input.GroupBy == structs.BoardByLabel {
+		label, err := s.labelRepo.GetById(userId, input.ColumnId)
+		if err != nil {
+			return "", errors.New("record not found")
+		}
+		itemLabels, err := s.labelRepo.GetByItemIds(userId, []int{item.Id})
+		if err != nil {
+			return "", err
+		}
+		for _, itemLabel := range itemLabels {
+			if itemLabel.Id == label.Id {
+				label.WipLimit = nil
+			}
+		}
+		return s.repo.MoveToLabel(userId, listId, item.Id, input.FromColumnId, label, input.Position)
+	}
+
+	statuses, err := s.statusRepo.GetAll(listId)
+	if err != nil {
+		return "", err
+	}
+	status, err := resolveStatus(statuses, item, structs.UpdateItemInput{StatusId: &input.ColumnId})
+	if err != nil {
+		return "", err
+	}
+
+	var completion *structs.ItemChange
+	if status.IsDone && !item.Done {
+		// A recurring item never stays done; the regular update rolls it
+		// over to its next occurrence instead, and it lands in the column
+		// of the status it reopens with.
+		if item.Recurrence != nil && *item.Recurrence != "" {
+			change, err := s.items.prepareUpdate(userId, item.Id, structs.UpdateItemInput{StatusId: &status.Id})
+			if err != nil {
+				return "", err
+			}
+			completion = &change
+			if change.Next != nil {
+				landing, ok := reopenedStatus(statuses, item, change)
+				if !ok {
+					return s.itemRepo.Complete(userId, change)
+				}
+				status = landing
+			}
+		} else if s.cfg.EnforceDependencies && item.State == structs.ItemStateBlocked {
+			return "", ErrItemBlocked
+		}
+	}
+	// Reordering within a column doesn't add to it.
+	if item.StatusId != nil && *item.StatusId == status.Id {
+		status.WipLimit = nil
+	}
+	return s.repo.MoveToStatus(userId, listId, item.Id, status, input.Position, completion)
+}
+
+// reopenedStatus is the status a recurring item rolled over by the change
+// has: the one the change sets, or else the one it keeps. It is false for
+// an item left without a status.
+func reopenedStatus(statuses []structs.Status, item structs.Item, change structs.ItemChange) (structs.Status, bool) {
+	id := item.StatusId
+	if change.Update != nil && change.Update.StatusId != nil {
+		id = change.Update.StatusId
+	}
+	if id == nil {
+		return structs.Status{}, false
+	}
+	for _, status := range statuses {
+		if status.Id == *id {
+			return status, true
+		}
+	}
+	return structs.Status{}, false
+}
+
+func (s *BoardService) statusBoard(listId int, items []structs.Item) (structs.Board, error) {
+	statuses, err := s.statusRepo.GetAll(listId)
+	if err != nil {
+		return structs.Board{}, err
+	}
+
+	none := structs.BoardColumn{Name: "No status", Items: []structs.Item{}}
+	columns := make([]structs.BoardColumn, len(statuses))
+	index := make(map[int]int, len(statuses))
+	for i, status := range statuses {
+		id := status.Id
+		columns[i] = structs.BoardColumn{Id: &id, Name: status.Name, WipLimit: status.WipLimit, Items: []structs.Item{}}
+		index[status.Id] = i
+	}
+
+	positions := make(map[int]int, len(items))
+	for _, item := range items {
+		positions[item.Id] = item.Position
+		if i, ok := index[statusId(item)]; ok {
+			columns[i].Items = append(columns[i].Items, item)
+		} else {
+			none.Items = append(none.Items, item)
+		}
+	}
+
+	return structs.Board{
+		GroupBy: structs.BoardByStatus,
+		Columns: sortColumns(none, columns, func(column structs.BoardColumn, item structs.Item) int {
+			return positions[item.Id]
+		}),
+	}, nil
+}
+
+func (s *BoardService) labelBoard(userId int, listId int, items []structs.Item) (structs.Board, error) {
+	labels, err := s.labelRepo.GetAll(userId)
+	if err != nil {
+		return structs.Board{}, err
+	}
+	boardPositions, err := s.repo.GetLabelPositions(userId, listId)
+	if err != nil {
+		return structs.Board{}, err
+	}
+
+	none := structs.BoardColumn{Name: "No label", Items: []structs.Item{}}
+	columns := make([]structs.BoardColumn, len(labels))
+	index := make(map[int]int, len(labels))
+	for i, label := range labels {
+		id := label.Id
+		columns[i] = structs.BoardColumn{Id: &id, Name: label.Name, WipLimit: label.WipLimit, Items: []structs.Item{}}
+		index[label.Id] = i
+	}
+
+	for _, item := range items {
+		if len(item.Labels) == 0 {
+			none.Items = append(none.Items, item)
+		}
+		for _, label := range item.Labels {
+			if i, ok := index[label.Id]; ok {
+				columns[i].Items = append(columns[i].Items, item)
+			}
+		}
+	}
+
+	positions := make(map[[2]int]int, len(boardPositions))
+	for _, position := range boardPositions {
+		positions[[2]int{position.ColumnId, position.ItemId}] = position.Position
+	}
+
+	return structs.Board{
+		GroupBy: structs.BoardByLabel,
+		Columns: sortColumns(none, columns, func(column structs.BoardColumn, item structs.Item) int {
+			if column.Id == nil {
+				return 0
+			}
+			return positions[[2]int{*column.Id, item.Id}]
+		}),
+	}, nil
+}
+
+// sortColumns orders the items of each column by their board position and
+// puts the column of unassigned items first when it isn't empty.
+func sortColumns(none structs.BoardColumn, columns []structs.BoardColumn, position func(structs.BoardColumn, structs.Item) int) []structs.BoardColumn {
+	if len(none.Items) > 0 {
+		columns = append([]structs.BoardColumn{none}, columns...)
+	}
+	for _, column := range columns {
+		items := column.Items
+		sort.SliceStable(items, func(i, j int) bool {
+			pi, pj := position(column, items[i]), position(column, items[j])
+			if pi != pj {
+				return pi < pj
+			}
+			return items[i].Id < items[j].Id
+		})
+	}
+	return columns
+}
+
+func statusId(item structs.Item) int {
+	if item.StatusId == nil {
+		return 0
+	}
+	return *item.StatusId
+}
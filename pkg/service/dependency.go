@@ -0,0 +1,49 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/fr13n8/todo-app/pkg/repository"
+	"github.com/fr13n8/todo-app/structs"
+)
+
+var (
+	ErrDependencyCycle = repository.ErrDependencyCycle
+	ErrItemBlocked     = errors.New("item is blocked by open items")
+)
+
+type DependencyService struct {
+	repo     repository.Dependency
+	itemRepo repository.TodoItem
+}
+
+func NewDependencyService(repo repository.Dependency, itemRepo repository.TodoItem) *DependencyService {
+	return &DependencyService{
+		repo:     repo,
+		itemRepo: itemRepo,
+	}
+}
+
+func (s *DependencyService) Create(userId int, itemId int, input structs.DependencyInput) (string, error) {
+	if _, err := s.itemRepo.GetById(userId, itemId); err != nil {
+		return "", errors.New("record not found")
+	}
+	if _, err := s.itemRepo.GetById(userId, input.BlockerId); err != nil {
+		return "", errors.New("record not found")
+	}
+	return s.repo.Create(userId, itemId, input.BlockerId)
+}
+
+func (s *DependencyService) GetBlockers(userId int, itemId int) ([]structs.Item, error) {
+	if _, err := s.itemRepo.GetById(userId, itemId); err != nil {
+		return nil, errors.New("record not found")
+	}
+	return s.repo.GetBlockers(userId, itemId)
+}
+
+func (s *DependencyService) Delete(userId int, itemId int, blockerId int) (string, error) {
+	if _, err := s.itemRepo.GetById(userId, itemId); err != nil {
+		return "", errors.New("record not found")
+	}
+	return s.repo.Delete(userId, itemId, blockerId)
+}
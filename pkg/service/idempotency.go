@@ -0,0 +1,77 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/fr13n8/todo-app/pkg/repository"
+	"github.com/fr13n8/todo-app/structs"
+)
+
+var (
+	ErrIdempotencyKeyReused = errors.New("the idempotency key was used for another request")
+	ErrRequestInProgress    = errors.New("a request with the idempotency key is in progress")
+)
+
+// claimTimeout is how long a request may hold its idempotency key. A request
+// still in progress after it is taken to have died with its server, and the
+// key is free again.
+const claimTimeout = 10 * time.Minute
+
+type IdempotencyService struct {
+	repo repository.Idempotency
+	cfg  Config
+}
+
+func NewIdempotencyService(repo repository.Idempotency, cfg Config) *IdempotencyService {
+	return &IdempotencyService{
+		repo: repo,
+		cfg:  cfg,
+	}
+}
+
+// Claim takes the key for the request with the fingerprint, which then goes
+// on and has its response stored or the key released. When the key was used
+// before, Claim returns the stored response to replay instead, or an error
+// if that request is still in progress or was another one.
+func (s *IdempotencyService) Claim(userId int, key string, fingerprint string) (*structs.IdempotentResponse, error) {
+	if s.cfg.IdempotencyWindow <= 0 {
+		return nil, nil
+	}
+
+	now := time.Now()
+	claimed, ok, err := s.repo.Claim(userId, key, fingerprint, now.Add(-s.cfg.IdempotencyWindow), now.Add(-claimTimeout))
+	if err != nil || ok {
+		return nil, err
+	}
+	if claimed.Fingerprint != fingerprint {
+		return nil, ErrIdempotencyKeyReused
+	}
+	if claimed.Status == 0 {
+		return nil, ErrRequestInProgress
+	}
+	return &claimed.IdempotentResponse, nil
+}
+
+func (s *IdempotencyService) Store(userId int, key string, response structs.IdempotentResponse) error {
+	if s.cfg.IdempotencyWindow <= 0 {
+		return nil
+	}
+	return s.repo.Store(userId, key, response)
+}
+
+func (s *IdempotencyService) Release(userId int, key string) error {
+	if s.cfg.IdempotencyWindow <= 0 {
+		return nil
+	}
+	return s.repo.Release(userId, key)
+}
+
+// PurgeExpired deletes the keys older than the window. It is meant to run
+// periodically.
+func (s *IdempotencyService) PurgeExpired() (int64, error) {
+	if s.cfg.IdempotencyWindow <= 0 {
+		return 0, nil
+	}
+	return s.repo.DeleteOlderThan(time.Now().Add(-s.cfg.IdempotencyWindow))
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/fr13n8/todo-app/pkg/repository"
+	"github.com/fr13n8/todo-app/structs"
+)
+
+const defaultLabelColor = "#808080"
+
+var ErrLabelExists = repository.ErrLabelExists
+
+type LabelService struct {
+	repo     repository.Label
+	itemRepo repository.TodoItem
+}
+
+func NewLabelService(repo repository.Label, itemRepo repository.TodoItem) *LabelService {
+	return &LabelService{
+		repo:     repo,
+		itemRepo: itemRepo,
+	}
+}
+
+func (s *LabelService) Create(userId int, label structs.Label) (int, string, error) {
+	if label.Color == "" {
+		label.Color = defaultLabelColor
+	}
+	return s.repo.Create(userId, label)
+}
+
+func (s *LabelService) GetAll(userId int) ([]structs.Label, error) {
+	return s.repo.GetAll(userId)
+}
+
+func (s *LabelService) GetById(userId int, labelId int) (structs.Label, error) {
+	return s.repo.GetById(userId, labelId)
+}
+
+func (s *LabelService) GetItems(userId int, labelId int) ([]structs.Item, error) {
+	if _, err := s.repo.GetById(userId, labelId); err != nil {
+		return nil, errors.New("record not found")
+	}
+	items, err := s.repo.GetItems(userId, labelId)
+	if err != nil {
+		return nil, err
+	}
+	return items, fillItemsLabels(s.repo, userId, items)
+}
+
+func (s *LabelService) Delete(userId int, labelId int) (string, error) {
+	if _, err := s.repo.GetById(userId, labelId); err != nil {
+		return "", errors.New("record not found")
+	}
+	return s.repo.Delete(userId, labelId)
+}
+
+func (s *LabelService) Update(userId int, labelId int, input structs.UpdateLabelInput) (string, error) {
+	if _, err := s.repo.GetById(userId, labelId); err != nil {
+		return "", errors.New("record not found")
+	}
+	if err := input.Validate(); err != nil {
+		return "", err
+	}
+	return s.repo.Update(userId, labelId, input)
+}
+
+func (s *LabelService) Attach(userId int, itemId int, labelId int) (string, error) {
+	if err := s.checkAccess(userId, itemId, labelId); err != nil {
+		return "", err
+	}
+	return s.repo.Attach(userId, itemId, labelId)
+}
+
+func (s *LabelService) Detach(userId int, itemId int, labelId int) (string, error) {
+	if err := s.checkAccess(userId, itemId, labelId); err != nil {
+		return "", err
+	}
+	return s.repo.Detach(userId, itemId, labelId)
+}
+
+func (s *LabelService) checkAccess(userId int, itemId int, labelId int) error {
+	if _, err := s.itemRepo.GetById(userId, itemId); err != nil {
+		return errors.New("record not found")
+	}
+	if _, err := s.repo.GetById(userId, labelId); err != nil {
+		return errors.New("record not found")
+	}
+	return nil
+}
+
+// fillItemsLabels loads the user's labels of every item with a single query.
+func fillItemsLabels(repo repository.Label, userId int, items []structs.Item) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	itemIds := make([]int, len(items))
+	for i, item := range items {
+		itemIds[i] = item.Id
+	}
+
+	itemLabels, err := repo.GetByItemIds(userId, itemIds)
+	if err != nil {
+		return err
+	}
+
+	labels := make(map[int][]structs.Label)
+	for _, itemLabel := range itemLabels {
+		labels[itemLabel.ItemId] = append(labels[itemLabel.ItemId], itemLabel.Label)
+	}
+	for i := range items {
+		items[i].Labels = labels[items[i].Id]
+	}
+	return nil
+}
@@ -5,6 +5,7 @@
 package mock_service
 
 import (
+	io "io"
 	reflect "reflect"
 
 	jwt "github.com/dgrijalva/jwt-go"
@@ -147,13 +148,45 @@ func (m *MockTodoList) EXPECT() *MockTodoListMockRecorder {
 	return m.recorder
 }
 
+// Archive mocks base method.
+func (m *MockTodoList) Archive(listId, userId int) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Archive", listId, userId)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Archive indicates an expected call of Archive.
+func (mr *MockTodoListMockRecorder) Archive(listId, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Archive", reflect.TypeOf((*MockTodoList)(nil).Archive), listId, userId)
+}
+
+// Batch mocks base method.
+func (m *MockTodoList) Batch(userId int, input structs.ListBatchInput) ([]structs.BatchResult, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Batch", userId, input)
+	ret0, _ := ret[0].([]structs.BatchResult)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Batch indicates an expected call of Batch.
+func (mr *MockTodoListMockRecorder) Batch(userId, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Batch", reflect.TypeOf((*MockTodoList)(nil).Batch), userId, input)
+}
+
 // Create mocks base method.
-func (m *MockTodoList) Create(userId int, list structs.List) (int, error) {
+func (m *MockTodoList) Create(userId int, list structs.List) (int, string, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Create", userId, list)
 	ret0, _ := ret[0].(int)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // Create indicates an expected call of Create.
@@ -163,32 +196,50 @@ func (mr *MockTodoListMockRecorder) Create(userId, list interface{}) *gomock.Cal
 }
 
 // Delete mocks base method.
-func (m *MockTodoList) Delete(listId, userId int) error {
+func (m *MockTodoList) Delete(listId, userId int, version *int) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", listId, userId)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "Delete", listId, userId, version)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockTodoListMockRecorder) Delete(listId, userId interface{}) *gomock.Call {
+func (mr *MockTodoListMockRecorder) Delete(listId, userId, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTodoList)(nil).Delete), listId, userId, version)
+}
+
+// Duplicate mocks base method.
+func (m *MockTodoList) Duplicate(userId, listId int, input structs.DuplicateListInput) (int, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Duplicate", userId, listId, input)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Duplicate indicates an expected call of Duplicate.
+func (mr *MockTodoListMockRecorder) Duplicate(userId, listId, input interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTodoList)(nil).Delete), listId, userId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Duplicate", reflect.TypeOf((*MockTodoList)(nil).Duplicate), userId, listId, input)
 }
 
 // GetAll mocks base method.
-func (m *MockTodoList) GetAll(userId int) ([]structs.List, error) {
+func (m *MockTodoList) GetAll(userId int, filter structs.ListFilter) ([]structs.List, structs.PageInfo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAll", userId)
+	ret := m.ctrl.Call(m, "GetAll", userId, filter)
 	ret0, _ := ret[0].([]structs.List)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(structs.PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // GetAll indicates an expected call of GetAll.
-func (mr *MockTodoListMockRecorder) GetAll(userId interface{}) *gomock.Call {
+func (mr *MockTodoListMockRecorder) GetAll(userId, filter interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockTodoList)(nil).GetAll), userId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockTodoList)(nil).GetAll), userId, filter)
 }
 
 // GetById mocks base method.
@@ -206,12 +257,58 @@ func (mr *MockTodoListMockRecorder) GetById(listId, userId interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockTodoList)(nil).GetById), listId, userId)
 }
 
+// Replace mocks base method.
+func (m *MockTodoList) Replace(listId, userId int, input structs.ReplaceListInput) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Replace", listId, userId, input)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Replace indicates an expected call of Replace.
+func (mr *MockTodoListMockRecorder) Replace(listId, userId, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Replace", reflect.TypeOf((*MockTodoList)(nil).Replace), listId, userId, input)
+}
+
+// Stamp mocks base method.
+func (m *MockTodoList) Stamp(userId int) (structs.CollectionStamp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stamp", userId)
+	ret0, _ := ret[0].(structs.CollectionStamp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Stamp indicates an expected call of Stamp.
+func (mr *MockTodoListMockRecorder) Stamp(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stamp", reflect.TypeOf((*MockTodoList)(nil).Stamp), userId)
+}
+
+// Unarchive mocks base method.
+func (m *MockTodoList) Unarchive(listId, userId int) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unarchive", listId, userId)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Unarchive indicates an expected call of Unarchive.
+func (mr *MockTodoListMockRecorder) Unarchive(listId, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unarchive", reflect.TypeOf((*MockTodoList)(nil).Unarchive), listId, userId)
+}
+
 // Update mocks base method.
-func (m *MockTodoList) Update(listId, userId int, list structs.UpdateListInput) error {
+func (m *MockTodoList) Update(listId, userId int, list structs.UpdateListInput) (string, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Update", listId, userId, list)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // Update indicates an expected call of Update.
@@ -243,13 +340,61 @@ func (m *MockTodoItem) EXPECT() *MockTodoItemMockRecorder {
 	return m.recorder
 }
 
+// ArchiveCompleted mocks base method.
+func (m *MockTodoItem) ArchiveCompleted() (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArchiveCompleted")
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ArchiveCompleted indicates an expected call of ArchiveCompleted.
+func (mr *MockTodoItemMockRecorder) ArchiveCompleted() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveCompleted", reflect.TypeOf((*MockTodoItem)(nil).ArchiveCompleted))
+}
+
+// Batch mocks base method.
+func (m *MockTodoItem) Batch(userId int, input structs.ItemBatchInput) ([]structs.BatchResult, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Batch", userId, input)
+	ret0, _ := ret[0].([]structs.BatchResult)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Batch indicates an expected call of Batch.
+func (mr *MockTodoItemMockRecorder) Batch(userId, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Batch", reflect.TypeOf((*MockTodoItem)(nil).Batch), userId, input)
+}
+
+// Copy mocks base method.
+func (m *MockTodoItem) Copy(userId, itemId int, input structs.CopyItemInput) (int, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Copy", userId, itemId, input)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Copy indicates an expected call of Copy.
+func (mr *MockTodoItemMockRecorder) Copy(userId, itemId, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Copy", reflect.TypeOf((*MockTodoItem)(nil).Copy), userId, itemId, input)
+}
+
 // Create mocks base method.
-func (m *MockTodoItem) Create(listId, userId int, input structs.Item) (int, error) {
+func (m *MockTodoItem) Create(listId, userId int, input structs.Item) (int, string, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Create", listId, userId, input)
 	ret0, _ := ret[0].(int)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // Create indicates an expected call of Create.
@@ -259,32 +404,34 @@ func (mr *MockTodoItemMockRecorder) Create(listId, userId, input interface{}) *g
 }
 
 // Delete mocks base method.
-func (m *MockTodoItem) Delete(userId, itemId int) error {
+func (m *MockTodoItem) Delete(userId, itemId int, version *int) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", userId, itemId)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "Delete", userId, itemId, version)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockTodoItemMockRecorder) Delete(userId, itemId interface{}) *gomock.Call {
+func (mr *MockTodoItemMockRecorder) Delete(userId, itemId, version interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTodoItem)(nil).Delete), userId, itemId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTodoItem)(nil).Delete), userId, itemId, version)
 }
 
 // GetAll mocks base method.
-func (m *MockTodoItem) GetAll(listId, userId int) ([]structs.Item, error) {
+func (m *MockTodoItem) GetAll(listId, userId int, filter structs.ItemFilter) ([]structs.Item, structs.PageInfo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAll", listId, userId)
+	ret := m.ctrl.Call(m, "GetAll", listId, userId, filter)
 	ret0, _ := ret[0].([]structs.Item)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(structs.PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // GetAll indicates an expected call of GetAll.
-func (mr *MockTodoItemMockRecorder) GetAll(listId, userId interface{}) *gomock.Call {
+func (mr *MockTodoItemMockRecorder) GetAll(listId, userId, filter interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockTodoItem)(nil).GetAll), listId, userId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockTodoItem)(nil).GetAll), listId, userId, filter)
 }
 
 // GetById mocks base method.
@@ -302,12 +449,73 @@ func (mr *MockTodoItemMockRecorder) GetById(userId, itemId interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockTodoItem)(nil).GetById), userId, itemId)
 }
 
+// GetCompletions mocks base method.
+func (m *MockTodoItem) GetCompletions(userId, itemId int) ([]structs.ItemCompletion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCompletions", userId, itemId)
+	ret0, _ := ret[0].([]structs.ItemCompletion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCompletions indicates an expected call of GetCompletions.
+func (mr *MockTodoItemMockRecorder) GetCompletions(userId, itemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCompletions", reflect.TypeOf((*MockTodoItem)(nil).GetCompletions), userId, itemId)
+}
+
+// Move mocks base method.
+func (m *MockTodoItem) Move(userId, itemId int, input structs.MoveItemInput) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Move", userId, itemId, input)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Move indicates an expected call of Move.
+func (mr *MockTodoItemMockRecorder) Move(userId, itemId, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Move", reflect.TypeOf((*MockTodoItem)(nil).Move), userId, itemId, input)
+}
+
+// Replace mocks base method.
+func (m *MockTodoItem) Replace(userId, itemId int, input structs.ReplaceItemInput) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Replace", userId, itemId, input)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Replace indicates an expected call of Replace.
+func (mr *MockTodoItemMockRecorder) Replace(userId, itemId, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Replace", reflect.TypeOf((*MockTodoItem)(nil).Replace), userId, itemId, input)
+}
+
+// Stamp mocks base method.
+func (m *MockTodoItem) Stamp(listId, userId int) (structs.CollectionStamp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stamp", listId, userId)
+	ret0, _ := ret[0].(structs.CollectionStamp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Stamp indicates an expected call of Stamp.
+func (mr *MockTodoItemMockRecorder) Stamp(listId, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stamp", reflect.TypeOf((*MockTodoItem)(nil).Stamp), listId, userId)
+}
+
 // Update mocks base method.
-func (m *MockTodoItem) Update(userId, itemId int, input structs.UpdateItemInput) error {
+func (m *MockTodoItem) Update(userId, itemId int, input structs.UpdateItemInput) (string, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Update", userId, itemId, input)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // Update indicates an expected call of Update.
@@ -315,3 +523,1179 @@ func (mr *MockTodoItemMockRecorder) Update(userId, itemId, input interface{}) *g
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTodoItem)(nil).Update), userId, itemId, input)
 }
+
+// MockLabel is a mock of Label interface.
+type MockLabel struct {
+	ctrl     *gomock.Controller
+	recorder *MockLabelMockRecorder
+}
+
+// MockLabelMockRecorder is the mock recorder for MockLabel.
+type MockLabelMockRecorder struct {
+	mock *MockLabel
+}
+
+// NewMockLabel creates a new mock instance.
+func NewMockLabel(ctrl *gomock.Controller) *MockLabel {
+	mock := &MockLabel{ctrl: ctrl}
+	mock.recorder = &MockLabelMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLabel) EXPECT() *MockLabelMockRecorder {
+	return m.recorder
+}
+
+// Attach mocks base method.
+func (m *MockLabel) Attach(userId, itemId, labelId int) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Attach", userId, itemId, labelId)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Attach indicates an expected call of Attach.
+func (mr *MockLabelMockRecorder) Attach(userId, itemId, labelId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Attach", reflect.TypeOf((*MockLabel)(nil).Attach), userId, itemId, labelId)
+}
+
+// Create mocks base method.
+func (m *MockLabel) Create(userId int, label structs.Label) (int, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", userId, label)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockLabelMockRecorder) Create(userId, label interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockLabel)(nil).Create), userId, label)
+}
+
+// Delete mocks base method.
+func (m *MockLabel) Delete(userId, labelId int) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", userId, labelId)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockLabelMockRecorder) Delete(userId, labelId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockLabel)(nil).Delete), userId, labelId)
+}
+
+// Detach mocks base method.
+func (m *MockLabel) Detach(userId, itemId, labelId int) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Detach", userId, itemId, labelId)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Detach indicates an expected call of Detach.
+func (mr *MockLabelMockRecorder) Detach(userId, itemId, labelId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Detach", reflect.TypeOf((*MockLabel)(nil).Detach), userId, itemId, labelId)
+}
+
+// GetAll mocks base method.
+func (m *MockLabel) GetAll(userId int) ([]structs.Label, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", userId)
+	ret0, _ := ret[0].([]structs.Label)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockLabelMockRecorder) GetAll(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockLabel)(nil).GetAll), userId)
+}
+
+// GetById mocks base method.
+func (m *MockLabel) GetById(userId, labelId int) (structs.Label, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", userId, labelId)
+	ret0, _ := ret[0].(structs.Label)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockLabelMockRecorder) GetById(userId, labelId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockLabel)(nil).GetById), userId, labelId)
+}
+
+// GetItems mocks base method.
+func (m *MockLabel) GetItems(userId, labelId int) ([]structs.Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItems", userId, labelId)
+	ret0, _ := ret[0].([]structs.Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetItems indicates an expected call of GetItems.
+func (mr *MockLabelMockRecorder) GetItems(userId, labelId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItems", reflect.TypeOf((*MockLabel)(nil).GetItems), userId, labelId)
+}
+
+// Update mocks base method.
+func (m *MockLabel) Update(userId, labelId int, input structs.UpdateLabelInput) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", userId, labelId, input)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockLabelMockRecorder) Update(userId, labelId, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockLabel)(nil).Update), userId, labelId, input)
+}
+
+// MockDependency is a mock of Dependency interface.
+type MockDependency struct {
+	ctrl     *gomock.Controller
+	recorder *MockDependencyMockRecorder
+}
+
+// MockDependencyMockRecorder is the mock recorder for MockDependency.
+type MockDependencyMockRecorder struct {
+	mock *MockDependency
+}
+
+// NewMockDependency creates a new mock instance.
+func NewMockDependency(ctrl *gomock.Controller) *MockDependency {
+	mock := &MockDependency{ctrl: ctrl}
+	mock.recorder = &MockDependencyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDependency) EXPECT() *MockDependencyMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockDependency) Create(userId, itemId int, input structs.DependencyInput) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", userId, itemId, input)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockDependencyMockRecorder) Create(userId, itemId, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockDependency)(nil).Create), userId, itemId, input)
+}
+
+// Delete mocks base method.
+func (m *MockDependency) Delete(userId, itemId, blockerId int) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", userId, itemId, blockerId)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockDependencyMockRecorder) Delete(userId, itemId, blockerId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockDependency)(nil).Delete), userId, itemId, blockerId)
+}
+
+// GetBlockers mocks base method.
+func (m *MockDependency) GetBlockers(userId, itemId int) ([]structs.Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlockers", userId, itemId)
+	ret0, _ := ret[0].([]structs.Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockers indicates an expected call of GetBlockers.
+func (mr *MockDependencyMockRecorder) GetBlockers(userId, itemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockers", reflect.TypeOf((*MockDependency)(nil).GetBlockers), userId, itemId)
+}
+
+// MockAttachment is a mock of Attachment interface.
+type MockAttachment struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttachmentMockRecorder
+}
+
+// MockAttachmentMockRecorder is the mock recorder for MockAttachment.
+type MockAttachmentMockRecorder struct {
+	mock *MockAttachment
+}
+
+// NewMockAttachment creates a new mock instance.
+func NewMockAttachment(ctrl *gomock.Controller) *MockAttachment {
+	mock := &MockAttachment{ctrl: ctrl}
+	mock.recorder = &MockAttachmentMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttachment) EXPECT() *MockAttachmentMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockAttachment) Delete(userId, itemId, attachmentId int) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", userId, itemId, attachmentId)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockAttachmentMockRecorder) Delete(userId, itemId, attachmentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAttachment)(nil).Delete), userId, itemId, attachmentId)
+}
+
+// Download mocks base method.
+func (m *MockAttachment) Download(userId, itemId, attachmentId int) (structs.Attachment, io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Download", userId, itemId, attachmentId)
+	ret0, _ := ret[0].(structs.Attachment)
+	ret1, _ := ret[1].(io.ReadCloser)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Download indicates an expected call of Download.
+func (mr *MockAttachmentMockRecorder) Download(userId, itemId, attachmentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Download", reflect.TypeOf((*MockAttachment)(nil).Download), userId, itemId, attachmentId)
+}
+
+// GetAll mocks base method.
+func (m *MockAttachment) GetAll(userId, itemId int) ([]structs.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", userId, itemId)
+	ret0, _ := ret[0].([]structs.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockAttachmentMockRecorder) GetAll(userId, itemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockAttachment)(nil).GetAll), userId, itemId)
+}
+
+// MaxSize mocks base method.
+func (m *MockAttachment) MaxSize() int64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MaxSize")
+	ret0, _ := ret[0].(int64)
+	return ret0
+}
+
+// MaxSize indicates an expected call of MaxSize.
+func (mr *MockAttachmentMockRecorder) MaxSize() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MaxSize", reflect.TypeOf((*MockAttachment)(nil).MaxSize))
+}
+
+// Upload mocks base method.
+func (m *MockAttachment) Upload(userId, itemId int, upload structs.AttachmentUpload) (int, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upload", userId, itemId, upload)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Upload indicates an expected call of Upload.
+func (mr *MockAttachmentMockRecorder) Upload(userId, itemId, upload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upload", reflect.TypeOf((*MockAttachment)(nil).Upload), userId, itemId, upload)
+}
+
+// MockTimeEntry is a mock of TimeEntry interface.
+type MockTimeEntry struct {
+	ctrl     *gomock.Controller
+	recorder *MockTimeEntryMockRecorder
+}
+
+// MockTimeEntryMockRecorder is the mock recorder for MockTimeEntry.
+type MockTimeEntryMockRecorder struct {
+	mock *MockTimeEntry
+}
+
+// NewMockTimeEntry creates a new mock instance.
+func NewMockTimeEntry(ctrl *gomock.Controller) *MockTimeEntry {
+	mock := &MockTimeEntry{ctrl: ctrl}
+	mock.recorder = &MockTimeEntryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTimeEntry) EXPECT() *MockTimeEntryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockTimeEntry) Create(userId, itemId int, input structs.TimeEntryInput) (int, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", userId, itemId, input)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockTimeEntryMockRecorder) Create(userId, itemId, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTimeEntry)(nil).Create), userId, itemId, input)
+}
+
+// Delete mocks base method.
+func (m *MockTimeEntry) Delete(userId, itemId, entryId int) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", userId, itemId, entryId)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockTimeEntryMockRecorder) Delete(userId, itemId, entryId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTimeEntry)(nil).Delete), userId, itemId, entryId)
+}
+
+// GetAll mocks base method.
+func (m *MockTimeEntry) GetAll(userId, itemId int) ([]structs.TimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", userId, itemId)
+	ret0, _ := ret[0].([]structs.TimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockTimeEntryMockRecorder) GetAll(userId, itemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockTimeEntry)(nil).GetAll), userId, itemId)
+}
+
+// GetReport mocks base method.
+func (m *MockTimeEntry) GetReport(userId int, filter structs.TimeReportFilter) ([]structs.TimeReportRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReport", userId, filter)
+	ret0, _ := ret[0].([]structs.TimeReportRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReport indicates an expected call of GetReport.
+func (mr *MockTimeEntryMockRecorder) GetReport(userId, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReport", reflect.TypeOf((*MockTimeEntry)(nil).GetReport), userId, filter)
+}
+
+// Start mocks base method.
+func (m *MockTimeEntry) Start(userId, itemId int) (int, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Start", userId, itemId)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockTimeEntryMockRecorder) Start(userId, itemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockTimeEntry)(nil).Start), userId, itemId)
+}
+
+// Stop mocks base method.
+func (m *MockTimeEntry) Stop(userId, itemId int) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stop", userId, itemId)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Stop indicates an expected call of Stop.
+func (mr *MockTimeEntryMockRecorder) Stop(userId, itemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockTimeEntry)(nil).Stop), userId, itemId)
+}
+
+// MockStatus is a mock of Status interface.
+type MockStatus struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatusMockRecorder
+}
+
+// MockStatusMockRecorder is the mock recorder for MockStatus.
+type MockStatusMockRecorder struct {
+	mock *MockStatus
+}
+
+// NewMockStatus creates a new mock instance.
+func NewMockStatus(ctrl *gomock.Controller) *MockStatus {
+	mock := &MockStatus{ctrl: ctrl}
+	mock.recorder = &MockStatusMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatus) EXPECT() *MockStatusMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockStatus) Create(userId, listId int, input structs.StatusInput) (int, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", userId, listId, input)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockStatusMockRecorder) Create(userId, listId, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockStatus)(nil).Create), userId, listId, input)
+}
+
+// Delete mocks base method.
+func (m *MockStatus) Delete(userId, statusId int) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", userId, statusId)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockStatusMockRecorder) Delete(userId, statusId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockStatus)(nil).Delete), userId, statusId)
+}
+
+// GetAll mocks base method.
+func (m *MockStatus) GetAll(userId, listId int) ([]structs.Status, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", userId, listId)
+	ret0, _ := ret[0].([]structs.Status)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockStatusMockRecorder) GetAll(userId, listId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockStatus)(nil).GetAll), userId, listId)
+}
+
+// GetById mocks base method.
+func (m *MockStatus) GetById(userId, statusId int) (structs.Status, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", userId, statusId)
+	ret0, _ := ret[0].(structs.Status)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockStatusMockRecorder) GetById(userId, statusId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockStatus)(nil).GetById), userId, statusId)
+}
+
+// Update mocks base method.
+func (m *MockStatus) Update(userId, statusId int, input structs.UpdateStatusInput) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", userId, statusId, input)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockStatusMockRecorder) Update(userId, statusId, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockStatus)(nil).Update), userId, statusId, input)
+}
+
+// MockBoard is a mock of Board interface.
+type MockBoard struct {
+	ctrl     *gomock.Controller
+	recorder *MockBoardMockRecorder
+}
+
+// MockBoardMockRecorder is the mock recorder for MockBoard.
+type MockBoardMockRecorder struct {
+	mock *MockBoard
+}
+
+// NewMockBoard creates a new mock instance.
+func NewMockBoard(ctrl *gomock.Controller) *MockBoard {
+	mock := &MockBoard{ctrl: ctrl}
+	mock.recorder = &MockBoardMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBoard) EXPECT() *MockBoardMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockBoard) Get(userId, listId int, groupBy string) (structs.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", userId, listId, groupBy)
+	ret0, _ := ret[0].(structs.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockBoardMockRecorder) Get(userId, listId, groupBy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockBoard)(nil).Get), userId, listId, groupBy)
+}
+
+// Move mocks base method.
+func (m *MockBoard) Move(userId, listId int, input structs.BoardMoveInput) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Move", userId, listId, input)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Move indicates an expected call of Move.
+func (mr *MockBoardMockRecorder) Move(userId, listId, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Move", reflect.TypeOf((*MockBoard)(nil).Move), userId, listId, input)
+}
+
+// MockTrash is a mock of Trash interface.
+type MockTrash struct {
+	ctrl     *gomock.Controller
+	recorder *MockTrashMockRecorder
+}
+
+// MockTrashMockRecorder is the mock recorder for MockTrash.
+type MockTrashMockRecorder struct {
+	mock *MockTrash
+}
+
+// NewMockTrash creates a new mock instance.
+func NewMockTrash(ctrl *gomock.Controller) *MockTrash {
+	mock := &MockTrash{ctrl: ctrl}
+	mock.recorder = &MockTrashMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTrash) EXPECT() *MockTrashMockRecorder {
+	return m.recorder
+}
+
+// Empty mocks base method.
+func (m *MockTrash) Empty(userId int) (structs.TrashPurge, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Empty", userId)
+	ret0, _ := ret[0].(structs.TrashPurge)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Empty indicates an expected call of Empty.
+func (mr *MockTrashMockRecorder) Empty(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Empty", reflect.TypeOf((*MockTrash)(nil).Empty), userId)
+}
+
+// GetAll mocks base method.
+func (m *MockTrash) GetAll(userId int) ([]structs.TrashEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", userId)
+	ret0, _ := ret[0].([]structs.TrashEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockTrashMockRecorder) GetAll(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockTrash)(nil).GetAll), userId)
+}
+
+// PurgeExpired mocks base method.
+func (m *MockTrash) PurgeExpired() (structs.TrashPurge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeExpired")
+	ret0, _ := ret[0].(structs.TrashPurge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeExpired indicates an expected call of PurgeExpired.
+func (mr *MockTrashMockRecorder) PurgeExpired() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeExpired", reflect.TypeOf((*MockTrash)(nil).PurgeExpired))
+}
+
+// PurgeItem mocks base method.
+func (m *MockTrash) PurgeItem(userId, itemId int) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeItem", userId, itemId)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeItem indicates an expected call of PurgeItem.
+func (mr *MockTrashMockRecorder) PurgeItem(userId, itemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeItem", reflect.TypeOf((*MockTrash)(nil).PurgeItem), userId, itemId)
+}
+
+// PurgeList mocks base method.
+func (m *MockTrash) PurgeList(userId, listId int) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeList", userId, listId)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeList indicates an expected call of PurgeList.
+func (mr *MockTrashMockRecorder) PurgeList(userId, listId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeList", reflect.TypeOf((*MockTrash)(nil).PurgeList), userId, listId)
+}
+
+// RestoreItem mocks base method.
+func (m *MockTrash) RestoreItem(userId, itemId int) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreItem", userId, itemId)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreItem indicates an expected call of RestoreItem.
+func (mr *MockTrashMockRecorder) RestoreItem(userId, itemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreItem", reflect.TypeOf((*MockTrash)(nil).RestoreItem), userId, itemId)
+}
+
+// RestoreList mocks base method.
+func (m *MockTrash) RestoreList(userId, listId int) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreList", userId, listId)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreList indicates an expected call of RestoreList.
+func (mr *MockTrashMockRecorder) RestoreList(userId, listId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreList", reflect.TypeOf((*MockTrash)(nil).RestoreList), userId, listId)
+}
+
+// MockRevision is a mock of Revision interface.
+type MockRevision struct {
+	ctrl     *gomock.Controller
+	recorder *MockRevisionMockRecorder
+}
+
+// MockRevisionMockRecorder is the mock recorder for MockRevision.
+type MockRevisionMockRecorder struct {
+	mock *MockRevision
+}
+
+// NewMockRevision creates a new mock instance.
+func NewMockRevision(ctrl *gomock.Controller) *MockRevision {
+	mock := &MockRevision{ctrl: ctrl}
+	mock.recorder = &MockRevisionMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRevision) EXPECT() *MockRevisionMockRecorder {
+	return m.recorder
+}
+
+// Diff mocks base method.
+func (m *MockRevision) Diff(userId, itemId, from, to int) (structs.RevisionDiff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Diff", userId, itemId, from, to)
+	ret0, _ := ret[0].(structs.RevisionDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Diff indicates an expected call of Diff.
+func (mr *MockRevisionMockRecorder) Diff(userId, itemId, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Diff", reflect.TypeOf((*MockRevision)(nil).Diff), userId, itemId, from, to)
+}
+
+// GetAll mocks base method.
+func (m *MockRevision) GetAll(userId, itemId int) ([]structs.ItemRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", userId, itemId)
+	ret0, _ := ret[0].([]structs.ItemRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockRevisionMockRecorder) GetAll(userId, itemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockRevision)(nil).GetAll), userId, itemId)
+}
+
+// GetByNumber mocks base method.
+func (m *MockRevision) GetByNumber(userId, itemId, revision int) (structs.ItemRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByNumber", userId, itemId, revision)
+	ret0, _ := ret[0].(structs.ItemRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByNumber indicates an expected call of GetByNumber.
+func (mr *MockRevisionMockRecorder) GetByNumber(userId, itemId, revision interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByNumber", reflect.TypeOf((*MockRevision)(nil).GetByNumber), userId, itemId, revision)
+}
+
+// Revert mocks base method.
+func (m *MockRevision) Revert(userId, itemId, revision int) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revert", userId, itemId, revision)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Revert indicates an expected call of Revert.
+func (mr *MockRevisionMockRecorder) Revert(userId, itemId, revision interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revert", reflect.TypeOf((*MockRevision)(nil).Revert), userId, itemId, revision)
+}
+
+// MockTemplate is a mock of Template interface.
+type MockTemplate struct {
+	ctrl     *gomock.Controller
+	recorder *MockTemplateMockRecorder
+}
+
+// MockTemplateMockRecorder is the mock recorder for MockTemplate.
+type MockTemplateMockRecorder struct {
+	mock *MockTemplate
+}
+
+// NewMockTemplate creates a new mock instance.
+func NewMockTemplate(ctrl *gomock.Controller) *MockTemplate {
+	mock := &MockTemplate{ctrl: ctrl}
+	mock.recorder = &MockTemplateMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTemplate) EXPECT() *MockTemplateMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockTemplate) Create(userId int, input structs.TemplateInput) (int, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", userId, input)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockTemplateMockRecorder) Create(userId, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTemplate)(nil).Create), userId, input)
+}
+
+// Delete mocks base method.
+func (m *MockTemplate) Delete(userId, templateId int) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", userId, templateId)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockTemplateMockRecorder) Delete(userId, templateId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTemplate)(nil).Delete), userId, templateId)
+}
+
+// GetAll mocks base method.
+func (m *MockTemplate) GetAll(userId int, filter structs.TemplateFilter) ([]structs.Template, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", userId, filter)
+	ret0, _ := ret[0].([]structs.Template)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockTemplateMockRecorder) GetAll(userId, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockTemplate)(nil).GetAll), userId, filter)
+}
+
+// GetById mocks base method.
+func (m *MockTemplate) GetById(userId, templateId int) (structs.Template, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", userId, templateId)
+	ret0, _ := ret[0].(structs.Template)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockTemplateMockRecorder) GetById(userId, templateId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockTemplate)(nil).GetById), userId, templateId)
+}
+
+// Instantiate mocks base method.
+func (m *MockTemplate) Instantiate(userId, templateId int, input structs.InstantiateTemplateInput) (int, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Instantiate", userId, templateId, input)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Instantiate indicates an expected call of Instantiate.
+func (mr *MockTemplateMockRecorder) Instantiate(userId, templateId, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Instantiate", reflect.TypeOf((*MockTemplate)(nil).Instantiate), userId, templateId, input)
+}
+
+// MockView is a mock of View interface.
+type MockView struct {
+	ctrl     *gomock.Controller
+	recorder *MockViewMockRecorder
+}
+
+// MockViewMockRecorder is the mock recorder for MockView.
+type MockViewMockRecorder struct {
+	mock *MockView
+}
+
+// NewMockView creates a new mock instance.
+func NewMockView(ctrl *gomock.Controller) *MockView {
+	mock := &MockView{ctrl: ctrl}
+	mock.recorder = &MockViewMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockView) EXPECT() *MockViewMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockView) Create(userId int, input structs.ViewInput) (int, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", userId, input)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockViewMockRecorder) Create(userId, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockView)(nil).Create), userId, input)
+}
+
+// Delete mocks base method.
+func (m *MockView) Delete(userId, viewId int) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", userId, viewId)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockViewMockRecorder) Delete(userId, viewId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockView)(nil).Delete), userId, viewId)
+}
+
+// GetAll mocks base method.
+func (m *MockView) GetAll(userId int) ([]structs.View, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", userId)
+	ret0, _ := ret[0].([]structs.View)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockViewMockRecorder) GetAll(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockView)(nil).GetAll), userId)
+}
+
+// GetById mocks base method.
+func (m *MockView) GetById(userId, viewId int) (structs.View, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", userId, viewId)
+	ret0, _ := ret[0].(structs.View)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockViewMockRecorder) GetById(userId, viewId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockView)(nil).GetById), userId, viewId)
+}
+
+// GetItems mocks base method.
+func (m *MockView) GetItems(userId, viewId int, filter structs.ViewItemsFilter) ([]structs.Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItems", userId, viewId, filter)
+	ret0, _ := ret[0].([]structs.Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetItems indicates an expected call of GetItems.
+func (mr *MockViewMockRecorder) GetItems(userId, viewId, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItems", reflect.TypeOf((*MockView)(nil).GetItems), userId, viewId, filter)
+}
+
+// Update mocks base method.
+func (m *MockView) Update(userId, viewId int, input structs.UpdateViewInput) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", userId, viewId, input)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockViewMockRecorder) Update(userId, viewId, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockView)(nil).Update), userId, viewId, input)
+}
+
+// MockSearch is a mock of Search interface.
+type MockSearch struct {
+	ctrl     *gomock.Controller
+	recorder *MockSearchMockRecorder
+}
+
+// MockSearchMockRecorder is the mock recorder for MockSearch.
+type MockSearchMockRecorder struct {
+	mock *MockSearch
+}
+
+// NewMockSearch creates a new mock instance.
+func NewMockSearch(ctrl *gomock.Controller) *MockSearch {
+	mock := &MockSearch{ctrl: ctrl}
+	mock.recorder = &MockSearchMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSearch) EXPECT() *MockSearchMockRecorder {
+	return m.recorder
+}
+
+// Search mocks base method.
+func (m *MockSearch) Search(userId int, query structs.SearchQuery) (structs.SearchResults, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", userId, query)
+	ret0, _ := ret[0].(structs.SearchResults)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockSearchMockRecorder) Search(userId, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockSearch)(nil).Search), userId, query)
+}
+
+// MockIdempotency is a mock of Idempotency interface.
+type MockIdempotency struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdempotencyMockRecorder
+}
+
+// MockIdempotencyMockRecorder is the mock recorder for MockIdempotency.
+type MockIdempotencyMockRecorder struct {
+	mock *MockIdempotency
+}
+
+// NewMockIdempotency creates a new mock instance.
+func NewMockIdempotency(ctrl *gomock.Controller) *MockIdempotency {
+	mock := &MockIdempotency{ctrl: ctrl}
+	mock.recorder = &MockIdempotencyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIdempotency) EXPECT() *MockIdempotencyMockRecorder {
+	return m.recorder
+}
+
+// Claim mocks base method.
+func (m *MockIdempotency) Claim(userId int, key, fingerprint string) (*structs.IdempotentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Claim", userId, key, fingerprint)
+	ret0, _ := ret[0].(*structs.IdempotentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Claim indicates an expected call of Claim.
+func (mr *MockIdempotencyMockRecorder) Claim(userId, key, fingerprint interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Claim", reflect.TypeOf((*MockIdempotency)(nil).Claim), userId, key, fingerprint)
+}
+
+// PurgeExpired mocks base method.
+func (m *MockIdempotency) PurgeExpired() (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeExpired")
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeExpired indicates an expected call of PurgeExpired.
+func (mr *MockIdempotencyMockRecorder) PurgeExpired() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeExpired", reflect.TypeOf((*MockIdempotency)(nil).PurgeExpired))
+}
+
+// Release mocks base method.
+func (m *MockIdempotency) Release(userId int, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", userId, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockIdempotencyMockRecorder) Release(userId, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockIdempotency)(nil).Release), userId, key)
+}
+
+// Store mocks base method.
+func (m *MockIdempotency) Store(userId int, key string, response structs.IdempotentResponse) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Store", userId, key, response)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Store indicates an expected call of Store.
+func (mr *MockIdempotencyMockRecorder) Store(userId, key, response interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Store", reflect.TypeOf((*MockIdempotency)(nil).Store), userId, key, response)
+}
+
+// MockUndo is a mock of Undo interface.
+type MockUndo struct {
+	ctrl     *gomock.Controller
+	recorder *MockUndoMockRecorder
+}
+
+// MockUndoMockRecorder is the mock recorder for MockUndo.
+type MockUndoMockRecorder struct {
+	mock *MockUndo
+}
+
+// NewMockUndo creates a new mock instance.
+func NewMockUndo(ctrl *gomock.Controller) *MockUndo {
+	mock := &MockUndo{ctrl: ctrl}
+	mock.recorder = &MockUndoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUndo) EXPECT() *MockUndoMockRecorder {
+	return m.recorder
+}
+
+// PurgeExpired mocks base method.
+func (m *MockUndo) PurgeExpired() (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeExpired")
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeExpired indicates an expected call of PurgeExpired.
+func (mr *MockUndoMockRecorder) PurgeExpired() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeExpired", reflect.TypeOf((*MockUndo)(nil).PurgeExpired))
+}
+
+// Undo mocks base method.
+func (m *MockUndo) Undo(userId int, token string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Undo", userId, token)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Undo indicates an expected call of Undo.
+func (mr *MockUndoMockRecorder) Undo(userId, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Undo", reflect.TypeOf((*MockUndo)(nil).Undo), userId, token)
+}
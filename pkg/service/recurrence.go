@@ -0,0 +1,39 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/teambition/rrule-go"
+)
+
+// parseRecurrence builds an RFC 5545 rule anchored at the series start, so
+// INTERVAL, COUNT and UNTIL are counted from the first occurrence.
+func parseRecurrence(rule string, start time.Time) (*rrule.RRule, error) {
+	option, err := rrule.StrToROption(rule)
+	if err != nil {
+		return nil, fmt.Errorf("invalid recurrence rule: %s", err.Error())
+	}
+	option.Dtstart = start
+
+	r, err := rrule.NewRRule(*option)
+	if err != nil {
+		return nil, fmt.Errorf("invalid recurrence rule: %s", err.Error())
+	}
+	return r, nil
+}
+
+// nextOccurrence returns the first occurrence strictly after the given time,
+// or nil when the series is over.
+func nextOccurrence(rule string, start time.Time, after time.Time) (*time.Time, error) {
+	r, err := parseRecurrence(rule, start)
+	if err != nil {
+		return nil, err
+	}
+
+	next := r.After(after, false)
+	if next.IsZero() {
+		return nil, nil
+	}
+	return &next, nil
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/fr13n8/todo-app/pkg/repository"
+	"github.com/fr13n8/todo-app/structs"
+)
+
+type RevisionService struct {
+	repo     repository.Revision
+	itemRepo repository.TodoItem
+	cfg      Config
+}
+
+func NewRevisionService(repo repository.Revision, itemRepo repository.TodoItem, cfg Config) *RevisionService {
+	return &RevisionService{
+		repo:     repo,
+		itemRepo: itemRepo,
+		cfg:      cfg,
+	}
+}
+
+func (s *RevisionService) GetAll(userId int, itemId int) ([]structs.ItemRevision, error) {
+	if _, err := s.itemRepo.GetById(userId, itemId); err != nil {
+		return nil, errors.New("record not found")
+	}
+
+	revisions, err := s.repo.GetAll(itemId)
+	if err != nil {
+		return nil, err
+	}
+	var previous structs.ItemRevision
+	for i := range revisions {
+		revisions[i].Changes = changedFields(previous, revisions[i])
+		previous = revisions[i]
+	}
+	return revisions, nil
+}
+
+func (s *RevisionService) GetByNumber(userId int, itemId int, revision int) (structs.ItemRevision, error) {
+	if _, err := s.itemRepo.GetById(userId, itemId); err != nil {
+		return structs.ItemRevision{}, errors.New("record not found")
+	}
+	return s.repo.GetByNumber(itemId, revision)
+}
+
+// Diff compares revision to an earlier or later one; from 0 stands for the
+// item before it was created.
+func (s *RevisionService) Diff(userId int, itemId int, from int, to int) (structs.RevisionDiff, error) {
+	if _, err := s.itemRepo.GetById(userId, itemId); err != nil {
+		return structs.RevisionDiff{}, errors.New("record not found")
+	}
+
+	toRevision, err := s.repo.GetByNumber(itemId, to)
+	if err != nil {
+		return structs.RevisionDiff{}, errors.New("record not found")
+	}
+	var fromRevision structs.ItemRevision
+	if from > 0 {
+		fromRevision, err = s.repo.GetByNumber(itemId, from)
+		if err != nil {
+			return structs.RevisionDiff{}, errors.New("record not found")
+		}
+	}
+
+	return structs.RevisionDiff{
+		From:    from,
+		To:      to,
+		Changes: revisionChanges(fromRevision, toRevision),
+	}, nil
+}
+
+// Revert restores the content of an earlier revision. It doesn't rewrite
+// the history: the restored content is recorded as the newest revision.
+func (s *RevisionService) Revert(userId int, itemId int, revision int) (string, error) {
+	item, err := s.itemRepo.GetById(userId, itemId)
+	if err != nil {
+		return "", errors.New("record not found")
+	}
+	rev, err := s.repo.GetByNumber(itemId, revision)
+	if err != nil {
+		return "", errors.New("record not found")
+	}
+	if rev.Done && !item.Done && s.cfg.EnforceDependencies && item.State == structs.ItemStateBlocked {
+		return "", ErrItemBlocked
+	}
+
+	return s.repo.Revert(userId, itemId, revision)
+}
+
+func changedFields(from structs.ItemRevision, to structs.ItemRevision) []string {
+	changes := revisionChanges(from, to)
+	fields := make([]string, 0, len(changes))
+	for _, change := range changes {
+		fields = append(fields, change.Field)
+	}
+	return fields
+}
+
+func revisionChanges(from structs.ItemRevision, to structs.ItemRevision) []structs.RevisionChange {
+	fields := []struct {
+		name  string
+		equal bool
+		from  interface{}
+		to    interface{}
+	}{
+		{"title", from.Title == to.Title, from.Title, to.Title},
+		{"description", from.Description == to.Description, from.Description, to.Description},
+		{"done", from.Done == to.Done, from.Done, to.Done},
+		{"status_id", equalInts(from.StatusId, to.StatusId), from.StatusId, to.StatusId},
+		{"due_date", equalTimes(from.DueDate, to.DueDate), from.DueDate, to.DueDate},
+		{"recurrence", equalStrings(from.Recurrence, to.Recurrence), from.Recurrence, to.Recurrence},
+		{"priority", from.Priority == to.Priority, from.Priority, to.Priority},
+		{"assignee_id", equalInts(from.AssigneeId, to.AssigneeId), from.AssigneeId, to.AssigneeId},
+	}
+
+	changes := make([]structs.RevisionChange, 0)
+	for _, field := range fields {
+		if !field.equal {
+			changes = append(changes, structs.RevisionChange{Field: field.name, From: field.from, To: field.to})
+		}
+	}
+	return changes
+}
+
+func equalInts(a *int, b *int) bool {
+	return a == nil && b == nil || a != nil && b != nil && *a == *b
+}
+
+func equalStrings(a *string, b *string) bool {
+	return a == nil && b == nil || a != nil && b != nil && *a == *b
+}
+
+func equalTimes(a *time.Time, b *time.Time) bool {
+	return a == nil && b == nil || a != nil && b != nil && a.Equal(*b)
+}
@@ -0,0 +1,43 @@
+package service
+
+import (
+	"github.com/fr13n8/todo-app/pkg/repository"
+	"github.com/fr13n8/todo-app/structs"
+)
+
+const defaultSearchLimit = 20
+
+type SearchService struct {
+	repo repository.Search
+}
+
+func NewSearchService(repo repository.Search) *SearchService {
+	return &SearchService{repo: repo}
+}
+
+// Search looks the query up in the lists and items the user can see; each
+// type is ranked and paged on its own.
+func (s *SearchService) Search(userId int, query structs.SearchQuery) (structs.SearchResults, error) {
+	var results structs.SearchResults
+	if query.Limit == 0 {
+		query.Limit = defaultSearchLimit
+	}
+
+	if query.Type == "" || query.Type == structs.SearchTypeList {
+		lists, err := s.repo.SearchLists(userId, query.Q, query.Limit, query.Offset)
+		if err != nil {
+			return results, err
+		}
+		results.Lists = &lists
+	}
+
+	if query.Type == "" || query.Type == structs.SearchTypeItem {
+		items, err := s.repo.SearchItems(userId, query.Q, query.Limit, query.Offset)
+		if err != nil {
+			return results, err
+		}
+		results.Items = &items
+	}
+
+	return results, nil
+}
@@ -1,8 +1,12 @@
 package service
 
 import (
+	"io"
+	"time"
+
 	"github.com/dgrijalva/jwt-go"
 	"github.com/fr13n8/todo-app/pkg/repository"
+	"github.com/fr13n8/todo-app/pkg/storage"
 	"github.com/fr13n8/todo-app/structs"
 )
 
@@ -18,31 +22,184 @@ type Authorization interface {
 }
 
 type TodoList interface {
-	Create(userId int, list structs.List) (int, error)
-	GetAll(userId int) ([]structs.List, error)
+	Create(userId int, list structs.List) (int, string, error)
+	GetAll(userId int, filter structs.ListFilter) ([]structs.List, structs.PageInfo, error)
 	GetById(listId int, userId int) (structs.List, error)
-	Delete(listId int, userId int) error
-	Update(listId int, userId int, list structs.UpdateListInput) error
+	Stamp(userId int) (structs.CollectionStamp, error)
+	Delete(listId int, userId int, version *int) (string, error)
+	Update(listId int, userId int, list structs.UpdateListInput) (string, error)
+	Replace(listId int, userId int, input structs.ReplaceListInput) (string, error)
+	Archive(listId int, userId int) (string, error)
+	Unarchive(listId int, userId int) (string, error)
+	Duplicate(userId int, listId int, input structs.DuplicateListInput) (int, string, error)
+	Batch(userId int, input structs.ListBatchInput) ([]structs.BatchResult, string, error)
 }
 
 type TodoItem interface {
-	Create(listId int, userId int, input structs.Item) (int, error)
-	GetAll(listId int, userId int) ([]structs.Item, error)
+	Create(listId int, userId int, input structs.Item) (int, string, error)
+	GetAll(listId int, userId int, filter structs.ItemFilter) ([]structs.Item, structs.PageInfo, error)
 	GetById(userId int, itemId int) (structs.Item, error)
-	Delete(userId int, itemId int) error
-	Update(userId int, itemId int, input structs.UpdateItemInput) error
+	Stamp(listId int, userId int) (structs.CollectionStamp, error)
+	Delete(userId int, itemId int, version *int) (string, error)
+	Update(userId int, itemId int, input structs.UpdateItemInput) (string, error)
+	Replace(userId int, itemId int, input structs.ReplaceItemInput) (string, error)
+	GetCompletions(userId int, itemId int) ([]structs.ItemCompletion, error)
+	Move(userId int, itemId int, input structs.MoveItemInput) (string, error)
+	Copy(userId int, itemId int, input structs.CopyItemInput) (int, string, error)
+	ArchiveCompleted() (int64, error)
+	Batch(userId int, input structs.ItemBatchInput) ([]structs.BatchResult, string, error)
+}
+
+type Label interface {
+	Create(userId int, label structs.Label) (int, string, error)
+	GetAll(userId int) ([]structs.Label, error)
+	GetById(userId int, labelId int) (structs.Label, error)
+	GetItems(userId int, labelId int) ([]structs.Item, error)
+	Delete(userId int, labelId int) (string, error)
+	Update(userId int, labelId int, input structs.UpdateLabelInput) (string, error)
+	Attach(userId int, itemId int, labelId int) (string, error)
+	Detach(userId int, itemId int, labelId int) (string, error)
+}
+
+type Dependency interface {
+	Create(userId int, itemId int, input structs.DependencyInput) (string, error)
+	GetBlockers(userId int, itemId int) ([]structs.Item, error)
+	Delete(userId int, itemId int, blockerId int) (string, error)
+}
+
+type Attachment interface {
+	Upload(userId int, itemId int, upload structs.AttachmentUpload) (int, string, error)
+	GetAll(userId int, itemId int) ([]structs.Attachment, error)
+	Download(userId int, itemId int, attachmentId int) (structs.Attachment, io.ReadCloser, error)
+	Delete(userId int, itemId int, attachmentId int) (string, error)
+	MaxSize() int64
+}
+
+type TimeEntry interface {
+	Start(userId int, itemId int) (int, string, error)
+	Stop(userId int, itemId int) (string, error)
+	Create(userId int, itemId int, input structs.TimeEntryInput) (int, string, error)
+	GetAll(userId int, itemId int) ([]structs.TimeEntry, error)
+	Delete(userId int, itemId int, entryId int) (string, error)
+	GetReport(userId int, filter structs.TimeReportFilter) ([]structs.TimeReportRow, error)
+}
+
+type Status interface {
+	Create(userId int, listId int, input structs.StatusInput) (int, string, error)
+	GetAll(userId int, listId int) ([]structs.Status, error)
+	GetById(userId int, statusId int) (structs.Status, error)
+	Update(userId int, statusId int, input structs.UpdateStatusInput) (string, error)
+	Delete(userId int, statusId int) (string, error)
+}
+
+type Board interface {
+	Get(userId int, listId int, groupBy string) (structs.Board, error)
+	Move(userId int, listId int, input structs.BoardMoveInput) (string, error)
+}
+
+type Trash interface {
+	GetAll(userId int) ([]structs.TrashEntry, error)
+	RestoreList(userId int, listId int) (string, error)
+	RestoreItem(userId int, itemId int) (string, error)
+	PurgeList(userId int, listId int) (string, error)
+	PurgeItem(userId int, itemId int) (string, error)
+	Empty(userId int) (structs.TrashPurge, string, error)
+	PurgeExpired() (structs.TrashPurge, error)
+}
+
+type Revision interface {
+	GetAll(userId int, itemId int) ([]structs.ItemRevision, error)
+	GetByNumber(userId int, itemId int, revision int) (structs.ItemRevision, error)
+	Diff(userId int, itemId int, from int, to int) (structs.RevisionDiff, error)
+	Revert(userId int, itemId int, revision int) (string, error)
+}
+
+type Template interface {
+	Create(userId int, input structs.TemplateInput) (int, string, error)
+	GetAll(userId int, filter structs.TemplateFilter) ([]structs.Template, error)
+	GetById(userId int, templateId int) (structs.Template, error)
+	Delete(userId int, templateId int) (string, error)
+	Instantiate(userId int, templateId int, input structs.InstantiateTemplateInput) (int, string, error)
+}
+
+type View interface {
+	Create(userId int, input structs.ViewInput) (int, string, error)
+	GetAll(userId int) ([]structs.View, error)
+	GetById(userId int, viewId int) (structs.View, error)
+	Update(userId int, viewId int, input structs.UpdateViewInput) (string, error)
+	Delete(userId int, viewId int) (string, error)
+	GetItems(userId int, viewId int, filter structs.ViewItemsFilter) ([]structs.Item, error)
+}
+
+type Search interface {
+	Search(userId int, query structs.SearchQuery) (structs.SearchResults, error)
+}
+
+type Idempotency interface {
+	Claim(userId int, key string, fingerprint string) (*structs.IdempotentResponse, error)
+	Store(userId int, key string, response structs.IdempotentResponse) error
+	Release(userId int, key string) error
+	PurgeExpired() (int64, error)
+}
+
+type Undo interface {
+	Undo(userId int, token string) (string, error)
+	PurgeExpired() (int64, error)
 }
 
 type Service struct {
 	Authorization
 	TodoList
 	TodoItem
+	Label
+	Dependency
+	Attachment
+	TimeEntry
+	Status
+	Board
+	Trash
+	Revision
+	Template
+	View
+	Search
+	Idempotency
+	Undo
+}
+
+type Config struct {
+	EnforceDependencies bool
+	// Limits in bytes for a single attachment and for all attachments of a
+	// user; zero disables the limit.
+	MaxAttachmentSize int64
+	AttachmentQuota   int64
+	// How long deleted lists and items stay in the trash; zero keeps them
+	// until they are purged by hand.
+	TrashRetention time.Duration
+	// How long responses to requests with an Idempotency-Key header are
+	// replayed; zero ignores the header.
+	IdempotencyWindow time.Duration
+	// How long the changes of a request can be undone; zero lets none be.
+	UndoWindow time.Duration
 }
 
-func NewService(repos *repository.Repository) *Service {
+func NewService(repos *repository.Repository, store storage.BlobStore, cfg Config) *Service {
+	todoItem := NewTodoItemService(repos.TodoItem, repos.TodoList, repos.Label, repos.Status, cfg)
 	return &Service{
 		Authorization: NewAuthService(repos.Authorization),
-		TodoList:      NewTodoListService(repos.TodoList),
-		TodoItem:      NewTodoItemService(repos.TodoItem, repos.TodoList),
+		TodoList:      NewTodoListService(repos.TodoList, repos.View, repos.Attachment, store, cfg),
+		TodoItem:      todoItem,
+		Label:         NewLabelService(repos.Label, repos.TodoItem),
+		Dependency:    NewDependencyService(repos.Dependency, repos.TodoItem),
+		Attachment:    NewAttachmentService(repos.Attachment, repos.TodoItem, store, cfg),
+		TimeEntry:     NewTimeEntryService(repos.TimeEntry, repos.TodoItem),
+		Status:        NewStatusService(repos.Status, repos.TodoList),
+		Board:         NewBoardService(repos.Board, repos.TodoItem, repos.TodoList, repos.Status, repos.Label, todoItem, cfg),
+		Trash:         NewTrashService(repos.Trash, store, cfg),
+		Revision:      NewRevisionService(repos.Revision, repos.TodoItem, cfg),
+		Template:      NewTemplateService(repos.Template, repos.TodoList),
+		View:          NewViewService(repos.View, repos.Label),
+		Search:        NewSearchService(repos.Search),
+		Idempotency:   NewIdempotencyService(repos.Idempotency, cfg),
+		Undo:          NewUndoService(repos.Undo, store, cfg),
 	}
 }
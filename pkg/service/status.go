@@ -0,0 +1,143 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/fr13n8/todo-app/pkg/repository"
+	"github.com/fr13n8/todo-app/structs"
+)
+
+var (
+	ErrInvalidStatus        = errors.New("status doesn't belong to the item's list")
+	ErrNoMatchingStatus     = errors.New("list has no status of the requested done category")
+	ErrTransitionNotAllowed = errors.New("status transition is not allowed")
+)
+
+type StatusService struct {
+	repo     repository.Status
+	listRepo repository.TodoList
+}
+
+func NewStatusService(repo repository.Status, listRepo repository.TodoList) *StatusService {
+	return &StatusService{
+		repo:     repo,
+		listRepo: listRepo,
+	}
+}
+
+func (s *StatusService) Create(userId int, listId int, input structs.StatusInput) (int, string, error) {
+	if _, err := s.listRepo.GetById(listId, userId); err != nil {
+		return 0, "", errors.New("record not found")
+	}
+	return s.repo.Create(userId, listId, input)
+}
+
+func (s *StatusService) GetAll(userId int, listId int) ([]structs.Status, error) {
+	if _, err := s.listRepo.GetById(listId, userId); err != nil {
+		return nil, errors.New("record not found")
+	}
+	return s.repo.GetAll(listId)
+}
+
+func (s *StatusService) GetById(userId int, statusId int) (structs.Status, error) {
+	return s.repo.GetById(userId, statusId)
+}
+
+func (s *StatusService) Update(userId int, statusId int, input structs.UpdateStatusInput) (string, error) {
+	if _, err := s.repo.GetById(userId, statusId); err != nil {
+		return "", errors.New("record not found")
+	}
+	if err := input.Validate(); err != nil {
+		return "", err
+	}
+	return s.repo.Update(userId, statusId, input)
+}
+
+func (s *StatusService) Delete(userId int, statusId int) (string, error) {
+	if _, err := s.repo.GetById(userId, statusId); err != nil {
+		return "", errors.New("record not found")
+	}
+	return s.repo.Delete(userId, statusId)
+}
+
+// resolveStatus finds the status an item ends up in when its status or its
+// done flag is changed, following the workflow of the item's list. A done
+// change keeps the current status if it's already of that category and
+// otherwise takes the first status of the category.
+func resolveStatus(statuses []structs.Status, item structs.Item, input structs.UpdateItemInput) (structs.Status, error) {
+	var current, target *structs.Status
+	for i := range statuses {
+		if item.StatusId != nil && statuses[i].Id == *item.StatusId {
+			current = &statuses[i]
+		}
+	}
+
+	switch {
+	case input.StatusId != nil:
+		for i := range statuses {
+			if statuses[i].Id == *input.StatusId {
+				target = &statuses[i]
+			}
+		}
+		if target == nil {
+			return structs.Status{}, ErrInvalidStatus
+		}
+	case current != nil && current.IsDone == *input.Done:
+		target = current
+	default:
+		for i := range statuses {
+			if statuses[i].IsDone == *input.Done {
+				target = &statuses[i]
+				break
+			}
+		}
+		if target == nil {
+			return structs.Status{}, ErrNoMatchingStatus
+		}
+	}
+
+	if current != nil && current.Id != target.Id && len(current.Transitions) > 0 && !containsId(current.Transitions, target.Id) {
+		return structs.Status{}, ErrTransitionNotAllowed
+	}
+	return *target, nil
+}
+
+// newItemStatus picks the status a new item starts in: the requested one or
+// else the list's initial status, if the list has a workflow at all.
+func newItemStatus(statuses []structs.Status, statusId *int) (*structs.Status, error) {
+	if statusId == nil {
+		if len(statuses) == 0 {
+			return nil, nil
+		}
+		if status := initialStatus(statuses); status != nil {
+			return status, nil
+		}
+		return &statuses[0], nil
+	}
+
+	for i := range statuses {
+		if statuses[i].Id == *statusId {
+			return &statuses[i], nil
+		}
+	}
+	return nil, ErrInvalidStatus
+}
+
+// initialStatus is where new items and rolled over recurring items start.
+func initialStatus(statuses []structs.Status) *structs.Status {
+	for i := range statuses {
+		if !statuses[i].IsDone {
+			return &statuses[i]
+		}
+	}
+	return nil
+}
+
+func containsId(ids []int, id int) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
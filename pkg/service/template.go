@@ -0,0 +1,52 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/fr13n8/todo-app/pkg/repository"
+	"github.com/fr13n8/todo-app/structs"
+)
+
+type TemplateService struct {
+	repo     repository.Template
+	listRepo repository.TodoList
+}
+
+func NewTemplateService(repo repository.Template, listRepo repository.TodoList) *TemplateService {
+	return &TemplateService{
+		repo:     repo,
+		listRepo: listRepo,
+	}
+}
+
+func (s *TemplateService) Create(userId int, input structs.TemplateInput) (int, string, error) {
+	if _, err := s.listRepo.GetById(input.ListId, userId); err != nil {
+		return 0, "", errors.New("record not found")
+	}
+	return s.repo.Create(userId, input)
+}
+
+func (s *TemplateService) GetAll(userId int, filter structs.TemplateFilter) ([]structs.Template, error) {
+	return s.repo.GetAll(userId, filter.Scope)
+}
+
+func (s *TemplateService) GetById(userId int, templateId int) (structs.Template, error) {
+	return s.repo.GetById(userId, templateId)
+}
+
+// Delete removes a template of the user; shared templates of others can be
+// used but not deleted.
+func (s *TemplateService) Delete(userId int, templateId int) (string, error) {
+	template, err := s.repo.GetById(userId, templateId)
+	if err != nil || template.UserId != userId {
+		return "", errors.New("record not found")
+	}
+	return s.repo.Delete(userId, templateId)
+}
+
+func (s *TemplateService) Instantiate(userId int, templateId int, input structs.InstantiateTemplateInput) (int, string, error) {
+	if _, err := s.repo.GetById(userId, templateId); err != nil {
+		return 0, "", errors.New("record not found")
+	}
+	return s.repo.Instantiate(userId, templateId, input.Title, input.StartDate)
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/fr13n8/todo-app/pkg/repository"
+	"github.com/fr13n8/todo-app/structs"
+)
+
+var ErrNoRunningTimer = repository.ErrNoRunningTimer
+
+type TimeEntryService struct {
+	repo     repository.TimeEntry
+	itemRepo repository.TodoItem
+}
+
+func NewTimeEntryService(repo repository.TimeEntry, itemRepo repository.TodoItem) *TimeEntryService {
+	return &TimeEntryService{
+		repo:     repo,
+		itemRepo: itemRepo,
+	}
+}
+
+// Start stops whatever timer the user has running and starts a new one on
+// the item.
+func (s *TimeEntryService) Start(userId int, itemId int) (int, string, error) {
+	if _, err := s.itemRepo.GetById(userId, itemId); err != nil {
+		return 0, "", errors.New("record not found")
+	}
+	return s.repo.Start(userId, itemId)
+}
+
+func (s *TimeEntryService) Stop(userId int, itemId int) (string, error) {
+	if _, err := s.itemRepo.GetById(userId, itemId); err != nil {
+		return "", errors.New("record not found")
+	}
+	return s.repo.Stop(userId, itemId)
+}
+
+func (s *TimeEntryService) Create(userId int, itemId int, input structs.TimeEntryInput) (int, string, error) {
+	if _, err := s.itemRepo.GetById(userId, itemId); err != nil {
+		return 0, "", errors.New("record not found")
+	}
+	if err := input.Validate(); err != nil {
+		return 0, "", err
+	}
+
+	return s.repo.Create(userId, structs.TimeEntry{
+		ItemId:    itemId,
+		StartedAt: input.StartedAt,
+		StoppedAt: &input.StoppedAt,
+		Note:      input.Note,
+	})
+}
+
+func (s *TimeEntryService) GetAll(userId int, itemId int) ([]structs.TimeEntry, error) {
+	if _, err := s.itemRepo.GetById(userId, itemId); err != nil {
+		return nil, errors.New("record not found")
+	}
+	return s.repo.GetAll(itemId)
+}
+
+func (s *TimeEntryService) Delete(userId int, itemId int, entryId int) (string, error) {
+	if _, err := s.itemRepo.GetById(userId, itemId); err != nil {
+		return "", errors.New("record not found")
+	}
+	return s.repo.Delete(userId, itemId, entryId)
+}
+
+func (s *TimeEntryService) GetReport(userId int, filter structs.TimeReportFilter) ([]structs.TimeReportRow, error) {
+	if !filter.To.After(filter.From) {
+		return nil, errors.New("report range is empty")
+	}
+	return s.repo.GetReport(userId, filter)
+}
@@ -2,57 +2,426 @@ package service
 
 import (
 	"errors"
+	"time"
 
+	"github.com/fr13n8/todo-app/pkg/querylang"
 	"github.com/fr13n8/todo-app/pkg/repository"
 	"github.com/fr13n8/todo-app/structs"
 )
 
+var (
+	ErrListArchived    = errors.New("the list is archived, unarchive it first")
+	ErrInvalidAssignee = errors.New("the assignee has no access to the list")
+)
+
 type TodoItemService struct {
-	repo     repository.TodoItem
-	listRepo repository.TodoList
+	repo       repository.TodoItem
+	listRepo   repository.TodoList
+	labelRepo  repository.Label
+	statusRepo repository.Status
+	cfg        Config
 }
 
-func NewTodoItemService(repo repository.TodoItem, listRepo repository.TodoList) *TodoItemService {
+func NewTodoItemService(repo repository.TodoItem, listRepo repository.TodoList, labelRepo repository.Label,
+	statusRepo repository.Status, cfg Config) *TodoItemService {
 	return &TodoItemService{
-		repo:     repo,
-		listRepo: listRepo,
+		repo:       repo,
+		listRepo:   listRepo,
+		labelRepo:  labelRepo,
+		statusRepo: statusRepo,
+		cfg:        cfg,
+	}
+}
+
+func (s *TodoItemService) Create(listId int, userId int, input structs.Item) (int, string, error) {
+	input, err := s.prepareCreate(listId, userId, input)
+	if err != nil {
+		return 0, "", err
 	}
+	return s.repo.Create(listId, userId, input)
 }
 
-func (s *TodoItemService) Create(listId int, userId int, input structs.Item) (int, error) {
+// prepareCreate checks a new item and settles its recurrence and status.
+func (s *TodoItemService) prepareCreate(listId int, userId int, input structs.Item) (structs.Item, error) {
 	_, err := s.listRepo.GetById(listId, userId)
 	if err != nil {
-		return 0, err
+		return input, err
+	}
+	if err := s.checkAssignee(listId, input.AssigneeId); err != nil {
+		return input, err
 	}
 
-	return s.repo.Create(listId, input)
+	if input.Recurrence != nil && *input.Recurrence == "" {
+		input.Recurrence = nil
+	}
+	if input.Recurrence != nil {
+		start := time.Now()
+		if input.DueDate != nil {
+			start = *input.DueDate
+		}
+		if _, err := parseRecurrence(*input.Recurrence, start); err != nil {
+			return input, err
+		}
+		input.RecurrenceStart = &start
+	}
+
+	// In lists with a workflow the done flag follows the status; elsewhere
+	// new items always start open.
+	statuses, err := s.statusRepo.GetAll(listId)
+	if err != nil {
+		return input, err
+	}
+	status, err := newItemStatus(statuses, input.StatusId)
+	if err != nil {
+		return input, err
+	}
+	input.StatusId, input.Done = nil, false
+	if status != nil {
+		input.StatusId, input.Done = &status.Id, status.IsDone
+	}
+
+	return input, nil
 }
 
-func (s *TodoItemService) GetAll(listId int, userId int) ([]structs.Item, error) {
+func (s *TodoItemService) GetAll(listId int, userId int, filter structs.ItemFilter) ([]structs.Item, structs.PageInfo, error) {
+	var page structs.PageInfo
 	_, err := s.listRepo.GetById(listId, userId)
 	if err != nil {
-		return nil, errors.New("record not found")
+		return nil, page, errors.New("record not found")
+	}
+	filter.LabelIds = uniqueIds(filter.LabelIds)
+	if filter.Expr, err = querylang.Parse(filter.Query); err != nil {
+		return nil, page, err
+	}
+
+	items, page, err := s.repo.GetAll(listId, userId, filter)
+	if err != nil {
+		return nil, page, err
+	}
+	return items, page, fillItemsLabels(s.labelRepo, userId, items)
+}
+
+// Stamp sums up the items of the list, for telling whether a listing of them
+// has changed without listing them.
+func (s *TodoItemService) Stamp(listId int, userId int) (structs.CollectionStamp, error) {
+	if _, err := s.listRepo.GetById(listId, userId); err != nil {
+		return structs.CollectionStamp{}, errors.New("record not found")
 	}
-	return s.repo.GetAll(listId, userId)
+	return s.repo.Stamp(listId, userId)
 }
 
 func (s *TodoItemService) GetById(userId int, itemId int) (structs.Item, error) {
-	return s.repo.GetById(userId, itemId)
+	item, err := s.repo.GetById(userId, itemId)
+	if err != nil {
+		return item, err
+	}
+
+	items := []structs.Item{item}
+	if err := fillItemsLabels(s.labelRepo, userId, items); err != nil {
+		return item, err
+	}
+	return items[0], nil
 }
 
-func (s *TodoItemService) Delete(userId int, itemId int) error {
+// Delete trashes the item; a version set refuses to trash any other one.
+func (s *TodoItemService) Delete(userId int, itemId int, version *int) (string, error) {
 	if _, err := s.repo.GetById(userId, itemId); err != nil {
-		return errors.New("record not found")
+		return "", ErrRecordNotFound
+	}
+
+	return s.repo.Delete(userId, itemId, version)
+}
+
+// Update applies the update to the item and returns the undo token, which
+// is empty when nothing had to change.
+func (s *TodoItemService) Update(userId int, itemId int, input structs.UpdateItemInput) (string, error) {
+	change, err := s.prepareUpdate(userId, itemId, input)
+	if err != nil {
+		return "", err
 	}
-	return s.repo.Delete(userId, itemId)
+
+	if change.Complete {
+		return s.repo.Complete(userId, change)
+	}
+	if change.Update != nil {
+		return s.repo.Update(userId, itemId, *change.Update)
+	}
+	return "", nil
 }
 
-func (s *TodoItemService) Update(userId int, itemId int, input structs.UpdateItemInput) error {
-	if _, err := s.repo.GetById(userId, itemId); err != nil {
-		return errors.New("record not found")
+// prepareUpdate checks an update and works out the change it makes: the
+// status follows the done flag and the other way around, and completing a
+// recurring item records the completion and rolls it forward to its next
+// occurrence instead of closing it.
+func (s *TodoItemService) prepareUpdate(userId int, itemId int, input structs.UpdateItemInput) (structs.ItemChange, error) {
+	change := structs.ItemChange{Op: structs.OpUpdate, ItemId: itemId, Version: input.Version}
+	item, err := s.repo.GetById(userId, itemId)
+	if err != nil {
+		return change, ErrRecordNotFound
 	}
 	if err := input.Validate(); err != nil {
-		return err
+		return change, err
+	}
+	if err := s.checkAssignee(item.ListId, input.AssigneeId); err != nil {
+		return change, err
+	}
+	if input.DueDate == nil && input.ClearDueDate {
+		item.DueDate = nil
+	}
+
+	if input.Recurrence != nil {
+		item.Recurrence = input.Recurrence
+		item.RecurrenceStart = nil
+		if *input.Recurrence != "" {
+			start := time.Now()
+			if input.DueDate != nil {
+				start = *input.DueDate
+			} else if item.DueDate != nil {
+				start = *item.DueDate
+			}
+			if _, err := parseRecurrence(*input.Recurrence, start); err != nil {
+				return change, err
+			}
+			input.RecurrenceStart = &start
+			item.RecurrenceStart = &start
+		}
+	}
+	if input.DueDate != nil {
+		item.DueDate = input.DueDate
+	}
+
+	var statuses []structs.Status
+	if input.StatusId != nil || input.Done != nil {
+		statuses, err = s.statusRepo.GetByItemId(itemId)
+		if err != nil {
+			return change, err
+		}
+	}
+	if input.StatusId != nil || (input.Done != nil && len(statuses) > 0) {
+		status, err := resolveStatus(statuses, item, input)
+		if err != nil {
+			return change, err
+		}
+		input.StatusId = &status.Id
+		input.Done = &status.IsDone
+	}
+
+	completing := input.Done != nil && *input.Done && !item.Done
+	if completing && s.cfg.EnforceDependencies && item.State == structs.ItemStateBlocked {
+		return change, ErrItemBlocked
+	}
+	if !completing || item.Recurrence == nil || *item.Recurrence == "" {
+		change.Update = &input
+		return change, nil
+	}
+
+	// Completing a recurring item rolls it forward to its next occurrence
+	// instead of closing it; the completion itself goes to the history.
+	input.Done = nil
+	input.StatusId = nil
+	if status := initialStatus(statuses); status != nil {
+		input.StatusId = &status.Id
+	}
+	if input.Validate() == nil {
+		change.Update = &input
+	}
+
+	// The series keeps its anchor, but an overdue item skips the
+	// occurrences already past.
+	now := time.Now()
+	start, after := now, now
+	if item.DueDate != nil {
+		start = *item.DueDate
+		if item.DueDate.After(now) {
+			after = *item.DueDate
+		}
+	}
+	if item.RecurrenceStart != nil {
+		start = *item.RecurrenceStart
+	}
+	next, err := nextOccurrence(*item.Recurrence, start, after)
+	if err != nil {
+		return change, err
+	}
+	change.Complete, change.Next = true, next
+	return change, nil
+}
+
+// checkAssignee checks that the assignee, if any, can access the list.
+func (s *TodoItemService) checkAssignee(listId int, assigneeId *int) error {
+	if assigneeId == nil {
+		return nil
+	}
+	if _, err := s.listRepo.GetById(listId, *assigneeId); err != nil {
+		return ErrInvalidAssignee
+	}
+	return nil
+}
+
+// Replace sets all the editable fields of the item. The fields that change
+// go through Update, so the status follows the done flag unless it changes
+// too.
+func (s *TodoItemService) Replace(userId int, itemId int, input structs.ReplaceItemInput) (string, error) {
+	item, err := s.repo.GetById(userId, itemId)
+	if err != nil {
+		return "", errors.New("record not found")
+	}
+	if input.Version != nil && *input.Version != item.Version {
+		return "", ErrVersionMismatch
+	}
+
+	update := itemChanges(item, input)
+	if update.Validate() != nil {
+		return "", nil
+	}
+	update.Version = input.Version
+	return s.Update(userId, itemId, update)
+}
+
+// itemChanges is the update turning the item into its replacement.
+func itemChanges(item structs.Item, input structs.ReplaceItemInput) structs.UpdateItemInput {
+	var update structs.UpdateItemInput
+	if input.Title != item.Title {
+		update.Title = &input.Title
+	}
+	if input.Description != item.Description {
+		update.Description = &input.Description
+	}
+	if input.Done != item.Done {
+		update.Done = &input.Done
+	}
+	if input.StatusId != nil && (item.StatusId == nil || *input.StatusId != *item.StatusId) {
+		update.StatusId = input.StatusId
+	}
+	if input.DueDate == nil {
+		update.ClearDueDate = item.DueDate != nil
+	} else if item.DueDate == nil || !input.DueDate.Equal(*item.DueDate) {
+		update.DueDate = input.DueDate
+	}
+	current, recurrence := "", ""
+	if item.Recurrence != nil {
+		current = *item.Recurrence
+	}
+	if input.Recurrence != nil {
+		recurrence = *input.Recurrence
+	}
+	if recurrence != current {
+		update.Recurrence = &recurrence
+	}
+	if input.Priority != item.Priority {
+		update.Priority = &input.Priority
+	}
+	if input.AssigneeId == nil {
+		update.ClearAssignee = item.AssigneeId != nil
+	} else if item.AssigneeId == nil || *input.AssigneeId != *item.AssigneeId {
+		update.AssigneeId = input.AssigneeId
+	}
+	return update
+}
+
+func (s *TodoItemService) GetCompletions(userId int, itemId int) ([]structs.ItemCompletion, error) {
+	if _, err := s.repo.GetById(userId, itemId); err != nil {
+		return nil, errors.New("record not found")
+	}
+	return s.repo.GetCompletions(userId, itemId)
+}
+
+func (s *TodoItemService) Move(userId int, itemId int, input structs.MoveItemInput) (string, error) {
+	if err := s.checkMove(userId, itemId, input.ListId); err != nil {
+		return "", err
+	}
+	return s.repo.Move(userId, itemId, input.ListId)
+}
+
+// checkMove checks that the item can go to the list. An archived list is
+// refused, as the item would drop out of sight along with it.
+func (s *TodoItemService) checkMove(userId int, itemId int, listId int) error {
+	if _, err := s.repo.GetById(userId, itemId); err != nil {
+		return ErrRecordNotFound
+	}
+	list, err := s.listRepo.GetById(listId, userId)
+	if err != nil {
+		return ErrRecordNotFound
+	}
+	if list.ArchivedAt != nil {
+		return ErrListArchived
+	}
+	return nil
+}
+
+func (s *TodoItemService) Copy(userId int, itemId int, input structs.CopyItemInput) (int, string, error) {
+	if err := s.checkMove(userId, itemId, input.ListId); err != nil {
+		return 0, "", err
+	}
+	return s.repo.Copy(userId, itemId, input)
+}
+
+// Batch checks the operations the way their own endpoints do, against the
+// items as they are before the batch, and applies them in one transaction.
+func (s *TodoItemService) Batch(userId int, input structs.ItemBatchInput) ([]structs.BatchResult, string, error) {
+	atomic := input.Mode != structs.BatchBestEffort
+	results := make([]structs.BatchResult, len(input.Operations))
+	changes := make([]structs.ItemChange, 0, len(input.Operations))
+	indexes := make([]int, 0, len(input.Operations))
+	for i, op := range input.Operations {
+		change, err := s.prepareOperation(userId, op)
+		if err != nil {
+			results[i].Err = err
+			if atomic {
+				return abortBatch(results, i), "", nil
+			}
+			continue
+		}
+		changes = append(changes, change)
+		indexes = append(indexes, i)
+	}
+	if len(changes) == 0 {
+		return results, "", nil
+	}
+
+	applied, token, err := s.repo.Batch(userId, changes, atomic)
+	if err != nil {
+		return nil, "", err
+	}
+	return mergeBatchResults(results, indexes, applied, atomic), token, nil
+}
+
+func (s *TodoItemService) prepareOperation(userId int, op structs.ItemOperation) (structs.ItemChange, error) {
+	change := structs.ItemChange{Op: op.Op, ItemId: op.Id, ListId: op.ListId}
+	if err := op.Validate(); err != nil {
+		return change, err
+	}
+
+	var err error
+	switch op.Op {
+	case structs.OpCreate:
+		change.Item, err = s.prepareCreate(op.ListId, userId, *op.Item)
+	case structs.OpUpdate:
+		change, err = s.prepareUpdate(userId, op.Id, *op.Update)
+	case structs.OpMove:
+		err = s.checkMove(userId, op.Id, op.ListId)
+	case structs.OpDelete:
+		if _, err = s.repo.GetById(userId, op.Id); err != nil {
+			err = ErrRecordNotFound
+		}
+	}
+	return change, err
+}
+
+// ArchiveCompleted applies the lists' auto-archive settings. Archived items
+// only drop out of the default item listing; they can still be found.
+func (s *TodoItemService) ArchiveCompleted() (int64, error) {
+	return s.repo.ArchiveCompleted()
+}
+
+func uniqueIds(ids []int) []int {
+	seen := make(map[int]bool, len(ids))
+	unique := make([]int, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
 	}
-	return s.repo.Update(userId, itemId, input)
+	return unique
 }
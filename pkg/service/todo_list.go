@@ -2,44 +2,240 @@ package service
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/fr13n8/todo-app/pkg/repository"
+	"github.com/fr13n8/todo-app/pkg/storage"
 	"github.com/fr13n8/todo-app/structs"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidSort     = repository.ErrInvalidSort
+	ErrInvalidCursor   = repository.ErrInvalidCursor
+	ErrVersionMismatch = repository.ErrVersionMismatch
 )
 
 type TodoListService struct {
-	repo repository.TodoList
+	repo           repository.TodoList
+	viewRepo       repository.View
+	attachmentRepo repository.Attachment
+	store          storage.BlobStore
+	cfg            Config
 }
 
-func NewTodoListService(repo repository.TodoList) *TodoListService {
-	return &TodoListService{repo: repo}
+func NewTodoListService(repo repository.TodoList, viewRepo repository.View, attachmentRepo repository.Attachment,
+	store storage.BlobStore, cfg Config) *TodoListService {
+	return &TodoListService{
+		repo:           repo,
+		viewRepo:       viewRepo,
+		attachmentRepo: attachmentRepo,
+		store:          store,
+		cfg:            cfg,
+	}
 }
 
-func (s *TodoListService) Create(userId int, list structs.List) (int, error) {
+func (s *TodoListService) Create(userId int, list structs.List) (int, string, error) {
 	return s.repo.Create(userId, list)
 }
 
-func (s *TodoListService) GetAll(userId int) ([]structs.List, error) {
-	return s.repo.GetAll(userId)
+// GetAll returns a page of the lists of the user, followed by the saved
+// views as smart lists on the last page when the filter asks for them. Views
+// have no audit trail, so they aren't narrowed down by the filter; archived
+// lists come without them.
+func (s *TodoListService) GetAll(userId int, filter structs.ListFilter) ([]structs.List, structs.PageInfo, error) {
+	lists, page, err := s.repo.GetAll(userId, filter)
+	if err != nil || !filter.Views || filter.Archived || page.NextCursor != "" {
+		return lists, page, err
+	}
+
+	views, err := s.viewRepo.GetAll(userId)
+	if err != nil {
+		return nil, page, err
+	}
+	for _, view := range views {
+		createdAt, updatedAt := view.CreatedAt, view.UpdatedAt
+		lists = append(lists, structs.List{
+			Id:        view.Id,
+			Title:     view.Name,
+			CreatedAt: &createdAt,
+			UpdatedAt: &updatedAt,
+			CreatedBy: &userId,
+			Smart:     true,
+		})
+	}
+	page.Total += len(views)
+	return lists, page, nil
 }
 
 func (s *TodoListService) GetById(listId int, userId int) (structs.List, error) {
 	return s.repo.GetById(listId, userId)
 }
 
-func (s *TodoListService) Delete(listId int, userId int) error {
+// Stamp sums up the lists of the user, for telling whether a listing of them
+// has changed without listing them.
+func (s *TodoListService) Stamp(userId int) (structs.CollectionStamp, error) {
+	return s.repo.Stamp(userId)
+}
+
+// Delete trashes the list; a version set refuses to trash any other one.
+func (s *TodoListService) Delete(listId int, userId int, version *int) (string, error) {
 	if _, err := s.repo.GetById(listId, userId); err != nil {
-		return errors.New("record not found")
+		return "", ErrRecordNotFound
 	}
-	return s.repo.Delete(listId, userId)
+	return s.repo.Delete(listId, userId, version)
 }
 
-func (s *TodoListService) Update(listId int, userId int, input structs.UpdateListInput) error {
+func (s *TodoListService) Update(listId int, userId int, input structs.UpdateListInput) (string, error) {
 	if _, err := s.repo.GetById(listId, userId); err != nil {
-		return errors.New("record not found")
+		return "", errors.New("record not found")
 	}
 	if err := input.Validate(); err != nil {
-		return err
+		return "", err
 	}
 	return s.repo.Update(listId, userId, input)
 }
+
+// Replace sets all the editable fields of the list. The undo token is empty
+// when nothing had to change.
+func (s *TodoListService) Replace(listId int, userId int, input structs.ReplaceListInput) (string, error) {
+	list, err := s.repo.GetById(listId, userId)
+	if err != nil {
+		return "", errors.New("record not found")
+	}
+	if input.Version != nil && *input.Version != list.Version {
+		return "", ErrVersionMismatch
+	}
+
+	update := listChanges(list, input)
+	if update.Validate() != nil {
+		return "", nil
+	}
+	update.Version = input.Version
+	return s.repo.Update(listId, userId, update)
+}
+
+// listChanges is the update turning the list into its replacement.
+func listChanges(list structs.List, input structs.ReplaceListInput) structs.UpdateListInput {
+	var update structs.UpdateListInput
+	if input.Title != list.Title {
+		update.Title = &input.Title
+	}
+	if input.Description != list.Description {
+		update.Description = &input.Description
+	}
+	current, days := 0, 0
+	if list.AutoArchiveDays != nil {
+		current = *list.AutoArchiveDays
+	}
+	if input.AutoArchiveDays != nil {
+		days = *input.AutoArchiveDays
+	}
+	if days != current {
+		update.AutoArchiveDays = &days
+	}
+	return update
+}
+
+func (s *TodoListService) Archive(listId int, userId int) (string, error) {
+	if _, err := s.repo.GetById(listId, userId); err != nil {
+		return "", errors.New("record not found")
+	}
+	return s.repo.SetArchived(listId, userId, true)
+}
+
+func (s *TodoListService) Unarchive(listId int, userId int) (string, error) {
+	if _, err := s.repo.GetById(listId, userId); err != nil {
+		return "", errors.New("record not found")
+	}
+	return s.repo.SetArchived(listId, userId, false)
+}
+
+// Duplicate copies the list. Blobs of copied attachments are stored under new
+// keys before the rows are written and removed again when the copy fails.
+func (s *TodoListService) Duplicate(userId int, listId int, input structs.DuplicateListInput) (int, string, error) {
+	if _, err := s.repo.GetById(listId, userId); err != nil {
+		return 0, "", errors.New("record not found")
+	}
+
+	storageKeys := make(map[string]string)
+	if input.Items && input.Attachments {
+		attachments, err := s.attachmentRepo.GetAllByList(listId)
+		if err != nil {
+			return 0, "", err
+		}
+		for _, attachment := range attachments {
+			key := fmt.Sprintf("%d/%s", userId, uuid.New().String())
+			storageKeys[attachment.StorageKey] = key
+			if err := copyBlob(s.store, attachment.StorageKey, key, attachment.ContentType); err != nil {
+				deleteBlobs(s.store, mapValues(storageKeys))
+				return 0, "", err
+			}
+		}
+	}
+
+	id, token, err := s.repo.Duplicate(userId, listId, input, storageKeys, s.cfg.AttachmentQuota)
+	if err != nil {
+		deleteBlobs(s.store, mapValues(storageKeys))
+		return 0, "", err
+	}
+	return id, token, nil
+}
+
+func mapValues(m map[string]string) []string {
+	values := make([]string, 0, len(m))
+	for _, value := range m {
+		values = append(values, value)
+	}
+	return values
+}
+
+// Batch checks the operations the way their own endpoints do, against the
+// lists as they are before the batch, and applies them in one transaction.
+func (s *TodoListService) Batch(userId int, input structs.ListBatchInput) ([]structs.BatchResult, string, error) {
+	atomic := input.Mode != structs.BatchBestEffort
+	results := make([]structs.BatchResult, len(input.Operations))
+	changes := make([]structs.ListChange, 0, len(input.Operations))
+	indexes := make([]int, 0, len(input.Operations))
+	for i, op := range input.Operations {
+		change, err := s.prepareOperation(userId, op)
+		if err != nil {
+			results[i].Err = err
+			if atomic {
+				return abortBatch(results, i), "", nil
+			}
+			continue
+		}
+		changes = append(changes, change)
+		indexes = append(indexes, i)
+	}
+	if len(changes) == 0 {
+		return results, "", nil
+	}
+
+	applied, token, err := s.repo.Batch(userId, changes, atomic)
+	if err != nil {
+		return nil, "", err
+	}
+	return mergeBatchResults(results, indexes, applied, atomic), token, nil
+}
+
+func (s *TodoListService) prepareOperation(userId int, op structs.ListOperation) (structs.ListChange, error) {
+	change := structs.ListChange{Op: op.Op, ListId: op.Id}
+	if err := op.Validate(); err != nil {
+		return change, err
+	}
+
+	if op.Op == structs.OpCreate {
+		change.List = *op.List
+		return change, nil
+	}
+	if _, err := s.repo.GetById(op.Id, userId); err != nil {
+		return change, ErrRecordNotFound
+	}
+	if op.Op == structs.OpUpdate {
+		change.Update = *op.Update
+		return change, op.Update.Validate()
+	}
+	return change, nil
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"time"
+
+	"github.com/fr13n8/todo-app/pkg/repository"
+	"github.com/fr13n8/todo-app/pkg/storage"
+	"github.com/fr13n8/todo-app/structs"
+)
+
+var ErrListInTrash = repository.ErrListInTrash
+
+type TrashService struct {
+	repo  repository.Trash
+	store storage.BlobStore
+	cfg   Config
+}
+
+func NewTrashService(repo repository.Trash, store storage.BlobStore, cfg Config) *TrashService {
+	return &TrashService{repo: repo, store: store, cfg: cfg}
+}
+
+func (s *TrashService) GetAll(userId int) ([]structs.TrashEntry, error) {
+	return s.repo.GetAll(userId)
+}
+
+func (s *TrashService) RestoreList(userId int, listId int) (string, error) {
+	return s.repo.RestoreList(userId, listId)
+}
+
+func (s *TrashService) RestoreItem(userId int, itemId int) (string, error) {
+	return s.repo.RestoreItem(userId, itemId)
+}
+
+// PurgeList deletes the list in the trash for good. Like PurgeItem and
+// Empty it can be undone, so the blobs of the attachments going away are
+// kept until UndoService.PurgeExpired.
+func (s *TrashService) PurgeList(userId int, listId int) (string, error) {
+	return s.repo.PurgeList(userId, listId)
+}
+
+func (s *TrashService) PurgeItem(userId int, itemId int) (string, error) {
+	return s.repo.PurgeItem(userId, itemId)
+}
+
+func (s *TrashService) Empty(userId int) (structs.TrashPurge, string, error) {
+	return s.repo.Empty(userId)
+}
+
+// PurgeExpired empties the trash of everything deleted longer than the
+// retention period ago. It is meant to run periodically.
+func (s *TrashService) PurgeExpired() (structs.TrashPurge, error) {
+	if s.cfg.TrashRetention <= 0 {
+		return structs.TrashPurge{}, nil
+	}
+
+	purge, err := s.repo.PurgeOlderThan(time.Now().Add(-s.cfg.TrashRetention))
+	if err != nil {
+		return purge, err
+	}
+	deleteBlobs(s.store, purge.StorageKeys)
+	return purge, nil
+}
@@ -0,0 +1,41 @@
+package service
+
+import (
+	"time"
+
+	"github.com/fr13n8/todo-app/pkg/repository"
+	"github.com/fr13n8/todo-app/pkg/storage"
+)
+
+var (
+	ErrUndoExpired  = repository.ErrUndoExpired
+	ErrUndoConflict = repository.ErrUndoConflict
+)
+
+type UndoService struct {
+	repo  repository.Undo
+	store storage.BlobStore
+	cfg   Config
+}
+
+func NewUndoService(repo repository.Undo, store storage.BlobStore, cfg Config) *UndoService {
+	return &UndoService{repo: repo, store: store, cfg: cfg}
+}
+
+// Undo reverses the action of the token if it was taken within the window.
+// It returns the token that redoes the action.
+func (s *UndoService) Undo(userId int, token string) (string, error) {
+	return s.repo.Undo(userId, token, time.Now().Add(-s.cfg.UndoWindow))
+}
+
+// PurgeExpired forgets the actions that can't be undone anymore, deleting
+// the blobs of the attachments they removed. It is meant to run
+// periodically.
+func (s *UndoService) PurgeExpired() (int64, error) {
+	deleted, keys, err := s.repo.DeleteOlderThan(time.Now().Add(-s.cfg.UndoWindow))
+	if err != nil {
+		return 0, err
+	}
+	deleteBlobs(s.store, keys)
+	return deleted, nil
+}
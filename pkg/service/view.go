@@ -0,0 +1,73 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/fr13n8/todo-app/pkg/querylang"
+	"github.com/fr13n8/todo-app/pkg/repository"
+	"github.com/fr13n8/todo-app/structs"
+)
+
+type ViewService struct {
+	repo      repository.View
+	labelRepo repository.Label
+}
+
+func NewViewService(repo repository.View, labelRepo repository.Label) *ViewService {
+	return &ViewService{
+		repo:      repo,
+		labelRepo: labelRepo,
+	}
+}
+
+func (s *ViewService) Create(userId int, input structs.ViewInput) (int, string, error) {
+	input.Query.LabelIds = uniqueIds(input.Query.LabelIds)
+	return s.repo.Create(userId, input)
+}
+
+func (s *ViewService) GetAll(userId int) ([]structs.View, error) {
+	return s.repo.GetAll(userId)
+}
+
+func (s *ViewService) GetById(userId int, viewId int) (structs.View, error) {
+	return s.repo.GetById(userId, viewId)
+}
+
+func (s *ViewService) Update(userId int, viewId int, input structs.UpdateViewInput) (string, error) {
+	if _, err := s.repo.GetById(userId, viewId); err != nil {
+		return "", errors.New("record not found")
+	}
+	if err := input.Validate(); err != nil {
+		return "", err
+	}
+	if input.Query != nil {
+		input.Query.LabelIds = uniqueIds(input.Query.LabelIds)
+	}
+	return s.repo.Update(userId, viewId, input)
+}
+
+func (s *ViewService) Delete(userId int, viewId int) (string, error) {
+	if _, err := s.repo.GetById(userId, viewId); err != nil {
+		return "", errors.New("record not found")
+	}
+	return s.repo.Delete(userId, viewId)
+}
+
+// GetItems returns the items matching the view and the filter, in the sort
+// order of the view.
+func (s *ViewService) GetItems(userId int, viewId int, filter structs.ViewItemsFilter) ([]structs.Item, error) {
+	view, err := s.repo.GetById(userId, viewId)
+	if err != nil {
+		return nil, errors.New("record not found")
+	}
+	expr, err := querylang.Parse(filter.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.repo.GetItems(userId, view.Query, expr)
+	if err != nil {
+		return nil, err
+	}
+	return items, fillItemsLabels(s.labelRepo, userId, items)
+}
@@ -0,0 +1,32 @@
+package storage
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalStore(t *testing.T) {
+	s, err := NewLocalStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when creating a local store", err)
+	}
+
+	assert.NoError(t, s.Put("1/blob", strings.NewReader("content"), "text/plain"))
+
+	r, err := s.Get("1/blob")
+	assert.NoError(t, err)
+	body, _ := io.ReadAll(r)
+	r.Close()
+	assert.Equal(t, "content", string(body))
+
+	assert.NoError(t, s.Delete("1/blob"))
+	assert.NoError(t, s.Delete("1/blob"))
+
+	_, err = s.Get("1/blob")
+	assert.Equal(t, ErrNotFound, err)
+
+	assert.Error(t, s.Put("../escape", strings.NewReader("content"), ""))
+}
@@ -0,0 +1,35 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+var ErrNotFound = errors.New("blob not found")
+
+type BlobStore interface {
+	Put(key string, r io.Reader, contentType string) error
+	Get(key string) (io.ReadCloser, error)
+	Delete(key string) error
+}
+
+type Config struct {
+	Driver    string
+	Path      string
+	Endpoint  string
+	Bucket    string
+	Region    string
+	AccessKey string
+	SecretKey string
+}
+
+func NewBlobStore(cfg Config) (BlobStore, error) {
+	switch cfg.Driver {
+	case "", "local":
+		return NewLocalStore(cfg.Path)
+	case "s3":
+		return NewS3Store(cfg.Endpoint, cfg.Bucket, cfg.Region, cfg.AccessKey, cfg.SecretKey)
+	}
+	return nil, fmt.Errorf("unknown storage driver %q", cfg.Driver)
+}
@@ -0,0 +1,3 @@
+DROP TABLE items_labels;
+
+DROP TABLE labels;
\ No newline at end of file
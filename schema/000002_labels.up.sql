@@ -0,0 +1,16 @@
+CREATE TABLE labels
+(
+    id serial not null unique,
+    user_id int references users(id) on delete cascade not null,
+    name varchar(255) not null,
+    color varchar(7) not null default '#808080',
+    unique (user_id, name)
+);
+
+CREATE TABLE items_labels
+(
+    id serial not null unique,
+    item_id int references todo_items(id) on delete cascade not null,
+    label_id int references labels(id) on delete cascade not null,
+    unique (item_id, label_id)
+);
@@ -0,0 +1,6 @@
+DROP TABLE items_completions;
+
+ALTER TABLE todo_items
+    DROP COLUMN recurrence_start,
+    DROP COLUMN recurrence,
+    DROP COLUMN due_date;
\ No newline at end of file
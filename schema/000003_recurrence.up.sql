@@ -0,0 +1,12 @@
+ALTER TABLE todo_items
+    ADD COLUMN due_date timestamptz,
+    ADD COLUMN recurrence varchar(255),
+    ADD COLUMN recurrence_start timestamptz;
+
+CREATE TABLE items_completions
+(
+    id serial not null unique,
+    item_id int references todo_items(id) on delete cascade not null,
+    due_date timestamptz,
+    completed_at timestamptz not null default now()
+);
@@ -0,0 +1 @@
+DROP TABLE items_dependencies;
\ No newline at end of file
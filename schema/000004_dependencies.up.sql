@@ -0,0 +1,8 @@
+CREATE TABLE items_dependencies
+(
+    id serial not null unique,
+    item_id int references todo_items(id) on delete cascade not null,
+    blocker_id int references todo_items(id) on delete cascade not null,
+    unique (item_id, blocker_id),
+    check (item_id <> blocker_id)
+);
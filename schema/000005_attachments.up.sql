@@ -0,0 +1,14 @@
+CREATE TABLE attachments
+(
+    id serial not null unique,
+    item_id int references todo_items(id) on delete cascade not null,
+    user_id int references users(id) on delete cascade not null,
+    filename varchar(255) not null,
+    content_type varchar(255) not null,
+    size bigint not null,
+    storage_key varchar(255) not null unique,
+    created_at timestamp with time zone not null default now()
+);
+
+CREATE INDEX attachments_item_id_idx ON attachments (item_id);
+CREATE INDEX attachments_user_id_idx ON attachments (user_id);
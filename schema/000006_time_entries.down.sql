@@ -0,0 +1 @@
+DROP TABLE time_entries;
\ No newline at end of file
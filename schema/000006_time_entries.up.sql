@@ -0,0 +1,14 @@
+CREATE TABLE time_entries
+(
+    id serial not null unique,
+    item_id int references todo_items(id) on delete cascade not null,
+    user_id int references users(id) on delete cascade not null,
+    started_at timestamp with time zone not null,
+    stopped_at timestamp with time zone,
+    note varchar(255) not null default '',
+    check (stopped_at >= started_at)
+);
+
+CREATE INDEX time_entries_item_id_idx ON time_entries (item_id);
+CREATE INDEX time_entries_user_id_started_at_idx ON time_entries (user_id, started_at);
+CREATE UNIQUE INDEX time_entries_running_idx ON time_entries (user_id) WHERE stopped_at IS NULL;
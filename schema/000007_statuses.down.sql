@@ -0,0 +1,6 @@
+ALTER TABLE todo_items
+    DROP COLUMN status_id;
+
+DROP TABLE statuses_transitions;
+
+DROP TABLE statuses;
\ No newline at end of file
@@ -0,0 +1,21 @@
+CREATE TABLE statuses
+(
+    id serial not null unique,
+    list_id int references todo_lists(id) on delete cascade not null,
+    name varchar(255) not null,
+    position int not null default 0,
+    is_done boolean not null default false,
+    unique (list_id, name)
+);
+
+CREATE TABLE statuses_transitions
+(
+    id serial not null unique,
+    from_status_id int references statuses(id) on delete cascade not null,
+    to_status_id int references statuses(id) on delete cascade not null,
+    unique (from_status_id, to_status_id),
+    check (from_status_id <> to_status_id)
+);
+
+ALTER TABLE todo_items
+    ADD COLUMN status_id int references statuses(id) on delete set null;
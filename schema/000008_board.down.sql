@@ -0,0 +1,11 @@
+ALTER TABLE labels
+    DROP COLUMN wip_limit;
+
+ALTER TABLE statuses
+    DROP COLUMN wip_limit;
+
+ALTER TABLE items_labels
+    DROP COLUMN position;
+
+ALTER TABLE todo_items
+    DROP COLUMN position;
\ No newline at end of file
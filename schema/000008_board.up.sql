@@ -0,0 +1,11 @@
+ALTER TABLE todo_items
+    ADD COLUMN position int not null default 0;
+
+ALTER TABLE items_labels
+    ADD COLUMN position int not null default 0;
+
+ALTER TABLE statuses
+    ADD COLUMN wip_limit int check (wip_limit > 0);
+
+ALTER TABLE labels
+    ADD COLUMN wip_limit int check (wip_limit > 0);
@@ -0,0 +1,18 @@
+DROP TRIGGER todo_items_touch ON todo_items;
+DROP TRIGGER todo_lists_touch ON todo_lists;
+
+DROP FUNCTION todo_items_touch();
+DROP FUNCTION todo_lists_touch();
+
+ALTER TABLE todo_items
+    DROP COLUMN updated_by,
+    DROP COLUMN created_by,
+    DROP COLUMN completed_at,
+    DROP COLUMN updated_at,
+    DROP COLUMN created_at;
+
+ALTER TABLE todo_lists
+    DROP COLUMN updated_by,
+    DROP COLUMN created_by,
+    DROP COLUMN updated_at,
+    DROP COLUMN created_at;
\ No newline at end of file
@@ -0,0 +1,57 @@
+ALTER TABLE todo_lists
+    ADD COLUMN created_at timestamptz not null default now(),
+    ADD COLUMN updated_at timestamptz not null default now(),
+    ADD COLUMN created_by int references users(id) on delete set null,
+    ADD COLUMN updated_by int references users(id) on delete set null;
+
+ALTER TABLE todo_items
+    ADD COLUMN created_at timestamptz not null default now(),
+    ADD COLUMN updated_at timestamptz not null default now(),
+    ADD COLUMN completed_at timestamptz,
+    ADD COLUMN created_by int references users(id) on delete set null,
+    ADD COLUMN updated_by int references users(id) on delete set null;
+
+UPDATE todo_lists tl SET created_by=ul.user_id, updated_by=ul.user_id
+    FROM users_lists ul WHERE ul.list_id=tl.id;
+
+UPDATE todo_items ti SET created_by=ul.user_id, updated_by=ul.user_id
+    FROM lists_items li, users_lists ul WHERE li.item_id=ti.id AND ul.list_id=li.list_id;
+
+UPDATE todo_items ti SET completed_at=COALESCE(
+        (SELECT MAX(ic.completed_at) FROM items_completions ic WHERE ic.item_id=ti.id), now())
+    WHERE ti.done;
+
+CREATE INDEX todo_lists_updated_at_idx ON todo_lists (updated_at);
+CREATE INDEX todo_items_updated_at_idx ON todo_items (updated_at);
+CREATE INDEX todo_items_completed_at_idx ON todo_items (completed_at);
+
+CREATE FUNCTION todo_lists_touch() RETURNS trigger AS $$
+BEGIN
+    NEW.updated_at := now();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+-- completed_at follows the done flag, whichever statement flips it.
+CREATE FUNCTION todo_items_touch() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'UPDATE' THEN
+        NEW.updated_at := now();
+    END IF;
+    IF NOT NEW.done THEN
+        NEW.completed_at := NULL;
+    ELSIF TG_OP = 'INSERT' OR NOT OLD.done THEN
+        NEW.completed_at := now();
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Only edits of the content count as updates; board reordering, which
+-- shifts the position of neighbouring items, doesn't.
+CREATE TRIGGER todo_lists_touch BEFORE UPDATE OF title, description ON todo_lists
+    FOR EACH ROW EXECUTE PROCEDURE todo_lists_touch();
+
+CREATE TRIGGER todo_items_touch
+    BEFORE INSERT OR UPDATE OF title, description, done, status_id, due_date, recurrence, recurrence_start ON todo_items
+    FOR EACH ROW EXECUTE PROCEDURE todo_items_touch();
@@ -0,0 +1,7 @@
+ALTER TABLE todo_items
+    DROP COLUMN deleted_by,
+    DROP COLUMN deleted_at;
+
+ALTER TABLE todo_lists
+    DROP COLUMN deleted_by,
+    DROP COLUMN deleted_at;
\ No newline at end of file
@@ -0,0 +1,10 @@
+ALTER TABLE todo_lists
+    ADD COLUMN deleted_at timestamptz,
+    ADD COLUMN deleted_by int references users(id) on delete set null;
+
+ALTER TABLE todo_items
+    ADD COLUMN deleted_at timestamptz,
+    ADD COLUMN deleted_by int references users(id) on delete set null;
+
+CREATE INDEX todo_lists_deleted_at_idx ON todo_lists (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX todo_items_deleted_at_idx ON todo_items (deleted_at) WHERE deleted_at IS NOT NULL;
@@ -0,0 +1,20 @@
+CREATE OR REPLACE FUNCTION todo_items_touch() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'UPDATE' THEN
+        NEW.updated_at := now();
+    END IF;
+    IF NOT NEW.done THEN
+        NEW.completed_at := NULL;
+    ELSIF TG_OP = 'INSERT' OR NOT OLD.done THEN
+        NEW.completed_at := now();
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+ALTER TABLE todo_items
+    DROP COLUMN archived_at;
+
+ALTER TABLE todo_lists
+    DROP COLUMN auto_archive_days,
+    DROP COLUMN archived_at;
\ No newline at end of file
@@ -0,0 +1,25 @@
+ALTER TABLE todo_lists
+    ADD COLUMN archived_at timestamptz,
+    ADD COLUMN auto_archive_days int check (auto_archive_days > 0);
+
+ALTER TABLE todo_items
+    ADD COLUMN archived_at timestamptz;
+
+CREATE INDEX todo_items_archive_idx ON todo_items (completed_at) WHERE done AND archived_at IS NULL;
+
+-- Items get archived once they are done for a while; reopening one takes
+-- it out of the archive again.
+CREATE OR REPLACE FUNCTION todo_items_touch() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'UPDATE' THEN
+        NEW.updated_at := now();
+    END IF;
+    IF NOT NEW.done THEN
+        NEW.completed_at := NULL;
+        NEW.archived_at := NULL;
+    ELSIF TG_OP = 'INSERT' OR NOT OLD.done THEN
+        NEW.completed_at := now();
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
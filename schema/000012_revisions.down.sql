@@ -0,0 +1,5 @@
+DROP TRIGGER todo_items_revise ON todo_items;
+
+DROP FUNCTION todo_items_revise();
+
+DROP TABLE items_revisions;
\ No newline at end of file
@@ -0,0 +1,42 @@
+CREATE TABLE items_revisions
+(
+    id serial not null unique,
+    item_id int references todo_items(id) on delete cascade not null,
+    revision int not null,
+    title varchar(255) not null,
+    description varchar(255),
+    done boolean not null,
+    status_id int references statuses(id) on delete set null,
+    due_date timestamptz,
+    recurrence varchar(255),
+    recurrence_start timestamptz,
+    created_by int references users(id) on delete set null,
+    created_at timestamptz not null default now(),
+    unique (item_id, revision)
+);
+
+INSERT INTO items_revisions (item_id, revision, title, description, done, status_id, due_date, recurrence,
+                             recurrence_start, created_by, created_at)
+    SELECT id, 1, title, description, done, status_id, due_date, recurrence, recurrence_start, updated_by, updated_at
+    FROM todo_items;
+
+-- Every change of an item's content is kept as a snapshot of the whole
+-- item, written by whoever the statement names as updated_by.
+CREATE FUNCTION todo_items_revise() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'UPDATE' AND (OLD.title, OLD.description, OLD.done, OLD.status_id, OLD.due_date, OLD.recurrence)
+            IS NOT DISTINCT FROM (NEW.title, NEW.description, NEW.done, NEW.status_id, NEW.due_date, NEW.recurrence) THEN
+        RETURN NULL;
+    END IF;
+    INSERT INTO items_revisions (item_id, revision, title, description, done, status_id, due_date, recurrence,
+                                 recurrence_start, created_by)
+        SELECT NEW.id, COALESCE(MAX(ir.revision), 0) + 1, NEW.title, NEW.description, NEW.done, NEW.status_id,
+               NEW.due_date, NEW.recurrence, NEW.recurrence_start, NEW.updated_by
+        FROM items_revisions ir WHERE ir.item_id=NEW.id;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER todo_items_revise
+    AFTER INSERT OR UPDATE OF title, description, done, status_id, due_date, recurrence ON todo_items
+    FOR EACH ROW EXECUTE PROCEDURE todo_items_revise();
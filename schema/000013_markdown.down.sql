@@ -0,0 +1,8 @@
+ALTER TABLE items_revisions
+    ALTER COLUMN description TYPE varchar(255) USING left(description, 255);
+
+ALTER TABLE todo_items
+    ALTER COLUMN description TYPE varchar(255) USING left(description, 255);
+
+ALTER TABLE todo_lists
+    ALTER COLUMN description TYPE varchar(255) USING left(description, 255);
\ No newline at end of file
@@ -0,0 +1,8 @@
+ALTER TABLE todo_lists
+    ALTER COLUMN description TYPE text;
+
+ALTER TABLE todo_items
+    ALTER COLUMN description TYPE text;
+
+ALTER TABLE items_revisions
+    ALTER COLUMN description TYPE text;
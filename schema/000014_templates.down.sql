@@ -0,0 +1,5 @@
+DROP TABLE template_items_labels;
+
+DROP TABLE template_items;
+
+DROP TABLE templates;
\ No newline at end of file
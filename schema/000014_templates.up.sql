@@ -0,0 +1,36 @@
+CREATE TABLE templates
+(
+    id serial not null unique,
+    user_id int references users(id) on delete cascade not null,
+    title varchar(255) not null,
+    description text not null default '',
+    shared boolean not null default false,
+    created_at timestamptz not null default now()
+);
+
+-- due_offset is in seconds from the start date a template is instantiated
+-- with; items without a due date have none.
+CREATE TABLE template_items
+(
+    id serial not null unique,
+    template_id int references templates(id) on delete cascade not null,
+    position int not null,
+    title varchar(255) not null,
+    description text not null default '',
+    due_offset bigint,
+    recurrence varchar(255),
+    unique (template_id, position)
+);
+
+-- Labels are kept by name, as the user instantiating a shared template has
+-- labels of their own; missing ones are created.
+CREATE TABLE template_items_labels
+(
+    id serial not null unique,
+    template_item_id int references template_items(id) on delete cascade not null,
+    name varchar(255) not null,
+    color varchar(7) not null
+);
+
+CREATE INDEX templates_user_id_idx ON templates (user_id);
+CREATE INDEX templates_shared_idx ON templates (shared) WHERE shared;
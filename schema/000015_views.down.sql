@@ -0,0 +1 @@
+DROP TABLE views;
\ No newline at end of file
@@ -0,0 +1,12 @@
+-- query holds the saved filter as JSON, see structs.ViewQuery.
+CREATE TABLE views
+(
+    id serial not null unique,
+    user_id int references users(id) on delete cascade not null,
+    name varchar(255) not null,
+    query jsonb not null default '{}',
+    created_at timestamptz not null default now(),
+    updated_at timestamptz not null default now()
+);
+
+CREATE INDEX views_user_id_idx ON views (user_id);
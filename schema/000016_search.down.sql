@@ -0,0 +1,5 @@
+ALTER TABLE todo_items
+    DROP COLUMN search_vector;
+
+ALTER TABLE todo_lists
+    DROP COLUMN search_vector;
\ No newline at end of file
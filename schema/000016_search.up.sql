@@ -0,0 +1,14 @@
+-- Titles weigh more than descriptions in the ranking. The text search
+-- configuration has to match searchConfig in the repository.
+ALTER TABLE todo_lists
+    ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector('english', title), 'A') || setweight(to_tsvector('english', coalesce(description, '')), 'B')
+    ) STORED;
+
+ALTER TABLE todo_items
+    ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector('english', title), 'A') || setweight(to_tsvector('english', coalesce(description, '')), 'B')
+    ) STORED;
+
+CREATE INDEX todo_lists_search_idx ON todo_lists USING gin (search_vector);
+CREATE INDEX todo_items_search_idx ON todo_items USING gin (search_vector);
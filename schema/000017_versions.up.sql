@@ -0,0 +1,21 @@
+ALTER TABLE todo_lists
+    ADD COLUMN version int not null default 1;
+
+ALTER TABLE todo_items
+    ADD COLUMN version int not null default 1;
+
+CREATE FUNCTION bump_version() RETURNS trigger AS $$
+BEGIN
+    NEW.version := OLD.version + 1;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+-- The version counts edits of the fields clients can replace, whichever
+-- statement makes them, so If-Match checks see every one of them.
+CREATE TRIGGER todo_lists_version BEFORE UPDATE OF title, description, auto_archive_days ON todo_lists
+    FOR EACH ROW EXECUTE PROCEDURE bump_version();
+
+CREATE TRIGGER todo_items_version
+    BEFORE UPDATE OF title, description, done, status_id, due_date, recurrence, recurrence_start ON todo_items
+    FOR EACH ROW EXECUTE PROCEDURE bump_version();
@@ -0,0 +1,15 @@
+-- A row claims a key for the first request made with it; status stays 0
+-- until its response is stored, which later requests with the key replay.
+CREATE TABLE idempotency_keys
+(
+    user_id int references users(id) on delete cascade not null,
+    key varchar(255) not null,
+    fingerprint varchar(64) not null,
+    status int not null default 0,
+    content_type varchar(255) not null default '',
+    body bytea,
+    created_at timestamptz not null default now(),
+    PRIMARY KEY (user_id, key)
+);
+
+CREATE INDEX idempotency_keys_created_at_idx ON idempotency_keys (created_at);
@@ -0,0 +1,12 @@
+DROP TRIGGER items_completions_undo ON items_completions;
+DROP TRIGGER items_labels_undo ON items_labels;
+DROP TRIGGER lists_items_undo ON lists_items;
+DROP TRIGGER todo_items_undo ON todo_items;
+DROP TRIGGER users_lists_undo ON users_lists;
+DROP TRIGGER todo_lists_undo ON todo_lists;
+
+DROP FUNCTION record_undo_change();
+
+DROP TABLE undo_changes;
+
+DROP TABLE undo_actions;
\ No newline at end of file
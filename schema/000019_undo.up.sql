@@ -0,0 +1,64 @@
+-- An undo action stands for one request; its token is handed to the client.
+CREATE TABLE undo_actions
+(
+    token varchar(36) not null primary key,
+    user_id int references users(id) on delete cascade not null,
+    created_at timestamptz not null default now()
+);
+
+CREATE INDEX undo_actions_created_at_idx ON undo_actions (created_at);
+
+-- The rows as they were before and after each change made for an action.
+-- old_row is null for an inserted row and new_row for a deleted one.
+CREATE TABLE undo_changes
+(
+    id bigserial not null unique,
+    token varchar(36) references undo_actions(token) on delete cascade not null,
+    table_name varchar(64) not null,
+    row_id int not null,
+    old_row jsonb,
+    new_row jsonb
+);
+
+CREATE INDEX undo_changes_token_idx ON undo_changes (token);
+
+-- Changes are only recorded in transactions that set todo.undo_token,
+-- whatever statement makes them, triggers included.
+CREATE FUNCTION record_undo_change() RETURNS trigger AS $$
+DECLARE
+    undo_token text := current_setting('todo.undo_token', true);
+BEGIN
+    IF undo_token IS NULL OR undo_token = '' THEN
+        RETURN NULL;
+    END IF;
+    IF TG_OP = 'INSERT' THEN
+        INSERT INTO undo_changes (token, table_name, row_id, new_row)
+            VALUES (undo_token, TG_TABLE_NAME, NEW.id, to_jsonb(NEW));
+    ELSIF TG_OP = 'DELETE' THEN
+        INSERT INTO undo_changes (token, table_name, row_id, old_row)
+            VALUES (undo_token, TG_TABLE_NAME, OLD.id, to_jsonb(OLD));
+    ELSIF to_jsonb(OLD) IS DISTINCT FROM to_jsonb(NEW) THEN
+        INSERT INTO undo_changes (token, table_name, row_id, old_row, new_row)
+            VALUES (undo_token, TG_TABLE_NAME, NEW.id, to_jsonb(OLD), to_jsonb(NEW));
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER todo_lists_undo AFTER INSERT OR UPDATE OR DELETE ON todo_lists
+    FOR EACH ROW EXECUTE PROCEDURE record_undo_change();
+
+CREATE TRIGGER users_lists_undo AFTER INSERT OR UPDATE OR DELETE ON users_lists
+    FOR EACH ROW EXECUTE PROCEDURE record_undo_change();
+
+CREATE TRIGGER todo_items_undo AFTER INSERT OR UPDATE OR DELETE ON todo_items
+    FOR EACH ROW EXECUTE PROCEDURE record_undo_change();
+
+CREATE TRIGGER lists_items_undo AFTER INSERT OR UPDATE OR DELETE ON lists_items
+    FOR EACH ROW EXECUTE PROCEDURE record_undo_change();
+
+CREATE TRIGGER items_labels_undo AFTER INSERT OR UPDATE OR DELETE ON items_labels
+    FOR EACH ROW EXECUTE PROCEDURE record_undo_change();
+
+CREATE TRIGGER items_completions_undo AFTER INSERT OR UPDATE OR DELETE ON items_completions
+    FOR EACH ROW EXECUTE PROCEDURE record_undo_change();
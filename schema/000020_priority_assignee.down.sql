@@ -0,0 +1,40 @@
+DROP TRIGGER todo_items_revise ON todo_items;
+
+CREATE TRIGGER todo_items_revise
+    AFTER INSERT OR UPDATE OF title, description, done, status_id, due_date, recurrence ON todo_items
+    FOR EACH ROW EXECUTE PROCEDURE todo_items_revise();
+
+CREATE OR REPLACE FUNCTION todo_items_revise() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'UPDATE' AND (OLD.title, OLD.description, OLD.done, OLD.status_id, OLD.due_date, OLD.recurrence)
+            IS NOT DISTINCT FROM (NEW.title, NEW.description, NEW.done, NEW.status_id, NEW.due_date, NEW.recurrence) THEN
+        RETURN NULL;
+    END IF;
+    INSERT INTO items_revisions (item_id, revision, title, description, done, status_id, due_date, recurrence,
+                                 recurrence_start, created_by)
+        SELECT NEW.id, COALESCE(MAX(ir.revision), 0) + 1, NEW.title, NEW.description, NEW.done, NEW.status_id,
+               NEW.due_date, NEW.recurrence, NEW.recurrence_start, NEW.updated_by
+        FROM items_revisions ir WHERE ir.item_id=NEW.id;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER todo_items_touch ON todo_items;
+
+CREATE TRIGGER todo_items_touch
+    BEFORE INSERT OR UPDATE OF title, description, done, status_id, due_date, recurrence, recurrence_start ON todo_items
+    FOR EACH ROW EXECUTE PROCEDURE todo_items_touch();
+
+DROP TRIGGER todo_items_version ON todo_items;
+
+CREATE TRIGGER todo_items_version
+    BEFORE UPDATE OF title, description, done, status_id, due_date, recurrence, recurrence_start ON todo_items
+    FOR EACH ROW EXECUTE PROCEDURE bump_version();
+
+ALTER TABLE items_revisions
+    DROP COLUMN assignee_id,
+    DROP COLUMN priority;
+
+ALTER TABLE todo_items
+    DROP COLUMN assignee_id,
+    DROP COLUMN priority;
@@ -0,0 +1,48 @@
+-- priority ranks items from 0, none, up to 4, urgent; see structs.Priority.
+ALTER TABLE todo_items
+    ADD COLUMN priority smallint not null default 0 check (priority BETWEEN 0 AND 4),
+    ADD COLUMN assignee_id int references users(id) on delete set null;
+
+CREATE INDEX todo_items_assignee_id_idx ON todo_items (assignee_id);
+
+ALTER TABLE items_revisions
+    ADD COLUMN priority smallint not null default 0,
+    ADD COLUMN assignee_id int references users(id) on delete set null;
+
+DROP TRIGGER todo_items_version ON todo_items;
+
+CREATE TRIGGER todo_items_version
+    BEFORE UPDATE OF title, description, done, status_id, due_date, recurrence, recurrence_start, priority, assignee_id
+    ON todo_items
+    FOR EACH ROW EXECUTE PROCEDURE bump_version();
+
+DROP TRIGGER todo_items_touch ON todo_items;
+
+CREATE TRIGGER todo_items_touch
+    BEFORE INSERT OR UPDATE OF title, description, done, status_id, due_date, recurrence, recurrence_start, priority,
+    assignee_id ON todo_items
+    FOR EACH ROW EXECUTE PROCEDURE todo_items_touch();
+
+CREATE OR REPLACE FUNCTION todo_items_revise() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'UPDATE' AND (OLD.title, OLD.description, OLD.done, OLD.status_id, OLD.due_date, OLD.recurrence,
+                             OLD.priority, OLD.assignee_id)
+            IS NOT DISTINCT FROM (NEW.title, NEW.description, NEW.done, NEW.status_id, NEW.due_date, NEW.recurrence,
+                                  NEW.priority, NEW.assignee_id) THEN
+        RETURN NULL;
+    END IF;
+    INSERT INTO items_revisions (item_id, revision, title, description, done, status_id, due_date, recurrence,
+                                 recurrence_start, priority, assignee_id, created_by)
+        SELECT NEW.id, COALESCE(MAX(ir.revision), 0) + 1, NEW.title, NEW.description, NEW.done, NEW.status_id,
+               NEW.due_date, NEW.recurrence, NEW.recurrence_start, NEW.priority, NEW.assignee_id, NEW.updated_by
+        FROM items_revisions ir WHERE ir.item_id=NEW.id;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER todo_items_revise ON todo_items;
+
+CREATE TRIGGER todo_items_revise
+    AFTER INSERT OR UPDATE OF title, description, done, status_id, due_date, recurrence, priority, assignee_id
+    ON todo_items
+    FOR EACH ROW EXECUTE PROCEDURE todo_items_revise();
@@ -0,0 +1,29 @@
+ALTER TABLE todo_items
+    DROP COLUMN search_vector,
+    ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector('english', title), 'A') || setweight(to_tsvector('english', coalesce(description, '')), 'B')
+    ) STORED;
+
+ALTER TABLE todo_lists
+    DROP COLUMN search_vector,
+    ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector('english', title), 'A') || setweight(to_tsvector('english', coalesce(description, '')), 'B')
+    ) STORED;
+
+CREATE INDEX todo_lists_search_idx ON todo_lists USING gin (search_vector);
+CREATE INDEX todo_items_search_idx ON todo_items USING gin (search_vector);
+
+DROP TRIGGER todo_items_search_language ON todo_items;
+
+DROP TRIGGER todo_lists_search_language ON todo_lists;
+
+DROP FUNCTION set_search_language();
+
+ALTER TABLE todo_items
+    DROP COLUMN search_language;
+
+ALTER TABLE todo_lists
+    DROP COLUMN search_language;
+
+ALTER TABLE users
+    DROP COLUMN search_language;
\ No newline at end of file
@@ -0,0 +1,42 @@
+-- search_language is the text search configuration a document is stemmed
+-- with. Lists and items take the one of the user creating them, and are
+-- searched with their own, so documents in other languages still match.
+ALTER TABLE users
+    ADD COLUMN search_language regconfig not null default 'english';
+
+ALTER TABLE todo_lists
+    ADD COLUMN search_language regconfig not null default 'english';
+
+ALTER TABLE todo_items
+    ADD COLUMN search_language regconfig not null default 'english';
+
+CREATE FUNCTION set_search_language() RETURNS trigger AS $$
+BEGIN
+    SELECT u.search_language INTO NEW.search_language FROM users u WHERE u.id = NEW.created_by;
+    NEW.search_language := coalesce(NEW.search_language, 'english');
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER todo_lists_search_language BEFORE INSERT ON todo_lists
+    FOR EACH ROW EXECUTE PROCEDURE set_search_language();
+
+CREATE TRIGGER todo_items_search_language BEFORE INSERT ON todo_items
+    FOR EACH ROW EXECUTE PROCEDURE set_search_language();
+
+ALTER TABLE todo_lists
+    DROP COLUMN search_vector,
+    ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector(search_language, title), 'A') ||
+        setweight(to_tsvector(search_language, coalesce(description, '')), 'B')
+    ) STORED;
+
+ALTER TABLE todo_items
+    DROP COLUMN search_vector,
+    ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector(search_language, title), 'A') ||
+        setweight(to_tsvector(search_language, coalesce(description, '')), 'B')
+    ) STORED;
+
+CREATE INDEX todo_lists_search_idx ON todo_lists USING gin (search_vector);
+CREATE INDEX todo_items_search_idx ON todo_items USING gin (search_vector);
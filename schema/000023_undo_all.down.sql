@@ -0,0 +1,28 @@
+CREATE OR REPLACE FUNCTION todo_items_revise() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'UPDATE' AND (OLD.title, OLD.description, OLD.done, OLD.status_id, OLD.due_date, OLD.recurrence,
+                             OLD.priority, OLD.assignee_id)
+            IS NOT DISTINCT FROM (NEW.title, NEW.description, NEW.done, NEW.status_id, NEW.due_date, NEW.recurrence,
+                                  NEW.priority, NEW.assignee_id) THEN
+        RETURN NULL;
+    END IF;
+    INSERT INTO items_revisions (item_id, revision, title, description, done, status_id, due_date, recurrence,
+                                 recurrence_start, priority, assignee_id, created_by)
+        SELECT NEW.id, COALESCE(MAX(ir.revision), 0) + 1, NEW.title, NEW.description, NEW.done, NEW.status_id,
+               NEW.due_date, NEW.recurrence, NEW.recurrence_start, NEW.priority, NEW.assignee_id, NEW.updated_by
+        FROM items_revisions ir WHERE ir.item_id=NEW.id;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER items_revisions_undo ON items_revisions;
+DROP TRIGGER views_undo ON views;
+DROP TRIGGER template_items_labels_undo ON template_items_labels;
+DROP TRIGGER template_items_undo ON template_items;
+DROP TRIGGER templates_undo ON templates;
+DROP TRIGGER statuses_transitions_undo ON statuses_transitions;
+DROP TRIGGER statuses_undo ON statuses;
+DROP TRIGGER time_entries_undo ON time_entries;
+DROP TRIGGER attachments_undo ON attachments;
+DROP TRIGGER items_dependencies_undo ON items_dependencies;
+DROP TRIGGER labels_undo ON labels;
@@ -0,0 +1,57 @@
+-- Every change a request can make is journaled, so every one of them can
+-- be undone.
+CREATE TRIGGER labels_undo AFTER INSERT OR UPDATE OR DELETE ON labels
+    FOR EACH ROW EXECUTE PROCEDURE record_undo_change();
+
+CREATE TRIGGER items_dependencies_undo AFTER INSERT OR UPDATE OR DELETE ON items_dependencies
+    FOR EACH ROW EXECUTE PROCEDURE record_undo_change();
+
+CREATE TRIGGER attachments_undo AFTER INSERT OR UPDATE OR DELETE ON attachments
+    FOR EACH ROW EXECUTE PROCEDURE record_undo_change();
+
+CREATE TRIGGER time_entries_undo AFTER INSERT OR UPDATE OR DELETE ON time_entries
+    FOR EACH ROW EXECUTE PROCEDURE record_undo_change();
+
+CREATE TRIGGER statuses_undo AFTER INSERT OR UPDATE OR DELETE ON statuses
+    FOR EACH ROW EXECUTE PROCEDURE record_undo_change();
+
+CREATE TRIGGER statuses_transitions_undo AFTER INSERT OR UPDATE OR DELETE ON statuses_transitions
+    FOR EACH ROW EXECUTE PROCEDURE record_undo_change();
+
+CREATE TRIGGER templates_undo AFTER INSERT OR UPDATE OR DELETE ON templates
+    FOR EACH ROW EXECUTE PROCEDURE record_undo_change();
+
+CREATE TRIGGER template_items_undo AFTER INSERT OR UPDATE OR DELETE ON template_items
+    FOR EACH ROW EXECUTE PROCEDURE record_undo_change();
+
+CREATE TRIGGER template_items_labels_undo AFTER INSERT OR UPDATE OR DELETE ON template_items_labels
+    FOR EACH ROW EXECUTE PROCEDURE record_undo_change();
+
+CREATE TRIGGER views_undo AFTER INSERT OR UPDATE OR DELETE ON views
+    FOR EACH ROW EXECUTE PROCEDURE record_undo_change();
+
+-- Revisions are history: undoing an edit is an edit of its own, so only
+-- the revisions purged along with an item are journaled, and putting the
+-- item back doesn't write a first revision next to the ones coming back.
+CREATE TRIGGER items_revisions_undo AFTER DELETE ON items_revisions
+    FOR EACH ROW EXECUTE PROCEDURE record_undo_change();
+
+CREATE OR REPLACE FUNCTION todo_items_revise() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'INSERT' AND current_setting('todo.undoing', true) = 'on' THEN
+        RETURN NULL;
+    END IF;
+    IF TG_OP = 'UPDATE' AND (OLD.title, OLD.description, OLD.done, OLD.status_id, OLD.due_date, OLD.recurrence,
+                             OLD.priority, OLD.assignee_id)
+            IS NOT DISTINCT FROM (NEW.title, NEW.description, NEW.done, NEW.status_id, NEW.due_date, NEW.recurrence,
+                                  NEW.priority, NEW.assignee_id) THEN
+        RETURN NULL;
+    END IF;
+    INSERT INTO items_revisions (item_id, revision, title, description, done, status_id, due_date, recurrence,
+                                 recurrence_start, priority, assignee_id, created_by)
+        SELECT NEW.id, COALESCE(MAX(ir.revision), 0) + 1, NEW.title, NEW.description, NEW.done, NEW.status_id,
+               NEW.due_date, NEW.recurrence, NEW.recurrence_start, NEW.priority, NEW.assignee_id, NEW.updated_by
+        FROM items_revisions ir WHERE ir.item_id=NEW.id;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
@@ -10,13 +10,16 @@ type Server struct {
 	httpServer *http.Server
 }
 
-func (s *Server) Run(port string, handler http.Handler) error {
+// Run starts the server. Read and write timeouts cover whole request and
+// response bodies, so they have to fit the largest upload and download.
+func (s *Server) Run(port string, handler http.Handler, readTimeout, writeTimeout time.Duration) error {
 	s.httpServer = &http.Server{
-		Addr:           ":" + port,
-		Handler:        handler,
-		MaxHeaderBytes: 1 << 28,
-		ReadTimeout:    1 * time.Second,
-		WriteTimeout:   1 * time.Second,
+		Addr:              ":" + port,
+		Handler:           handler,
+		MaxHeaderBytes:    1 << 28,
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       readTimeout,
+		WriteTimeout:      writeTimeout,
 	}
 
 	return s.httpServer.ListenAndServe()
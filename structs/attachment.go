@@ -0,0 +1,23 @@
+package structs
+
+import (
+	"io"
+	"time"
+)
+
+type Attachment struct {
+	Id          int       `json:"id" db:"id"`
+	ItemId      int       `json:"item_id" db:"item_id"`
+	UserId      int       `json:"-" db:"user_id"`
+	Filename    string    `json:"filename" db:"filename"`
+	ContentType string    `json:"content_type" db:"content_type"`
+	Size        int64     `json:"size" db:"size"`
+	StorageKey  string    `json:"-" db:"storage_key"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+}
+
+type AttachmentUpload struct {
+	Filename    string
+	ContentType string
+	Reader      io.Reader
+}
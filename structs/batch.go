@@ -0,0 +1,123 @@
+package structs
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	BatchAtomic     = "atomic"
+	BatchBestEffort = "best_effort"
+)
+
+const (
+	OpCreate = "create"
+	OpUpdate = "update"
+	OpMove   = "move"
+	OpDelete = "delete"
+)
+
+var ErrInvalidOperation = errors.New("invalid batch operation")
+
+// ItemBatchInput is a batch of item operations. In atomic mode, the
+// default, they are all applied or none is; best effort applies the ones
+// that succeed.
+type ItemBatchInput struct {
+	Mode       string          `json:"mode" binding:"omitempty,oneof=atomic best_effort"`
+	Operations []ItemOperation `json:"operations" binding:"required,min=1,max=500,dive"`
+}
+
+// ItemOperation creates an item in ListId, updates the item Id, moves it to
+// ListId or deletes it.
+type ItemOperation struct {
+	Op     string           `json:"op" binding:"required,oneof=create update move delete"`
+	Id     int              `json:"id"`
+	ListId int              `json:"list_id"`
+	Item   *Item            `json:"item"`
+	Update *UpdateItemInput `json:"update"`
+}
+
+func (o ItemOperation) Validate() error {
+	var missing bool
+	var needs string
+	switch o.Op {
+	case OpCreate:
+		missing, needs = o.ListId == 0 || o.Item == nil, "list_id and item"
+	case OpUpdate:
+		missing, needs = o.Id == 0 || o.Update == nil, "id and update"
+	case OpMove:
+		missing, needs = o.Id == 0 || o.ListId == 0, "id and list_id"
+	default:
+		missing, needs = o.Id == 0, "id"
+	}
+	if missing {
+		return fmt.Errorf("%w: %s needs %s", ErrInvalidOperation, o.Op, needs)
+	}
+	return nil
+}
+
+// ListBatchInput is a batch of list operations, see ItemBatchInput.
+type ListBatchInput struct {
+	Mode       string          `json:"mode" binding:"omitempty,oneof=atomic best_effort"`
+	Operations []ListOperation `json:"operations" binding:"required,min=1,max=500,dive"`
+}
+
+// ListOperation creates a list, updates the list Id or deletes it.
+type ListOperation struct {
+	Op     string           `json:"op" binding:"required,oneof=create update delete"`
+	Id     int              `json:"id"`
+	List   *List            `json:"list"`
+	Update *UpdateListInput `json:"update"`
+}
+
+func (o ListOperation) Validate() error {
+	var missing bool
+	var needs string
+	switch o.Op {
+	case OpCreate:
+		missing, needs = o.List == nil, "list"
+	case OpUpdate:
+		missing, needs = o.Id == 0 || o.Update == nil, "id and update"
+	default:
+		missing, needs = o.Id == 0, "id"
+	}
+	if missing {
+		return fmt.Errorf("%w: %s needs %s", ErrInvalidOperation, o.Op, needs)
+	}
+	return nil
+}
+
+// ItemChange is an item operation the service checked, as the repository
+// applies it.
+type ItemChange struct {
+	Op     string
+	ItemId int
+	ListId int
+	Item   Item
+	// Update is nil when the update only completes a recurring item.
+	Update *UpdateItemInput
+	// Complete records a completion of the item, which moves on to Next
+	// when it recurs.
+	Complete bool
+	Next     *time.Time
+	// Version is the version of the item the change must be made to, or
+	// nil for any. The update carries it too.
+	Version *int
+}
+
+// ListChange is a list operation the service checked, as the repository
+// applies it.
+type ListChange struct {
+	Op     string
+	ListId int
+	List   List
+	Update UpdateListInput
+}
+
+// BatchResult is the outcome of an operation of a batch: the id of the list
+// or item it was applied to, or why it failed.
+type BatchResult struct {
+	Id  int
+	Err error
+}
@@ -0,0 +1,41 @@
+package structs
+
+const (
+	BoardByStatus = "status"
+	BoardByLabel  = "label"
+)
+
+type Board struct {
+	GroupBy string        `json:"group_by"`
+	Columns []BoardColumn `json:"columns"`
+}
+
+// BoardColumn holds the items of one status or label in board order. The
+// column without an id collects the items that have no status or label.
+type BoardColumn struct {
+	Id       *int   `json:"id"`
+	Name     string `json:"name"`
+	WipLimit *int   `json:"wip_limit,omitempty"`
+	Items    []Item `json:"items"`
+}
+
+type BoardPosition struct {
+	ItemId   int `db:"item_id"`
+	ColumnId int `db:"column_id"`
+	Position int `db:"position"`
+}
+
+// BoardMoveInput moves an item into a column at the given position. On a
+// label board FromColumnId names the label the item leaves; without it the
+// item gets the target label in addition to its others.
+type BoardMoveInput struct {
+	GroupBy      string `json:"group_by" binding:"omitempty,oneof=status label"`
+	ItemId       int    `json:"item_id" binding:"required"`
+	FromColumnId *int   `json:"from_column_id"`
+	ColumnId     int    `json:"column_id" binding:"required"`
+	Position     int    `json:"position" binding:"min=0"`
+}
+
+type BoardQuery struct {
+	GroupBy string `form:"group_by" binding:"omitempty,oneof=status label"`
+}
@@ -0,0 +1,49 @@
+package structs
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// IdempotentResponse is the response stored for a request made with an
+// Idempotency-Key header.
+type IdempotentResponse struct {
+	Status      int    `db:"status"`
+	ContentType string `db:"content_type"`
+	Body        []byte `db:"body"`
+	// Headers are the response headers replayed along with the body, such
+	// as the undo token.
+	Headers ResponseHeaders `db:"headers"`
+}
+
+// ResponseHeaders maps header names to their values.
+type ResponseHeaders map[string]string
+
+// Value stores the headers in their jsonb column.
+func (h ResponseHeaders) Value() (driver.Value, error) {
+	if h == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(map[string]string(h))
+}
+
+func (h *ResponseHeaders) Scan(src interface{}) error {
+	switch data := src.(type) {
+	case []byte:
+		return json.Unmarshal(data, h)
+	case string:
+		return json.Unmarshal([]byte(data), h)
+	}
+	return errors.New("unsupported response headers value")
+}
+
+// IdempotencyKey is a key claimed by a request. Fingerprint identifies the
+// request; Status is zero while the request is still in progress.
+type IdempotencyKey struct {
+	Key         string    `db:"key"`
+	Fingerprint string    `db:"fingerprint"`
+	CreatedAt   time.Time `db:"created_at"`
+	IdempotentResponse
+}
@@ -0,0 +1,35 @@
+package structs
+
+import "errors"
+
+type Label struct {
+	Id       int    `json:"id" db:"id"`
+	Name     string `json:"name" binding:"required,max=255" maxLength:"255" db:"name"`
+	Color    string `json:"color" binding:"omitempty,hexcolor" db:"color"`
+	WipLimit *int   `json:"wip_limit,omitempty" binding:"omitempty,min=1" db:"wip_limit"`
+}
+
+type ItemsLabel struct {
+	Id      int
+	ItemId  int
+	LabelId int
+}
+
+type ItemLabel struct {
+	ItemId int `db:"item_id"`
+	Label
+}
+
+// UpdateLabelInput changes a label; a zero WipLimit removes the limit.
+type UpdateLabelInput struct {
+	Name     *string `json:"name" binding:"omitempty,max=255" maxLength:"255"`
+	Color    *string `json:"color" binding:"omitempty,hexcolor"`
+	WipLimit *int    `json:"wip_limit" binding:"omitempty,min=0"`
+}
+
+func (i UpdateLabelInput) Validate() error {
+	if i.Name == nil && i.Color == nil && i.WipLimit == nil {
+		return errors.New("update stru has no values")
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package structs
+
+import "time"
+
+// ItemRevision is a snapshot of an item's content after one of its changes.
+// Changes names the fields that differ from the revision before.
+type ItemRevision struct {
+	ItemId          int        `json:"item_id" db:"item_id"`
+	Revision        int        `json:"revision" db:"revision"`
+	Title           string     `json:"title" db:"title"`
+	Description     string     `json:"description" db:"description"`
+	Done            bool       `json:"done" db:"done"`
+	StatusId        *int       `json:"status_id,omitempty" db:"status_id"`
+	DueDate         *time.Time `json:"due_date,omitempty" db:"due_date"`
+	Recurrence      *string    `json:"recurrence,omitempty" db:"recurrence"`
+	RecurrenceStart *time.Time `json:"-" db:"recurrence_start"`
+	Priority        Priority   `json:"priority,omitempty" swaggertype:"string" enums:"none,low,medium,high,urgent" db:"priority"`
+	AssigneeId      *int       `json:"assignee_id,omitempty" db:"assignee_id"`
+	CreatedBy       *int       `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
+	Changes         []string   `json:"changes" db:"-"`
+}
+
+type RevisionChange struct {
+	Field string      `json:"field"`
+	From  interface{} `json:"from"`
+	To    interface{} `json:"to"`
+}
+
+type RevisionDiff struct {
+	From    int              `json:"from"`
+	To      int              `json:"to"`
+	Changes []RevisionChange `json:"changes"`
+}
+
+// RevisionDiffQuery picks the revision a diff starts from; without it the
+// diff is against the revision right before.
+type RevisionDiffQuery struct {
+	From *int `form:"from" binding:"omitempty,min=0"`
+}
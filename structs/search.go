@@ -0,0 +1,37 @@
+package structs
+
+const (
+	SearchTypeList = "list"
+	SearchTypeItem = "item"
+)
+
+// SearchQuery is a web search style query: words, "quoted phrases", or and
+// -excluded words. Type narrows the search to lists or items; Limit and
+// Offset page through each group of results.
+type SearchQuery struct {
+	Q      string `form:"q" binding:"required,max=255"`
+	Type   string `form:"type" binding:"omitempty,oneof=list item"`
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset int    `form:"offset" binding:"omitempty,min=0"`
+}
+
+// SearchHit is a matching list or item. Snippet is HTML escaped text with
+// the matches wrapped in <mark> tags.
+type SearchHit struct {
+	Id      int     `json:"id" db:"id"`
+	ListId  *int    `json:"list_id,omitempty" db:"list_id"`
+	Title   string  `json:"title" db:"title"`
+	Snippet string  `json:"snippet" db:"snippet"`
+	Rank    float64 `json:"rank" db:"rank"`
+}
+
+type SearchGroup struct {
+	Total int         `json:"total"`
+	Hits  []SearchHit `json:"hits"`
+}
+
+// SearchResults groups the hits by type, best ranked first.
+type SearchResults struct {
+	Lists *SearchGroup `json:"lists,omitempty"`
+	Items *SearchGroup `json:"items,omitempty"`
+}
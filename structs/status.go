@@ -0,0 +1,45 @@
+package structs
+
+import "errors"
+
+// Status is a step of a list's workflow. Items in a status with IsDone set
+// count as done. When Transitions is not empty, items may only move from
+// this status to the statuses listed there. WipLimit caps the number of
+// items a board can hold in the status.
+type Status struct {
+	Id          int    `json:"id" db:"id"`
+	ListId      int    `json:"list_id" db:"list_id"`
+	Name        string `json:"name" db:"name"`
+	Position    int    `json:"position" db:"position"`
+	IsDone      bool   `json:"is_done" db:"is_done"`
+	WipLimit    *int   `json:"wip_limit,omitempty" db:"wip_limit"`
+	Transitions []int  `json:"transitions,omitempty" db:"-"`
+}
+
+type StatusTransition struct {
+	FromStatusId int `db:"from_status_id"`
+	ToStatusId   int `db:"to_status_id"`
+}
+
+type StatusInput struct {
+	Name        string `json:"name" binding:"required,max=255"`
+	IsDone      bool   `json:"is_done"`
+	WipLimit    *int   `json:"wip_limit" binding:"omitempty,min=1"`
+	Transitions []int  `json:"transitions"`
+}
+
+// UpdateStatusInput changes a status; a zero WipLimit removes the limit.
+type UpdateStatusInput struct {
+	Name        *string `json:"name" binding:"omitempty,max=255"`
+	Position    *int    `json:"position" binding:"omitempty,min=0"`
+	IsDone      *bool   `json:"is_done"`
+	WipLimit    *int    `json:"wip_limit" binding:"omitempty,min=0"`
+	Transitions *[]int  `json:"transitions"`
+}
+
+func (i UpdateStatusInput) Validate() error {
+	if i.Name == nil && i.Position == nil && i.IsDone == nil && i.WipLimit == nil && i.Transitions == nil {
+		return errors.New("update stru has no values")
+	}
+	return nil
+}
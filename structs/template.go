@@ -0,0 +1,59 @@
+package structs
+
+import "time"
+
+const (
+	TemplateScopeOwn       = "own"
+	TemplateScopeWorkspace = "workspace"
+)
+
+// Template is a list saved for reuse. Shared templates are offered to
+// everyone in the workspace; the others only to their owner.
+type Template struct {
+	Id          int            `json:"id" db:"id"`
+	UserId      int            `json:"user_id" db:"user_id"`
+	Title       string         `json:"title" db:"title"`
+	Description string         `json:"description" db:"description"`
+	Shared      bool           `json:"shared" db:"shared"`
+	CreatedAt   time.Time      `json:"created_at" db:"created_at"`
+	Items       []TemplateItem `json:"items,omitempty" db:"-"`
+}
+
+// TemplateItem is an item of a template; DueOffset is the number of
+// seconds its due date lies after the start date.
+type TemplateItem struct {
+	Id          int             `json:"-" db:"id"`
+	Position    int             `json:"position" db:"position"`
+	Title       string          `json:"title" db:"title"`
+	Description string          `json:"description" db:"description"`
+	DueOffset   *int64          `json:"due_offset,omitempty" db:"due_offset"`
+	Recurrence  *string         `json:"recurrence,omitempty" db:"recurrence"`
+	Labels      []TemplateLabel `json:"labels,omitempty" db:"-"`
+}
+
+type TemplateLabel struct {
+	TemplateItemId int    `json:"-" db:"template_item_id"`
+	Name           string `json:"name" db:"name"`
+	Color          string `json:"color" db:"color"`
+}
+
+// TemplateInput saves a list as a template. Due offsets are taken from
+// StartDate, by default the earliest due date in the list. The title
+// defaults to the list's.
+type TemplateInput struct {
+	ListId    int        `json:"list_id" binding:"required"`
+	Title     string     `json:"title" binding:"max=255" maxLength:"255"`
+	Shared    bool       `json:"shared"`
+	StartDate *time.Time `json:"start_date"`
+}
+
+type TemplateFilter struct {
+	Scope string `form:"scope" binding:"omitempty,oneof=own workspace"`
+}
+
+// InstantiateTemplateInput creates a list from a template, its items due at
+// StartDate plus their offset.
+type InstantiateTemplateInput struct {
+	Title     string    `json:"title" binding:"max=255" maxLength:"255"`
+	StartDate time.Time `json:"start_date" binding:"required"`
+}
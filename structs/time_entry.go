@@ -0,0 +1,51 @@
+package structs
+
+import (
+	"errors"
+	"time"
+)
+
+type TimeEntry struct {
+	Id        int        `json:"id" db:"id"`
+	ItemId    int        `json:"item_id" db:"item_id"`
+	UserId    int        `json:"user_id" db:"user_id"`
+	StartedAt time.Time  `json:"started_at" db:"started_at"`
+	StoppedAt *time.Time `json:"stopped_at" db:"stopped_at"`
+	Note      string     `json:"note" db:"note"`
+}
+
+type TimeEntryInput struct {
+	StartedAt time.Time `json:"started_at" binding:"required"`
+	StoppedAt time.Time `json:"stopped_at" binding:"required"`
+	Note      string    `json:"note" binding:"max=255"`
+}
+
+func (i TimeEntryInput) Validate() error {
+	if !i.StoppedAt.After(i.StartedAt) {
+		return errors.New("stopped_at must be after started_at")
+	}
+	if i.StoppedAt.After(time.Now()) {
+		return errors.New("time entry can't end in the future")
+	}
+	return nil
+}
+
+const (
+	TimeReportByList  = "list"
+	TimeReportByLabel = "label"
+	TimeReportByDay   = "day"
+)
+
+// TimeReportFilter selects the entries started in [From, To) and the
+// dimension they are summed by; days are cut in From's location.
+type TimeReportFilter struct {
+	From    time.Time
+	To      time.Time
+	GroupBy string
+}
+
+type TimeReportRow struct {
+	Id      *int   `json:"id,omitempty" db:"id"`
+	Name    string `json:"name" db:"name"`
+	Seconds int64  `json:"seconds" db:"seconds"`
+}
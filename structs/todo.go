@@ -1,11 +1,33 @@
 package structs
 
-import "errors"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fr13n8/todo-app/pkg/querylang"
+)
 
 type List struct {
-	Id          int    `json:"id" db:"id"`
-	Title       string `json:"title" binding:"required" db:"title"`
-	Description string `json:"description" db:"description"`
+	Id              int        `json:"id" db:"id"`
+	Title           string     `json:"title" binding:"required,max=255" maxLength:"255" db:"title"`
+	Description     string     `json:"description" binding:"max=65535" maxLength:"65535" db:"description"`
+	DescriptionHTML string     `json:"description_html,omitempty" db:"-"`
+	CreatedAt       *time.Time `json:"created_at,omitempty" db:"created_at"`
+	UpdatedAt       *time.Time `json:"updated_at,omitempty" db:"updated_at"`
+	CompletedAt     *time.Time `json:"completed_at,omitempty" db:"completed_at"`
+	CreatedBy       *int       `json:"created_by,omitempty" db:"created_by"`
+	UpdatedBy       *int       `json:"updated_by,omitempty" db:"updated_by"`
+	ArchivedAt      *time.Time `json:"archived_at,omitempty" db:"archived_at"`
+	AutoArchiveDays *int       `json:"auto_archive_days,omitempty" binding:"omitempty,min=1" db:"auto_archive_days"`
+	// Version counts the edits of the list; it goes in the ETag header.
+	Version int `json:"-" db:"version"`
+	// Smart marks a saved view listed along with the lists; its id is the
+	// id of the view.
+	Smart bool `json:"smart,omitempty" db:"-"`
 }
 
 type UsersList struct {
@@ -15,10 +37,171 @@ type UsersList struct {
 }
 
 type Item struct {
-	Id          int    `json:"id" db:"id"`
-	Title       string `json:"title" binding:"required" db:"title"`
-	Description string `json:"description" db:"description"`
-	Done        bool   `json:"done" db:"done"`
+	Id              int        `json:"id" db:"id"`
+	Title           string     `json:"title" binding:"required,max=255" maxLength:"255" db:"title"`
+	Description     string     `json:"description" binding:"max=65535" maxLength:"65535" db:"description"`
+	DescriptionHTML string     `json:"description_html,omitempty" db:"-"`
+	Done            bool       `json:"done" db:"done"`
+	StatusId        *int       `json:"status_id,omitempty" db:"status_id"`
+	DueDate         *time.Time `json:"due_date,omitempty" db:"due_date"`
+	Recurrence      *string    `json:"recurrence,omitempty" binding:"omitempty,max=255" maxLength:"255" db:"recurrence"`
+	RecurrenceStart *time.Time `json:"-" db:"recurrence_start"`
+	State           string     `json:"state,omitempty" db:"state"`
+	TrackedSeconds  int64      `json:"tracked_seconds,omitempty" db:"tracked_seconds"`
+	Position        int        `json:"-" db:"position"`
+	CreatedAt       *time.Time `json:"created_at,omitempty" db:"created_at"`
+	UpdatedAt       *time.Time `json:"updated_at,omitempty" db:"updated_at"`
+	CompletedAt     *time.Time `json:"completed_at,omitempty" db:"completed_at"`
+	CreatedBy       *int       `json:"created_by,omitempty" db:"created_by"`
+	UpdatedBy       *int       `json:"updated_by,omitempty" db:"updated_by"`
+	ArchivedAt      *time.Time `json:"archived_at,omitempty" db:"archived_at"`
+	Labels          []Label    `json:"labels,omitempty" db:"-"`
+	Priority        Priority   `json:"priority,omitempty" swaggertype:"string" enums:"none,low,medium,high,urgent" db:"priority"`
+	// AssigneeId is a user with access to the list of the item.
+	AssigneeId *int `json:"assignee_id,omitempty" db:"assignee_id"`
+	ListId     int  `json:"list_id,omitempty" db:"list_id"`
+	// Version counts the edits of the item; it goes in the ETag header.
+	Version int `json:"-" db:"version"`
+}
+
+// Priority ranks items from PriorityNone up to PriorityUrgent. Clients see
+// it by name.
+type Priority int
+
+const (
+	PriorityNone Priority = iota
+	PriorityLow
+	PriorityMedium
+	PriorityHigh
+	PriorityUrgent
+)
+
+// The filter language shares the names, so priority>=high reads the same
+// as the JSON.
+var priorityNames = querylang.Priorities
+
+func (p Priority) String() string {
+	if p < 0 || int(p) >= len(priorityNames) {
+		return strconv.Itoa(int(p))
+	}
+	return priorityNames[p]
+}
+
+// ParsePriority reads a priority by name, ignoring case.
+func ParsePriority(name string) (Priority, error) {
+	for i, priorityName := range priorityNames {
+		if strings.EqualFold(name, priorityName) {
+			return Priority(i), nil
+		}
+	}
+	return PriorityNone, fmt.Errorf("unknown priority %q, expected one of %s", name, strings.Join(priorityNames, ", "))
+}
+
+func (p Priority) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.String())
+}
+
+func (p *Priority) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return err
+	}
+	priority, err := ParsePriority(name)
+	if err != nil {
+		return err
+	}
+	*p = priority
+	return nil
+}
+
+const (
+	ItemStateReady   = "ready"
+	ItemStateBlocked = "blocked"
+	ItemStateDone    = "done"
+)
+
+type DependencyInput struct {
+	BlockerId int `json:"blocker_id" binding:"required"`
+}
+
+type ItemCompletion struct {
+	Id          int        `json:"id" db:"id"`
+	ItemId      int        `json:"item_id" db:"item_id"`
+	DueDate     *time.Time `json:"due_date,omitempty" db:"due_date"`
+	CompletedAt time.Time  `json:"completed_at" db:"completed_at"`
+}
+
+type ItemFilter struct {
+	AuditFilter
+	LabelIds []int `form:"label"`
+	// Archived switches from the active items to the archived ones.
+	Archived bool `form:"archived"`
+	// Query is an expression of the filter language, see package
+	// querylang; the service parses it into Expr.
+	Query string         `form:"q" binding:"max=1000"`
+	Expr  querylang.Node `form:"-"`
+	Done  *bool          `form:"done"`
+	// TitleContains keeps the items whose title contains it, ignoring case.
+	TitleContains string `form:"title~" binding:"max=255"`
+	PageFilter
+}
+
+type ListFilter struct {
+	AuditFilter
+	// Archived switches from the active lists to the archived ones.
+	Archived bool `form:"archived"`
+	// Views appends the saved views as smart lists.
+	Views bool `form:"views"`
+	// Done keeps the lists whose items are all done, or the others.
+	Done *bool `form:"done"`
+	// TitleContains keeps the lists whose title contains it, ignoring case.
+	TitleContains string `form:"title~" binding:"max=255"`
+	PageFilter
+}
+
+// AuditFilter narrows lists or items down by when and by whom they were
+// created, updated and completed. Times are RFC 3339; the ranges include
+// After and exclude Before.
+//
+// Sort orders them by comma separated keys, such as -completed_at,title; a
+// key prefixed with - is descending and the others go in Order.
+type AuditFilter struct {
+	Sort            string     `form:"sort" binding:"max=255"`
+	Order           string     `form:"order" binding:"omitempty,oneof=asc desc"`
+	CreatedAfter    *time.Time `form:"created_after"`
+	CreatedBefore   *time.Time `form:"created_before"`
+	UpdatedAfter    *time.Time `form:"updated_after"`
+	UpdatedBefore   *time.Time `form:"updated_before"`
+	CompletedAfter  *time.Time `form:"completed_after"`
+	CompletedBefore *time.Time `form:"completed_before"`
+	CreatedBy       *int       `form:"created_by"`
+	UpdatedBy       *int       `form:"updated_by"`
+}
+
+// PageFilter asks for a page of a listing. Cursor is the next cursor of the
+// previous page; without a limit the rest of the listing comes at once.
+type PageFilter struct {
+	Cursor string `form:"cursor" binding:"max=1024"`
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=500"`
+}
+
+// PageInfo comes with a page of a listing. Total counts the rows matching
+// the filter on all pages; NextCursor is empty on the last page.
+type PageInfo struct {
+	Total      int    `json:"total"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// CollectionStamp sums up the rows behind a listing, trashed and archived
+// ones included, cheaply enough to check on every poll. Adding, editing,
+// trashing, restoring, archiving, labeling or moving a row changes it; it
+// leaves out blockers and tracked time.
+type CollectionStamp struct {
+	Count int   `db:"count"`
+	IdSum int64 `db:"id_sum"`
+	// Live counts the rows neither trashed nor archived.
+	Live         int        `db:"live"`
+	LastModified *time.Time `db:"last_modified"`
 }
 
 type ListsItem struct {
@@ -27,27 +210,103 @@ type ListsItem struct {
 	ItemId int
 }
 
+type MoveItemInput struct {
+	ListId int `json:"list_id" binding:"required"`
+}
+
+type CopyItemInput struct {
+	ListId int  `json:"list_id" binding:"required"`
+	Labels bool `json:"labels"`
+}
+
+// DuplicateListInput picks what goes into the copy of a list besides the list
+// itself and its statuses. Title defaults to the title of the original.
+type DuplicateListInput struct {
+	Title       string `json:"title" binding:"max=255" maxLength:"255"`
+	Items       bool   `json:"items"`
+	Done        bool   `json:"done"`
+	Labels      bool   `json:"labels"`
+	Attachments bool   `json:"attachments"`
+}
+
 type UpdateListInput struct {
-	Title       *string `json:"title"`
-	Description *string `json:"description"`
+	Title       *string `json:"title" binding:"omitempty,max=255" maxLength:"255"`
+	Description *string `json:"description" binding:"omitempty,max=65535" maxLength:"65535"`
+	// AutoArchiveDays of 0 turns auto-archiving off.
+	AutoArchiveDays *int `json:"auto_archive_days" binding:"omitempty,min=0"`
+	// Version, when set, applies the update only to that version of the
+	// list.
+	Version *int `json:"-"`
 }
 
 func (i UpdateListInput) Validate() error {
-	if i.Title == nil && i.Description == nil {
+	if i.Title == nil && i.Description == nil && i.AutoArchiveDays == nil {
 		return errors.New("update stru has no values")
 	}
 	return nil
 }
 
+// ReplaceListInput holds all the fields of a list that can be edited. PUT
+// replaces them with it and PATCH patches it; null or missing fields are
+// cleared.
+type ReplaceListInput struct {
+	Title           string `json:"title" binding:"required,max=255" maxLength:"255"`
+	Description     string `json:"description" binding:"max=65535" maxLength:"65535"`
+	AutoArchiveDays *int   `json:"auto_archive_days" binding:"omitempty,min=1"`
+	// Version, when set, replaces only that version of the list.
+	Version *int `json:"-"`
+}
+
+// Replacement is the list as a ReplaceListInput.
+func (l List) Replacement() ReplaceListInput {
+	return ReplaceListInput{Title: l.Title, Description: l.Description, AutoArchiveDays: l.AutoArchiveDays}
+}
+
 type UpdateItemInput struct {
-	Title       *string `json:"title"`
-	Description *string `json:"description"`
-	Done        *bool   `json:"done"`
+	Title           *string    `json:"title" binding:"omitempty,max=255" maxLength:"255"`
+	Description     *string    `json:"description" binding:"omitempty,max=65535" maxLength:"65535"`
+	Done            *bool      `json:"done"`
+	StatusId        *int       `json:"status_id"`
+	DueDate         *time.Time `json:"due_date"`
+	Recurrence      *string    `json:"recurrence" binding:"omitempty,max=255" maxLength:"255"`
+	RecurrenceStart *time.Time `json:"-"`
+	Priority        *Priority  `json:"priority" swaggertype:"string" enums:"none,low,medium,high,urgent"`
+	AssigneeId      *int       `json:"assignee_id"`
+	// ClearDueDate removes the due date and ClearAssignee the assignee;
+	// replacements set them.
+	ClearDueDate  bool `json:"-"`
+	ClearAssignee bool `json:"-"`
+	// Version, when set, applies the update only to that version of the
+	// item.
+	Version *int `json:"-"`
 }
 
 func (i UpdateItemInput) Validate() error {
-	if i.Title == nil && i.Description == nil && i.Done == nil {
+	if i.Title == nil && i.Description == nil && i.Done == nil && i.StatusId == nil && i.DueDate == nil && i.Recurrence == nil &&
+		i.Priority == nil && i.AssigneeId == nil && !i.ClearDueDate && !i.ClearAssignee {
 		return errors.New("update stru has no values")
 	}
 	return nil
 }
+
+// ReplaceItemInput holds all the fields of an item that can be edited. PUT
+// replaces them with it and PATCH patches it; null or missing fields are
+// cleared, except the status, which then follows the done flag.
+type ReplaceItemInput struct {
+	Title       string     `json:"title" binding:"required,max=255" maxLength:"255"`
+	Description string     `json:"description" binding:"max=65535" maxLength:"65535"`
+	Done        bool       `json:"done"`
+	StatusId    *int       `json:"status_id"`
+	DueDate     *time.Time `json:"due_date"`
+	Recurrence  *string    `json:"recurrence" binding:"omitempty,max=255" maxLength:"255"`
+	Priority    Priority   `json:"priority" swaggertype:"string" enums:"none,low,medium,high,urgent"`
+	AssigneeId  *int       `json:"assignee_id"`
+	// Version, when set, replaces only that version of the item.
+	Version *int `json:"-"`
+}
+
+// Replacement is the item as a ReplaceItemInput.
+func (i Item) Replacement() ReplaceItemInput {
+	return ReplaceItemInput{Title: i.Title, Description: i.Description, Done: i.Done, StatusId: i.StatusId,
+		DueDate: i.DueDate, Recurrence: i.Recurrence, Priority: i.Priority, AssigneeId: i.AssigneeId}
+}
@@ -0,0 +1,27 @@
+package structs
+
+import "time"
+
+const (
+	TrashList = "list"
+	TrashItem = "item"
+)
+
+// TrashEntry is a list or an item in the trash. Items trashed along with
+// their list aren't listed on their own; they come back with the list.
+type TrashEntry struct {
+	Type      string    `json:"type" db:"type"`
+	Id        int       `json:"id" db:"id"`
+	Title     string    `json:"title" db:"title"`
+	ListId    *int      `json:"list_id,omitempty" db:"list_id"`
+	DeletedAt time.Time `json:"deleted_at" db:"deleted_at"`
+	DeletedBy *int      `json:"deleted_by,omitempty" db:"deleted_by"`
+}
+
+// TrashPurge counts what a purge removed for good. StorageKeys are the blobs
+// of the removed attachments, left for the caller to delete.
+type TrashPurge struct {
+	Lists       int      `json:"lists" db:"lists"`
+	Items       int      `json:"items" db:"items"`
+	StorageKeys []string `json:"-" db:"-"`
+}
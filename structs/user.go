@@ -5,6 +5,9 @@ type User struct {
 	Name     string `json:"name" binding:"required" db:"name"`
 	UserName string `json:"username" binding:"required" db:"username"`
 	Password string `json:"password" binding:"required" db:"password"`
+	// SearchLanguage is the text search configuration the lists and items
+	// the user creates are stemmed with.
+	SearchLanguage string `json:"search_language,omitempty" db:"search_language"`
 }
 
 type SignInInput struct {
@@ -13,9 +16,12 @@ type SignInInput struct {
 }
 
 type SignUpInput struct {
-	Name     string `json:"name" binding:"required" db:"name"`
-	UserName string `json:"username" binding:"required" db:"username"`
+	Name     string `json:"name" binding:"required,max=255" maxLength:"255" db:"name"`
+	UserName string `json:"username" binding:"required,max=255" maxLength:"255" db:"username"`
 	Password string `json:"password" binding:"required" db:"password"`
+	// SearchLanguage names a Postgres text search configuration, such as
+	// german or simple; english by default.
+	SearchLanguage string `json:"search_language,omitempty" binding:"max=63" maxLength:"63" db:"search_language"`
 }
 
 type RefreshTokenInput struct {
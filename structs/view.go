@@ -0,0 +1,72 @@
+package structs
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// View is a saved item query the user opens like a list. Its items come
+// from every list the user can access.
+type View struct {
+	Id        int       `json:"id" db:"id"`
+	Name      string    `json:"name" db:"name"`
+	Query     ViewQuery `json:"query" db:"query"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// ViewQuery is the filter of a view. Empty fields don't filter. Labels must
+// all be on an item; DueWithinDays keeps items due in that many days from
+// now, overdue ones included, MinPriority the items of that priority or a
+// higher one and Mine the items assigned to the user.
+//
+// Sort orders the items by comma separated keys, as on item listings.
+type ViewQuery struct {
+	Done          *bool     `json:"done,omitempty"`
+	LabelIds      []int     `json:"label_ids,omitempty"`
+	DueWithinDays *int      `json:"due_within_days,omitempty" binding:"omitempty,min=0"`
+	MinPriority   *Priority `json:"min_priority,omitempty" swaggertype:"string" enums:"none,low,medium,high,urgent"`
+	Mine          bool      `json:"mine,omitempty"`
+	Sort          string    `json:"sort,omitempty" binding:"max=255"`
+	Order         string    `json:"order,omitempty" binding:"omitempty,oneof=asc desc"`
+}
+
+// Value stores the query in its jsonb column.
+func (q ViewQuery) Value() (driver.Value, error) {
+	return json.Marshal(q)
+}
+
+func (q *ViewQuery) Scan(src interface{}) error {
+	switch data := src.(type) {
+	case []byte:
+		return json.Unmarshal(data, q)
+	case string:
+		return json.Unmarshal([]byte(data), q)
+	}
+	return errors.New("unsupported view query value")
+}
+
+// ViewItemsFilter narrows the items of a view down further with an
+// expression of the filter language.
+type ViewItemsFilter struct {
+	Query string `form:"q" binding:"max=1000"`
+}
+
+type ViewInput struct {
+	Name  string    `json:"name" binding:"required,max=255" maxLength:"255"`
+	Query ViewQuery `json:"query"`
+}
+
+type UpdateViewInput struct {
+	Name  *string    `json:"name" binding:"omitempty,max=255" maxLength:"255"`
+	Query *ViewQuery `json:"query"`
+}
+
+func (i UpdateViewInput) Validate() error {
+	if i.Name == nil && i.Query == nil {
+		return errors.New("update stru has no values")
+	}
+	return nil
+}